@@ -1,56 +1,148 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata" // embed timezone database for per-channel timezones
 
 	"github.com/diegoclair/slack-rotation-bot/internal/config"
 	"github.com/diegoclair/slack-rotation-bot/internal/database"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
 	"github.com/diegoclair/slack-rotation-bot/internal/domain/service"
 	"github.com/diegoclair/slack-rotation-bot/internal/handlers"
 	"github.com/diegoclair/slack-rotation-bot/migrator/sqlite"
 	"github.com/joho/godotenv"
 	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
 )
 
+// shutdownTimeout is how long the HTTP server waits for the requests being handled on shutdown
+const shutdownTimeout = 10 * time.Second
+
 func main() {
 	if err := godotenv.Load(); err != nil {
 		log.Println("Warning: .env file not found")
 	}
 
-	cfg := config.Load()
+	if err := run(config.Load()); err != nil {
+		log.Fatal(err)
+	}
+	log.Println("Bot stopped")
+}
+
+// run starts the bot and blocks until it receives SIGINT or SIGTERM or cannot go on. On the way
+// out the commands and reminders being sent are given time to finish.
+func run(cfg *config.Config) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if cfg.SocketMode && cfg.SlackAppToken == "" {
+		return errors.New("SLACK_APP_TOKEN is required when SLACK_SOCKET_MODE is enabled")
+	}
+
+	if cfg.SlackBotToken == "" && cfg.SlackClientID == "" {
+		return errors.New("SLACK_BOT_TOKEN is required unless the app is installed through OAuth with SLACK_CLIENT_ID")
+	}
 
 	db, err := database.New(cfg.DatabasePath)
 	if err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
+		return fmt.Errorf("failed to initialize database: %w", err)
 	}
 	defer db.Close()
 
 	log.Println("Running migrations...")
 	if err := sqlite.Migrate(db.DB()); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
+		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 	log.Println("Migrations completed successfully")
 
-	slackClient := slack.New(cfg.SlackBotToken)
+	slackClient := slack.New(cfg.SlackBotToken, slack.OptionAppLevelToken(cfg.SlackAppToken))
 
 	dataManager := database.NewInstance(db)
-	serviceInstance := service.NewInstance(dataManager, slackClient)
 
+	// The services and the handler share the clock so they all agree on the current day
+	clock := service.NewSystemClock()
+
+	// Workspaces installed through OAuth use their own bot token, the others SLACK_BOT_TOKEN
+	var defaultClient contract.SlackClient
+	if cfg.SlackBotToken != "" {
+		defaultClient = slackClient
+	}
+	slackClients := service.NewSlackClients(dataManager, clock, defaultClient, func(botToken string) contract.SlackClient {
+		return slack.New(botToken)
+	})
+
+	serviceInstance := service.NewInstance(dataManager, slackClients, clock)
+	serviceInstance.Scheduler.SetGracePeriod(cfg.NotificationGracePeriod)
+	serviceInstance.Scheduler.SetWorkers(cfg.NotificationWorkers)
+	serviceInstance.Scheduler.SetSendInterval(cfg.NotificationSendInterval)
+
+	// Deferred calls run in reverse, the handler stops before the scheduler
 	serviceInstance.Scheduler.Start()
 	defer serviceInstance.Scheduler.Stop()
 
-	handler := handlers.New(slackClient, serviceInstance.Rotation, cfg.SlackSigningSecret)
+	handler := handlers.New(slackClients, serviceInstance.Rotation, clock, cfg.SlackSigningSecret)
+	if cfg.SlackClientID != "" {
+		handler.SetOAuth(handlers.OAuthConfig{
+			ClientID:     cfg.SlackClientID,
+			ClientSecret: cfg.SlackClientSecret,
+			RedirectURL:  cfg.SlackRedirectURL,
+		})
+	}
+
+	handler.SetCommandWorkers(cfg.CommandWorkers)
+	handler.Start()
+	defer handler.Stop()
+
+	// With Socket Mode Slack is reached over a WebSocket, so no HTTP listener is needed
+	if cfg.SocketMode {
+		log.Println("Starting in Socket Mode")
+		if err := handler.RunSocketMode(ctx, socketmode.New(slackClient)); err != nil {
+			return fmt.Errorf("socket mode stopped: %w", err)
+		}
+		stop()
+		log.Println("Shutting down...")
+		return nil
+	}
 
 	http.HandleFunc("/slack/commands", handler.HandleSlashCommand)
+	http.HandleFunc("/slack/interactions", handler.HandleInteraction)
+	http.HandleFunc("/slack/events", handler.HandleEvent)
+	http.HandleFunc("/slack/install", handler.HandleInstall)
+	http.HandleFunc("/slack/oauth/callback", handler.HandleOAuthCallback)
 	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprintf(w, "OK")
 	})
 
-	log.Printf("Server starting on port %s", cfg.Port)
-	if err := http.ListenAndServe(":"+cfg.Port, nil); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	server := &http.Server{Addr: ":" + cfg.Port}
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("Server starting on port %s", cfg.Port)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		return fmt.Errorf("server stopped: %w", err)
+	case <-ctx.Done():
 	}
+
+	// A second signal kills the bot right away
+	stop()
+	log.Println("Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shut down the server: %v", err)
+	}
+
+	return nil
 }
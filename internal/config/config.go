@@ -1,6 +1,11 @@
 package config
 
-import "os"
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
 
 type Config struct {
 	SlackBotToken      string
@@ -8,6 +13,21 @@ type Config struct {
 	SlackSigningSecret string
 	DatabasePath       string
 	Port               string
+	// SlackClientID, SlackClientSecret and SlackRedirectURL enable installing the app in other
+	// workspaces through /slack/install
+	SlackClientID     string
+	SlackClientSecret string
+	SlackRedirectURL  string
+	// SocketMode receives Slack requests over a WebSocket instead of the HTTP endpoints
+	SocketMode bool
+	// NotificationGracePeriod is how late a reminder missed while the bot was down is still sent
+	NotificationGracePeriod time.Duration
+	// NotificationWorkers is how many reminders are sent at the same time
+	NotificationWorkers int
+	// NotificationSendInterval spaces the reminders due at the same time to respect Slack rate limits
+	NotificationSendInterval time.Duration
+	// CommandWorkers is how many slow slash commands, like adding several users, run at the same time
+	CommandWorkers int
 }
 
 func Load() *Config {
@@ -15,8 +35,17 @@ func Load() *Config {
 		SlackBotToken:      getEnv("SLACK_BOT_TOKEN", ""),
 		SlackAppToken:      getEnv("SLACK_APP_TOKEN", ""),
 		SlackSigningSecret: getEnv("SLACK_SIGNING_SECRET", ""),
+		SlackClientID:      getEnv("SLACK_CLIENT_ID", ""),
+		SlackClientSecret:  getEnv("SLACK_CLIENT_SECRET", ""),
+		SlackRedirectURL:   getEnv("SLACK_REDIRECT_URL", ""),
 		DatabasePath:       getEnv("DATABASE_PATH", "./rotation.db"),
 		Port:               getEnv("PORT", "3000"),
+		SocketMode:         getEnv("SLACK_SOCKET_MODE", "false") == "true",
+
+		NotificationGracePeriod:  getDurationEnv("NOTIFICATION_GRACE_PERIOD", time.Hour),
+		NotificationWorkers:      getIntEnv("NOTIFICATION_WORKERS", 8),
+		NotificationSendInterval: getDurationEnv("NOTIFICATION_SEND_INTERVAL", 50*time.Millisecond),
+		CommandWorkers:           getIntEnv("COMMAND_WORKERS", 4),
 	}
 }
 
@@ -26,3 +55,33 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getIntEnv(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 1 {
+		log.Printf("Warning: invalid %s %q, using %d", key, value, defaultValue)
+		return defaultValue
+	}
+
+	return number
+}
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		log.Printf("Warning: invalid %s %q, using %s", key, value, defaultValue)
+		return defaultValue
+	}
+
+	return duration
+}
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+)
+
+type availabilityRepo struct {
+	db dbConn
+}
+
+func newAvailabilityRepo(db dbConn) contract.AvailabilityRepo {
+	return &availabilityRepo{db: db}
+}
+
+func (r *availabilityRepo) Create(availability *entity.Availability) error {
+	query := `
+		INSERT INTO user_availability (user_id, start_date, end_date, reason)
+		VALUES (?, ?, ?, ?)
+	`
+
+	result, err := r.db.Exec(query,
+		availability.UserID,
+		availability.StartDate.Format(domain.DateFormat),
+		availability.EndDate.Format(domain.DateFormat),
+		availability.Reason,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create availability: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	availability.ID = id
+	return nil
+}
+
+// GetUpcomingByChannel returns the away periods of the channel members that end on or after from
+func (r *availabilityRepo) GetUpcomingByChannel(channelID int64, from time.Time) ([]*entity.Availability, error) {
+	query := `
+		SELECT a.id, a.user_id, u.slack_user_id, a.start_date, a.end_date, a.reason, a.created_at
+		FROM user_availability a
+		INNER JOIN users u ON u.id = a.user_id
+		WHERE u.channel_id = ? AND a.end_date >= ?
+		ORDER BY a.start_date ASC, a.end_date ASC
+	`
+
+	rows, err := r.db.Query(query, channelID, from.Format(domain.DateFormat))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability: %w", err)
+	}
+	defer rows.Close()
+
+	var periods []*entity.Availability
+	for rows.Next() {
+		period := &entity.Availability{}
+		var startDate, endDate string
+		err := rows.Scan(
+			&period.ID,
+			&period.UserID,
+			&period.SlackUserID,
+			&startDate,
+			&endDate,
+			&period.Reason,
+			&period.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan availability: %w", err)
+		}
+
+		// Convert stored YYYY-MM-DD text to dates
+		if period.StartDate, err = time.Parse(domain.DateFormat, startDate); err != nil {
+			return nil, fmt.Errorf("failed to parse availability start date: %w", err)
+		}
+		if period.EndDate, err = time.Parse(domain.DateFormat, endDate); err != nil {
+			return nil, fmt.Errorf("failed to parse availability end date: %w", err)
+		}
+		periods = append(periods, period)
+	}
+
+	return periods, nil
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvailabilityRepository_Create(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newAvailabilityRepo(db.conn)
+	user := createTestUser(t, db, "C123456789", "U123456789")
+
+	availability := &entity.Availability{
+		UserID:    user.ID,
+		StartDate: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC),
+		Reason:    "vacation",
+	}
+
+	err := repo.Create(availability)
+	require.NoError(t, err, "Failed to create availability")
+
+	assert.NotZero(t, availability.ID, "Expected availability ID to be set after creation")
+}
+
+func TestAvailabilityRepository_GetUpcomingByChannel(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newAvailabilityRepo(db.conn)
+	user := createTestUser(t, db, "C123456789", "U123456789")
+	otherChannelUser := createTestUser(t, db, "C987654321", "U987654321")
+
+	past := &entity.Availability{
+		UserID:    user.ID,
+		StartDate: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 9, 5, 0, 0, 0, 0, time.UTC),
+	}
+	upcoming := &entity.Availability{
+		UserID:    user.ID,
+		StartDate: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC),
+		Reason:    "vacation",
+	}
+	current := &entity.Availability{
+		UserID:    user.ID,
+		StartDate: time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+	}
+	otherChannel := &entity.Availability{
+		UserID:    otherChannelUser.ID,
+		StartDate: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC),
+	}
+	for _, availability := range []*entity.Availability{past, upcoming, current, otherChannel} {
+		require.NoError(t, repo.Create(availability))
+	}
+
+	t.Run("should return periods not ended yet ordered by start date", func(t *testing.T) {
+		periods, err := repo.GetUpcomingByChannel(user.ChannelID, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+
+		require.NoError(t, err)
+		require.Len(t, periods, 2)
+		assert.Equal(t, current.ID, periods[0].ID)
+		assert.Equal(t, upcoming.ID, periods[1].ID)
+		assert.Equal(t, user.ID, periods[1].UserID)
+		assert.Equal(t, user.SlackUserID, periods[1].SlackUserID)
+		assert.True(t, upcoming.StartDate.Equal(periods[1].StartDate))
+		assert.True(t, upcoming.EndDate.Equal(periods[1].EndDate))
+		assert.Equal(t, "vacation", periods[1].Reason)
+	})
+
+	t.Run("should return empty slice when nobody is away", func(t *testing.T) {
+		periods, err := repo.GetUpcomingByChannel(user.ChannelID, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC))
+
+		require.NoError(t, err)
+		assert.Empty(t, periods)
+	})
+}
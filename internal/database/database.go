@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -20,15 +22,21 @@ type DB struct {
 	conn *sql.DB
 }
 
+// busyTimeout is how long a query waits for a lock held by another instance of the bot
+// sharing the database file before failing
+const busyTimeout = 5 * time.Second
+
 func New(dbPath string) (*DB, error) {
-	conn, err := sql.Open("sqlite3", dbPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+	separator := "?"
+	if strings.Contains(dbPath, "?") {
+		separator = "&"
 	}
 
-	// Enable foreign keys
-	if _, err := conn.Exec("PRAGMA foreign_keys = ON"); err != nil {
-		return nil, fmt.Errorf("failed to enable foreign keys: %w", err)
+	// Foreign keys are enabled in the DSN so every connection of the pool enforces them
+	dsn := fmt.Sprintf("%s%s_busy_timeout=%d&_foreign_keys=1", dbPath, separator, busyTimeout.Milliseconds())
+	conn, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
 	return &DB{conn: conn}, nil
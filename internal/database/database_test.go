@@ -0,0 +1,54 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/diegoclair/slack-rotation-bot/migrator/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_ForeignKeys(t *testing.T) {
+	db, err := New(filepath.Join(t.TempDir(), "bot.db"))
+	require.NoError(t, err)
+	defer CleanupTestDB(t, db)
+
+	require.NoError(t, sqlite.Migrate(db.conn))
+
+	user := createTestUser(t, db, "C123456789", "U123456789")
+	entry := &entity.RotationHistory{
+		ChannelID:   user.ChannelID,
+		RotationID:  user.RotationID,
+		UserID:      user.ID,
+		SlackUserID: user.SlackUserID,
+		DisplayName: user.DisplayName,
+		PresentedAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		Kind:        domain.HistoryKindAutomatic,
+	}
+	require.NoError(t, newHistoryRepo(db.conn).Create(entry))
+
+	ctx := context.Background()
+
+	// Holding a connection makes the pool open a second one for the delete
+	first, err := db.conn.Conn(ctx)
+	require.NoError(t, err)
+	defer first.Close()
+
+	second, err := db.conn.Conn(ctx)
+	require.NoError(t, err)
+	defer second.Close()
+
+	_, err = second.ExecContext(ctx, "DELETE FROM users WHERE id = ?", user.ID)
+	require.NoError(t, err)
+
+	var userID sql.NullInt64
+	err = second.QueryRowContext(ctx, "SELECT user_id FROM rotation_history WHERE id = ?", entry.ID).Scan(&userID)
+	require.NoError(t, err)
+	assert.False(t, userID.Valid, "Expected the history entry to lose its member once the member is deleted")
+}
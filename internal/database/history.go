@@ -0,0 +1,126 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+)
+
+type historyRepo struct {
+	db dbConn
+}
+
+func newHistoryRepo(db dbConn) contract.HistoryRepo {
+	return &historyRepo{db: db}
+}
+
+func (r *historyRepo) Create(entry *entity.RotationHistory) error {
+	query := `
+		INSERT INTO rotation_history (channel_id, rotation_id, user_id, slack_user_id, display_name, presented_at, kind, triggered_by, is_backup)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.Exec(query,
+		entry.ChannelID,
+		entry.RotationID,
+		entry.UserID,
+		entry.SlackUserID,
+		entry.DisplayName,
+		entry.PresentedAt,
+		entry.Kind,
+		entry.TriggeredBy,
+		entry.IsBackup,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create history entry: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	entry.ID = id
+	return nil
+}
+
+// GetByRotationID returns the latest turns of the rotation, most recent first with the
+// presenter of each turn before its backups
+func (r *historyRepo) GetByRotationID(rotationID int64, limit int) ([]*entity.RotationHistory, error) {
+	query := `
+		SELECT id, channel_id, rotation_id, user_id, slack_user_id, display_name, presented_at, kind, triggered_by, is_backup, created_at
+		FROM rotation_history
+		WHERE rotation_id = ?
+		ORDER BY presented_at DESC, is_backup ASC, id DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.Query(query, rotationID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*entity.RotationHistory
+	for rows.Next() {
+		entry := &entity.RotationHistory{}
+		var userID sql.NullInt64
+		err := rows.Scan(
+			&entry.ID,
+			&entry.ChannelID,
+			&entry.RotationID,
+			&userID,
+			&entry.SlackUserID,
+			&entry.DisplayName,
+			&entry.PresentedAt,
+			&entry.Kind,
+			&entry.TriggeredBy,
+			&entry.IsBackup,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan history entry: %w", err)
+		}
+
+		// user_id is cleared when the member is removed from the rotation
+		entry.UserID = userID.Int64
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// GetTurnStats returns how many turns each current member had and when the last one was.
+// Members without turns are not included, and backing up a presenter does not count as a turn.
+func (r *historyRepo) GetTurnStats(rotationID int64) ([]*entity.TurnStats, error) {
+	query := `
+		SELECT h.user_id, s.turns, h.presented_at
+		FROM rotation_history h
+		INNER JOIN (
+			SELECT user_id, COUNT(*) AS turns, MAX(id) AS last_id
+			FROM rotation_history
+			WHERE rotation_id = ? AND user_id IS NOT NULL AND is_backup = 0
+			GROUP BY user_id
+		) s ON h.id = s.last_id
+		ORDER BY h.user_id ASC
+	`
+
+	rows, err := r.db.Query(query, rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get turn stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []*entity.TurnStats
+	for rows.Next() {
+		stat := &entity.TurnStats{}
+		if err := rows.Scan(&stat.UserID, &stat.Turns, &stat.LastTurnAt); err != nil {
+			return nil, fmt.Errorf("failed to scan turn stats: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
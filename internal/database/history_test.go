@@ -0,0 +1,179 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryRepository_Create(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newHistoryRepo(db.conn)
+	user := createTestUser(t, db, "C123456789", "U123456789")
+
+	entry := &entity.RotationHistory{
+		ChannelID:   user.ChannelID,
+		RotationID:  user.RotationID,
+		UserID:      user.ID,
+		SlackUserID: user.SlackUserID,
+		DisplayName: user.DisplayName,
+		PresentedAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		Kind:        domain.HistoryKindAutomatic,
+	}
+
+	err := repo.Create(entry)
+	require.NoError(t, err, "Failed to create history entry")
+
+	assert.NotZero(t, entry.ID, "Expected history entry ID to be set after creation")
+}
+
+func TestHistoryRepository_GetByRotationID(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newHistoryRepo(db.conn)
+	user := createTestUser(t, db, "C123456789", "U123456789")
+
+	// Create entries out of order
+	days := []int{19, 21, 20}
+	kinds := []string{domain.HistoryKindAutomatic, domain.HistoryKindOverride, domain.HistoryKindSkip}
+	for i, day := range days {
+		entry := &entity.RotationHistory{
+			ChannelID:   user.ChannelID,
+			RotationID:  user.RotationID,
+			UserID:      user.ID,
+			SlackUserID: user.SlackUserID,
+			DisplayName: user.DisplayName,
+			PresentedAt: time.Date(2026, 10, day, 12, 0, 0, 0, time.UTC),
+			Kind:        kinds[i],
+			TriggeredBy: "U987654321",
+		}
+		require.NoError(t, repo.Create(entry))
+	}
+
+	t.Run("should return latest entries first", func(t *testing.T) {
+		entries, err := repo.GetByRotationID(user.RotationID, 10)
+
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+		assert.Equal(t, domain.HistoryKindOverride, entries[0].Kind)
+		assert.Equal(t, domain.HistoryKindSkip, entries[1].Kind)
+		assert.Equal(t, domain.HistoryKindAutomatic, entries[2].Kind)
+		assert.True(t, time.Date(2026, 10, 21, 12, 0, 0, 0, time.UTC).Equal(entries[0].PresentedAt))
+		assert.Equal(t, user.ID, entries[0].UserID)
+		assert.Equal(t, "U123456789", entries[0].SlackUserID)
+		assert.Equal(t, "Test User", entries[0].DisplayName)
+		assert.Equal(t, "U987654321", entries[0].TriggeredBy)
+	})
+
+	t.Run("should limit the number of entries", func(t *testing.T) {
+		entries, err := repo.GetByRotationID(user.RotationID, 2)
+
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+	})
+
+	t.Run("should return empty slice for rotation without history", func(t *testing.T) {
+		entries, err := repo.GetByRotationID(999, 10)
+
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestHistoryRepository_GetByRotationID_WithBackups(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newHistoryRepo(db.conn)
+	userA, userB := createTestSwapUsers(t, db)
+
+	// The backup is stored after the presenter of the same turn
+	presentedAt := time.Date(2026, 10, 21, 12, 0, 0, 0, time.UTC)
+	for _, entry := range []*entity.RotationHistory{
+		{UserID: userB.ID, SlackUserID: userB.SlackUserID, DisplayName: userB.DisplayName, IsBackup: true},
+		{UserID: userA.ID, SlackUserID: userA.SlackUserID, DisplayName: userA.DisplayName},
+	} {
+		entry.ChannelID = userA.ChannelID
+		entry.RotationID = userA.RotationID
+		entry.PresentedAt = presentedAt
+		entry.Kind = domain.HistoryKindAutomatic
+		require.NoError(t, repo.Create(entry))
+	}
+
+	entries, err := repo.GetByRotationID(userA.RotationID, 10)
+
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, userA.ID, entries[0].UserID)
+	assert.False(t, entries[0].IsBackup)
+	assert.Equal(t, userB.ID, entries[1].UserID)
+	assert.True(t, entries[1].IsBackup)
+}
+
+func TestHistoryRepository_GetTurnStats(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newHistoryRepo(db.conn)
+	userA, userB := createTestSwapUsers(t, db)
+
+	turns := []struct {
+		user *entity.User
+		day  int
+	}{
+		{userA, 19},
+		{userB, 20},
+		{userA, 21},
+	}
+	for _, turn := range turns {
+		entry := &entity.RotationHistory{
+			ChannelID:   turn.user.ChannelID,
+			RotationID:  turn.user.RotationID,
+			UserID:      turn.user.ID,
+			SlackUserID: turn.user.SlackUserID,
+			DisplayName: turn.user.DisplayName,
+			PresentedAt: time.Date(2026, 10, turn.day, 12, 0, 0, 0, time.UTC),
+			Kind:        domain.HistoryKindAutomatic,
+		}
+		require.NoError(t, repo.Create(entry))
+	}
+
+	// Backing up the presenter is not a turn
+	require.NoError(t, repo.Create(&entity.RotationHistory{
+		ChannelID:   userB.ChannelID,
+		RotationID:  userB.RotationID,
+		UserID:      userB.ID,
+		SlackUserID: userB.SlackUserID,
+		DisplayName: userB.DisplayName,
+		PresentedAt: time.Date(2026, 10, 21, 12, 0, 0, 0, time.UTC),
+		Kind:        domain.HistoryKindAutomatic,
+		IsBackup:    true,
+	}))
+
+	t.Run("should count turns and keep the latest one per member", func(t *testing.T) {
+		stats, err := repo.GetTurnStats(userA.RotationID)
+
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+		assert.Equal(t, userA.ID, stats[0].UserID)
+		assert.Equal(t, 2, stats[0].Turns)
+		assert.True(t, time.Date(2026, 10, 21, 12, 0, 0, 0, time.UTC).Equal(stats[0].LastTurnAt))
+		assert.Equal(t, userB.ID, stats[1].UserID)
+		assert.Equal(t, 1, stats[1].Turns)
+		assert.True(t, time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC).Equal(stats[1].LastTurnAt))
+	})
+
+	t.Run("should return empty slice for rotation without history", func(t *testing.T) {
+		stats, err := repo.GetTurnStats(999)
+
+		require.NoError(t, err)
+		assert.Empty(t, stats)
+	})
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+)
+
+type holidayRepo struct {
+	db dbConn
+}
+
+func newHolidayRepo(db dbConn) contract.HolidayRepo {
+	return &holidayRepo{db: db}
+}
+
+func (r *holidayRepo) Create(holiday *entity.Holiday) error {
+	query := `
+		INSERT INTO channel_holidays (channel_id, start_date, end_date, description)
+		VALUES (?, ?, ?, ?)
+	`
+
+	result, err := r.db.Exec(query,
+		holiday.ChannelID,
+		holiday.StartDate.Format(domain.DateFormat),
+		holiday.EndDate.Format(domain.DateFormat),
+		holiday.Description,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create holiday: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	holiday.ID = id
+	return nil
+}
+
+func (r *holidayRepo) GetByChannelID(channelID int64) ([]*entity.Holiday, error) {
+	query := `
+		SELECT id, channel_id, start_date, end_date, description, created_at
+		FROM channel_holidays
+		WHERE channel_id = ?
+		ORDER BY start_date ASC, end_date ASC
+	`
+
+	rows, err := r.db.Query(query, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get holidays: %w", err)
+	}
+	defer rows.Close()
+
+	var holidays []*entity.Holiday
+	for rows.Next() {
+		holiday := &entity.Holiday{}
+		var startDate, endDate string
+		err := rows.Scan(
+			&holiday.ID,
+			&holiday.ChannelID,
+			&startDate,
+			&endDate,
+			&holiday.Description,
+			&holiday.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan holiday: %w", err)
+		}
+
+		// Convert stored YYYY-MM-DD text to dates
+		if holiday.StartDate, err = time.Parse(domain.DateFormat, startDate); err != nil {
+			return nil, fmt.Errorf("failed to parse holiday start date: %w", err)
+		}
+		if holiday.EndDate, err = time.Parse(domain.DateFormat, endDate); err != nil {
+			return nil, fmt.Errorf("failed to parse holiday end date: %w", err)
+		}
+		holidays = append(holidays, holiday)
+	}
+
+	return holidays, nil
+}
+
+func (r *holidayRepo) DeleteByRange(channelID int64, startDate, endDate time.Time) (int64, error) {
+	query := `DELETE FROM channel_holidays WHERE channel_id = ? AND start_date = ? AND end_date = ?`
+
+	result, err := r.db.Exec(query,
+		channelID,
+		startDate.Format(domain.DateFormat),
+		endDate.Format(domain.DateFormat),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete holiday: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get deleted rows: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,134 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHolidayRepository_Create(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newHolidayRepo(db.conn)
+
+	// Create a channel first
+	channelRepo := newChannelRepo(db.conn)
+	channel := &entity.Channel{
+		SlackChannelID:   "C123456789",
+		SlackChannelName: "test-channel",
+		SlackTeamID:      "T123456789",
+		IsActive:         true,
+	}
+	err := channelRepo.Create(channel)
+	require.NoError(t, err)
+
+	holiday := &entity.Holiday{
+		ChannelID:   channel.ID,
+		StartDate:   time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2026, 12, 26, 0, 0, 0, 0, time.UTC),
+		Description: "Christmas",
+	}
+
+	err = repo.Create(holiday)
+	require.NoError(t, err, "Failed to create holiday")
+
+	assert.NotZero(t, holiday.ID, "Expected holiday ID to be set after creation")
+}
+
+func TestHolidayRepository_GetByChannelID(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newHolidayRepo(db.conn)
+
+	// Create a channel first
+	channelRepo := newChannelRepo(db.conn)
+	channel := &entity.Channel{
+		SlackChannelID:   "C123456789",
+		SlackChannelName: "test-channel",
+		SlackTeamID:      "T123456789",
+		IsActive:         true,
+	}
+	err := channelRepo.Create(channel)
+	require.NoError(t, err)
+
+	// Create holidays out of order
+	newYear := &entity.Holiday{
+		ChannelID: channel.ID,
+		StartDate: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	err = repo.Create(newYear)
+	require.NoError(t, err)
+
+	christmas := &entity.Holiday{
+		ChannelID:   channel.ID,
+		StartDate:   time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2026, 12, 26, 0, 0, 0, 0, time.UTC),
+		Description: "Christmas",
+	}
+	err = repo.Create(christmas)
+	require.NoError(t, err)
+
+	t.Run("should return holidays ordered by start date", func(t *testing.T) {
+		holidays, err := repo.GetByChannelID(channel.ID)
+
+		require.NoError(t, err)
+		require.Len(t, holidays, 2)
+
+		assert.Equal(t, christmas.ID, holidays[0].ID)
+		assert.Equal(t, christmas.StartDate, holidays[0].StartDate)
+		assert.Equal(t, christmas.EndDate, holidays[0].EndDate)
+		assert.Equal(t, "Christmas", holidays[0].Description)
+		assert.Equal(t, newYear.ID, holidays[1].ID)
+	})
+
+	t.Run("should return empty slice when channel has no holidays", func(t *testing.T) {
+		holidays, err := repo.GetByChannelID(99999)
+
+		require.NoError(t, err)
+		assert.Empty(t, holidays)
+	})
+}
+
+func TestHolidayRepository_DeleteByRange(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newHolidayRepo(db.conn)
+
+	// Create a channel first
+	channelRepo := newChannelRepo(db.conn)
+	channel := &entity.Channel{
+		SlackChannelID:   "C123456789",
+		SlackChannelName: "test-channel",
+		SlackTeamID:      "T123456789",
+		IsActive:         true,
+	}
+	err := channelRepo.Create(channel)
+	require.NoError(t, err)
+
+	start := time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 12, 26, 0, 0, 0, 0, time.UTC)
+
+	err = repo.Create(&entity.Holiday{ChannelID: channel.ID, StartDate: start, EndDate: end})
+	require.NoError(t, err)
+
+	// A different range is not deleted
+	deleted, err := repo.DeleteByRange(channel.ID, start, start)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+
+	// The exact range is deleted
+	deleted, err = repo.DeleteByRange(channel.ID, start, end)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	holidays, err := repo.GetByChannelID(channel.ID)
+	require.NoError(t, err)
+	assert.Empty(t, holidays)
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+)
+
+type installationRepo struct {
+	db dbConn
+}
+
+func newInstallationRepo(db dbConn) contract.InstallationRepo {
+	return &installationRepo{db: db}
+}
+
+// Save creates the installation of the team, or replaces the token and details of the one
+// it already has when the app is installed again
+func (r *installationRepo) Save(installation *entity.Installation) error {
+	query := `
+		INSERT INTO installations (slack_team_id, slack_team_name, bot_user_id, bot_token, scope, installed_by)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(slack_team_id) DO UPDATE SET
+			slack_team_name = excluded.slack_team_name,
+			bot_user_id = excluded.bot_user_id,
+			bot_token = excluded.bot_token,
+			scope = excluded.scope,
+			installed_by = excluded.installed_by,
+			updated_at = ?
+	`
+
+	_, err := r.db.Exec(query,
+		installation.SlackTeamID,
+		installation.SlackTeamName,
+		installation.BotUserID,
+		installation.BotToken,
+		installation.Scope,
+		installation.InstalledBy,
+		time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save installation: %w", err)
+	}
+
+	// LastInsertId is not reliable when the row was updated instead
+	err = r.db.QueryRow(`SELECT id FROM installations WHERE slack_team_id = ?`, installation.SlackTeamID).
+		Scan(&installation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get installation id: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTeamID returns nil when the team has not installed the app
+func (r *installationRepo) GetByTeamID(slackTeamID string) (*entity.Installation, error) {
+	installation := &entity.Installation{}
+	query := `
+		SELECT id, slack_team_id, slack_team_name, bot_user_id, bot_token, scope,
+			installed_by, created_at, updated_at
+		FROM installations
+		WHERE slack_team_id = ?
+	`
+
+	err := r.db.QueryRow(query, slackTeamID).Scan(
+		&installation.ID,
+		&installation.SlackTeamID,
+		&installation.SlackTeamName,
+		&installation.BotUserID,
+		&installation.BotToken,
+		&installation.Scope,
+		&installation.InstalledBy,
+		&installation.CreatedAt,
+		&installation.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installation: %w", err)
+	}
+
+	return installation, nil
+}
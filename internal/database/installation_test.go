@@ -0,0 +1,88 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallationRepository_Save(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newInstallationRepo(db.conn)
+
+	t.Run("should create installation", func(t *testing.T) {
+		installation := &entity.Installation{
+			SlackTeamID:   "T123456789",
+			SlackTeamName: "Acme",
+			BotUserID:     "B123456789",
+			BotToken:      "xoxb-first",
+			Scope:         "commands,chat:write",
+			InstalledBy:   "U123456789",
+		}
+
+		err := repo.Save(installation)
+		require.NoError(t, err, "Failed to save installation")
+
+		assert.NotZero(t, installation.ID, "Expected installation ID to be set after saving")
+	})
+
+	t.Run("should replace the installation of a team installing again", func(t *testing.T) {
+		first, err := repo.GetByTeamID("T123456789")
+		require.NoError(t, err)
+		require.NotNil(t, first)
+
+		installation := &entity.Installation{
+			SlackTeamID:   "T123456789",
+			SlackTeamName: "Acme Corp",
+			BotUserID:     "B123456789",
+			BotToken:      "xoxb-second",
+			Scope:         "commands,chat:write,users:read",
+			InstalledBy:   "U987654321",
+		}
+
+		err = repo.Save(installation)
+		require.NoError(t, err, "Failed to save installation")
+		assert.Equal(t, first.ID, installation.ID, "Expected the same installation to be updated")
+
+		saved, err := repo.GetByTeamID("T123456789")
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.Equal(t, "Acme Corp", saved.SlackTeamName)
+		assert.Equal(t, "xoxb-second", saved.BotToken)
+		assert.Equal(t, "commands,chat:write,users:read", saved.Scope)
+		assert.Equal(t, "U987654321", saved.InstalledBy)
+	})
+}
+
+func TestInstallationRepository_GetByTeamID(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newInstallationRepo(db.conn)
+
+	require.NoError(t, repo.Save(&entity.Installation{
+		SlackTeamID: "T123456789",
+		BotUserID:   "B123456789",
+		BotToken:    "xoxb-token",
+	}))
+
+	t.Run("should get installation by team ID", func(t *testing.T) {
+		installation, err := repo.GetByTeamID("T123456789")
+		require.NoError(t, err)
+		require.NotNil(t, installation)
+
+		assert.Equal(t, "T123456789", installation.SlackTeamID)
+		assert.Equal(t, "B123456789", installation.BotUserID)
+		assert.Equal(t, "xoxb-token", installation.BotToken)
+	})
+
+	t.Run("should return nil for a team without installation", func(t *testing.T) {
+		installation, err := repo.GetByTeamID("T000000000")
+		require.NoError(t, err)
+		assert.Nil(t, installation)
+	})
+}
@@ -9,10 +9,16 @@ import (
 
 // instance implements DataManager interface
 type instance struct {
-	db            *DB
-	channelRepo   contract.ChannelRepo
-	userRepo      contract.UserRepo
-	schedulerRepo contract.SchedulerRepo
+	db               *DB
+	channelRepo      contract.ChannelRepo
+	rotationRepo     contract.RotationRepo
+	userRepo         contract.UserRepo
+	schedulerRepo    contract.SchedulerRepo
+	holidayRepo      contract.HolidayRepo
+	availabilityRepo contract.AvailabilityRepo
+	historyRepo      contract.HistoryRepo
+	swapRepo         contract.SwapRepo
+	installationRepo contract.InstallationRepo
 }
 
 // NewInstance creates a new database instance with all repositories
@@ -27,16 +33,28 @@ func NewInstance(db *DB) contract.DataManager {
 // repoInstances initializes all repositories
 func (i *instance) repoInstances() {
 	i.channelRepo = newChannelRepo(i.db.conn)
+	i.rotationRepo = newRotationRepo(i.db.conn)
 	i.userRepo = newUserRepo(i.db.conn)
 	i.schedulerRepo = newSchedulerRepo(i.db.conn)
+	i.holidayRepo = newHolidayRepo(i.db.conn)
+	i.availabilityRepo = newAvailabilityRepo(i.db.conn)
+	i.historyRepo = newHistoryRepo(i.db.conn)
+	i.swapRepo = newSwapRepo(i.db.conn)
+	i.installationRepo = newInstallationRepo(i.db.conn)
 }
 
 // repoInstancesWithConn creates repository instances with custom dbConn
 func repoInstancesWithConn(db dbConn) *instance {
 	return &instance{
-		channelRepo:   newChannelRepo(db),
-		userRepo:      newUserRepo(db),
-		schedulerRepo: newSchedulerRepo(db),
+		channelRepo:      newChannelRepo(db),
+		rotationRepo:     newRotationRepo(db),
+		userRepo:         newUserRepo(db),
+		schedulerRepo:    newSchedulerRepo(db),
+		holidayRepo:      newHolidayRepo(db),
+		availabilityRepo: newAvailabilityRepo(db),
+		historyRepo:      newHistoryRepo(db),
+		swapRepo:         newSwapRepo(db),
+		installationRepo: newInstallationRepo(db),
 	}
 }
 
@@ -45,6 +63,11 @@ func (i *instance) Channel() contract.ChannelRepo {
 	return i.channelRepo
 }
 
+// Rotation returns the rotation repository
+func (i *instance) Rotation() contract.RotationRepo {
+	return i.rotationRepo
+}
+
 // User returns the user repository
 func (i *instance) User() contract.UserRepo {
 	return i.userRepo
@@ -55,6 +78,31 @@ func (i *instance) Scheduler() contract.SchedulerRepo {
 	return i.schedulerRepo
 }
 
+// Holiday returns the holiday repository
+func (i *instance) Holiday() contract.HolidayRepo {
+	return i.holidayRepo
+}
+
+// Availability returns the user availability repository
+func (i *instance) Availability() contract.AvailabilityRepo {
+	return i.availabilityRepo
+}
+
+// History returns the rotation history repository
+func (i *instance) History() contract.HistoryRepo {
+	return i.historyRepo
+}
+
+// Swap returns the rotation swap repository
+func (i *instance) Swap() contract.SwapRepo {
+	return i.swapRepo
+}
+
+// Installation returns the workspace installation repository
+func (i *instance) Installation() contract.InstallationRepo {
+	return i.installationRepo
+}
+
 // WithTransaction executes a function within a database transaction
 func (i *instance) WithTransaction(ctx context.Context, fn func(dm contract.DataManager) error) error {
 	tx, err := i.db.Begin()
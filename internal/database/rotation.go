@@ -0,0 +1,138 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+)
+
+type rotationRepo struct {
+	db dbConn
+}
+
+func newRotationRepo(db dbConn) contract.RotationRepo {
+	return &rotationRepo{db: db}
+}
+
+func (r *rotationRepo) Create(rotation *entity.Rotation) error {
+	query := `
+		INSERT INTO rotations (channel_id, name, is_primary)
+		VALUES (?, ?, ?)
+	`
+
+	result, err := r.db.Exec(query,
+		rotation.ChannelID,
+		rotation.Name,
+		rotation.IsPrimary,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create rotation: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	rotation.ID = id
+	return nil
+}
+
+func (r *rotationRepo) GetByID(id int64) (*entity.Rotation, error) {
+	query := `
+		SELECT id, channel_id, name, is_primary, created_at
+		FROM rotations
+		WHERE id = ?
+	`
+
+	return r.getOne(query, id)
+}
+
+// GetPrimary returns the rotation used by the commands that do not name a rotation
+func (r *rotationRepo) GetPrimary(channelID int64) (*entity.Rotation, error) {
+	query := `
+		SELECT id, channel_id, name, is_primary, created_at
+		FROM rotations
+		WHERE channel_id = ? AND is_primary = 1
+		LIMIT 1
+	`
+
+	return r.getOne(query, channelID)
+}
+
+func (r *rotationRepo) GetByChannelAndName(channelID int64, name string) (*entity.Rotation, error) {
+	query := `
+		SELECT id, channel_id, name, is_primary, created_at
+		FROM rotations
+		WHERE channel_id = ? AND name = ?
+	`
+
+	return r.getOne(query, channelID, name)
+}
+
+// GetByChannelID returns every rotation of the channel, the primary one first
+func (r *rotationRepo) GetByChannelID(channelID int64) ([]*entity.Rotation, error) {
+	query := `
+		SELECT id, channel_id, name, is_primary, created_at
+		FROM rotations
+		WHERE channel_id = ?
+		ORDER BY is_primary DESC, name ASC
+	`
+
+	rows, err := r.db.Query(query, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rotations: %w", err)
+	}
+	defer rows.Close()
+
+	var rotations []*entity.Rotation
+	for rows.Next() {
+		rotation := &entity.Rotation{}
+		err := rows.Scan(
+			&rotation.ID,
+			&rotation.ChannelID,
+			&rotation.Name,
+			&rotation.IsPrimary,
+			&rotation.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rotation: %w", err)
+		}
+		rotations = append(rotations, rotation)
+	}
+
+	return rotations, nil
+}
+
+// Delete removes the rotation, its members, schedule, history and swaps are removed with it
+func (r *rotationRepo) Delete(id int64) error {
+	query := `DELETE FROM rotations WHERE id = ?`
+
+	_, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete rotation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *rotationRepo) getOne(query string, args ...interface{}) (*entity.Rotation, error) {
+	rotation := &entity.Rotation{}
+	err := r.db.QueryRow(query, args...).Scan(
+		&rotation.ID,
+		&rotation.ChannelID,
+		&rotation.Name,
+		&rotation.IsPrimary,
+		&rotation.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rotation: %w", err)
+	}
+
+	return rotation, nil
+}
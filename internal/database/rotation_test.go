@@ -0,0 +1,148 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotationRepository_Create(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newRotationRepo(db.conn)
+	channel := createTestChannel(t, db, "C123456789")
+
+	t.Run("should create rotation", func(t *testing.T) {
+		rotation := &entity.Rotation{
+			ChannelID: channel.ID,
+			Name:      "reviewers",
+		}
+
+		err := repo.Create(rotation)
+		require.NoError(t, err, "Failed to create rotation")
+
+		assert.NotZero(t, rotation.ID, "Expected rotation ID to be set after creation")
+	})
+
+	t.Run("should reject duplicated name in the same channel", func(t *testing.T) {
+		err := repo.Create(&entity.Rotation{ChannelID: channel.ID, Name: "reviewers"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRotationRepository_Get(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newRotationRepo(db.conn)
+	channel := createTestChannel(t, db, "C123456789")
+	primary := createTestRotation(t, db, channel.ID)
+	reviewers := &entity.Rotation{ChannelID: channel.ID, Name: "reviewers"}
+	require.NoError(t, repo.Create(reviewers))
+
+	t.Run("should get rotation by id", func(t *testing.T) {
+		found, err := repo.GetByID(reviewers.ID)
+
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, channel.ID, found.ChannelID)
+		assert.Equal(t, "reviewers", found.Name)
+		assert.False(t, found.IsPrimary)
+	})
+
+	t.Run("should get primary rotation of the channel", func(t *testing.T) {
+		found, err := repo.GetPrimary(channel.ID)
+
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, primary.ID, found.ID)
+		assert.True(t, found.IsPrimary)
+	})
+
+	t.Run("should get rotation by channel and name", func(t *testing.T) {
+		found, err := repo.GetByChannelAndName(channel.ID, "reviewers")
+
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, reviewers.ID, found.ID)
+	})
+
+	t.Run("should return nil when rotation not found", func(t *testing.T) {
+		found, err := repo.GetByID(99999)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+
+		found, err = repo.GetPrimary(99999)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+
+		found, err = repo.GetByChannelAndName(channel.ID, "unknown")
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
+
+func TestRotationRepository_GetByChannelID(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newRotationRepo(db.conn)
+	channel := createTestChannel(t, db, "C123456789")
+	for _, name := range []string{"reviewers", "oncall"} {
+		require.NoError(t, repo.Create(&entity.Rotation{ChannelID: channel.ID, Name: name}))
+	}
+	primary := createTestRotation(t, db, channel.ID)
+
+	t.Run("should return the primary rotation first, then by name", func(t *testing.T) {
+		rotations, err := repo.GetByChannelID(channel.ID)
+
+		require.NoError(t, err)
+		require.Len(t, rotations, 3)
+		assert.Equal(t, primary.ID, rotations[0].ID)
+		assert.Equal(t, "oncall", rotations[1].Name)
+		assert.Equal(t, "reviewers", rotations[2].Name)
+	})
+
+	t.Run("should return empty slice for channel without rotations", func(t *testing.T) {
+		rotations, err := repo.GetByChannelID(99999)
+
+		require.NoError(t, err)
+		assert.Empty(t, rotations)
+	})
+}
+
+func TestRotationRepository_Delete(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newRotationRepo(db.conn)
+	channel := createTestChannel(t, db, "C123456789")
+	rotation := &entity.Rotation{ChannelID: channel.ID, Name: "reviewers"}
+	require.NoError(t, repo.Create(rotation))
+
+	err := repo.Delete(rotation.ID)
+	require.NoError(t, err, "Failed to delete rotation")
+
+	deleted, err := repo.GetByID(rotation.ID)
+	require.NoError(t, err)
+	assert.Nil(t, deleted, "Expected rotation to be deleted")
+}
+
+// createTestChannel creates an active channel
+func createTestChannel(t *testing.T, db *DB, slackChannelID string) *entity.Channel {
+	t.Helper()
+
+	channel := &entity.Channel{
+		SlackChannelID:   slackChannelID,
+		SlackChannelName: "test-channel",
+		SlackTeamID:      "T123456789",
+		IsActive:         true,
+	}
+	require.NoError(t, newChannelRepo(db.conn).Create(channel))
+
+	return channel
+}
@@ -10,6 +10,14 @@ import (
 	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
 )
 
+// headsUpWantedColumn tells whether an active member of the rotation gets a heads-up the day
+// before, by their own choice or by following the rotation setting
+const headsUpWantedColumn = `EXISTS (
+			SELECT 1 FROM users u
+			WHERE u.rotation_id = s.rotation_id AND u.is_active = 1
+				AND (u.dm_reminders = 'day-before' OR (u.dm_reminders = '' AND s.dm_reminders = 'day-before'))
+		)`
+
 type schedulerRepo struct {
 	db dbConn
 }
@@ -20,8 +28,8 @@ func newSchedulerRepo(db dbConn) contract.SchedulerRepo {
 
 func (r *schedulerRepo) Create(scheduler *entity.Scheduler) error {
 	query := `
-		INSERT INTO scheduler_configs (channel_id, notification_time, active_days, is_enabled, role)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO scheduler_configs (channel_id, rotation_id, notification_time, active_days, is_enabled, role, timezone, strategy, assignees, backup_role, dm_reminders, topic_sync, user_group_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	// Convert ActiveDays to JSON for storage
@@ -32,10 +40,18 @@ func (r *schedulerRepo) Create(scheduler *entity.Scheduler) error {
 
 	result, err := r.db.Exec(query,
 		scheduler.ChannelID,
+		scheduler.RotationID,
 		scheduler.NotificationTime,
 		string(activeDaysJSON),
 		scheduler.IsEnabled,
 		scheduler.Role,
+		scheduler.Timezone,
+		scheduler.Strategy,
+		scheduler.Assignees,
+		scheduler.BackupRole,
+		scheduler.DMReminders,
+		scheduler.TopicSync,
+		scheduler.UserGroupID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create scheduler: %w", err)
@@ -50,38 +66,29 @@ func (r *schedulerRepo) Create(scheduler *entity.Scheduler) error {
 	return nil
 }
 
-func (r *schedulerRepo) GetByChannelID(channelID int64) (*entity.Scheduler, error) {
-	scheduler := &entity.Scheduler{}
+func (r *schedulerRepo) GetByRotationID(rotationID int64) (*entity.Scheduler, error) {
 	query := `
-		SELECT id, channel_id, notification_time, active_days, is_enabled, role, created_at, updated_at
-		FROM scheduler_configs
-		WHERE channel_id = ?
+		SELECT s.id, s.channel_id, s.rotation_id, s.notification_time, s.active_days, s.is_enabled, s.role, s.timezone, s.strategy, s.assignees, s.backup_role, s.dm_reminders, s.topic_sync, s.topic_prefix, s.user_group_id, s.last_notified_at, s.created_at, s.updated_at,
+		` + headsUpWantedColumn + `
+		FROM scheduler_configs s
+		WHERE s.rotation_id = ?
 	`
 
-	var activeDaysJSON string
-	err := r.db.QueryRow(query, channelID).Scan(
-		&scheduler.ID,
-		&scheduler.ChannelID,
-		&scheduler.NotificationTime,
-		&activeDaysJSON,
-		&scheduler.IsEnabled,
-		&scheduler.Role,
-		&scheduler.CreatedAt,
-		&scheduler.UpdatedAt,
-	)
-	if err == sql.ErrNoRows {
-		return nil, nil
-	}
-	if err != nil {
-		return nil, fmt.Errorf("failed to get scheduler: %w", err)
-	}
+	return r.getOne(query, rotationID)
+}
 
-	// Convert JSON to ActiveDays slice
-	if err := json.Unmarshal([]byte(activeDaysJSON), &scheduler.ActiveDays); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal active days: %w", err)
-	}
+// GetByChannelID returns the scheduler of the channel primary rotation, which holds the
+// settings shared by the whole channel such as the timezone used for holidays
+func (r *schedulerRepo) GetByChannelID(channelID int64) (*entity.Scheduler, error) {
+	query := `
+		SELECT s.id, s.channel_id, s.rotation_id, s.notification_time, s.active_days, s.is_enabled, s.role, s.timezone, s.strategy, s.assignees, s.backup_role, s.dm_reminders, s.topic_sync, s.topic_prefix, s.user_group_id, s.last_notified_at, s.created_at, s.updated_at,
+		` + headsUpWantedColumn + `
+		FROM scheduler_configs s
+		INNER JOIN rotations r ON r.id = s.rotation_id
+		WHERE s.channel_id = ? AND r.is_primary = 1
+	`
 
-	return scheduler, nil
+	return r.getOne(query, channelID)
 }
 
 func (r *schedulerRepo) Update(scheduler *entity.Scheduler) error {
@@ -91,8 +98,15 @@ func (r *schedulerRepo) Update(scheduler *entity.Scheduler) error {
 			active_days = ?,
 			is_enabled = ?,
 			role = ?,
+			timezone = ?,
+			strategy = ?,
+			assignees = ?,
+			backup_role = ?,
+			dm_reminders = ?,
+			topic_sync = ?,
+			user_group_id = ?,
 			updated_at = ?
-		WHERE channel_id = ?
+		WHERE rotation_id = ?
 	`
 
 	// Convert ActiveDays to JSON for storage
@@ -106,8 +120,15 @@ func (r *schedulerRepo) Update(scheduler *entity.Scheduler) error {
 		string(activeDaysJSON),
 		scheduler.IsEnabled,
 		scheduler.Role,
+		scheduler.Timezone,
+		scheduler.Strategy,
+		scheduler.Assignees,
+		scheduler.BackupRole,
+		scheduler.DMReminders,
+		scheduler.TopicSync,
+		scheduler.UserGroupID,
 		time.Now(),
-		scheduler.ChannelID,
+		scheduler.RotationID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update scheduler: %w", err)
@@ -116,10 +137,10 @@ func (r *schedulerRepo) Update(scheduler *entity.Scheduler) error {
 	return nil
 }
 
-func (r *schedulerRepo) Delete(channelID int64) error {
-	query := `DELETE FROM scheduler_configs WHERE channel_id = ?`
+func (r *schedulerRepo) Delete(rotationID int64) error {
+	query := `DELETE FROM scheduler_configs WHERE rotation_id = ?`
 
-	_, err := r.db.Exec(query, channelID)
+	_, err := r.db.Exec(query, rotationID)
 	if err != nil {
 		return fmt.Errorf("failed to delete scheduler: %w", err)
 	}
@@ -127,14 +148,161 @@ func (r *schedulerRepo) Delete(channelID int64) error {
 	return nil
 }
 
+// GetEnabled returns the schedulers to notify, leaving out the channels that are archived
 func (r *schedulerRepo) GetEnabled() ([]*entity.Scheduler, error) {
 	query := `
-		SELECT id, channel_id, notification_time, active_days, is_enabled, role, created_at, updated_at
-		FROM scheduler_configs
-		WHERE is_enabled = 1
+		SELECT s.id, s.channel_id, s.rotation_id, s.notification_time, s.active_days, s.is_enabled, s.role, s.timezone, s.strategy, s.assignees, s.backup_role, s.dm_reminders, s.topic_sync, s.topic_prefix, s.user_group_id, s.last_notified_at, s.created_at, s.updated_at,
+		` + headsUpWantedColumn + `
+		FROM scheduler_configs s
+		INNER JOIN channels c ON c.id = s.channel_id
+		WHERE s.is_enabled = 1 AND c.is_active = 1
+	`
+
+	return r.getMany(query)
+}
+
+// GetEnabledByChannelID returns the schedulers of the channel to notify, none when the channel
+// is archived
+func (r *schedulerRepo) GetEnabledByChannelID(channelID int64) ([]*entity.Scheduler, error) {
+	query := `
+		SELECT s.id, s.channel_id, s.rotation_id, s.notification_time, s.active_days, s.is_enabled, s.role, s.timezone, s.strategy, s.assignees, s.backup_role, s.dm_reminders, s.topic_sync, s.topic_prefix, s.user_group_id, s.last_notified_at, s.created_at, s.updated_at,
+		` + headsUpWantedColumn + `
+		FROM scheduler_configs s
+		INNER JOIN channels c ON c.id = s.channel_id
+		WHERE s.channel_id = ? AND s.is_enabled = 1 AND c.is_active = 1
+	`
+
+	return r.getMany(query, channelID)
+}
+
+func (r *schedulerRepo) SetEnabled(rotationID int64, enabled bool) error {
+	query := `
+		UPDATE scheduler_configs SET
+			is_enabled = ?,
+			updated_at = ?
+		WHERE rotation_id = ?
+	`
+
+	_, err := r.db.Exec(query, enabled, time.Now(), rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to set scheduler enabled status: %w", err)
+	}
+
+	return nil
+}
+
+// SetTopicPrefix records the start of the part of the channel topic the bot wrote for the
+// rotation. It is kept apart from Update, which saves the settings read before the topic
+// changed.
+func (r *schedulerRepo) SetTopicPrefix(rotationID int64, prefix string) error {
+	query := `
+		UPDATE scheduler_configs SET
+			topic_prefix = ?
+		WHERE rotation_id = ?
+	`
+
+	_, err := r.db.Exec(query, prefix, rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to set topic prefix: %w", err)
+	}
+
+	return nil
+}
+
+// MarkNotified records that the reminder of the rotation due at occurrence is being sent. It
+// reports false when that occurrence or a later one was already recorded, so each reminder
+// goes out at most once even if several schedulers race for it.
+func (r *schedulerRepo) MarkNotified(rotationID int64, occurrence time.Time) (bool, error) {
+	query := `
+		UPDATE scheduler_configs SET
+			last_notified_at = ?
+		WHERE rotation_id = ? AND (last_notified_at IS NULL OR last_notified_at < ?)
+	`
+
+	// Always stored in UTC so the stored values compare in chronological order
+	occurrence = occurrence.UTC()
+
+	result, err := r.db.Exec(query, occurrence, rotationID, occurrence)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark scheduler as notified: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// MarkHeadsUp records that the heads-up sent the day before the reminder due at occurrence is
+// being sent, reporting false when it was already recorded like MarkNotified
+func (r *schedulerRepo) MarkHeadsUp(rotationID int64, occurrence time.Time) (bool, error) {
+	query := `
+		UPDATE scheduler_configs SET
+			last_heads_up_at = ?
+		WHERE rotation_id = ? AND (last_heads_up_at IS NULL OR last_heads_up_at < ?)
 	`
 
-	rows, err := r.db.Query(query)
+	// Always stored in UTC so the stored values compare in chronological order
+	occurrence = occurrence.UTC()
+
+	result, err := r.db.Exec(query, occurrence, rotationID, occurrence)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark heads-up as sent: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+func (r *schedulerRepo) getOne(query string, args ...interface{}) (*entity.Scheduler, error) {
+	scheduler := &entity.Scheduler{}
+	var activeDaysJSON string
+	var lastNotifiedAt sql.NullTime
+	err := r.db.QueryRow(query, args...).Scan(
+		&scheduler.ID,
+		&scheduler.ChannelID,
+		&scheduler.RotationID,
+		&scheduler.NotificationTime,
+		&activeDaysJSON,
+		&scheduler.IsEnabled,
+		&scheduler.Role,
+		&scheduler.Timezone,
+		&scheduler.Strategy,
+		&scheduler.Assignees,
+		&scheduler.BackupRole,
+		&scheduler.DMReminders,
+		&scheduler.TopicSync,
+		&scheduler.TopicPrefix,
+		&scheduler.UserGroupID,
+		&lastNotifiedAt,
+		&scheduler.CreatedAt,
+		&scheduler.UpdatedAt,
+		&scheduler.HeadsUpWanted,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduler: %w", err)
+	}
+	scheduler.LastNotifiedAt = lastNotifiedAt.Time
+
+	// Convert JSON to ActiveDays slice
+	if err := json.Unmarshal([]byte(activeDaysJSON), &scheduler.ActiveDays); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal active days: %w", err)
+	}
+
+	return scheduler, nil
+}
+
+func (r *schedulerRepo) getMany(query string, args ...interface{}) ([]*entity.Scheduler, error) {
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get enabled schedulers: %w", err)
 	}
@@ -144,19 +312,32 @@ func (r *schedulerRepo) GetEnabled() ([]*entity.Scheduler, error) {
 	for rows.Next() {
 		scheduler := &entity.Scheduler{}
 		var activeDaysJSON string
+		var lastNotifiedAt sql.NullTime
 		err := rows.Scan(
 			&scheduler.ID,
 			&scheduler.ChannelID,
+			&scheduler.RotationID,
 			&scheduler.NotificationTime,
 			&activeDaysJSON,
 			&scheduler.IsEnabled,
 			&scheduler.Role,
+			&scheduler.Timezone,
+			&scheduler.Strategy,
+			&scheduler.Assignees,
+			&scheduler.BackupRole,
+			&scheduler.DMReminders,
+			&scheduler.TopicSync,
+			&scheduler.TopicPrefix,
+			&scheduler.UserGroupID,
+			&lastNotifiedAt,
 			&scheduler.CreatedAt,
 			&scheduler.UpdatedAt,
+			&scheduler.HeadsUpWanted,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan scheduler: %w", err)
 		}
+		scheduler.LastNotifiedAt = lastNotifiedAt.Time
 
 		// Convert JSON to ActiveDays slice
 		if err := json.Unmarshal([]byte(activeDaysJSON), &scheduler.ActiveDays); err != nil {
@@ -167,19 +348,3 @@ func (r *schedulerRepo) GetEnabled() ([]*entity.Scheduler, error) {
 
 	return schedulers, nil
 }
-
-func (r *schedulerRepo) SetEnabled(channelID int64, enabled bool) error {
-	query := `
-		UPDATE scheduler_configs SET
-			is_enabled = ?,
-			updated_at = ?
-		WHERE channel_id = ?
-	`
-
-	_, err := r.db.Exec(query, enabled, time.Now(), channelID)
-	if err != nil {
-		return fmt.Errorf("failed to set scheduler enabled status: %w", err)
-	}
-
-	return nil
-}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"testing"
+	"time"
 
 	"github.com/diegoclair/slack-rotation-bot/internal/domain"
 	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
@@ -25,9 +26,11 @@ func TestSchedulerRepository_Create(t *testing.T) {
 	}
 	err := channelRepo.Create(channel)
 	require.NoError(t, err)
+	rotation := createTestRotation(t, db, channel.ID)
 
 	scheduler := &entity.Scheduler{
 		ChannelID:        channel.ID,
+		RotationID:       rotation.ID,
 		NotificationTime: "09:00",
 		ActiveDays:       domain.DefaultActiveDays,
 		IsEnabled:        true,
@@ -56,10 +59,12 @@ func TestSchedulerRepository_GetByChannelID(t *testing.T) {
 	}
 	err := channelRepo.Create(channel)
 	require.NoError(t, err)
+	rotation := createTestRotation(t, db, channel.ID)
 
 	// Create a test scheduler
 	original := &entity.Scheduler{
 		ChannelID:        channel.ID,
+		RotationID:       rotation.ID,
 		NotificationTime: "14:30",
 		ActiveDays:       []int{1, 3, 5}, // Mon, Wed, Fri
 		IsEnabled:        true,
@@ -75,6 +80,7 @@ func TestSchedulerRepository_GetByChannelID(t *testing.T) {
 	require.NotNil(t, found, "Expected to find scheduler")
 
 	assert.Equal(t, original.ChannelID, found.ChannelID)
+	assert.Equal(t, original.RotationID, found.RotationID)
 	assert.Equal(t, original.NotificationTime, found.NotificationTime)
 	assert.Equal(t, original.ActiveDays, found.ActiveDays)
 	assert.Equal(t, original.IsEnabled, found.IsEnabled)
@@ -86,6 +92,60 @@ func TestSchedulerRepository_GetByChannelID(t *testing.T) {
 	assert.Nil(t, notFound, "Expected nil when scheduler not found")
 }
 
+func TestSchedulerRepository_GetByRotationID(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newSchedulerRepo(db.conn)
+	rotationRepo := newRotationRepo(db.conn)
+
+	// Create a channel with a primary and a named rotation
+	channelRepo := newChannelRepo(db.conn)
+	channel := &entity.Channel{
+		SlackChannelID:   "C123456789",
+		SlackChannelName: "test-channel",
+		SlackTeamID:      "T123456789",
+		IsActive:         true,
+	}
+	err := channelRepo.Create(channel)
+	require.NoError(t, err)
+	primary := createTestRotation(t, db, channel.ID)
+	reviewers := &entity.Rotation{ChannelID: channel.ID, Name: "reviewers"}
+	require.NoError(t, rotationRepo.Create(reviewers))
+
+	for _, s := range []*entity.Scheduler{
+		{ChannelID: channel.ID, RotationID: primary.ID, NotificationTime: "09:00", ActiveDays: domain.DefaultActiveDays, IsEnabled: true, Role: "presenter"},
+		{ChannelID: channel.ID, RotationID: reviewers.ID, NotificationTime: "14:00", ActiveDays: []int{2, 4}, IsEnabled: true, Role: "reviewer"},
+	} {
+		require.NoError(t, repo.Create(s), "Failed to create test scheduler")
+	}
+
+	t.Run("should return the scheduler of the rotation", func(t *testing.T) {
+		found, err := repo.GetByRotationID(reviewers.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+
+		assert.Equal(t, reviewers.ID, found.RotationID)
+		assert.Equal(t, "14:00", found.NotificationTime)
+		assert.Equal(t, "reviewer", found.Role)
+	})
+
+	t.Run("should return the primary rotation scheduler by channel", func(t *testing.T) {
+		found, err := repo.GetByChannelID(channel.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+
+		assert.Equal(t, primary.ID, found.RotationID)
+		assert.Equal(t, "09:00", found.NotificationTime)
+	})
+
+	t.Run("should return nil when rotation not found", func(t *testing.T) {
+		found, err := repo.GetByRotationID(99999)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
+
 func TestSchedulerRepository_Update(t *testing.T) {
 	db := SetupTestDB(t)
 	defer CleanupTestDB(t, db)
@@ -102,10 +162,12 @@ func TestSchedulerRepository_Update(t *testing.T) {
 	}
 	err := channelRepo.Create(channel)
 	require.NoError(t, err)
+	rotation := createTestRotation(t, db, channel.ID)
 
 	// Create a test scheduler
 	scheduler := &entity.Scheduler{
 		ChannelID:        channel.ID,
+		RotationID:       rotation.ID,
 		NotificationTime: "09:00",
 		ActiveDays:       domain.DefaultActiveDays,
 		IsEnabled:        true,
@@ -120,6 +182,12 @@ func TestSchedulerRepository_Update(t *testing.T) {
 	scheduler.ActiveDays = []int{2, 4} // Tue, Thu
 	scheduler.IsEnabled = false
 	scheduler.Role = "facilitator"
+	scheduler.Timezone = "America/Sao_Paulo"
+	scheduler.Strategy = domain.StrategyFair
+	scheduler.Assignees = 2
+	scheduler.BackupRole = "shadow"
+	scheduler.TopicSync = true
+	scheduler.UserGroupID = "S0123ABCDEF"
 
 	err = repo.Update(scheduler)
 	require.NoError(t, err, "Failed to update scheduler")
@@ -133,6 +201,12 @@ func TestSchedulerRepository_Update(t *testing.T) {
 	assert.Equal(t, []int{2, 4}, updated.ActiveDays)
 	assert.False(t, updated.IsEnabled)
 	assert.Equal(t, "facilitator", updated.Role)
+	assert.Equal(t, "America/Sao_Paulo", updated.Timezone)
+	assert.Equal(t, domain.StrategyFair, updated.Strategy)
+	assert.Equal(t, 2, updated.Assignees)
+	assert.Equal(t, "shadow", updated.BackupRole)
+	assert.True(t, updated.TopicSync)
+	assert.Equal(t, "S0123ABCDEF", updated.UserGroupID)
 }
 
 func TestSchedulerRepository_Delete(t *testing.T) {
@@ -151,10 +225,12 @@ func TestSchedulerRepository_Delete(t *testing.T) {
 	}
 	err := channelRepo.Create(channel)
 	require.NoError(t, err)
+	rotation := createTestRotation(t, db, channel.ID)
 
 	// Create a test scheduler
 	scheduler := &entity.Scheduler{
 		ChannelID:        channel.ID,
+		RotationID:       rotation.ID,
 		NotificationTime: "09:00",
 		ActiveDays:       domain.DefaultActiveDays,
 		IsEnabled:        true,
@@ -165,7 +241,7 @@ func TestSchedulerRepository_Delete(t *testing.T) {
 	require.NoError(t, err, "Failed to create test scheduler")
 
 	// Delete the scheduler
-	err = repo.Delete(channel.ID)
+	err = repo.Delete(rotation.ID)
 	require.NoError(t, err, "Failed to delete scheduler")
 
 	// Verify deletion
@@ -203,15 +279,18 @@ func TestSchedulerRepository_GetEnabled(t *testing.T) {
 		},
 	}
 
+	rotations := make([]*entity.Rotation, 0, len(channels))
 	for _, ch := range channels {
 		err := channelRepo.Create(ch)
 		require.NoError(t, err, "Failed to create test channel")
+		rotations = append(rotations, createTestRotation(t, db, ch.ID))
 	}
 
 	// Create test schedulers
 	schedulers := []*entity.Scheduler{
 		{
 			ChannelID:        channels[0].ID,
+			RotationID:       rotations[0].ID,
 			NotificationTime: "09:00",
 			ActiveDays:       domain.DefaultActiveDays,
 			IsEnabled:        true, // Enabled
@@ -219,6 +298,7 @@ func TestSchedulerRepository_GetEnabled(t *testing.T) {
 		},
 		{
 			ChannelID:        channels[1].ID,
+			RotationID:       rotations[1].ID,
 			NotificationTime: "10:00",
 			ActiveDays:       domain.DefaultActiveDays,
 			IsEnabled:        true, // Enabled
@@ -226,6 +306,7 @@ func TestSchedulerRepository_GetEnabled(t *testing.T) {
 		},
 		{
 			ChannelID:        channels[2].ID,
+			RotationID:       rotations[2].ID,
 			NotificationTime: "11:00",
 			ActiveDays:       domain.DefaultActiveDays,
 			IsEnabled:        false, // Disabled
@@ -249,6 +330,74 @@ func TestSchedulerRepository_GetEnabled(t *testing.T) {
 	for _, s := range enabledSchedulers {
 		assert.True(t, s.IsEnabled, "Expected all returned schedulers to be enabled")
 	}
+
+	// Archived channels are not notified
+	channels[0].IsActive = false
+	require.NoError(t, channelRepo.Update(channels[0]))
+
+	enabledSchedulers, err = repo.GetEnabled()
+	require.NoError(t, err)
+	assert.Len(t, enabledSchedulers, 1)
+	for _, s := range enabledSchedulers {
+		assert.NotEqual(t, channels[0].ID, s.ChannelID, "Expected archived channel to be left out")
+	}
+}
+
+func TestSchedulerRepository_GetEnabledByChannelID(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newSchedulerRepo(db.conn)
+	channelRepo := newChannelRepo(db.conn)
+
+	channel := &entity.Channel{
+		SlackChannelID:   "C123456789",
+		SlackChannelName: "channel-1",
+		SlackTeamID:      "T123456789",
+		IsActive:         true,
+	}
+	other := &entity.Channel{
+		SlackChannelID:   "C987654321",
+		SlackChannelName: "channel-2",
+		SlackTeamID:      "T123456789",
+		IsActive:         true,
+	}
+	require.NoError(t, channelRepo.Create(channel))
+	require.NoError(t, channelRepo.Create(other))
+
+	// Two rotations in the channel, one of them paused, and one in another channel
+	oncall := &entity.Rotation{ChannelID: channel.ID, Name: "oncall"}
+	require.NoError(t, newRotationRepo(db.conn).Create(oncall))
+	rotations := []*entity.Rotation{
+		createTestRotation(t, db, channel.ID),
+		oncall,
+		createTestRotation(t, db, other.ID),
+	}
+	for i, rotation := range rotations {
+		err := repo.Create(&entity.Scheduler{
+			ChannelID:        rotation.ChannelID,
+			RotationID:       rotation.ID,
+			NotificationTime: "09:00",
+			ActiveDays:       domain.DefaultActiveDays,
+			IsEnabled:        i != 1,
+			Role:             "presenter",
+		})
+		require.NoError(t, err, "Failed to create test scheduler")
+	}
+
+	enabledSchedulers, err := repo.GetEnabledByChannelID(channel.ID)
+	require.NoError(t, err)
+	require.Len(t, enabledSchedulers, 1)
+	assert.Equal(t, rotations[0].ID, enabledSchedulers[0].RotationID)
+	assert.Equal(t, domain.DefaultActiveDays, enabledSchedulers[0].ActiveDays)
+
+	// Archived channels are not notified
+	channel.IsActive = false
+	require.NoError(t, channelRepo.Update(channel))
+
+	enabledSchedulers, err = repo.GetEnabledByChannelID(channel.ID)
+	require.NoError(t, err)
+	assert.Empty(t, enabledSchedulers)
 }
 
 func TestSchedulerRepository_SetEnabled(t *testing.T) {
@@ -267,10 +416,12 @@ func TestSchedulerRepository_SetEnabled(t *testing.T) {
 	}
 	err := channelRepo.Create(channel)
 	require.NoError(t, err)
+	rotation := createTestRotation(t, db, channel.ID)
 
 	// Create a test scheduler
 	scheduler := &entity.Scheduler{
 		ChannelID:        channel.ID,
+		RotationID:       rotation.ID,
 		NotificationTime: "09:00",
 		ActiveDays:       domain.DefaultActiveDays,
 		IsEnabled:        true,
@@ -281,7 +432,7 @@ func TestSchedulerRepository_SetEnabled(t *testing.T) {
 	require.NoError(t, err, "Failed to create test scheduler")
 
 	// Disable the scheduler
-	err = repo.SetEnabled(channel.ID, false)
+	err = repo.SetEnabled(rotation.ID, false)
 	require.NoError(t, err, "Failed to set scheduler disabled")
 
 	// Verify the change
@@ -291,7 +442,7 @@ func TestSchedulerRepository_SetEnabled(t *testing.T) {
 	assert.False(t, updated.IsEnabled, "Expected scheduler to be disabled")
 
 	// Enable it again
-	err = repo.SetEnabled(channel.ID, true)
+	err = repo.SetEnabled(rotation.ID, true)
 	require.NoError(t, err, "Failed to set scheduler enabled")
 
 	// Verify the change
@@ -300,3 +451,198 @@ func TestSchedulerRepository_SetEnabled(t *testing.T) {
 	require.NotNil(t, updated, "Expected to find updated scheduler")
 	assert.True(t, updated.IsEnabled, "Expected scheduler to be enabled")
 }
+
+func TestSchedulerRepository_SetTopicPrefix(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newSchedulerRepo(db.conn)
+
+	// Create a channel first
+	channelRepo := newChannelRepo(db.conn)
+	channel := &entity.Channel{
+		SlackChannelID:   "C123456789",
+		SlackChannelName: "test-channel",
+		SlackTeamID:      "T123456789",
+		IsActive:         true,
+	}
+	err := channelRepo.Create(channel)
+	require.NoError(t, err)
+	rotation := createTestRotation(t, db, channel.ID)
+
+	scheduler := &entity.Scheduler{
+		ChannelID:        channel.ID,
+		RotationID:       rotation.ID,
+		NotificationTime: "09:00",
+		ActiveDays:       domain.DefaultActiveDays,
+		IsEnabled:        true,
+		Role:             "presenter",
+		TopicSync:        true,
+	}
+	require.NoError(t, repo.Create(scheduler))
+
+	err = repo.SetTopicPrefix(rotation.ID, "presenter: ")
+	require.NoError(t, err)
+
+	updated, err := repo.GetByRotationID(rotation.ID)
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, "presenter: ", updated.TopicPrefix)
+
+	// Saving the settings read before keeps the prefix
+	scheduler.Role = "On duty"
+	require.NoError(t, repo.Update(scheduler))
+
+	updated, err = repo.GetByRotationID(rotation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "presenter: ", updated.TopicPrefix)
+	assert.Equal(t, "On duty", updated.Role)
+}
+
+func TestSchedulerRepository_MarkNotified(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newSchedulerRepo(db.conn)
+
+	// Create a channel first
+	channelRepo := newChannelRepo(db.conn)
+	channel := &entity.Channel{
+		SlackChannelID:   "C123456789",
+		SlackChannelName: "test-channel",
+		SlackTeamID:      "T123456789",
+		IsActive:         true,
+	}
+	err := channelRepo.Create(channel)
+	require.NoError(t, err)
+	rotation := createTestRotation(t, db, channel.ID)
+
+	scheduler := &entity.Scheduler{
+		ChannelID:        channel.ID,
+		RotationID:       rotation.ID,
+		NotificationTime: "09:00",
+		ActiveDays:       domain.DefaultActiveDays,
+		IsEnabled:        true,
+		Role:             "presenter",
+	}
+	require.NoError(t, repo.Create(scheduler))
+
+	created, err := repo.GetByRotationID(rotation.ID)
+	require.NoError(t, err)
+	assert.True(t, created.LastNotifiedAt.IsZero(), "Expected no notification before the first one")
+
+	monday := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+
+	claimed, err := repo.MarkNotified(rotation.ID, monday)
+	require.NoError(t, err)
+	assert.True(t, claimed, "Expected the first notification to be claimed")
+
+	// The same occurrence is only claimed once, whatever the timezone it is given in
+	claimed, err = repo.MarkNotified(rotation.ID, monday.In(time.FixedZone("BRT", -3*60*60)))
+	require.NoError(t, err)
+	assert.False(t, claimed, "Expected the occurrence to be claimed only once")
+
+	claimed, err = repo.MarkNotified(rotation.ID, tuesday)
+	require.NoError(t, err)
+	assert.True(t, claimed, "Expected the next occurrence to be claimed")
+
+	claimed, err = repo.MarkNotified(rotation.ID, monday)
+	require.NoError(t, err)
+	assert.False(t, claimed, "Expected an older occurrence not to be claimed")
+
+	updated, err := repo.GetByRotationID(rotation.ID)
+	require.NoError(t, err)
+	assert.True(t, tuesday.Equal(updated.LastNotifiedAt), "Expected %v but got %v", tuesday, updated.LastNotifiedAt)
+}
+
+func TestSchedulerRepository_MarkHeadsUp(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newSchedulerRepo(db.conn)
+	channel := createTestChannel(t, db, "C123456789")
+	rotation := createTestRotation(t, db, channel.ID)
+
+	require.NoError(t, repo.Create(&entity.Scheduler{
+		ChannelID:        channel.ID,
+		RotationID:       rotation.ID,
+		NotificationTime: "09:00",
+		ActiveDays:       domain.DefaultActiveDays,
+		IsEnabled:        true,
+	}))
+
+	monday := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	claimed, err := repo.MarkHeadsUp(rotation.ID, monday)
+	require.NoError(t, err)
+	assert.True(t, claimed, "Expected the first heads-up to be claimed")
+
+	claimed, err = repo.MarkHeadsUp(rotation.ID, monday)
+	require.NoError(t, err)
+	assert.False(t, claimed, "Expected the heads-up to be claimed only once")
+
+	// Heads-ups are tracked apart from the reminders
+	claimed, err = repo.MarkNotified(rotation.ID, monday)
+	require.NoError(t, err)
+	assert.True(t, claimed, "Expected the reminder to be claimed after its heads-up")
+}
+
+func TestSchedulerRepository_HeadsUpWanted(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newSchedulerRepo(db.conn)
+	userRepo := newUserRepo(db.conn)
+	channel := createTestChannel(t, db, "C123456789")
+	rotation := createTestRotation(t, db, channel.ID)
+
+	scheduler := &entity.Scheduler{
+		ChannelID:        channel.ID,
+		RotationID:       rotation.ID,
+		NotificationTime: "09:00",
+		ActiveDays:       domain.DefaultActiveDays,
+		IsEnabled:        true,
+		DMReminders:      domain.DMRemindersOff,
+	}
+	require.NoError(t, repo.Create(scheduler))
+
+	user := &entity.User{ChannelID: channel.ID, RotationID: rotation.ID, SlackUserID: "U123456789", IsActive: true}
+	require.NoError(t, userRepo.Create(user))
+
+	headsUpWanted := func() bool {
+		t.Helper()
+		schedulers, err := repo.GetEnabledByChannelID(channel.ID)
+		require.NoError(t, err)
+		require.Len(t, schedulers, 1)
+		return schedulers[0].HeadsUpWanted
+	}
+
+	t.Run("should not want heads-ups by default", func(t *testing.T) {
+		created, err := repo.GetByRotationID(rotation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.DMRemindersOff, created.DMReminders)
+		assert.False(t, created.HeadsUpWanted)
+	})
+
+	t.Run("should want heads-ups when members follow the rotation setting", func(t *testing.T) {
+		scheduler.DMReminders = domain.DMRemindersDayBefore
+		require.NoError(t, repo.Update(scheduler))
+
+		assert.True(t, headsUpWanted())
+	})
+
+	t.Run("should not want heads-ups when the members chose otherwise", func(t *testing.T) {
+		require.NoError(t, userRepo.SetDMReminders(user.ID, domain.DMRemindersOn))
+
+		assert.False(t, headsUpWanted())
+	})
+
+	t.Run("should want heads-ups when a member asked for them", func(t *testing.T) {
+		scheduler.DMReminders = domain.DMRemindersOff
+		require.NoError(t, repo.Update(scheduler))
+		require.NoError(t, userRepo.SetDMReminders(user.ID, domain.DMRemindersDayBefore))
+
+		assert.True(t, headsUpWanted())
+	})
+}
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+)
+
+type swapRepo struct {
+	db dbConn
+}
+
+func newSwapRepo(db dbConn) contract.SwapRepo {
+	return &swapRepo{db: db}
+}
+
+func (r *swapRepo) Create(swap *entity.Swap) error {
+	query := `
+		INSERT INTO rotation_swaps (channel_id, rotation_id, user_a_id, user_b_id, swap_date, applied_at, created_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	// Zero dates are stored as NULL
+	var swapDate sql.NullString
+	if !swap.SwapDate.IsZero() {
+		swapDate = sql.NullString{String: swap.SwapDate.Format(domain.DateFormat), Valid: true}
+	}
+	var appliedAt sql.NullTime
+	if !swap.AppliedAt.IsZero() {
+		appliedAt = sql.NullTime{Time: swap.AppliedAt, Valid: true}
+	}
+
+	result, err := r.db.Exec(query,
+		swap.ChannelID,
+		swap.RotationID,
+		swap.UserAID,
+		swap.UserBID,
+		swapDate,
+		appliedAt,
+		swap.CreatedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create swap: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	swap.ID = id
+	return nil
+}
+
+// GetPendingByRotation returns the dated swaps not applied yet whose date is on or before until
+func (r *swapRepo) GetPendingByRotation(rotationID int64, until time.Time) ([]*entity.Swap, error) {
+	query := `
+		SELECT id, channel_id, rotation_id, user_a_id, user_b_id, swap_date, created_by, created_at
+		FROM rotation_swaps
+		WHERE rotation_id = ? AND applied_at IS NULL AND swap_date IS NOT NULL AND swap_date <= ?
+		ORDER BY swap_date ASC, id ASC
+	`
+
+	rows, err := r.db.Query(query, rotationID, until.Format(domain.DateFormat))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending swaps: %w", err)
+	}
+	defer rows.Close()
+
+	var swaps []*entity.Swap
+	for rows.Next() {
+		swap := &entity.Swap{}
+		var swapDate string
+		err := rows.Scan(
+			&swap.ID,
+			&swap.ChannelID,
+			&swap.RotationID,
+			&swap.UserAID,
+			&swap.UserBID,
+			&swapDate,
+			&swap.CreatedBy,
+			&swap.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan swap: %w", err)
+		}
+
+		if swap.SwapDate, err = time.Parse(domain.DateFormat, swapDate); err != nil {
+			return nil, fmt.Errorf("failed to parse swap date: %w", err)
+		}
+		swaps = append(swaps, swap)
+	}
+
+	return swaps, nil
+}
+
+func (r *swapRepo) MarkApplied(swapID int64, appliedAt time.Time) error {
+	query := `UPDATE rotation_swaps SET applied_at = ? WHERE id = ?`
+
+	_, err := r.db.Exec(query, appliedAt, swapID)
+	if err != nil {
+		return fmt.Errorf("failed to mark swap as applied: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwapRepository_Create(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newSwapRepo(db.conn)
+	userA, userB := createTestSwapUsers(t, db)
+
+	t.Run("should create immediate swap", func(t *testing.T) {
+		swap := &entity.Swap{
+			ChannelID:  userA.ChannelID,
+			RotationID: userA.RotationID,
+			UserAID:    userA.ID,
+			UserBID:    userB.ID,
+			AppliedAt:  time.Now(),
+			CreatedBy:  "U987654321",
+		}
+
+		err := repo.Create(swap)
+		require.NoError(t, err, "Failed to create swap")
+
+		assert.NotZero(t, swap.ID, "Expected swap ID to be set after creation")
+	})
+
+	t.Run("should create dated swap", func(t *testing.T) {
+		swap := &entity.Swap{
+			ChannelID:  userA.ChannelID,
+			RotationID: userA.RotationID,
+			UserAID:    userA.ID,
+			UserBID:    userB.ID,
+			SwapDate:   time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC),
+		}
+
+		err := repo.Create(swap)
+		require.NoError(t, err, "Failed to create swap")
+
+		assert.NotZero(t, swap.ID, "Expected swap ID to be set after creation")
+	})
+}
+
+func TestSwapRepository_GetPendingByRotation(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repo := newSwapRepo(db.conn)
+	userA, userB := createTestSwapUsers(t, db)
+
+	// Immediate swaps are applied on creation and never pending
+	require.NoError(t, repo.Create(&entity.Swap{
+		ChannelID:  userA.ChannelID,
+		RotationID: userA.RotationID,
+		UserAID:    userA.ID,
+		UserBID:    userB.ID,
+		AppliedAt:  time.Now(),
+	}))
+	for _, day := range []int{22, 20, 27} {
+		require.NoError(t, repo.Create(&entity.Swap{
+			ChannelID:  userA.ChannelID,
+			RotationID: userA.RotationID,
+			UserAID:    userA.ID,
+			UserBID:    userB.ID,
+			SwapDate:   time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC),
+			CreatedBy:  "U987654321",
+		}))
+	}
+
+	t.Run("should return dated swaps due until the given day, oldest first", func(t *testing.T) {
+		swaps, err := repo.GetPendingByRotation(userA.RotationID, time.Date(2026, 10, 22, 9, 0, 0, 0, time.UTC))
+
+		require.NoError(t, err)
+		require.Len(t, swaps, 2)
+		assert.Equal(t, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), swaps[0].SwapDate)
+		assert.Equal(t, time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC), swaps[1].SwapDate)
+		assert.Equal(t, userA.ID, swaps[0].UserAID)
+		assert.Equal(t, userB.ID, swaps[0].UserBID)
+		assert.Equal(t, "U987654321", swaps[0].CreatedBy)
+		assert.True(t, swaps[0].IsPending())
+	})
+
+	t.Run("should skip applied swaps", func(t *testing.T) {
+		swaps, err := repo.GetPendingByRotation(userA.RotationID, time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Len(t, swaps, 3)
+
+		require.NoError(t, repo.MarkApplied(swaps[0].ID, time.Now()))
+
+		swaps, err = repo.GetPendingByRotation(userA.RotationID, time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Len(t, swaps, 2)
+		assert.Equal(t, time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC), swaps[0].SwapDate)
+	})
+
+	t.Run("should return empty slice for rotation without swaps", func(t *testing.T) {
+		swaps, err := repo.GetPendingByRotation(999, time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC))
+
+		require.NoError(t, err)
+		assert.Empty(t, swaps)
+	})
+}
+
+// createTestSwapUsers creates two members in the same rotation
+func createTestSwapUsers(t *testing.T, db *DB) (*entity.User, *entity.User) {
+	t.Helper()
+
+	userA := createTestUser(t, db, "C123456789", "U111111111")
+	userB := &entity.User{
+		ChannelID:   userA.ChannelID,
+		RotationID:  userA.RotationID,
+		SlackUserID: "U222222222",
+		DisplayName: "Other User",
+		IsActive:    true,
+	}
+	require.NoError(t, newUserRepo(db.conn).Create(userB))
+
+	return userA, userB
+}
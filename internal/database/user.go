@@ -17,18 +17,28 @@ func newUserRepo(db dbConn) contract.UserRepo {
 }
 
 func (r *userRepo) Create(user *entity.User) error {
+	// New members go to the end of the rotation order
+	var position int
+	err := r.db.QueryRow(`SELECT COALESCE(MAX(position), 0) + 1 FROM users WHERE rotation_id = ?`, user.RotationID).Scan(&position)
+	if err != nil {
+		return fmt.Errorf("failed to get next position: %w", err)
+	}
+
 	query := `
-		INSERT INTO users (channel_id, slack_user_id, slack_user_name, display_name, is_active, last_presenter)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO users (channel_id, rotation_id, slack_user_id, slack_user_name, display_name, is_active, last_presenter, last_backup, position)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.Exec(query,
 		user.ChannelID,
+		user.RotationID,
 		user.SlackUserID,
 		user.SlackUserName,
 		user.DisplayName,
 		user.IsActive,
 		user.LastPresenter,
+		user.LastBackup,
+		position,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
@@ -40,25 +50,30 @@ func (r *userRepo) Create(user *entity.User) error {
 	}
 
 	user.ID = id
+	user.Position = position
 	return nil
 }
 
-func (r *userRepo) GetByChannelAndSlackID(channelID int64, slackUserID string) (*entity.User, error) {
+func (r *userRepo) GetByRotationAndSlackID(rotationID int64, slackUserID string) (*entity.User, error) {
 	user := &entity.User{}
 	query := `
-		SELECT id, channel_id, slack_user_id, slack_user_name, display_name, is_active, last_presenter, joined_at
+		SELECT id, channel_id, rotation_id, slack_user_id, slack_user_name, display_name, is_active, last_presenter, last_backup, position, dm_reminders, joined_at
 		FROM users
-		WHERE channel_id = ? AND slack_user_id = ?
+		WHERE rotation_id = ? AND slack_user_id = ?
 	`
 
-	err := r.db.QueryRow(query, channelID, slackUserID).Scan(
+	err := r.db.QueryRow(query, rotationID, slackUserID).Scan(
 		&user.ID,
 		&user.ChannelID,
+		&user.RotationID,
 		&user.SlackUserID,
 		&user.SlackUserName,
 		&user.DisplayName,
 		&user.IsActive,
 		&user.LastPresenter,
+		&user.LastBackup,
+		&user.Position,
+		&user.DMReminders,
 		&user.JoinedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -71,15 +86,56 @@ func (r *userRepo) GetByChannelAndSlackID(channelID int64, slackUserID string) (
 	return user, nil
 }
 
-func (r *userRepo) GetActiveUsersByChannel(channelID int64) ([]*entity.User, error) {
+func (r *userRepo) GetActiveUsersByRotation(rotationID int64) ([]*entity.User, error) {
+	query := `
+		SELECT id, channel_id, rotation_id, slack_user_id, slack_user_name, display_name, is_active, last_presenter, last_backup, position, dm_reminders, joined_at
+		FROM users
+		WHERE rotation_id = ? AND is_active = 1
+		ORDER BY position ASC, joined_at ASC, id ASC
+	`
+
+	rows, err := r.db.Query(query, rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*entity.User
+	for rows.Next() {
+		user := &entity.User{}
+		err := rows.Scan(
+			&user.ID,
+			&user.ChannelID,
+			&user.RotationID,
+			&user.SlackUserID,
+			&user.SlackUserName,
+			&user.DisplayName,
+			&user.IsActive,
+			&user.LastPresenter,
+			&user.LastBackup,
+			&user.Position,
+			&user.DMReminders,
+			&user.JoinedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// GetBySlackUserID returns every rotation membership of the Slack user, in all channels
+func (r *userRepo) GetBySlackUserID(slackUserID string) ([]*entity.User, error) {
 	query := `
-		SELECT id, channel_id, slack_user_id, slack_user_name, display_name, is_active, last_presenter, joined_at
+		SELECT id, channel_id, rotation_id, slack_user_id, slack_user_name, display_name, is_active, last_presenter, last_backup, position, dm_reminders, joined_at
 		FROM users
-		WHERE channel_id = ? AND is_active = 1
-		ORDER BY joined_at ASC
+		WHERE slack_user_id = ?
+		ORDER BY channel_id ASC, rotation_id ASC
 	`
 
-	rows, err := r.db.Query(query, channelID)
+	rows, err := r.db.Query(query, slackUserID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get users: %w", err)
 	}
@@ -91,11 +147,15 @@ func (r *userRepo) GetActiveUsersByChannel(channelID int64) ([]*entity.User, err
 		err := rows.Scan(
 			&user.ID,
 			&user.ChannelID,
+			&user.RotationID,
 			&user.SlackUserID,
 			&user.SlackUserName,
 			&user.DisplayName,
 			&user.IsActive,
 			&user.LastPresenter,
+			&user.LastBackup,
+			&user.Position,
+			&user.DMReminders,
 			&user.JoinedAt,
 		)
 		if err != nil {
@@ -118,9 +178,9 @@ func (r *userRepo) Delete(userID int64) error {
 	return nil
 }
 
-func (r *userRepo) ClearLastPresenter(channelID int64) error {
-	query := `UPDATE users SET last_presenter = 0 WHERE channel_id = ?`
-	_, err := r.db.Exec(query, channelID)
+func (r *userRepo) ClearLastPresenter(rotationID int64) error {
+	query := `UPDATE users SET last_presenter = 0 WHERE rotation_id = ?`
+	_, err := r.db.Exec(query, rotationID)
 	if err != nil {
 		return fmt.Errorf("failed to clear last presenter: %w", err)
 	}
@@ -136,23 +196,27 @@ func (r *userRepo) SetLastPresenter(userID int64) error {
 	return nil
 }
 
-func (r *userRepo) GetLastPresenter(channelID int64) (*entity.User, error) {
+func (r *userRepo) GetLastPresenter(rotationID int64) (*entity.User, error) {
 	user := &entity.User{}
 	query := `
-		SELECT id, channel_id, slack_user_id, slack_user_name, display_name, is_active, last_presenter, joined_at
+		SELECT id, channel_id, rotation_id, slack_user_id, slack_user_name, display_name, is_active, last_presenter, last_backup, position, dm_reminders, joined_at
 		FROM users
-		WHERE channel_id = ? AND last_presenter = 1
+		WHERE rotation_id = ? AND last_presenter = 1
 		LIMIT 1
 	`
 
-	err := r.db.QueryRow(query, channelID).Scan(
+	err := r.db.QueryRow(query, rotationID).Scan(
 		&user.ID,
 		&user.ChannelID,
+		&user.RotationID,
 		&user.SlackUserID,
 		&user.SlackUserName,
 		&user.DisplayName,
 		&user.IsActive,
 		&user.LastPresenter,
+		&user.LastBackup,
+		&user.Position,
+		&user.DMReminders,
 		&user.JoinedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -164,3 +228,85 @@ func (r *userRepo) GetLastPresenter(channelID int64) (*entity.User, error) {
 
 	return user, nil
 }
+
+func (r *userRepo) ClearLastBackups(rotationID int64) error {
+	query := `UPDATE users SET last_backup = 0 WHERE rotation_id = ?`
+	_, err := r.db.Exec(query, rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to clear last backups: %w", err)
+	}
+	return nil
+}
+
+func (r *userRepo) SetLastBackup(userID int64) error {
+	query := `UPDATE users SET last_backup = 1 WHERE id = ?`
+	_, err := r.db.Exec(query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to set last backup: %w", err)
+	}
+	return nil
+}
+
+// GetLastBackups returns the members backing up the current presenter in rotation order
+func (r *userRepo) GetLastBackups(rotationID int64) ([]*entity.User, error) {
+	query := `
+		SELECT id, channel_id, rotation_id, slack_user_id, slack_user_name, display_name, is_active, last_presenter, last_backup, position, dm_reminders, joined_at
+		FROM users
+		WHERE rotation_id = ? AND last_backup = 1
+		ORDER BY position ASC, joined_at ASC, id ASC
+	`
+
+	rows, err := r.db.Query(query, rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last backups: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*entity.User
+	for rows.Next() {
+		user := &entity.User{}
+		err := rows.Scan(
+			&user.ID,
+			&user.ChannelID,
+			&user.RotationID,
+			&user.SlackUserID,
+			&user.SlackUserName,
+			&user.DisplayName,
+			&user.IsActive,
+			&user.LastPresenter,
+			&user.LastBackup,
+			&user.Position,
+			&user.DMReminders,
+			&user.JoinedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// SetDMReminders stores the DM reminders chosen by the member, empty to follow the rotation
+func (r *userRepo) SetDMReminders(userID int64, dmReminders string) error {
+	query := `UPDATE users SET dm_reminders = ? WHERE id = ?`
+	_, err := r.db.Exec(query, dmReminders, userID)
+	if err != nil {
+		return fmt.Errorf("failed to set DM reminders: %w", err)
+	}
+	return nil
+}
+
+// UpdatePositions stores the rotation order, userIDs[0] gets position 1
+func (r *userRepo) UpdatePositions(userIDs []int64) error {
+	query := `UPDATE users SET position = ? WHERE id = ?`
+
+	for i, userID := range userIDs {
+		if _, err := r.db.Exec(query, i+1, userID); err != nil {
+			return fmt.Errorf("failed to update user position: %w", err)
+		}
+	}
+
+	return nil
+}
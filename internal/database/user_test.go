@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
 	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,10 +26,12 @@ func TestUserRepo_Create(t *testing.T) {
 	channelRepo := newChannelRepo(db.conn)
 	err := channelRepo.Create(channel)
 	require.NoError(t, err)
+	rotation := createTestRotation(t, db, channel.ID)
 
 	t.Run("should create user successfully", func(t *testing.T) {
 		user := &entity.User{
 			ChannelID:       channel.ID,
+			RotationID:      rotation.ID,
 			SlackUserID:     "U123456789",
 			SlackUserName:   "testuser",
 			DisplayName:     "Test User",
@@ -51,6 +54,7 @@ func TestUserRepo_Create(t *testing.T) {
 	t.Run("should create user with last presenter flag", func(t *testing.T) {
 		user := &entity.User{
 			ChannelID:       channel.ID,
+			RotationID:      rotation.ID,
 			SlackUserID:     "U987654321",
 			SlackUserName:   "presenter",
 			DisplayName:     "Presenter User",
@@ -66,7 +70,7 @@ func TestUserRepo_Create(t *testing.T) {
 	})
 }
 
-func TestUserRepo_GetByChannelAndSlackID(t *testing.T) {
+func TestUserRepo_GetByRotationAndSlackID(t *testing.T) {
 	db := SetupTestDB(t)
 	defer db.Close()
 
@@ -82,9 +86,11 @@ func TestUserRepo_GetByChannelAndSlackID(t *testing.T) {
 	channelRepo := newChannelRepo(db.conn)
 	err := channelRepo.Create(channel)
 	require.NoError(t, err)
+	rotation := createTestRotation(t, db, channel.ID)
 
 	testUser := &entity.User{
 		ChannelID:       channel.ID,
+		RotationID:      rotation.ID,
 		SlackUserID:     "U123456789",
 		SlackUserName:   "testuser",
 		DisplayName:     "Test User",
@@ -95,7 +101,7 @@ func TestUserRepo_GetByChannelAndSlackID(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("should return user when found", func(t *testing.T) {
-		user, err := userRepo.GetByChannelAndSlackID(channel.ID, "U123456789")
+		user, err := userRepo.GetByRotationAndSlackID(rotation.ID, "U123456789")
 
 		require.NoError(t, err)
 		require.NotNil(t, user)
@@ -108,21 +114,21 @@ func TestUserRepo_GetByChannelAndSlackID(t *testing.T) {
 	})
 
 	t.Run("should return nil when user not found", func(t *testing.T) {
-		user, err := userRepo.GetByChannelAndSlackID(channel.ID, "U999999999")
+		user, err := userRepo.GetByRotationAndSlackID(rotation.ID, "U999999999")
 
 		require.NoError(t, err)
 		assert.Nil(t, user)
 	})
 
-	t.Run("should return nil when channel not found", func(t *testing.T) {
-		user, err := userRepo.GetByChannelAndSlackID(999, "U123456789")
+	t.Run("should return nil when rotation not found", func(t *testing.T) {
+		user, err := userRepo.GetByRotationAndSlackID(999, "U123456789")
 
 		require.NoError(t, err)
 		assert.Nil(t, user)
 	})
 }
 
-func TestUserRepo_GetActiveUsersByChannel(t *testing.T) {
+func TestUserRepo_GetActiveUsersByRotation(t *testing.T) {
 	db := SetupTestDB(t)
 	defer db.Close()
 
@@ -138,10 +144,12 @@ func TestUserRepo_GetActiveUsersByChannel(t *testing.T) {
 	channelRepo := newChannelRepo(db.conn)
 	err := channelRepo.Create(channel)
 	require.NoError(t, err)
+	rotation := createTestRotation(t, db, channel.ID)
 
 	// Create active users
 	activeUser1 := &entity.User{
 		ChannelID:       channel.ID,
+		RotationID:      rotation.ID,
 		SlackUserID:     "U123456789",
 		SlackUserName:   "user1",
 		DisplayName:     "User One",
@@ -155,6 +163,7 @@ func TestUserRepo_GetActiveUsersByChannel(t *testing.T) {
 
 	activeUser2 := &entity.User{
 		ChannelID:       channel.ID,
+		RotationID:      rotation.ID,
 		SlackUserID:     "U987654321",
 		SlackUserName:   "user2",
 		DisplayName:     "User Two",
@@ -167,6 +176,7 @@ func TestUserRepo_GetActiveUsersByChannel(t *testing.T) {
 	// Create inactive user
 	inactiveUser := &entity.User{
 		ChannelID:       channel.ID,
+		RotationID:      rotation.ID,
 		SlackUserID:     "U555555555",
 		SlackUserName:   "user3",
 		DisplayName:     "User Three",
@@ -177,7 +187,7 @@ func TestUserRepo_GetActiveUsersByChannel(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("should return only active users ordered by joined_at", func(t *testing.T) {
-		users, err := userRepo.GetActiveUsersByChannel(channel.ID)
+		users, err := userRepo.GetActiveUsersByRotation(rotation.ID)
 
 		require.NoError(t, err)
 		require.Len(t, users, 2)
@@ -192,6 +202,26 @@ func TestUserRepo_GetActiveUsersByChannel(t *testing.T) {
 		}
 	})
 
+	t.Run("should assign increasing positions on create", func(t *testing.T) {
+		assert.Equal(t, 1, activeUser1.Position)
+		assert.Equal(t, 2, activeUser2.Position)
+		assert.Equal(t, 3, inactiveUser.Position)
+	})
+
+	t.Run("should return users ordered by stored position", func(t *testing.T) {
+		err := userRepo.UpdatePositions([]int64{activeUser2.ID, inactiveUser.ID, activeUser1.ID})
+		require.NoError(t, err)
+
+		users, err := userRepo.GetActiveUsersByRotation(rotation.ID)
+
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, activeUser2.ID, users[0].ID)
+		assert.Equal(t, 1, users[0].Position)
+		assert.Equal(t, activeUser1.ID, users[1].ID)
+		assert.Equal(t, 3, users[1].Position)
+	})
+
 	t.Run("should return empty slice when no active users", func(t *testing.T) {
 		// Create a new channel with no users
 		emptyChannel := &entity.Channel{
@@ -202,12 +232,36 @@ func TestUserRepo_GetActiveUsersByChannel(t *testing.T) {
 		}
 		err := channelRepo.Create(emptyChannel)
 		require.NoError(t, err)
+		emptyRotation := createTestRotation(t, db, emptyChannel.ID)
 
-		users, err := userRepo.GetActiveUsersByChannel(emptyChannel.ID)
+		users, err := userRepo.GetActiveUsersByRotation(emptyRotation.ID)
 
 		require.NoError(t, err)
 		assert.Empty(t, users)
 	})
+
+	t.Run("should keep members of other rotations of the channel apart", func(t *testing.T) {
+		reviewers := &entity.Rotation{ChannelID: channel.ID, Name: "reviewers"}
+		require.NoError(t, newRotationRepo(db.conn).Create(reviewers))
+
+		// The same person can be a member of several rotations
+		reviewer := &entity.User{
+			ChannelID:   channel.ID,
+			RotationID:  reviewers.ID,
+			SlackUserID: "U123456789",
+			DisplayName: "User One",
+			IsActive:    true,
+		}
+		require.NoError(t, userRepo.Create(reviewer))
+		assert.Equal(t, 1, reviewer.Position)
+
+		users, err := userRepo.GetActiveUsersByRotation(reviewers.ID)
+
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, reviewer.ID, users[0].ID)
+		assert.Equal(t, reviewers.ID, users[0].RotationID)
+	})
 }
 
 func TestUserRepo_Delete(t *testing.T) {
@@ -226,9 +280,11 @@ func TestUserRepo_Delete(t *testing.T) {
 	channelRepo := newChannelRepo(db.conn)
 	err := channelRepo.Create(channel)
 	require.NoError(t, err)
+	rotation := createTestRotation(t, db, channel.ID)
 
 	testUser := &entity.User{
 		ChannelID:       channel.ID,
+		RotationID:      rotation.ID,
 		SlackUserID:     "U123456789",
 		SlackUserName:   "testuser",
 		DisplayName:     "Test User",
@@ -244,7 +300,7 @@ func TestUserRepo_Delete(t *testing.T) {
 		require.NoError(t, err)
 
 		// Verify user is deleted
-		user, err := userRepo.GetByChannelAndSlackID(channel.ID, "U123456789")
+		user, err := userRepo.GetByRotationAndSlackID(rotation.ID, "U123456789")
 		require.NoError(t, err)
 		assert.Nil(t, user)
 	})
@@ -272,10 +328,12 @@ func TestUserRepo_ClearLastPresenter(t *testing.T) {
 	channelRepo := newChannelRepo(db.conn)
 	err := channelRepo.Create(channel)
 	require.NoError(t, err)
+	rotation := createTestRotation(t, db, channel.ID)
 
 	// Create users with last presenter flags
 	user1 := &entity.User{
 		ChannelID:       channel.ID,
+		RotationID:      rotation.ID,
 		SlackUserID:     "U123456789",
 		SlackUserName:   "user1",
 		DisplayName:     "User One",
@@ -287,6 +345,7 @@ func TestUserRepo_ClearLastPresenter(t *testing.T) {
 
 	user2 := &entity.User{
 		ChannelID:       channel.ID,
+		RotationID:      rotation.ID,
 		SlackUserID:     "U987654321",
 		SlackUserName:   "user2",
 		DisplayName:     "User Two",
@@ -297,17 +356,17 @@ func TestUserRepo_ClearLastPresenter(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("should clear all last presenter flags for channel", func(t *testing.T) {
-		err := userRepo.ClearLastPresenter(channel.ID)
+		err := userRepo.ClearLastPresenter(rotation.ID)
 
 		require.NoError(t, err)
 
 		// Verify no users have last presenter flag set
-		lastPresenter, err := userRepo.GetLastPresenter(channel.ID)
+		lastPresenter, err := userRepo.GetLastPresenter(rotation.ID)
 		require.NoError(t, err)
 		assert.Nil(t, lastPresenter)
 
 		// Verify user1 no longer has last presenter flag
-		updatedUser1, err := userRepo.GetByChannelAndSlackID(channel.ID, "U123456789")
+		updatedUser1, err := userRepo.GetByRotationAndSlackID(rotation.ID, "U123456789")
 		require.NoError(t, err)
 		assert.False(t, updatedUser1.LastPresenter)
 	})
@@ -329,9 +388,11 @@ func TestUserRepo_SetLastPresenter(t *testing.T) {
 	channelRepo := newChannelRepo(db.conn)
 	err := channelRepo.Create(channel)
 	require.NoError(t, err)
+	rotation := createTestRotation(t, db, channel.ID)
 
 	testUser := &entity.User{
 		ChannelID:       channel.ID,
+		RotationID:      rotation.ID,
 		SlackUserID:     "U123456789",
 		SlackUserName:   "testuser",
 		DisplayName:     "Test User",
@@ -347,7 +408,7 @@ func TestUserRepo_SetLastPresenter(t *testing.T) {
 		require.NoError(t, err)
 
 		// Verify user has last presenter flag set
-		updatedUser, err := userRepo.GetByChannelAndSlackID(channel.ID, "U123456789")
+		updatedUser, err := userRepo.GetByRotationAndSlackID(rotation.ID, "U123456789")
 		require.NoError(t, err)
 		assert.True(t, updatedUser.LastPresenter)
 	})
@@ -375,10 +436,12 @@ func TestUserRepo_GetLastPresenter(t *testing.T) {
 	channelRepo := newChannelRepo(db.conn)
 	err := channelRepo.Create(channel)
 	require.NoError(t, err)
+	rotation := createTestRotation(t, db, channel.ID)
 
 	// Create users
 	user1 := &entity.User{
 		ChannelID:       channel.ID,
+		RotationID:      rotation.ID,
 		SlackUserID:     "U123456789",
 		SlackUserName:   "user1",
 		DisplayName:     "User One",
@@ -390,6 +453,7 @@ func TestUserRepo_GetLastPresenter(t *testing.T) {
 
 	user2 := &entity.User{
 		ChannelID:       channel.ID,
+		RotationID:      rotation.ID,
 		SlackUserID:     "U987654321",
 		SlackUserName:   "user2",
 		DisplayName:     "User Two",
@@ -400,7 +464,7 @@ func TestUserRepo_GetLastPresenter(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("should return user with last presenter flag", func(t *testing.T) {
-		lastPresenter, err := userRepo.GetLastPresenter(channel.ID)
+		lastPresenter, err := userRepo.GetLastPresenter(rotation.ID)
 
 		require.NoError(t, err)
 		require.NotNil(t, lastPresenter)
@@ -411,19 +475,140 @@ func TestUserRepo_GetLastPresenter(t *testing.T) {
 
 	t.Run("should return nil when no last presenter", func(t *testing.T) {
 		// Clear all last presenter flags
-		err := userRepo.ClearLastPresenter(channel.ID)
+		err := userRepo.ClearLastPresenter(rotation.ID)
 		require.NoError(t, err)
 
-		lastPresenter, err := userRepo.GetLastPresenter(channel.ID)
+		lastPresenter, err := userRepo.GetLastPresenter(rotation.ID)
 
 		require.NoError(t, err)
 		assert.Nil(t, lastPresenter)
 	})
 
-	t.Run("should return nil for non-existent channel", func(t *testing.T) {
+	t.Run("should return nil for non-existent rotation", func(t *testing.T) {
 		lastPresenter, err := userRepo.GetLastPresenter(99999)
 
 		require.NoError(t, err)
 		assert.Nil(t, lastPresenter)
 	})
-}
\ No newline at end of file
+}
+
+// createTestUser creates a channel and an active member of its primary rotation
+func createTestUser(t *testing.T, db *DB, slackChannelID, slackUserID string) *entity.User {
+	t.Helper()
+
+	channel := createTestChannel(t, db, slackChannelID)
+	rotation := createTestRotation(t, db, channel.ID)
+
+	user := &entity.User{
+		ChannelID:   channel.ID,
+		RotationID:  rotation.ID,
+		SlackUserID: slackUserID,
+		DisplayName: "Test User",
+		IsActive:    true,
+	}
+	require.NoError(t, newUserRepo(db.conn).Create(user))
+
+	return user
+}
+
+// createTestRotation creates the primary rotation of a channel
+func createTestRotation(t *testing.T, db *DB, channelID int64) *entity.Rotation {
+	t.Helper()
+
+	rotation := &entity.Rotation{
+		ChannelID: channelID,
+		Name:      "default",
+		IsPrimary: true,
+	}
+	require.NoError(t, newRotationRepo(db.conn).Create(rotation))
+
+	return rotation
+}
+
+func TestUserRepo_LastBackups(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	userRepo := newUserRepo(db.conn)
+	userA, userB := createTestSwapUsers(t, db)
+
+	t.Run("should return backups in rotation order", func(t *testing.T) {
+		require.NoError(t, userRepo.SetLastBackup(userB.ID))
+		require.NoError(t, userRepo.SetLastBackup(userA.ID))
+
+		backups, err := userRepo.GetLastBackups(userA.RotationID)
+
+		require.NoError(t, err)
+		require.Len(t, backups, 2)
+		assert.Equal(t, userA.ID, backups[0].ID)
+		assert.Equal(t, userB.ID, backups[1].ID)
+		assert.True(t, backups[0].LastBackup)
+	})
+
+	t.Run("should clear backups of the rotation", func(t *testing.T) {
+		require.NoError(t, userRepo.ClearLastBackups(userA.RotationID))
+
+		backups, err := userRepo.GetLastBackups(userA.RotationID)
+
+		require.NoError(t, err)
+		assert.Empty(t, backups)
+	})
+}
+
+func TestUserRepo_GetBySlackUserID(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	userRepo := newUserRepo(db.conn)
+	channelA := createTestChannel(t, db, "C111111111")
+	channelB := createTestChannel(t, db, "C222222222")
+	rotationA := createTestRotation(t, db, channelA.ID)
+	rotationB := createTestRotation(t, db, channelB.ID)
+
+	for _, rotation := range []*entity.Rotation{rotationB, rotationA} {
+		require.NoError(t, userRepo.Create(&entity.User{
+			ChannelID:   rotation.ChannelID,
+			RotationID:  rotation.ID,
+			SlackUserID: "U123456789",
+			IsActive:    true,
+		}))
+	}
+	require.NoError(t, userRepo.Create(&entity.User{
+		ChannelID:   channelA.ID,
+		RotationID:  rotationA.ID,
+		SlackUserID: "U987654321",
+		IsActive:    true,
+	}))
+
+	t.Run("should return memberships in every channel", func(t *testing.T) {
+		users, err := userRepo.GetBySlackUserID("U123456789")
+
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, channelA.ID, users[0].ChannelID)
+		assert.Equal(t, channelB.ID, users[1].ChannelID)
+	})
+
+	t.Run("should return empty slice for unknown user", func(t *testing.T) {
+		users, err := userRepo.GetBySlackUserID("U000000000")
+
+		require.NoError(t, err)
+		assert.Empty(t, users)
+	})
+}
+
+func TestUserRepo_SetDMReminders(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	userRepo := newUserRepo(db.conn)
+	user := createTestUser(t, db, "C123456789", "U123456789")
+	assert.Empty(t, user.DMReminders, "Expected new members to follow the rotation setting")
+
+	require.NoError(t, userRepo.SetDMReminders(user.ID, domain.DMRemindersDayBefore))
+
+	updated, err := userRepo.GetByRotationAndSlackID(user.RotationID, user.SlackUserID)
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, domain.DMRemindersDayBefore, updated.DMReminders)
+}
@@ -38,3 +38,82 @@ var DefaultActiveDays = []int{Monday, Tuesday, Wednesday, Thursday, Friday}
 
 // DefaultRole is the default role name when none is configured
 const DefaultRole = "On duty"
+
+// DefaultBackupRole is the default name of the backup assignees when none is configured
+const DefaultBackupRole = "backup"
+
+// MaxAssignees limits how many members can share a turn
+const MaxAssignees = 5
+
+// DefaultTimezone is the default IANA timezone for notifications
+const DefaultTimezone = "UTC"
+
+// DefaultRotationName is the name of the primary rotation created for every channel
+const DefaultRotationName = "default"
+
+// CommandWords are the names and aliases of the /rotation commands, such words cannot name a rotation
+var CommandWords = map[string]bool{
+	"add":       true,
+	"remove":    true,
+	"rm":        true,
+	"list":      true,
+	"ls":        true,
+	"move":      true,
+	"order":     true,
+	"shuffle":   true,
+	"swap":      true,
+	"create":    true,
+	"delete":    true,
+	"rotations": true,
+	"config":    true,
+	"next":      true,
+	"pause":     true,
+	"resume":    true,
+	"status":    true,
+	"holidays":  true,
+	"holiday":   true,
+	"history":   true,
+	"away":      true,
+	"me":        true,
+	"schedule":  true,
+	"notify":    true,
+	"help":      true,
+}
+
+// Rotation strategies decide who takes the next turn
+const (
+	StrategyRoundRobin = "round-robin" // Follow the rotation order
+	StrategyShuffled   = "shuffled"    // Random order, reshuffled at the start of every cycle
+	StrategyFair       = "fair"        // Fewest turns first, then longest since the last turn
+)
+
+// DefaultStrategy is the rotation strategy used when none is configured
+const DefaultStrategy = StrategyRoundRobin
+
+// Direct message reminders sent to the assignees, set per rotation and per member
+const (
+	DMRemindersOff       = "off"        // Only the channel reminder
+	DMRemindersOn        = "on"         // Also a DM at the notification time
+	DMRemindersDayBefore = "day-before" // Also a DM at the notification time and a heads-up the day before
+)
+
+// DateFormat is the layout used for calendar dates in commands and storage (YYYY-MM-DD)
+const DateFormat = "2006-01-02"
+
+// TurnDateFormat is the layout used to show upcoming turns to people (e.g. Thu Oct 22)
+const TurnDateFormat = "Mon Jan 2"
+
+// History kinds describe how a turn was assigned
+const (
+	HistoryKindAutomatic = "automatic" // Picked by the scheduler
+	HistoryKindSkip      = "skip"      // Manually skipped with /rotation next
+	HistoryKindOverride  = "override"  // Manually assigned with /rotation next @user
+)
+
+// Fields of the configuration editor, also used as the block IDs of its modal
+const (
+	ConfigFieldTime    = "time"
+	ConfigFieldDays    = "days"
+	ConfigFieldRole    = "role"
+	ConfigFieldEnabled = "enabled"
+)
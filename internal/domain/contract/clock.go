@@ -0,0 +1,25 @@
+package contract
+
+import "time"
+
+// Clock tells the time and waits for it to pass. The services take it as a dependency
+// so tests can move time forward instead of waiting for real.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+
+	// NewTimer creates a timer that fires once d has passed
+	NewTimer(d time.Duration) Timer
+
+	// Sleep blocks until d has passed
+	Sleep(d time.Duration)
+}
+
+// Timer is a single event in the future, like time.Timer
+type Timer interface {
+	// C returns the channel that receives the time when the timer fires
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing, reporting whether it was still pending
+	Stop() bool
+}
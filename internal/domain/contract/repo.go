@@ -2,6 +2,7 @@ package contract
 
 import (
 	"context"
+	"time"
 
 	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
 )
@@ -10,8 +11,14 @@ import (
 type DataManager interface {
 	WithTransaction(ctx context.Context, fn func(dm DataManager) error) error
 	Channel() ChannelRepo
+	Rotation() RotationRepo
 	User() UserRepo
 	Scheduler() SchedulerRepo
+	Holiday() HolidayRepo
+	Availability() AvailabilityRepo
+	History() HistoryRepo
+	Swap() SwapRepo
+	Installation() InstallationRepo
 }
 
 // ChannelRepo defines the contract for channel repository
@@ -23,23 +30,77 @@ type ChannelRepo interface {
 	GetActiveChannels() ([]*entity.Channel, error)
 }
 
+// RotationRepo defines the contract for rotation repository
+type RotationRepo interface {
+	Create(rotation *entity.Rotation) error
+	GetByID(id int64) (*entity.Rotation, error)
+	GetPrimary(channelID int64) (*entity.Rotation, error)
+	GetByChannelAndName(channelID int64, name string) (*entity.Rotation, error)
+	GetByChannelID(channelID int64) ([]*entity.Rotation, error)
+	Delete(id int64) error
+}
+
 // UserRepo defines the contract for user repository
 type UserRepo interface {
 	Create(user *entity.User) error
-	GetByChannelAndSlackID(channelID int64, slackUserID string) (*entity.User, error)
-	GetActiveUsersByChannel(channelID int64) ([]*entity.User, error)
+	GetByRotationAndSlackID(rotationID int64, slackUserID string) (*entity.User, error)
+	GetActiveUsersByRotation(rotationID int64) ([]*entity.User, error)
+	GetBySlackUserID(slackUserID string) ([]*entity.User, error)
 	Delete(userID int64) error
-	ClearLastPresenter(channelID int64) error
+	ClearLastPresenter(rotationID int64) error
 	SetLastPresenter(userID int64) error
-	GetLastPresenter(channelID int64) (*entity.User, error)
+	GetLastPresenter(rotationID int64) (*entity.User, error)
+	ClearLastBackups(rotationID int64) error
+	SetLastBackup(userID int64) error
+	GetLastBackups(rotationID int64) ([]*entity.User, error)
+	UpdatePositions(userIDs []int64) error
+	SetDMReminders(userID int64, dmReminders string) error
 }
 
 // SchedulerRepo defines the contract for scheduler repository
 type SchedulerRepo interface {
 	Create(scheduler *entity.Scheduler) error
+	GetByRotationID(rotationID int64) (*entity.Scheduler, error)
 	GetByChannelID(channelID int64) (*entity.Scheduler, error)
 	Update(scheduler *entity.Scheduler) error
-	Delete(channelID int64) error
+	Delete(rotationID int64) error
 	GetEnabled() ([]*entity.Scheduler, error)
-	SetEnabled(channelID int64, enabled bool) error
+	GetEnabledByChannelID(channelID int64) ([]*entity.Scheduler, error)
+	SetEnabled(rotationID int64, enabled bool) error
+	SetTopicPrefix(rotationID int64, prefix string) error
+	MarkNotified(rotationID int64, occurrence time.Time) (bool, error)
+	MarkHeadsUp(rotationID int64, occurrence time.Time) (bool, error)
+}
+
+// HolidayRepo defines the contract for holiday repository
+type HolidayRepo interface {
+	Create(holiday *entity.Holiday) error
+	GetByChannelID(channelID int64) ([]*entity.Holiday, error)
+	DeleteByRange(channelID int64, startDate, endDate time.Time) (int64, error)
+}
+
+// AvailabilityRepo defines the contract for user availability repository
+type AvailabilityRepo interface {
+	Create(availability *entity.Availability) error
+	GetUpcomingByChannel(channelID int64, from time.Time) ([]*entity.Availability, error)
+}
+
+// HistoryRepo defines the contract for rotation history repository
+type HistoryRepo interface {
+	Create(entry *entity.RotationHistory) error
+	GetByRotationID(rotationID int64, limit int) ([]*entity.RotationHistory, error)
+	GetTurnStats(rotationID int64) ([]*entity.TurnStats, error)
+}
+
+// SwapRepo defines the contract for rotation swap repository
+type SwapRepo interface {
+	Create(swap *entity.Swap) error
+	GetPendingByRotation(rotationID int64, until time.Time) ([]*entity.Swap, error)
+	MarkApplied(swapID int64, appliedAt time.Time) error
+}
+
+// InstallationRepo defines the contract for the workspace installation repository
+type InstallationRepo interface {
+	Save(installation *entity.Installation) error
+	GetByTeamID(slackTeamID string) (*entity.Installation, error)
 }
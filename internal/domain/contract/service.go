@@ -8,16 +8,42 @@ import (
 
 type RotationService interface {
 	SetupChannel(slackChannelID, channelName, teamID string) (*entity.Channel, bool, error)
-	AddUser(channelID int64, slackUserID string) error
-	RemoveUser(channelID int64, slackUserID string) error
-	GetNextPresenter(channelID int64) (*entity.User, error)
-	RecordPresentation(ctx context.Context, channelID, userID int64) error
-	UpdateChannelConfig(channelID int64, configType, configValue string) error
-	ListUsers(channelID int64) ([]*entity.User, error)
-	GetCurrentPresenter(channelID int64) (*entity.User, error)
-	PauseScheduler(channelID int64) error
-	ResumeScheduler(channelID int64) error
+	GetRotation(channelID int64, name string) (*entity.Rotation, error)
+	GetRotationByID(rotationID int64) (*entity.Rotation, error)
+	CreateRotation(channelID int64, name string) (*entity.Rotation, error)
+	DeleteRotation(channelID int64, name string) error
+	ListRotations(channelID int64) ([]*entity.Rotation, error)
+	AddUser(ctx context.Context, rotationID int64, slackUserID string) error
+	RemoveUser(rotationID int64, slackUserID string) error
+	GetNextAssignees(rotationID int64) ([]*entity.User, error)
+	RecordPresentation(ctx context.Context, rotationID int64, users []*entity.User, kind, triggeredBy string) error
+	GetHistory(rotationID int64, limit int) ([]*entity.RotationHistory, error)
+	UpdateChannelConfig(rotationID int64, configType, configValue string) error
+	UpdateSchedulerSettings(rotationID int64, settings entity.SchedulerSettings) error
+	ListUsers(rotationID int64) ([]*entity.User, error)
+	GetCurrentPresenter(rotationID int64) (*entity.User, error)
+	GetCurrentBackups(rotationID int64) ([]*entity.User, error)
+	PauseScheduler(rotationID int64) error
+	ResumeScheduler(rotationID int64) error
 	GetChannelConfig(channelID int64) (*entity.Channel, error)
-	GetSchedulerConfig(channelID int64) (*entity.Scheduler, error)
+	GetSchedulerConfig(rotationID int64) (*entity.Scheduler, error)
 	GetChannelStatus(channelID int) (*entity.Channel, error)
+	AddHoliday(channelID int64, dateRange, description string) (*entity.Holiday, error)
+	ListHolidays(channelID int64) ([]*entity.Holiday, error)
+	RemoveHoliday(channelID int64, dateRange string) error
+	ImportHolidays(ctx context.Context, channelID int64, icsBody string) (int, error)
+	SetUserAway(rotationID int64, slackUserID, dateRange, reason string) (*entity.Availability, error)
+	ListAbsences(channelID int64) ([]*entity.Availability, error)
+	MoveUser(ctx context.Context, rotationID int64, slackUserID string, position int) ([]*entity.User, error)
+	SetOrder(ctx context.Context, rotationID int64, slackUserIDs []string) ([]*entity.User, error)
+	ShuffleUsers(ctx context.Context, rotationID int64) ([]*entity.User, error)
+	SwapUsers(ctx context.Context, rotationID int64, slackUserIDA, slackUserIDB, date, requestedBy string) (*entity.Swap, error)
+	RemoveLeftMember(slackChannelID, slackUserID string) error
+	RemoveDeactivatedUser(slackUserID string) error
+	SetChannelActive(slackChannelID string, active bool) error
+	RenameChannel(slackChannelID, name string) error
+	GetUserRotations(slackUserID string) ([]*entity.UserRotation, error)
+	GetSchedule(rotationID int64, count int) ([]entity.ScheduledTurn, error)
+	SaveInstallation(installation *entity.Installation) error
+	SetDMReminders(rotationID int64, slackUserID, value string) error
 }
\ No newline at end of file
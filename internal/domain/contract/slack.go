@@ -1,13 +1,51 @@
 package contract
 
-import "github.com/slack-go/slack"
+import (
+	"context"
+
+	"github.com/slack-go/slack"
+)
 
 // SlackClient defines the interface for Slack operations
 // This allows mocking in tests while keeping the real implementation simple
 type SlackClient interface {
-	// GetUserInfo retrieves user information from Slack
-	GetUserInfo(userID string) (*slack.User, error)
+	// GetUserInfoContext retrieves user information from Slack, giving up when ctx is done
+	GetUserInfoContext(ctx context.Context, userID string) (*slack.User, error)
 	
 	// PostMessage sends a message to a Slack channel
 	PostMessage(channelID string, options ...slack.MsgOption) (string, string, error)
-}
\ No newline at end of file
+
+	// PostMessageContext sends a message to a Slack channel, giving up when ctx is done
+	PostMessageContext(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error)
+
+	// UpdateMessage replaces a message previously posted by the bot
+	UpdateMessage(channelID, timestamp string, options ...slack.MsgOption) (string, string, string, error)
+
+	// OpenConversationContext opens the direct message conversation with the given users
+	OpenConversationContext(ctx context.Context, params *slack.OpenConversationParameters) (*slack.Channel, bool, bool, error)
+
+	// GetConversationInfoContext retrieves a channel, with its current topic
+	GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error)
+
+	// SetTopicOfConversationContext replaces the topic of a channel
+	SetTopicOfConversationContext(ctx context.Context, channelID, topic string) (*slack.Channel, error)
+
+	// UpdateUserGroupMembersContext replaces the members of a user group, members are comma separated user IDs
+	UpdateUserGroupMembersContext(ctx context.Context, userGroup string, members string, options ...slack.UpdateUserGroupMembersOption) (slack.UserGroup, error)
+
+	// OpenView opens a modal for the user that triggered an interaction
+	OpenView(triggerID string, view slack.ModalViewRequest) (*slack.ViewResponse, error)
+
+	// PublishView replaces the App Home tab of a user
+	PublishView(userID string, view slack.HomeTabViewRequest, hash string) (*slack.ViewResponse, error)
+}
+
+// SlackClientFactory returns the SlackClient of each workspace, since every workspace that
+// installed the app has its own bot token
+type SlackClientFactory interface {
+	// ForTeam returns the client of the workspace with the given Slack team ID
+	ForTeam(teamID string) (SlackClient, error)
+
+	// Forget drops the client cached for the workspace, so a new bot token is picked up
+	Forget(teamID string)
+}
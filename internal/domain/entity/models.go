@@ -12,25 +12,123 @@ type Channel struct {
 	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// Installation is a workspace that installed the app through OAuth, with the bot token
+// used to talk to it
+type Installation struct {
+	ID            int64     `json:"id" db:"id"`
+	SlackTeamID   string    `json:"slack_team_id" db:"slack_team_id"`
+	SlackTeamName string    `json:"slack_team_name" db:"slack_team_name"`
+	BotUserID     string    `json:"bot_user_id" db:"bot_user_id"`
+	BotToken      string    `json:"-" db:"bot_token"`
+	Scope         string    `json:"scope" db:"scope"`
+	InstalledBy   string    `json:"installed_by" db:"installed_by"` // Slack user ID of who installed the app
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Rotation is a named list of members taking turns in a channel. A channel can have
+// several rotations, commands that do not name one use the primary rotation.
+type Rotation struct {
+	ID        int64     `json:"id" db:"id"`
+	ChannelID int64     `json:"channel_id" db:"channel_id"`
+	Name      string    `json:"name" db:"name"`
+	IsPrimary bool      `json:"is_primary" db:"is_primary"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// CommandPrefix returns the slash command that targets the rotation, e.g. "/rotation reviewers"
+func (r *Rotation) CommandPrefix() string {
+	if r.IsPrimary {
+		return "/rotation"
+	}
+	return "/rotation " + r.Name
+}
+
+// ReminderTitle names the rotation in the reminder when the channel has several of them
+func (r *Rotation) ReminderTitle() string {
+	if r.IsPrimary {
+		return "Rotation Reminder"
+	}
+	return "Rotation Reminder: " + r.Name
+}
+
 type Scheduler struct {
 	ID               int64     `json:"id" db:"id"`
 	ChannelID        int64     `json:"channel_id" db:"channel_id"`
-	NotificationTime string    `json:"notification_time" db:"notification_time"` // HH:MM format in UTC
+	RotationID       int64     `json:"rotation_id" db:"rotation_id"`
+	NotificationTime string    `json:"notification_time" db:"notification_time"` // HH:MM format in the scheduler timezone
 	ActiveDays       []int     `json:"active_days" db:"active_days"`             // ISO 8601 weekdays (1-7)
 	IsEnabled        bool      `json:"is_enabled" db:"is_enabled"`               // Scheduler enabled/disabled
 	Role             string    `json:"role" db:"role"`                           // Role name (e.g., "presenter", "reviewer", "On duty")
+	Timezone         string    `json:"timezone" db:"timezone"`                   // IANA timezone name (e.g., "America/Sao_Paulo")
+	Strategy         string    `json:"strategy" db:"strategy"`                   // Rotation strategy (round-robin, shuffled, fair)
+	Assignees        int       `json:"assignees" db:"assignees"`                 // Members per turn, the presenter plus backups
+	BackupRole       string    `json:"backup_role" db:"backup_role"`             // Role name of the backups (e.g., "backup", "pair")
+	LastNotifiedAt   time.Time `json:"last_notified_at" db:"last_notified_at"`   // Occurrence of the last reminder sent, zero before the first one
+	DMReminders      string    `json:"dm_reminders" db:"dm_reminders"`           // DM reminders of the members that did not choose (off, on, day-before)
+	HeadsUpWanted    bool      `json:"heads_up_wanted" db:"heads_up_wanted"`     // An active member gets a heads-up the day before, read only
+	TopicSync        bool      `json:"topic_sync" db:"topic_sync"`               // Keep the channel topic saying who is on duty
+	TopicPrefix      string    `json:"topic_prefix" db:"topic_prefix"`           // Start of the part of the topic last written by the bot, set through SetTopicPrefix only
+	UserGroupID      string    `json:"user_group_id" db:"user_group_id"`         // Slack user group kept with the assignees as members, empty when not linked
 	CreatedAt        time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// SchedulerSettings holds the values submitted in the configuration editor, before they are validated
+type SchedulerSettings struct {
+	NotificationTime string   // HH:MM format in the scheduler timezone
+	ActiveDays       []string // Weekday numbers, "1" for Monday to "7" for Sunday
+	Role             string
+	IsEnabled        bool
+}
+
+// GetLocation returns the scheduler timezone, falling back to UTC when unset or invalid
+func (s *Scheduler) GetLocation() *time.Location {
+	if s.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// GetAssignees returns how many members take each turn, at least one
+func (s *Scheduler) GetAssignees() int {
+	if s.Assignees < 1 {
+		return 1
+	}
+	return s.Assignees
+}
+
+type Holiday struct {
+	ID          int64     `json:"id" db:"id"`
+	ChannelID   int64     `json:"channel_id" db:"channel_id"`
+	StartDate   time.Time `json:"start_date" db:"start_date"` // First skipped date (inclusive)
+	EndDate     time.Time `json:"end_date" db:"end_date"`     // Last skipped date (inclusive)
+	Description string    `json:"description" db:"description"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// Covers reports whether the calendar date of day falls within the holiday range
+func (h *Holiday) Covers(day time.Time) bool {
+	date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	return !date.Before(h.StartDate) && !date.After(h.EndDate)
+}
+
 type User struct {
 	ID            int64     `json:"id" db:"id"`
 	ChannelID     int64     `json:"channel_id" db:"channel_id"`
+	RotationID    int64     `json:"rotation_id" db:"rotation_id"`
 	SlackUserID   string    `json:"slack_user_id" db:"slack_user_id"`
 	SlackUserName string    `json:"slack_user_name" db:"slack_user_name"`
 	DisplayName   string    `json:"display_name" db:"display_name"`
 	IsActive      bool      `json:"is_active" db:"is_active"`
 	LastPresenter bool      `json:"last_presenter" db:"last_presenter"`
+	LastBackup    bool      `json:"last_backup" db:"last_backup"`   // Backs up the current presenter
+	Position      int       `json:"position" db:"position"`         // 1-based place in the rotation order
+	DMReminders   string    `json:"dm_reminders" db:"dm_reminders"` // Empty to follow the rotation setting
 	JoinedAt      time.Time `json:"joined_at" db:"joined_at"`
 }
 
@@ -44,3 +142,75 @@ func (u *User) GetDisplayName() string {
 	}
 	return "Unknown User"
 }
+
+type Availability struct {
+	ID          int64     `json:"id" db:"id"`
+	UserID      int64     `json:"user_id" db:"user_id"`
+	SlackUserID string    `json:"slack_user_id" db:"slack_user_id"` // Loaded from the member, away periods apply to every rotation of the channel
+	StartDate   time.Time `json:"start_date" db:"start_date"`       // First day away (inclusive)
+	EndDate     time.Time `json:"end_date" db:"end_date"`           // Last day away (inclusive)
+	Reason      string    `json:"reason" db:"reason"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// Covers reports whether the calendar date of day falls within the away period
+func (a *Availability) Covers(day time.Time) bool {
+	date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	return !date.Before(a.StartDate) && !date.After(a.EndDate)
+}
+
+type RotationHistory struct {
+	ID          int64     `json:"id" db:"id"`
+	ChannelID   int64     `json:"channel_id" db:"channel_id"`
+	RotationID  int64     `json:"rotation_id" db:"rotation_id"`
+	UserID      int64     `json:"user_id" db:"user_id"` // Zero when the member was removed
+	SlackUserID string    `json:"slack_user_id" db:"slack_user_id"`
+	DisplayName string    `json:"display_name" db:"display_name"`
+	PresentedAt time.Time `json:"presented_at" db:"presented_at"`
+	Kind        string    `json:"kind" db:"kind"`                 // automatic, skip or override
+	TriggeredBy string    `json:"triggered_by" db:"triggered_by"` // Slack user ID, empty for the scheduler
+	IsBackup    bool      `json:"is_backup" db:"is_backup"`       // Backed up the presenter of the turn
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+type Swap struct {
+	ID         int64     `json:"id" db:"id"`
+	ChannelID  int64     `json:"channel_id" db:"channel_id"`
+	RotationID int64     `json:"rotation_id" db:"rotation_id"`
+	UserAID    int64     `json:"user_a_id" db:"user_a_id"`
+	UserBID    int64     `json:"user_b_id" db:"user_b_id"`
+	SwapDate   time.Time `json:"swap_date" db:"swap_date"`   // Zero for immediate swaps
+	AppliedAt  time.Time `json:"applied_at" db:"applied_at"` // Zero while the swap is pending
+	CreatedBy  string    `json:"created_by" db:"created_by"` // Slack user ID
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// IsPending reports whether the swap has not changed the rotation order yet
+func (s *Swap) IsPending() bool {
+	return s.AppliedAt.IsZero()
+}
+
+// ScheduledTurn is an upcoming occurrence of a rotation with the member expected to take it
+type ScheduledTurn struct {
+	Date      time.Time // Notification time in the scheduler timezone
+	Presenter *User     // Nil when every member is away on that date
+	Backups   []*User   // Follow the presenter when the rotation has several assignees
+}
+
+// UserRotation is a rotation a member belongs to, with where their turn stands
+type UserRotation struct {
+	Channel   *Channel
+	Rotation  *Rotation
+	Scheduler *Scheduler
+	Member    *User     // The membership of the person in the rotation
+	OnDuty    *User     // Current presenter, nil before the first turn
+	NextTurn  time.Time // Next expected turn of the member, zero when none is in sight
+	AwayToday bool
+}
+
+// TurnStats summarizes the turns a member had in a rotation
+type TurnStats struct {
+	UserID     int64     `json:"user_id" db:"user_id"`
+	Turns      int       `json:"turns" db:"turns"`
+	LastTurnAt time.Time `json:"last_turn_at" db:"last_turn_at"`
+}
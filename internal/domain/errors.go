@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrEveryoneAway is returned when every active member of a rotation is away on the requested day
+var ErrEveryoneAway = errors.New("everyone in the rotation is away")
+
+// FieldErrors is returned when some values of a form are invalid, mapping each field to
+// the reason it was rejected so it can be shown next to the field
+type FieldErrors map[string]string
+
+func (e FieldErrors) Error() string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = fmt.Sprintf("%s: %s", field, e[field])
+	}
+
+	return "invalid " + strings.Join(messages, "; ")
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+)
+
+// SetUserAway marks a rotation member as away for an inclusive date range.
+// Away members are skipped but keep their place in the rotation order.
+func (s *rotationService) SetUserAway(rotationID int64, slackUserID, dateRange, reason string) (*entity.Availability, error) {
+	start, end, err := parseDateRange(dateRange)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.dm.User().GetByRotationAndSlackID(rotationID, slackUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if user == nil {
+		return nil, fmt.Errorf("user not found in rotation")
+	}
+
+	availability := &entity.Availability{
+		UserID:      user.ID,
+		SlackUserID: user.SlackUserID,
+		StartDate:   start,
+		EndDate:     end,
+		Reason:      strings.TrimSpace(reason),
+	}
+
+	if err := s.dm.Availability().Create(availability); err != nil {
+		return nil, fmt.Errorf("failed to create away period: %w", err)
+	}
+
+	return availability, nil
+}
+
+// ListAbsences returns the away periods of the channel members that have not ended yet
+func (s *rotationService) ListAbsences(channelID int64) ([]*entity.Availability, error) {
+	loc, err := s.channelLocation(channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	periods, err := s.dm.Availability().GetUpcomingByChannel(channelID, dateOf(s.clock.Now().In(loc)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get away periods: %w", err)
+	}
+
+	return periods, nil
+}
+
+// channelLocation returns the timezone of the channel primary rotation, UTC when not configured
+func (s *rotationService) channelLocation(channelID int64) (*time.Location, error) {
+	scheduler, err := s.dm.Scheduler().GetByChannelID(channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduler config: %w", err)
+	}
+
+	if scheduler == nil {
+		return time.UTC, nil
+	}
+
+	return scheduler.GetLocation(), nil
+}
+
+// rotationLocation returns the timezone configured for the rotation, UTC when not configured
+func (s *rotationService) rotationLocation(rotationID int64) (*time.Location, error) {
+	scheduler, err := s.dm.Scheduler().GetByRotationID(rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduler config: %w", err)
+	}
+
+	if scheduler == nil {
+		return time.UTC, nil
+	}
+
+	return scheduler.GetLocation(), nil
+}
+
+// dateOf returns the calendar date of t as UTC midnight, the representation used for stored dates
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// getAwayUserIDs returns the IDs of the members of users that are away on day. Away periods
+// belong to the person, so someone away in one rotation of the channel is away in all of them.
+func getAwayUserIDs(dm contract.DataManager, users []*entity.User, day time.Time) (map[int64]bool, error) {
+	if len(users) == 0 {
+		return make(map[int64]bool), nil
+	}
+
+	periods, err := dm.Availability().GetUpcomingByChannel(users[0].ChannelID, dateOf(day))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get away periods: %w", err)
+	}
+
+	return awayUserIDs(users, periods, day), nil
+}
+
+// awayUserIDs returns the IDs of the members of users that one of periods covers on day
+func awayUserIDs(users []*entity.User, periods []*entity.Availability, day time.Time) map[int64]bool {
+	awaySlackIDs := make(map[string]bool)
+	for _, period := range periods {
+		if period.Covers(day) {
+			awaySlackIDs[period.SlackUserID] = true
+		}
+	}
+
+	away := make(map[int64]bool)
+	for _, user := range users {
+		if awaySlackIDs[user.SlackUserID] {
+			away[user.ID] = true
+		}
+	}
+
+	return away
+}
+
+// nextAvailableUser walks the rotation order starting at startIndex and returns the first
+// member that is not away, or nil when everyone is away
+func nextAvailableUser(users []*entity.User, startIndex int, away map[int64]bool) *entity.User {
+	for i := 0; i < len(users); i++ {
+		user := users[(startIndex+i)%len(users)]
+		if !away[user.ID] {
+			return user
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_nextAvailableUser(t *testing.T) {
+	users := []*entity.User{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	tests := []struct {
+		name       string
+		startIndex int
+		away       map[int64]bool
+		wantID     int64
+	}{
+		{name: "Should return user at start index", startIndex: 1, away: map[int64]bool{}, wantID: 2},
+		{name: "Should skip away user", startIndex: 1, away: map[int64]bool{2: true}, wantID: 3},
+		{name: "Should wrap around when skipping", startIndex: 2, away: map[int64]bool{3: true}, wantID: 1},
+		{name: "Should return nil when everyone is away", startIndex: 0, away: map[int64]bool{1: true, 2: true, 3: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextAvailableUser(users, tt.startIndex, tt.away)
+
+			if tt.wantID == 0 {
+				assert.Nil(t, got)
+				return
+			}
+
+			require.NotNil(t, got)
+			assert.Equal(t, tt.wantID, got.ID)
+		})
+	}
+}
+
+func Test_rotationService_SetUserAway(t *testing.T) {
+	type args struct {
+		rotationID  int64
+		slackUserID string
+		dateRange   string
+		reason      string
+	}
+	tests := []struct {
+		name      string
+		buildMock func(mocks allMocks, args args)
+		args      args
+		wantErr   bool
+	}{
+		{
+			name: "Should mark user away for date range",
+			args: args{
+				rotationID:  1,
+				slackUserID: "U123456789",
+				dateRange:   "2026-10-20..2026-10-24",
+				reason:      " vacation ",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				user := &entity.User{ID: 5, RotationID: args.rotationID, SlackUserID: args.slackUserID}
+
+				gomock.InOrder(
+					mocks.mockUserRepo.EXPECT().
+						GetByRotationAndSlackID(args.rotationID, args.slackUserID).
+						Return(user, nil).Times(1),
+
+					mocks.mockAvailabilityRepo.EXPECT().
+						Create(gomock.Any()).
+						DoAndReturn(func(a *entity.Availability) error {
+							require.Equal(t, user.ID, a.UserID)
+							require.Equal(t, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), a.StartDate)
+							require.Equal(t, time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC), a.EndDate)
+							require.Equal(t, "vacation", a.Reason)
+							return nil
+						}).Times(1),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error for invalid range",
+			args: args{
+				rotationID:  1,
+				slackUserID: "U123456789",
+				dateRange:   "next week",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when user is not in rotation",
+			args: args{
+				rotationID:  1,
+				slackUserID: "U999999999",
+				dateRange:   "2026-10-20",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockUserRepo.EXPECT().
+					GetByRotationAndSlackID(args.rotationID, args.slackUserID).
+					Return(nil, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when repository fails",
+			args: args{
+				rotationID:  1,
+				slackUserID: "U123456789",
+				dateRange:   "2026-10-20",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockUserRepo.EXPECT().
+						GetByRotationAndSlackID(args.rotationID, args.slackUserID).
+						Return(&entity.User{ID: 5}, nil).Times(1),
+
+					mocks.mockAvailabilityRepo.EXPECT().
+						Create(gomock.Any()).
+						Return(assert.AnError).Times(1),
+				)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+			if tt.buildMock != nil {
+				tt.buildMock(m, tt.args)
+			}
+
+			got, err := s.SetUserAway(tt.args.rotationID, tt.args.slackUserID, tt.args.dateRange, tt.args.reason)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, got)
+		})
+	}
+}
+
+func Test_rotationService_ListAbsences(t *testing.T) {
+	m, ctrl := newServiceTestMock(t)
+	defer ctrl.Finish()
+
+	s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	periods := []*entity.Availability{
+		{ID: 1, UserID: 2, StartDate: today, EndDate: today.AddDate(0, 0, 3)},
+	}
+
+	gomock.InOrder(
+		m.mockSchedulerRepo.EXPECT().
+			GetByChannelID(int64(1)).
+			Return(&entity.Scheduler{ChannelID: 1, Timezone: "UTC"}, nil).Times(1),
+
+		m.mockAvailabilityRepo.EXPECT().
+			GetUpcomingByChannel(int64(1), today).
+			Return(periods, nil).Times(1),
+	)
+
+	got, err := s.ListAbsences(1)
+
+	require.NoError(t, err)
+	assert.Equal(t, periods, got)
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+)
+
+// systemClock is the contract.Clock backed by the time package
+type systemClock struct{}
+
+// NewSystemClock returns the clock used outside of tests, reading the system time
+func NewSystemClock() contract.Clock {
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) contract.Timer {
+	return systemTimer{timer: time.NewTimer(d)}
+}
+
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+type systemTimer struct {
+	timer *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t systemTimer) Stop() bool {
+	return t.timer.Stop()
+}
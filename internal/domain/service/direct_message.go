@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/slack-go/slack"
+)
+
+// parseDMReminders validates a DM reminders setting such as "on", returning its canonical name
+func parseDMReminders(input string) (string, error) {
+	value := strings.ToLower(strings.TrimSpace(input))
+	switch value {
+	case domain.DMRemindersOff, domain.DMRemindersOn, domain.DMRemindersDayBefore:
+		return value, nil
+	default:
+		return "", fmt.Errorf("invalid DM reminders. Use %s, %s or %s", domain.DMRemindersOn, domain.DMRemindersOff, domain.DMRemindersDayBefore)
+	}
+}
+
+// dmRemindersOf returns the DM reminders of the member, the rotation setting unless they chose
+// and off when neither did. scheduler is the config of the rotation, nil when it has none.
+func dmRemindersOf(user *entity.User, scheduler *entity.Scheduler) string {
+	if user.DMReminders != "" {
+		return user.DMReminders
+	}
+	if scheduler == nil || scheduler.DMReminders == "" {
+		return domain.DMRemindersOff
+	}
+	return scheduler.DMReminders
+}
+
+// SetDMReminders stores the DM reminders chosen by a member of the rotation, which override the
+// setting of the rotation for them
+func (s *rotationService) SetDMReminders(rotationID int64, slackUserID, value string) error {
+	dmReminders, err := parseDMReminders(value)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.dm.User().GetByRotationAndSlackID(rotationID, slackUserID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if user == nil {
+		return fmt.Errorf("you are not in the rotation")
+	}
+
+	if err := s.dm.User().SetDMReminders(user.ID, dmReminders); err != nil {
+		return err
+	}
+
+	// The scheduler only plans heads-ups for the rotations where someone asked for them
+	if s.scheduler != nil {
+		s.scheduler.NotifyConfigChange(user.ChannelID)
+	}
+
+	return nil
+}
+
+// scheduleHeadsUp returns the heads-up queue entry of the rotation with the time of its next
+// heads-up, or nil when it has none or none of its members asked for one
+func scheduleHeadsUp(scheduler *entity.Scheduler, holidays []*entity.Holiday, now time.Time) *queuedRotation {
+	if !scheduler.HeadsUpWanted {
+		return nil
+	}
+
+	next := nextHeadsUp(scheduler, holidays, now)
+	if next.IsZero() {
+		return nil
+	}
+
+	return &queuedRotation{config: scheduler, holidays: holidays, next: next}
+}
+
+// nextHeadsUp returns the first heads-up time of the scheduler after now, the day before an
+// occurrence at the same wall-clock time, or the zero time when there is none
+func nextHeadsUp(scheduler *entity.Scheduler, holidays []*entity.Holiday, now time.Time) time.Time {
+	loc := scheduler.GetLocation()
+
+	// The heads-up of the next occurrence may have passed already, the one after it has not
+	for occurrence := nextOccurrence(scheduler, holidays, now); !occurrence.IsZero(); occurrence = nextOccurrence(scheduler, holidays, occurrence) {
+		local := occurrence.In(loc)
+		headsUp := time.Date(local.Year(), local.Month(), local.Day()-1, local.Hour(), local.Minute(), 0, 0, loc)
+		if headsUp.After(now) {
+			return headsUp
+		}
+	}
+
+	return time.Time{}
+}
+
+// sendHeadsUp sends a DM to the members expected on duty at occurrence that asked for a heads-up
+// the day before. Nothing is sent when none of the members asked for it, when the rotation is no
+// longer due at occurrence or when another instance of the bot already sent it.
+func (s *scheduler) sendHeadsUp(ctx context.Context, rotationID int64, occurrence time.Time) error {
+	schedulerConfig, err := s.dm.Scheduler().GetByRotationID(rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to get scheduler config: %w", err)
+	}
+
+	if schedulerConfig == nil || !schedulerConfig.IsEnabled {
+		return nil
+	}
+
+	users, err := s.dm.User().GetActiveUsersByRotation(rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to get users: %w", err)
+	}
+
+	wanted := false
+	for _, user := range users {
+		if dmRemindersOf(user, schedulerConfig) == domain.DMRemindersDayBefore {
+			wanted = true
+			break
+		}
+	}
+	if !wanted {
+		return nil
+	}
+
+	holidays, err := s.dm.Holiday().GetByChannelID(schedulerConfig.ChannelID)
+	if err != nil {
+		return fmt.Errorf("failed to get holidays: %w", err)
+	}
+
+	before := occurrence.Add(-time.Nanosecond)
+	if !nextOccurrence(schedulerConfig, holidays, before).Equal(occurrence) {
+		return nil
+	}
+
+	today := dateOf(s.clock.Now().In(schedulerConfig.GetLocation()))
+	absences, err := s.dm.Availability().GetUpcomingByChannel(schedulerConfig.ChannelID, today)
+	if err != nil {
+		return fmt.Errorf("failed to get away periods: %w", err)
+	}
+
+	swaps, err := s.dm.Swap().GetPendingByRotation(rotationID, today.AddDate(1, 0, 0))
+	if err != nil {
+		return fmt.Errorf("failed to get pending swaps: %w", err)
+	}
+
+	// Who takes the turn is only decided at the notification time, so the heads-up goes to the
+	// members expected on duty
+	turns, err := projectTurns(s.dm, schedulerConfig, users, holidays, absences, swaps, before, 1)
+	if err != nil {
+		return err
+	}
+
+	if len(turns) == 0 || turns[0].Presenter == nil {
+		return nil
+	}
+
+	role := schedulerConfig.Role
+	if role == "" {
+		role = domain.DefaultRole
+	}
+
+	// Role of each assignee that asked for a heads-up, by Slack user ID
+	roles := make(map[string]string)
+	for i, user := range append([]*entity.User{turns[0].Presenter}, turns[0].Backups...) {
+		if dmRemindersOf(user, schedulerConfig) != domain.DMRemindersDayBefore {
+			continue
+		}
+		roles[user.SlackUserID] = role
+		if i > 0 {
+			roles[user.SlackUserID] = backupRoleOf(schedulerConfig)
+		}
+	}
+	if len(roles) == 0 {
+		return nil
+	}
+
+	claimed, err := s.dm.Scheduler().MarkHeadsUp(rotationID, occurrence)
+	if err != nil {
+		return fmt.Errorf("failed to mark heads-up as sent: %w", err)
+	}
+
+	if !claimed {
+		return nil
+	}
+
+	rotation, err := s.dm.Rotation().GetByID(rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to get rotation: %w", err)
+	}
+
+	if rotation == nil {
+		return fmt.Errorf("rotation not found")
+	}
+
+	channel, err := s.dm.Channel().GetByID(rotation.ChannelID)
+	if err != nil {
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	if channel == nil {
+		return fmt.Errorf("channel not found")
+	}
+
+	slackClient, err := s.slackClients.ForTeam(channel.SlackTeamID)
+	if err != nil {
+		return fmt.Errorf("failed to get Slack client: %w", err)
+	}
+
+	for slackUserID, userRole := range roles {
+		text := fmt.Sprintf("👋 Heads-up: you're *%s* tomorrow in %s", userRole, dmLocation(rotation, channel))
+		if err := sendDirectMessage(ctx, slackClient, slackUserID, text); err != nil {
+			log.Printf("Failed to send heads-up to user %s: %v", slackUserID, err)
+		}
+	}
+
+	return nil
+}
+
+// sendDirectReminders sends a DM to the assignees of the turn that asked for one, after the
+// reminder was posted in the channel. Failures are only logged, the channel reminder went out.
+// schedulerConfig is nil when the rotation has no config, only the members that chose DMs get one.
+func sendDirectReminders(ctx context.Context, slackClient contract.SlackClient, rotation *entity.Rotation, channel *entity.Channel, schedulerConfig *entity.Scheduler, assignees []*entity.User, role, backupRole string) {
+	for i, user := range assignees {
+		if dmRemindersOf(user, schedulerConfig) == domain.DMRemindersOff {
+			continue
+		}
+
+		userRole := role
+		if i > 0 {
+			userRole = backupRole
+		}
+
+		text := fmt.Sprintf("🔔 You're *%s* today in %s", userRole, dmLocation(rotation, channel))
+		if err := sendDirectMessage(ctx, slackClient, user.SlackUserID, text); err != nil {
+			log.Printf("Failed to send reminder DM to user %s: %v", user.SlackUserID, err)
+		}
+	}
+}
+
+// dmLocation names the channel of the rotation in a DM, with the rotation when the channel has
+// several of them
+func dmLocation(rotation *entity.Rotation, channel *entity.Channel) string {
+	if rotation.IsPrimary {
+		return fmt.Sprintf("<#%s>", channel.SlackChannelID)
+	}
+	return fmt.Sprintf("<#%s> (%s rotation)", channel.SlackChannelID, rotation.Name)
+}
+
+// sendDirectMessage opens the DM conversation of the bot with the user and posts text to it
+func sendDirectMessage(ctx context.Context, slackClient contract.SlackClient, slackUserID, text string) error {
+	conversation, _, _, err := slackClient.OpenConversationContext(ctx, &slack.OpenConversationParameters{
+		Users:    []string{slackUserID},
+		ReturnIM: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open conversation: %w", err)
+	}
+
+	_, _, err = slackClient.PostMessageContext(
+		ctx,
+		conversation.ID,
+		slack.MsgOptionText(text, false),
+		slack.MsgOptionAsUser(false),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return nil
+}
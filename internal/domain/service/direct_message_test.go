@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// dmChannel is the DM conversation returned by conversations.open
+func dmChannel(id string) *slack.Channel {
+	return &slack.Channel{GroupConversation: slack.GroupConversation{Conversation: slack.Conversation{ID: id}}}
+}
+
+func Test_dmRemindersOf(t *testing.T) {
+	rotation := &entity.Scheduler{DMReminders: domain.DMRemindersDayBefore}
+
+	assert.Equal(t, domain.DMRemindersOn, dmRemindersOf(&entity.User{DMReminders: domain.DMRemindersOn}, rotation))
+	assert.Equal(t, domain.DMRemindersDayBefore, dmRemindersOf(&entity.User{}, rotation))
+	assert.Equal(t, domain.DMRemindersOff, dmRemindersOf(&entity.User{}, &entity.Scheduler{}))
+	assert.Equal(t, domain.DMRemindersOff, dmRemindersOf(&entity.User{}, nil))
+}
+
+func Test_rotationService_SetDMReminders(t *testing.T) {
+	user := &entity.User{ID: 7, ChannelID: 3, RotationID: 1, SlackUserID: "U123456789"}
+
+	tests := []struct {
+		name      string
+		value     string
+		buildMock func(mocks allMocks)
+		wantErr   bool
+	}{
+		{
+			name:  "Should store the setting of the member",
+			value: "Day-Before",
+			buildMock: func(mocks allMocks) {
+				mocks.mockUserRepo.EXPECT().GetByRotationAndSlackID(int64(1), "U123456789").Return(user, nil).Times(1)
+				mocks.mockUserRepo.EXPECT().SetDMReminders(int64(7), domain.DMRemindersDayBefore).Return(nil).Times(1)
+			},
+		},
+		{
+			name:    "Should reject an unknown setting",
+			value:   "sometimes",
+			wantErr: true,
+		},
+		{
+			name:  "Should return error when the user is not in the rotation",
+			value: "on",
+			buildMock: func(mocks allMocks) {
+				mocks.mockUserRepo.EXPECT().GetByRotationAndSlackID(int64(1), "U123456789").Return(nil, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "Should return error when saving fails",
+			value: "off",
+			buildMock: func(mocks allMocks) {
+				mocks.mockUserRepo.EXPECT().GetByRotationAndSlackID(int64(1), "U123456789").Return(user, nil).Times(1)
+				mocks.mockUserRepo.EXPECT().SetDMReminders(int64(7), domain.DMRemindersOff).Return(assert.AnError).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+			if tt.buildMock != nil {
+				tt.buildMock(m)
+			}
+
+			err := s.SetDMReminders(1, "U123456789", tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_nextHeadsUp(t *testing.T) {
+	weekdays := &entity.Scheduler{ID: 1, NotificationTime: "09:00", ActiveDays: domain.DefaultActiveDays, IsEnabled: true}
+	saoPaulo := &entity.Scheduler{ID: 2, NotificationTime: "09:00", ActiveDays: domain.DefaultActiveDays, IsEnabled: true, Timezone: "America/Sao_Paulo"}
+
+	// Monday, January 1st 2024
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, 1, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		scheduler *entity.Scheduler
+		holidays  []*entity.Holiday
+		now       time.Time
+		want      time.Time
+	}{
+		{
+			name:      "Should be due the day before the next reminder",
+			scheduler: weekdays,
+			now:       at(1, 8),
+			want:      at(1, 9),
+		},
+		{
+			name:      "Should move to the following reminder once passed",
+			scheduler: weekdays,
+			now:       at(1, 10),
+			want:      at(2, 9),
+		},
+		{
+			name:      "Should be due on Sunday for the Monday reminder",
+			scheduler: weekdays,
+			now:       at(5, 10),
+			want:      at(7, 9),
+		},
+		{
+			name:      "Should skip the holidays",
+			scheduler: weekdays,
+			holidays:  []*entity.Holiday{{StartDate: at(3, 0), EndDate: at(3, 0)}},
+			now:       at(1, 10),
+			want:      at(3, 9),
+		},
+		{
+			name:      "Should follow the timezone of the scheduler",
+			scheduler: saoPaulo,
+			now:       at(1, 10),
+			want:      at(1, 12),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextHeadsUp(tt.scheduler, tt.holidays, tt.now)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func Test_scheduleHeadsUp(t *testing.T) {
+	now := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	config := &entity.Scheduler{ID: 1, ChannelID: 1, RotationID: 1, NotificationTime: "09:00", ActiveDays: domain.DefaultActiveDays, IsEnabled: true}
+
+	t.Run("Should not queue rotations where nobody asked for a heads-up", func(t *testing.T) {
+		assert.Nil(t, scheduleHeadsUp(config, nil, now))
+	})
+
+	t.Run("Should queue the next heads-up when someone asked for it", func(t *testing.T) {
+		wanted := *config
+		wanted.HeadsUpWanted = true
+
+		item := scheduleHeadsUp(&wanted, nil, now)
+		require.NotNil(t, item)
+		assert.Equal(t, time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), item.next)
+	})
+}
+
+func Test_scheduler_sendHeadsUp(t *testing.T) {
+	// Heads-up sent on Monday at 09:00 for the reminder of Tuesday
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	occurrence := now.AddDate(0, 0, 1)
+
+	newConfig := func(dmReminders string) *entity.Scheduler {
+		return &entity.Scheduler{
+			ID:               1,
+			ChannelID:        1,
+			RotationID:       1,
+			NotificationTime: "09:00",
+			ActiveDays:       domain.DefaultActiveDays,
+			IsEnabled:        true,
+			Role:             "presenter",
+			Assignees:        2,
+			DMReminders:      dmReminders,
+		}
+	}
+
+	// U1 took the last turn, so U2 is expected on Tuesday with U3 as backup
+	newUsers := func(dmReminders ...string) []*entity.User {
+		users := []*entity.User{
+			{ID: 1, ChannelID: 1, RotationID: 1, SlackUserID: "U1", LastPresenter: true, Position: 1},
+			{ID: 2, ChannelID: 1, RotationID: 1, SlackUserID: "U2", Position: 2},
+			{ID: 3, ChannelID: 1, RotationID: 1, SlackUserID: "U3", Position: 3},
+		}
+		for i, value := range dmReminders {
+			users[i].DMReminders = value
+		}
+		return users
+	}
+
+	// expectProjection expects the calls made to find who is on duty at the occurrence
+	expectProjection := func(mocks allMocks) {
+		mocks.mockHolidayRepo.EXPECT().GetByChannelID(int64(1)).Return(nil, nil).Times(1)
+		mocks.mockAvailabilityRepo.EXPECT().GetUpcomingByChannel(int64(1), gomock.Any()).Return(nil, nil).Times(1)
+		mocks.mockSwapRepo.EXPECT().GetPendingByRotation(int64(1), gomock.Any()).Return(nil, nil).Times(1)
+	}
+
+	expectRotation := func(mocks allMocks) {
+		mocks.mockRotationRepo.EXPECT().GetByID(int64(1)).Return(&entity.Rotation{ID: 1, ChannelID: 1, IsPrimary: true}, nil).Times(1)
+		mocks.mockChannelRepo.EXPECT().GetByID(int64(1)).Return(&entity.Channel{ID: 1, SlackChannelID: "C123456789"}, nil).Times(1)
+	}
+
+	expectDM := func(mocks allMocks, slackUserID, text string) {
+		mocks.mockSlackClient.EXPECT().
+			OpenConversationContext(gomock.Any(), &slack.OpenConversationParameters{Users: []string{slackUserID}, ReturnIM: true}).
+			Return(dmChannel("D"+slackUserID), false, false, nil).Times(1)
+		mocks.mockSlackClient.EXPECT().
+			PostMessageContext(gomock.Any(), "D"+slackUserID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, options ...slack.MsgOption) (string, string, error) {
+				_, values, err := slack.UnsafeApplyMsgOptions("", "", "", options...)
+				require.NoError(t, err)
+				assert.Equal(t, text, values.Get("text"))
+				return "", "", nil
+			}).Times(1)
+	}
+
+	tests := []struct {
+		name      string
+		buildMock func(mocks allMocks)
+	}{
+		{
+			name: "Should send the heads-up to the assignees expected tomorrow",
+			buildMock: func(mocks allMocks) {
+				mocks.mockSchedulerRepo.EXPECT().GetByRotationID(int64(1)).Return(newConfig(domain.DMRemindersDayBefore), nil).Times(1)
+				mocks.mockUserRepo.EXPECT().GetActiveUsersByRotation(int64(1)).Return(newUsers(), nil).Times(1)
+				expectProjection(mocks)
+				mocks.mockSchedulerRepo.EXPECT().MarkHeadsUp(int64(1), occurrence).Return(true, nil).Times(1)
+				expectRotation(mocks)
+				expectDM(mocks, "U2", "👋 Heads-up: you're *presenter* tomorrow in <#C123456789>")
+				expectDM(mocks, "U3", "👋 Heads-up: you're *backup* tomorrow in <#C123456789>")
+			},
+		},
+		{
+			name: "Should only send to the assignees that asked for it",
+			buildMock: func(mocks allMocks) {
+				mocks.mockSchedulerRepo.EXPECT().GetByRotationID(int64(1)).Return(newConfig(domain.DMRemindersOff), nil).Times(1)
+				mocks.mockUserRepo.EXPECT().GetActiveUsersByRotation(int64(1)).Return(newUsers("", "", domain.DMRemindersDayBefore), nil).Times(1)
+				expectProjection(mocks)
+				mocks.mockSchedulerRepo.EXPECT().MarkHeadsUp(int64(1), occurrence).Return(true, nil).Times(1)
+				expectRotation(mocks)
+				expectDM(mocks, "U3", "👋 Heads-up: you're *backup* tomorrow in <#C123456789>")
+			},
+		},
+		{
+			name: "Should not send when nobody asked for it",
+			buildMock: func(mocks allMocks) {
+				mocks.mockSchedulerRepo.EXPECT().GetByRotationID(int64(1)).Return(newConfig(domain.DMRemindersOn), nil).Times(1)
+				mocks.mockUserRepo.EXPECT().GetActiveUsersByRotation(int64(1)).Return(newUsers(), nil).Times(1)
+			},
+		},
+		{
+			name: "Should not send when only members off duty tomorrow asked for it",
+			buildMock: func(mocks allMocks) {
+				mocks.mockSchedulerRepo.EXPECT().GetByRotationID(int64(1)).Return(newConfig(domain.DMRemindersOff), nil).Times(1)
+				mocks.mockUserRepo.EXPECT().GetActiveUsersByRotation(int64(1)).Return(newUsers(domain.DMRemindersDayBefore), nil).Times(1)
+				expectProjection(mocks)
+			},
+		},
+		{
+			name: "Should not send when the reminder moved",
+			buildMock: func(mocks allMocks) {
+				moved := newConfig(domain.DMRemindersDayBefore)
+				moved.NotificationTime = "10:00"
+
+				mocks.mockSchedulerRepo.EXPECT().GetByRotationID(int64(1)).Return(moved, nil).Times(1)
+				mocks.mockUserRepo.EXPECT().GetActiveUsersByRotation(int64(1)).Return(newUsers(), nil).Times(1)
+				mocks.mockHolidayRepo.EXPECT().GetByChannelID(int64(1)).Return(nil, nil).Times(1)
+			},
+		},
+		{
+			name: "Should not send a heads-up already sent by another instance",
+			buildMock: func(mocks allMocks) {
+				mocks.mockSchedulerRepo.EXPECT().GetByRotationID(int64(1)).Return(newConfig(domain.DMRemindersDayBefore), nil).Times(1)
+				mocks.mockUserRepo.EXPECT().GetActiveUsersByRotation(int64(1)).Return(newUsers(), nil).Times(1)
+				expectProjection(mocks)
+				mocks.mockSchedulerRepo.EXPECT().MarkHeadsUp(int64(1), occurrence).Return(false, nil).Times(1)
+			},
+		},
+		{
+			name: "Should not send while the rotation is paused",
+			buildMock: func(mocks allMocks) {
+				paused := newConfig(domain.DMRemindersDayBefore)
+				paused.IsEnabled = false
+
+				mocks.mockSchedulerRepo.EXPECT().GetByRotationID(int64(1)).Return(paused, nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			s := newScheduler(m.mockDataManager, m.mockSlackClients, newFakeClock(now))
+
+			if tt.buildMock != nil {
+				tt.buildMock(m)
+			}
+
+			require.NoError(t, s.sendHeadsUp(context.Background(), 1, occurrence))
+		})
+	}
+}
+
+func Test_sendDirectReminders(t *testing.T) {
+	rotation := &entity.Rotation{ID: 2, ChannelID: 1, Name: "reviewers"}
+	channel := &entity.Channel{ID: 1, SlackChannelID: "C123456789"}
+	config := &entity.Scheduler{RotationID: 2, DMReminders: domain.DMRemindersOn}
+
+	t.Run("Should send a DM to the assignees that did not turn them off", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		assignees := []*entity.User{
+			{ID: 1, SlackUserID: "U1"},
+			{ID: 2, SlackUserID: "U2", DMReminders: domain.DMRemindersOff},
+			{ID: 3, SlackUserID: "U3", DMReminders: domain.DMRemindersDayBefore},
+		}
+
+		texts := map[string]string{}
+		m.mockSlackClient.EXPECT().
+			OpenConversationContext(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, params *slack.OpenConversationParameters) (*slack.Channel, bool, bool, error) {
+				return dmChannel("D" + params.Users[0]), false, false, nil
+			}).Times(2)
+		m.mockSlackClient.EXPECT().
+			PostMessageContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
+				_, values, err := slack.UnsafeApplyMsgOptions("", "", "", options...)
+				require.NoError(t, err)
+				texts[channelID] = values.Get("text")
+				return "", "", nil
+			}).Times(2)
+
+		sendDirectReminders(context.Background(), m.mockSlackClient, rotation, channel, config, assignees, "reviewer", "pair")
+
+		assert.Equal(t, map[string]string{
+			"DU1": "🔔 You're *reviewer* today in <#C123456789> (reviewers rotation)",
+			"DU3": "🔔 You're *pair* today in <#C123456789> (reviewers rotation)",
+		}, texts)
+	})
+
+	t.Run("Should go on when a DM cannot be sent", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		assignees := []*entity.User{{ID: 1, SlackUserID: "U1"}, {ID: 2, SlackUserID: "U2"}}
+
+		gomock.InOrder(
+			m.mockSlackClient.EXPECT().
+				OpenConversationContext(gomock.Any(), gomock.Any()).
+				Return(nil, false, false, assert.AnError).Times(1),
+			m.mockSlackClient.EXPECT().
+				OpenConversationContext(gomock.Any(), gomock.Any()).
+				Return(dmChannel("DU2"), false, false, nil).Times(1),
+			m.mockSlackClient.EXPECT().
+				PostMessageContext(gomock.Any(), "DU2", gomock.Any(), gomock.Any()).
+				Return("", "", nil).Times(1),
+		)
+
+		sendDirectReminders(context.Background(), m.mockSlackClient, rotation, channel, config, assignees, "reviewer", "pair")
+	})
+
+	t.Run("Should not send anything when the rotation has no config", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		sendDirectReminders(context.Background(), m.mockSlackClient, rotation, channel, nil, []*entity.User{{ID: 1, SlackUserID: "U1"}}, "reviewer", "pair")
+	})
+}
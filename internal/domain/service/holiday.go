@@ -0,0 +1,283 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+)
+
+// maxDateRangeDays limits how long a single date range can be
+const maxDateRangeDays = 366
+
+func (s *rotationService) AddHoliday(channelID int64, dateRange, description string) (*entity.Holiday, error) {
+	start, end, err := parseDateRange(dateRange)
+	if err != nil {
+		return nil, err
+	}
+
+	holiday := &entity.Holiday{
+		ChannelID:   channelID,
+		StartDate:   start,
+		EndDate:     end,
+		Description: strings.TrimSpace(description),
+	}
+
+	if err := s.dm.Holiday().Create(holiday); err != nil {
+		return nil, fmt.Errorf("failed to create holiday: %w", err)
+	}
+
+	// Notify scheduler so the next notification skips the new dates
+	if s.scheduler != nil {
+		s.scheduler.NotifyConfigChange(channelID)
+	}
+
+	return holiday, nil
+}
+
+// ListHolidays returns the holidays that have not ended yet in the channel timezone
+func (s *rotationService) ListHolidays(channelID int64) ([]*entity.Holiday, error) {
+	holidays, err := s.dm.Holiday().GetByChannelID(channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get holidays: %w", err)
+	}
+
+	loc, err := s.channelLocation(channelID)
+	if err != nil {
+		return nil, err
+	}
+	today := dateOf(s.clock.Now().In(loc))
+
+	var upcoming []*entity.Holiday
+	for _, holiday := range holidays {
+		if !holiday.EndDate.Before(today) {
+			upcoming = append(upcoming, holiday)
+		}
+	}
+
+	return upcoming, nil
+}
+
+func (s *rotationService) RemoveHoliday(channelID int64, dateRange string) error {
+	start, end, err := parseDateRange(dateRange)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.dm.Holiday().DeleteByRange(channelID, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to remove holiday: %w", err)
+	}
+
+	if deleted == 0 {
+		return fmt.Errorf("no holiday found for %s. Use `/rotation holidays list` to see the configured dates", strings.TrimSpace(dateRange))
+	}
+
+	// Notify scheduler so the removed dates are considered again
+	if s.scheduler != nil {
+		s.scheduler.NotifyConfigChange(channelID)
+	}
+
+	return nil
+}
+
+// ImportHolidays adds every all-day event of an iCalendar (.ics) body as a holiday.
+// Events already configured with the same dates are ignored.
+func (s *rotationService) ImportHolidays(ctx context.Context, channelID int64, icsBody string) (int, error) {
+	events, err := parseICS(icsBody)
+	if err != nil {
+		return 0, err
+	}
+
+	existing, err := s.dm.Holiday().GetByChannelID(channelID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get holidays: %w", err)
+	}
+
+	known := make(map[string]bool)
+	for _, holiday := range existing {
+		known[holidayKey(holiday)] = true
+	}
+
+	imported := 0
+	for _, event := range events {
+		event.ChannelID = channelID
+		if known[holidayKey(event)] {
+			continue
+		}
+
+		// A long calendar stops being imported once the command runs out of time
+		if err := ctx.Err(); err != nil {
+			return imported, fmt.Errorf("import interrupted after %d holidays: %w", imported, err)
+		}
+
+		if err := s.dm.Holiday().Create(event); err != nil {
+			return imported, fmt.Errorf("failed to create holiday: %w", err)
+		}
+		known[holidayKey(event)] = true
+		imported++
+	}
+
+	// Notify scheduler so the next notification skips the new dates
+	if imported > 0 && s.scheduler != nil {
+		s.scheduler.NotifyConfigChange(channelID)
+	}
+
+	return imported, nil
+}
+
+func holidayKey(holiday *entity.Holiday) string {
+	return holiday.StartDate.Format(domain.DateFormat) + ".." + holiday.EndDate.Format(domain.DateFormat)
+}
+
+// isHoliday reports whether the calendar date of day is covered by any holiday
+func isHoliday(holidays []*entity.Holiday, day time.Time) bool {
+	for _, holiday := range holidays {
+		if holiday.Covers(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDateRange parses "YYYY-MM-DD" or "YYYY-MM-DD..YYYY-MM-DD" into an inclusive date range
+func parseDateRange(input string) (time.Time, time.Time, error) {
+	invalid := fmt.Errorf("invalid date. Use YYYY-MM-DD or YYYY-MM-DD..YYYY-MM-DD. Example: 2026-12-24..2026-12-26")
+
+	value := strings.TrimSpace(input)
+	startValue, endValue, isRange := strings.Cut(value, "..")
+	if !isRange {
+		endValue = startValue
+	}
+
+	start, err := time.Parse(domain.DateFormat, strings.TrimSpace(startValue))
+	if err != nil {
+		return time.Time{}, time.Time{}, invalid
+	}
+
+	end, err := time.Parse(domain.DateFormat, strings.TrimSpace(endValue))
+	if err != nil {
+		return time.Time{}, time.Time{}, invalid
+	}
+
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid date range. The end date must not be before the start date")
+	}
+
+	if end.Sub(start) > maxDateRangeDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid date range. A range can span at most %d days", maxDateRangeDays)
+	}
+
+	return start, end, nil
+}
+
+// icsPropertyPattern finds iCalendar properties when line breaks were lost (e.g. pasted in a single line)
+var icsPropertyPattern = regexp.MustCompile(`\s+((?:BEGIN|END|DTSTART|DTEND|DTSTAMP|SUMMARY|UID|DESCRIPTION|LOCATION|TRANSP|SEQUENCE|STATUS|CATEGORIES|CLASS|CREATED|LAST-MODIFIED|RRULE|VERSION|PRODID|CALSCALE|METHOD|X-[A-Z-]+)[:;])`)
+
+// parseICS extracts the dates of every VEVENT in an iCalendar body.
+// Only the date part of DTSTART/DTEND is used, and DTEND is exclusive as defined by RFC 5545.
+func parseICS(body string) ([]*entity.Holiday, error) {
+	if !strings.Contains(body, "\n") {
+		body = icsPropertyPattern.ReplaceAllString(body, "\n$1")
+	}
+
+	// Unfold continuation lines (lines starting with a space or tab)
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+
+	var holidays []*entity.Holiday
+	var current *entity.Holiday
+	var hasEnd bool
+
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		// Drop parameters such as DTSTART;VALUE=DATE
+		name, _, _ = strings.Cut(strings.ToUpper(name), ";")
+
+		switch name {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				current = &entity.Holiday{}
+				hasEnd = false
+			}
+		case "DTSTART":
+			if current != nil {
+				date, err := parseICSDate(value)
+				if err != nil {
+					return nil, err
+				}
+				current.StartDate = date
+			}
+		case "DTEND":
+			if current != nil {
+				date, err := parseICSDate(value)
+				if err != nil {
+					return nil, err
+				}
+				current.EndDate = date
+				hasEnd = true
+			}
+		case "SUMMARY":
+			if current != nil {
+				current.Description = strings.TrimSpace(strings.ReplaceAll(value, `\,`, ","))
+			}
+		case "END":
+			if current == nil || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			if current.StartDate.IsZero() {
+				return nil, fmt.Errorf("invalid calendar: event %q has no DTSTART", current.Description)
+			}
+
+			// DTEND is exclusive, so the last skipped date is the day before it
+			if hasEnd && current.EndDate.After(current.StartDate) {
+				current.EndDate = current.EndDate.AddDate(0, 0, -1)
+			} else {
+				current.EndDate = current.StartDate
+			}
+
+			holidays = append(holidays, current)
+			current = nil
+		}
+	}
+
+	if len(holidays) == 0 {
+		return nil, fmt.Errorf("no events found in calendar. Paste the contents of an .ics file")
+	}
+
+	return holidays, nil
+}
+
+// parseICSDate parses DATE (20261224) and DATE-TIME (20261224T000000Z) values, keeping only the date
+func parseICSDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid calendar date: %s", value)
+	}
+
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid calendar date: %s", value)
+	}
+
+	return date, nil
+}
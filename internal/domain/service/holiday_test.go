@@ -0,0 +1,344 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_parseDateRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "Should parse a single date",
+			input:     "2026-12-24",
+			wantStart: time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "Should parse an inclusive range",
+			input:     " 2026-12-24..2026-12-26 ",
+			wantStart: time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 12, 26, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Should reject an invalid date",
+			input:   "2026-13-01",
+			wantErr: true,
+		},
+		{
+			name:    "Should reject an end before the start",
+			input:   "2026-12-26..2026-12-24",
+			wantErr: true,
+		},
+		{
+			name:    "Should reject a range longer than a year",
+			input:   "2026-01-01..2027-06-01",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := parseDateRange(tt.input)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
+
+func Test_parseICS(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []*entity.Holiday
+		wantErr bool
+	}{
+		{
+			name: "Should parse all-day events with exclusive DTEND",
+			input: "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" +
+				"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20261224\r\nDTEND;VALUE=DATE:20261227\r\nSUMMARY:Christmas\r\nEND:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20270101\r\nDTEND;VALUE=DATE:20270102\r\nSUMMARY:New Year\\, Day\r\nEND:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+			want: []*entity.Holiday{
+				{StartDate: time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 12, 26, 0, 0, 0, 0, time.UTC), Description: "Christmas"},
+				{StartDate: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), Description: "New Year, Day"},
+			},
+		},
+		{
+			name:  "Should parse events without DTEND and with folded lines",
+			input: "BEGIN:VEVENT\nDTSTART:20261225T000000Z\nSUMMARY:Christmas\n  Day\nEND:VEVENT\n",
+			want: []*entity.Holiday{
+				{StartDate: time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC), Description: "Christmas Day"},
+			},
+		},
+		{
+			name:  "Should parse a calendar pasted in a single line",
+			input: "BEGIN:VCALENDAR BEGIN:VEVENT DTSTART;VALUE=DATE:20261224 DTEND;VALUE=DATE:20261225 SUMMARY:Christmas Eve END:VEVENT END:VCALENDAR",
+			want: []*entity.Holiday{
+				{StartDate: time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC), Description: "Christmas Eve"},
+			},
+		},
+		{
+			name:    "Should return error when there are no events",
+			input:   "BEGIN:VCALENDAR\nEND:VCALENDAR\n",
+			wantErr: true,
+		},
+		{
+			name:    "Should return error for an invalid date",
+			input:   "BEGIN:VEVENT\nDTSTART:2026\nEND:VEVENT\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseICS(tt.input)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_rotationService_AddHoliday(t *testing.T) {
+	type args struct {
+		channelID   int64
+		dateRange   string
+		description string
+	}
+	tests := []struct {
+		name      string
+		buildMock func(mocks allMocks, args args)
+		args      args
+		wantErr   bool
+	}{
+		{
+			name: "Should add holiday range",
+			args: args{
+				channelID:   1,
+				dateRange:   "2026-12-24..2026-12-26",
+				description: "Christmas",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockHolidayRepo.EXPECT().
+					Create(gomock.Any()).
+					DoAndReturn(func(h *entity.Holiday) error {
+						require.Equal(t, args.channelID, h.ChannelID)
+						require.Equal(t, time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC), h.StartDate)
+						require.Equal(t, time.Date(2026, 12, 26, 0, 0, 0, 0, time.UTC), h.EndDate)
+						require.Equal(t, args.description, h.Description)
+						return nil
+					}).Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error for invalid range",
+			args: args{
+				channelID: 1,
+				dateRange: "tomorrow",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when repository fails",
+			args: args{
+				channelID: 1,
+				dateRange: "2026-12-24",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockHolidayRepo.EXPECT().
+					Create(gomock.Any()).
+					Return(assert.AnError).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+			if tt.buildMock != nil {
+				tt.buildMock(m, tt.args)
+			}
+
+			got, err := s.AddHoliday(tt.args.channelID, tt.args.dateRange, tt.args.description)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, got)
+		})
+	}
+}
+
+func Test_rotationService_ListHolidays(t *testing.T) {
+	m, ctrl := newServiceTestMock(t)
+	defer ctrl.Finish()
+
+	s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	past := &entity.Holiday{ID: 1, StartDate: today.AddDate(0, 0, -10), EndDate: today.AddDate(0, 0, -8)}
+	ongoing := &entity.Holiday{ID: 2, StartDate: today.AddDate(0, 0, -1), EndDate: today.AddDate(0, 0, 1)}
+	future := &entity.Holiday{ID: 3, StartDate: today.AddDate(0, 0, 5), EndDate: today.AddDate(0, 0, 5)}
+
+	gomock.InOrder(
+		m.mockHolidayRepo.EXPECT().
+			GetByChannelID(int64(1)).
+			Return([]*entity.Holiday{past, ongoing, future}, nil).Times(1),
+
+		m.mockSchedulerRepo.EXPECT().
+			GetByChannelID(int64(1)).
+			Return(nil, nil).Times(1),
+	)
+
+	got, err := s.ListHolidays(1)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.Holiday{ongoing, future}, got)
+}
+
+func Test_rotationService_RemoveHoliday(t *testing.T) {
+	type args struct {
+		channelID int64
+		dateRange string
+	}
+	tests := []struct {
+		name      string
+		buildMock func(mocks allMocks, args args)
+		args      args
+		wantErr   bool
+	}{
+		{
+			name: "Should remove holiday",
+			args: args{channelID: 1, dateRange: "2026-12-24..2026-12-26"},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockHolidayRepo.EXPECT().
+					DeleteByRange(args.channelID, time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 26, 0, 0, 0, 0, time.UTC)).
+					Return(int64(1), nil).Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error when holiday does not exist",
+			args: args{channelID: 1, dateRange: "2026-12-24"},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockHolidayRepo.EXPECT().
+					DeleteByRange(args.channelID, gomock.Any(), gomock.Any()).
+					Return(int64(0), nil).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+			if tt.buildMock != nil {
+				tt.buildMock(m, tt.args)
+			}
+
+			err := s.RemoveHoliday(tt.args.channelID, tt.args.dateRange)
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_rotationService_ImportHolidays(t *testing.T) {
+	m, ctrl := newServiceTestMock(t)
+	defer ctrl.Finish()
+
+	s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+	ics := "BEGIN:VCALENDAR\n" +
+		"BEGIN:VEVENT\nDTSTART;VALUE=DATE:20261224\nDTEND;VALUE=DATE:20261227\nSUMMARY:Christmas\nEND:VEVENT\n" +
+		"BEGIN:VEVENT\nDTSTART;VALUE=DATE:20270101\nDTEND;VALUE=DATE:20270102\nSUMMARY:New Year\nEND:VEVENT\n" +
+		"END:VCALENDAR\n"
+
+	// Christmas is already configured, so only New Year is imported
+	existing := []*entity.Holiday{
+		{ID: 1, ChannelID: 1, StartDate: time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 12, 26, 0, 0, 0, 0, time.UTC)},
+	}
+
+	gomock.InOrder(
+		m.mockHolidayRepo.EXPECT().
+			GetByChannelID(int64(1)).
+			Return(existing, nil).Times(1),
+
+		m.mockHolidayRepo.EXPECT().
+			Create(gomock.Any()).
+			DoAndReturn(func(h *entity.Holiday) error {
+				require.Equal(t, int64(1), h.ChannelID)
+				require.Equal(t, "New Year", h.Description)
+				return nil
+			}).Times(1),
+	)
+
+	imported, err := s.ImportHolidays(context.Background(), 1, ics)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, imported)
+}
+
+func Test_rotationService_ImportHolidays_Canceled(t *testing.T) {
+	m, ctrl := newServiceTestMock(t)
+	defer ctrl.Finish()
+
+	s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+	ics := "BEGIN:VCALENDAR\n" +
+		"BEGIN:VEVENT\nDTSTART;VALUE=DATE:20270101\nDTEND;VALUE=DATE:20270102\nSUMMARY:New Year\nEND:VEVENT\n" +
+		"END:VCALENDAR\n"
+
+	m.mockHolidayRepo.EXPECT().GetByChannelID(int64(1)).Return(nil, nil).Times(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Nothing is created once the command is out of time
+	imported, err := s.ImportHolidays(ctx, 1, ics)
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, imported)
+}
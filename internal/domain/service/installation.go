@@ -0,0 +1,26 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+)
+
+// SaveInstallation stores the bot token of a workspace that installed the app, replacing the
+// previous one when it is installed again
+func (s *rotationService) SaveInstallation(installation *entity.Installation) error {
+	if installation.SlackTeamID == "" || installation.BotToken == "" {
+		return fmt.Errorf("installation is missing the team or the bot token")
+	}
+
+	if err := s.dm.Installation().Save(installation); err != nil {
+		return fmt.Errorf("failed to save installation: %w", err)
+	}
+
+	// The cached client still holds the previous token
+	s.slackClients.Forget(installation.SlackTeamID)
+
+	log.Printf("App installed in workspace %s (%s) by %s", installation.SlackTeamID, installation.SlackTeamName, installation.InstalledBy)
+	return nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_rotationService_SaveInstallation(t *testing.T) {
+	installation := &entity.Installation{
+		SlackTeamID:   "T123456789",
+		SlackTeamName: "Acme",
+		BotToken:      "xoxb-token",
+		InstalledBy:   "U123456789",
+	}
+
+	t.Run("should save the installation and drop the cached client", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+		m.mockInstallationRepo.EXPECT().Save(installation).Return(nil).Times(1)
+		m.mockSlackClients.EXPECT().Forget("T123456789").Times(1)
+
+		require.NoError(t, s.SaveInstallation(installation))
+	})
+
+	t.Run("should return error without bot token", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+		err := s.SaveInstallation(&entity.Installation{SlackTeamID: "T123456789"})
+		require.Error(t, err)
+	})
+
+	t.Run("should return error when saving fails", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+		m.mockInstallationRepo.EXPECT().Save(installation).Return(assert.AnError).Times(1)
+
+		err := s.SaveInstallation(installation)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -9,9 +9,11 @@ type Instance struct {
 	Scheduler *scheduler
 }
 
-func NewInstance(dm contract.DataManager, slackClient contract.SlackClient) *Instance {
-	rotationService := newRotation(dm, slackClient)
-	schedulerService := newScheduler(dm, slackClient)
+// NewInstance wires the services together. clock is shared by both so they agree on the time,
+// use NewSystemClock outside of tests. slackClients resolves the Slack client of each workspace.
+func NewInstance(dm contract.DataManager, slackClients contract.SlackClientFactory, clock contract.Clock) *Instance {
+	rotationService := newRotation(dm, slackClients, clock)
+	schedulerService := newScheduler(dm, slackClients, clock)
 
 	// Connect services to avoid circular dependency
 	rotationService.SetScheduler(schedulerService)
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/slack-go/slack"
+)
+
+// RemoveLeftMember takes a member that left the Slack channel out of every rotation of
+// the channel and posts a notice about it. Unknown channels are ignored.
+func (s *rotationService) RemoveLeftMember(slackChannelID, slackUserID string) error {
+	channel, err := s.dm.Channel().GetBySlackID(slackChannelID)
+	if err != nil {
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	if channel == nil {
+		return nil
+	}
+
+	users, err := s.dm.User().GetBySlackUserID(slackUserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var members []*entity.User
+	for _, user := range users {
+		if user.ChannelID == channel.ID {
+			members = append(members, user)
+		}
+	}
+
+	return s.removeMembers(channel, slackUserID, members, "left the channel")
+}
+
+// RemoveDeactivatedUser takes a user deactivated in Slack out of every rotation, in all
+// channels, and posts a notice in each channel
+func (s *rotationService) RemoveDeactivatedUser(slackUserID string) error {
+	users, err := s.dm.User().GetBySlackUserID(slackUserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Users come sorted by channel, so memberships of a channel are next to each other
+	for start := 0; start < len(users); {
+		end := start
+		for end < len(users) && users[end].ChannelID == users[start].ChannelID {
+			end++
+		}
+
+		channel, err := s.dm.Channel().GetByID(users[start].ChannelID)
+		if err != nil {
+			return fmt.Errorf("failed to get channel: %w", err)
+		}
+
+		if channel != nil {
+			if err := s.removeMembers(channel, slackUserID, users[start:end], "was deactivated"); err != nil {
+				return err
+			}
+		}
+
+		start = end
+	}
+
+	return nil
+}
+
+// SetChannelActive marks the channel as archived or unarchived. Archived channels are not
+// notified until they are unarchived. Unknown channels are ignored.
+func (s *rotationService) SetChannelActive(slackChannelID string, active bool) error {
+	channel, err := s.dm.Channel().GetBySlackID(slackChannelID)
+	if err != nil {
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	if channel == nil || channel.IsActive == active {
+		return nil
+	}
+
+	channel.IsActive = active
+	if err := s.dm.Channel().Update(channel); err != nil {
+		return fmt.Errorf("failed to update channel: %w", err)
+	}
+
+	// Notify scheduler so archived channels stop or start being notified
+	if s.scheduler != nil {
+		s.scheduler.NotifyConfigChange(channel.ID)
+	}
+
+	return nil
+}
+
+// RenameChannel keeps the stored channel name in sync with Slack. Unknown channels are ignored.
+func (s *rotationService) RenameChannel(slackChannelID, name string) error {
+	channel, err := s.dm.Channel().GetBySlackID(slackChannelID)
+	if err != nil {
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	if channel == nil || channel.SlackChannelName == name {
+		return nil
+	}
+
+	channel.SlackChannelName = name
+	if err := s.dm.Channel().Update(channel); err != nil {
+		return fmt.Errorf("failed to update channel: %w", err)
+	}
+
+	return nil
+}
+
+// removeMembers deletes the rotation memberships of one user in the channel and tells the
+// channel why, reason completes the sentence "<@user> ..."
+func (s *rotationService) removeMembers(channel *entity.Channel, slackUserID string, members []*entity.User, reason string) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	var rotations []*entity.Rotation
+	err := s.dm.WithTransaction(context.Background(), func(tx contract.DataManager) error {
+		for _, member := range members {
+			rotation, err := tx.Rotation().GetByID(member.RotationID)
+			if err != nil {
+				return fmt.Errorf("failed to get rotation: %w", err)
+			}
+
+			if err := tx.User().Delete(member.ID); err != nil {
+				return fmt.Errorf("failed to remove user: %w", err)
+			}
+
+			if rotation != nil {
+				rotations = append(rotations, rotation)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Removed %s from %d rotation(s) of channel %s: %s", slackUserID, len(members), channel.SlackChannelID, reason)
+
+	// Archived channels cannot receive messages
+	if !channel.IsActive {
+		return nil
+	}
+
+	slackClient, err := s.slackClients.ForTeam(channel.SlackTeamID)
+	if err != nil {
+		return fmt.Errorf("failed to get Slack client: %w", err)
+	}
+
+	message := fmt.Sprintf("👋 <@%s> %s and was removed from %s.", slackUserID, reason, formatRemovedFrom(rotations))
+
+	_, _, err = slackClient.PostMessage(
+		channel.SlackChannelID,
+		slack.MsgOptionText(message, false),
+		slack.MsgOptionAsUser(false),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to send Slack message: %w", err)
+	}
+
+	return nil
+}
+
+// formatRemovedFrom names the rotations a member was removed from, e.g. "the *reviewers* rotation"
+func formatRemovedFrom(rotations []*entity.Rotation) string {
+	if len(rotations) == 0 || (len(rotations) == 1 && rotations[0].IsPrimary) {
+		return "the rotation"
+	}
+
+	names := make([]string, len(rotations))
+	for i, rotation := range rotations {
+		names[i] = fmt.Sprintf("*%s*", rotation.Name)
+	}
+
+	if len(names) == 1 {
+		return fmt.Sprintf("the %s rotation", names[0])
+	}
+
+	return fmt.Sprintf("the %s rotations", strings.Join(names, ", "))
+}
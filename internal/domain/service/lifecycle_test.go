@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_rotationService_RemoveLeftMember(t *testing.T) {
+	channel := &entity.Channel{ID: 1, SlackChannelID: "C123456789", IsActive: true}
+	primary := &entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}
+
+	type args struct {
+		slackChannelID string
+		slackUserID    string
+	}
+	tests := []struct {
+		name      string
+		buildMock func(mocks allMocks, args args)
+		args      args
+		wantErr   bool
+	}{
+		{
+			name: "Should remove member from the channel rotations and post a notice",
+			args: args{slackChannelID: "C123456789", slackUserID: "U123"},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockChannelRepo.EXPECT().
+						GetBySlackID(args.slackChannelID).
+						Return(channel, nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						GetBySlackUserID(args.slackUserID).
+						Return([]*entity.User{
+							{ID: 10, ChannelID: 1, RotationID: 1, SlackUserID: args.slackUserID},
+							{ID: 20, ChannelID: 2, RotationID: 5, SlackUserID: args.slackUserID},
+						}, nil).Times(1),
+
+					mocks.mockDataManager.EXPECT().
+						WithTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+							return fn(mocks.mockDataManager)
+						}).Times(1),
+
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(int64(1)).
+						Return(primary, nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						Delete(int64(10)).
+						Return(nil).Times(1),
+
+					mocks.mockSlackClient.EXPECT().
+						PostMessage(args.slackChannelID, gomock.Any(), gomock.Any()).
+						Return("", "", nil).Times(1),
+				)
+			},
+		},
+		{
+			name: "Should do nothing when user is not in the channel rotations",
+			args: args{slackChannelID: "C123456789", slackUserID: "U123"},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockChannelRepo.EXPECT().
+						GetBySlackID(args.slackChannelID).
+						Return(channel, nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						GetBySlackUserID(args.slackUserID).
+						Return([]*entity.User{{ID: 20, ChannelID: 2, RotationID: 5}}, nil).Times(1),
+				)
+			},
+		},
+		{
+			name: "Should ignore unknown channel",
+			args: args{slackChannelID: "C000000000", slackUserID: "U123"},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockChannelRepo.EXPECT().
+					GetBySlackID(args.slackChannelID).
+					Return(nil, nil).Times(1)
+			},
+		},
+		{
+			name: "Should return error when delete fails",
+			args: args{slackChannelID: "C123456789", slackUserID: "U123"},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockChannelRepo.EXPECT().
+						GetBySlackID(args.slackChannelID).
+						Return(channel, nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						GetBySlackUserID(args.slackUserID).
+						Return([]*entity.User{{ID: 10, ChannelID: 1, RotationID: 1}}, nil).Times(1),
+
+					mocks.mockDataManager.EXPECT().
+						WithTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+							return fn(mocks.mockDataManager)
+						}).Times(1),
+
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(int64(1)).
+						Return(primary, nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						Delete(int64(10)).
+						Return(assert.AnError).Times(1),
+				)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+			if tt.buildMock != nil {
+				tt.buildMock(m, tt.args)
+			}
+
+			err := s.RemoveLeftMember(tt.args.slackChannelID, tt.args.slackUserID)
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_rotationService_RemoveDeactivatedUser(t *testing.T) {
+	m, ctrl := newServiceTestMock(t)
+	defer ctrl.Finish()
+
+	s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+	gomock.InOrder(
+		m.mockUserRepo.EXPECT().
+			GetBySlackUserID("U123").
+			Return([]*entity.User{
+				{ID: 10, ChannelID: 1, RotationID: 1},
+				{ID: 11, ChannelID: 1, RotationID: 2},
+				{ID: 20, ChannelID: 2, RotationID: 5},
+			}, nil).Times(1),
+
+		// Channel 1 is active, the member is removed from both rotations with a notice
+		m.mockChannelRepo.EXPECT().
+			GetByID(int64(1)).
+			Return(&entity.Channel{ID: 1, SlackChannelID: "C1", IsActive: true}, nil).Times(1),
+		m.mockDataManager.EXPECT().
+			WithTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+				return fn(m.mockDataManager)
+			}).Times(1),
+		m.mockRotationRepo.EXPECT().GetByID(int64(1)).Return(&entity.Rotation{ID: 1, IsPrimary: true}, nil).Times(1),
+		m.mockUserRepo.EXPECT().Delete(int64(10)).Return(nil).Times(1),
+		m.mockRotationRepo.EXPECT().GetByID(int64(2)).Return(&entity.Rotation{ID: 2, Name: "reviewers"}, nil).Times(1),
+		m.mockUserRepo.EXPECT().Delete(int64(11)).Return(nil).Times(1),
+		m.mockSlackClient.EXPECT().PostMessage("C1", gomock.Any(), gomock.Any()).Return("", "", nil).Times(1),
+
+		// Channel 2 is archived, the member is removed without a notice
+		m.mockChannelRepo.EXPECT().
+			GetByID(int64(2)).
+			Return(&entity.Channel{ID: 2, SlackChannelID: "C2", IsActive: false}, nil).Times(1),
+		m.mockDataManager.EXPECT().
+			WithTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+				return fn(m.mockDataManager)
+			}).Times(1),
+		m.mockRotationRepo.EXPECT().GetByID(int64(5)).Return(&entity.Rotation{ID: 5, IsPrimary: true}, nil).Times(1),
+		m.mockUserRepo.EXPECT().Delete(int64(20)).Return(nil).Times(1),
+	)
+
+	err := s.RemoveDeactivatedUser("U123")
+
+	require.NoError(t, err)
+}
+
+func Test_rotationService_SetChannelActive(t *testing.T) {
+	t.Run("Should archive active channel", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+		gomock.InOrder(
+			m.mockChannelRepo.EXPECT().
+				GetBySlackID("C123456789").
+				Return(&entity.Channel{ID: 1, SlackChannelID: "C123456789", IsActive: true}, nil).Times(1),
+			m.mockChannelRepo.EXPECT().
+				Update(gomock.Any()).
+				DoAndReturn(func(channel *entity.Channel) error {
+					require.False(t, channel.IsActive)
+					return nil
+				}).Times(1),
+		)
+
+		require.NoError(t, s.SetChannelActive("C123456789", false))
+	})
+
+	t.Run("Should not update channel already in the state", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+		m.mockChannelRepo.EXPECT().
+			GetBySlackID("C123456789").
+			Return(&entity.Channel{ID: 1, SlackChannelID: "C123456789", IsActive: true}, nil).Times(1)
+
+		require.NoError(t, s.SetChannelActive("C123456789", true))
+	})
+}
+
+func Test_rotationService_RenameChannel(t *testing.T) {
+	m, ctrl := newServiceTestMock(t)
+	defer ctrl.Finish()
+
+	s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+	gomock.InOrder(
+		m.mockChannelRepo.EXPECT().
+			GetBySlackID("C123456789").
+			Return(&entity.Channel{ID: 1, SlackChannelID: "C123456789", SlackChannelName: "old-name"}, nil).Times(1),
+		m.mockChannelRepo.EXPECT().
+			Update(gomock.Any()).
+			DoAndReturn(func(channel *entity.Channel) error {
+				require.Equal(t, "new-name", channel.SlackChannelName)
+				return nil
+			}).Times(1),
+	)
+
+	require.NoError(t, s.RenameChannel("C123456789", "new-name"))
+}
+
+func Test_formatRemovedFrom(t *testing.T) {
+	primary := &entity.Rotation{Name: domain.DefaultRotationName, IsPrimary: true}
+	reviewers := &entity.Rotation{Name: "reviewers"}
+	oncall := &entity.Rotation{Name: "oncall"}
+
+	assert.Equal(t, "the rotation", formatRemovedFrom([]*entity.Rotation{primary}))
+	assert.Equal(t, "the *reviewers* rotation", formatRemovedFrom([]*entity.Rotation{reviewers}))
+	assert.Equal(t, "the *default*, *oncall* rotations", formatRemovedFrom([]*entity.Rotation{primary, oncall}))
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"container/heap"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+)
+
+// queuedRotation is a rotation waiting in the notification queue for its next reminder
+type queuedRotation struct {
+	config   *entity.Scheduler
+	holidays []*entity.Holiday
+	// next is when the rotation is notified next
+	next time.Time
+	// sent is the last occurrence handed to the workers, which is not notified again even
+	// before the workers record it in the database
+	sent time.Time
+	// index is the position in the heap, kept up to date by the queue
+	index int
+}
+
+// notificationQueue orders the rotations by their next notification time, the earliest first.
+// Rotations are updated one channel at a time, so a config change doesn't reload every rotation.
+// It is only used by the scheduler main loop and is not safe for concurrent use.
+type notificationQueue struct {
+	items      []*queuedRotation
+	byRotation map[int64]*queuedRotation
+	byChannel  map[int64]map[int64]*queuedRotation
+}
+
+func newNotificationQueue() *notificationQueue {
+	return &notificationQueue{
+		byRotation: make(map[int64]*queuedRotation),
+		byChannel:  make(map[int64]map[int64]*queuedRotation),
+	}
+}
+
+// Len, Less, Swap, Push and Pop implement heap.Interface, use the other methods instead
+
+func (q *notificationQueue) Len() int {
+	return len(q.items)
+}
+
+func (q *notificationQueue) Less(i, j int) bool {
+	if q.items[i].next.Equal(q.items[j].next) {
+		// Keeps the rotations due at the same time in a stable order
+		return q.items[i].config.RotationID < q.items[j].config.RotationID
+	}
+	return q.items[i].next.Before(q.items[j].next)
+}
+
+func (q *notificationQueue) Swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.items[i].index = i
+	q.items[j].index = j
+}
+
+func (q *notificationQueue) Push(x any) {
+	item := x.(*queuedRotation)
+	item.index = len(q.items)
+	q.items = append(q.items, item)
+}
+
+func (q *notificationQueue) Pop() any {
+	last := len(q.items) - 1
+	item := q.items[last]
+	q.items[last] = nil
+	q.items = q.items[:last]
+	item.index = -1
+	return item
+}
+
+// set adds the rotation to the queue, or moves it when it is already queued
+func (q *notificationQueue) set(item *queuedRotation) {
+	if existing, ok := q.byRotation[item.config.RotationID]; ok {
+		item.index = existing.index
+		q.items[item.index] = item
+		heap.Fix(q, item.index)
+	} else {
+		heap.Push(q, item)
+	}
+
+	q.byRotation[item.config.RotationID] = item
+	channel, ok := q.byChannel[item.config.ChannelID]
+	if !ok {
+		channel = make(map[int64]*queuedRotation)
+		q.byChannel[item.config.ChannelID] = channel
+	}
+	channel[item.config.RotationID] = item
+}
+
+// get returns the queued rotation, or nil when it isn't queued
+func (q *notificationQueue) get(rotationID int64) *queuedRotation {
+	return q.byRotation[rotationID]
+}
+
+// remove takes the rotation out of the queue
+func (q *notificationQueue) remove(rotationID int64) {
+	item, ok := q.byRotation[rotationID]
+	if !ok {
+		return
+	}
+
+	heap.Remove(q, item.index)
+	delete(q.byRotation, rotationID)
+
+	channel := q.byChannel[item.config.ChannelID]
+	delete(channel, rotationID)
+	if len(channel) == 0 {
+		delete(q.byChannel, item.config.ChannelID)
+	}
+}
+
+// channelRotations returns the IDs of the queued rotations of the channel
+func (q *notificationQueue) channelRotations(channelID int64) []int64 {
+	rotationIDs := make([]int64, 0, len(q.byChannel[channelID]))
+	for rotationID := range q.byChannel[channelID] {
+		rotationIDs = append(rotationIDs, rotationID)
+	}
+	return rotationIDs
+}
+
+// peek returns the rotation notified first, or nil when the queue is empty
+func (q *notificationQueue) peek() *queuedRotation {
+	if len(q.items) == 0 {
+		return nil
+	}
+	return q.items[0]
+}
+
+// popDue takes out the rotations due at the earliest time, as long as it is not after now
+func (q *notificationQueue) popDue(now time.Time) []*queuedRotation {
+	first := q.peek()
+	if first == nil || first.next.After(now) {
+		return nil
+	}
+
+	occurrence := first.next
+	var due []*queuedRotation
+	for next := q.peek(); next != nil && next.next.Equal(occurrence); next = q.peek() {
+		q.remove(next.config.RotationID)
+		due = append(due, next)
+	}
+
+	return due
+}
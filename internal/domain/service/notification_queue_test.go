@@ -0,0 +1,89 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_notificationQueue(t *testing.T) {
+	base := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	queued := func(channelID, rotationID int64, next time.Time) *queuedRotation {
+		return &queuedRotation{
+			config: &entity.Scheduler{ChannelID: channelID, RotationID: rotationID},
+			next:   next,
+		}
+	}
+
+	t.Run("should return the earliest rotation first", func(t *testing.T) {
+		q := newNotificationQueue()
+		q.set(queued(1, 1, base.Add(2*time.Hour)))
+		q.set(queued(2, 2, base))
+		q.set(queued(3, 3, base.Add(time.Hour)))
+
+		require.NotNil(t, q.peek())
+		assert.Equal(t, int64(2), q.peek().config.RotationID)
+		assert.Equal(t, 3, q.Len())
+	})
+
+	t.Run("should move a rotation set again", func(t *testing.T) {
+		q := newNotificationQueue()
+		q.set(queued(1, 1, base))
+		q.set(queued(2, 2, base.Add(time.Hour)))
+
+		q.set(queued(1, 1, base.Add(2*time.Hour)))
+
+		assert.Equal(t, 2, q.Len())
+		assert.Equal(t, int64(2), q.peek().config.RotationID)
+		assert.Equal(t, base.Add(2*time.Hour), q.get(1).next)
+	})
+
+	t.Run("should remove a rotation", func(t *testing.T) {
+		q := newNotificationQueue()
+		q.set(queued(1, 1, base))
+		q.set(queued(1, 3, base.Add(time.Hour)))
+
+		q.remove(1)
+		q.remove(42) // Unknown rotations are ignored
+
+		assert.Equal(t, 1, q.Len())
+		assert.Nil(t, q.get(1))
+		assert.Equal(t, []int64{3}, q.channelRotations(1))
+	})
+
+	t.Run("should list the rotations of a channel", func(t *testing.T) {
+		q := newNotificationQueue()
+		q.set(queued(1, 1, base))
+		q.set(queued(1, 3, base))
+		q.set(queued(2, 2, base))
+
+		assert.ElementsMatch(t, []int64{1, 3}, q.channelRotations(1))
+		assert.Empty(t, q.channelRotations(4))
+	})
+
+	t.Run("should pop the rotations due at the earliest time", func(t *testing.T) {
+		q := newNotificationQueue()
+		q.set(queued(1, 3, base))
+		q.set(queued(1, 1, base))
+		q.set(queued(2, 2, base.Add(time.Minute)))
+
+		assert.Empty(t, q.popDue(base.Add(-time.Second)), "nothing is due yet")
+
+		due := q.popDue(base.Add(time.Hour))
+		require.Len(t, due, 2)
+		assert.Equal(t, int64(1), due[0].config.RotationID)
+		assert.Equal(t, int64(3), due[1].config.RotationID)
+		assert.Equal(t, 1, q.Len())
+
+		due = q.popDue(base.Add(time.Hour))
+		require.Len(t, due, 1)
+		assert.Equal(t, int64(2), due[0].config.RotationID)
+
+		assert.Nil(t, q.peek())
+		assert.Empty(t, q.popDue(base.Add(time.Hour)))
+	})
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+)
+
+// MoveUser places a member at a 1-based position of the rotation order and returns the new order
+func (s *rotationService) MoveUser(ctx context.Context, rotationID int64, slackUserID string, position int) ([]*entity.User, error) {
+	users, err := s.dm.User().GetActiveUsersByRotation(rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	if position < 1 || position > len(users) {
+		return nil, fmt.Errorf("invalid position %d. Use a number between 1 and %d", position, len(users))
+	}
+
+	index := -1
+	for i, user := range users {
+		if user.SlackUserID == slackUserID {
+			index = i
+			break
+		}
+	}
+
+	if index == -1 {
+		return nil, fmt.Errorf("user not found in rotation")
+	}
+
+	moved := users[index]
+	ordered := append(append([]*entity.User{}, users[:index]...), users[index+1:]...)
+	ordered = append(ordered[:position-1], append([]*entity.User{moved}, ordered[position-1:]...)...)
+
+	if err := s.saveOrder(ctx, ordered); err != nil {
+		return nil, err
+	}
+
+	return ordered, nil
+}
+
+// SetOrder puts the given members first, in the given order. Members not listed keep
+// their relative order after them.
+func (s *rotationService) SetOrder(ctx context.Context, rotationID int64, slackUserIDs []string) ([]*entity.User, error) {
+	users, err := s.dm.User().GetActiveUsersByRotation(rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	bySlackID := make(map[string]*entity.User)
+	for _, user := range users {
+		bySlackID[user.SlackUserID] = user
+	}
+
+	listed := make(map[int64]bool)
+	var ordered []*entity.User
+	for _, slackUserID := range slackUserIDs {
+		user, ok := bySlackID[slackUserID]
+		if !ok {
+			return nil, fmt.Errorf("<@%s> is not in the rotation", slackUserID)
+		}
+		if listed[user.ID] {
+			return nil, fmt.Errorf("<@%s> is listed more than once", slackUserID)
+		}
+		listed[user.ID] = true
+		ordered = append(ordered, user)
+	}
+
+	for _, user := range users {
+		if !listed[user.ID] {
+			ordered = append(ordered, user)
+		}
+	}
+
+	if err := s.saveOrder(ctx, ordered); err != nil {
+		return nil, err
+	}
+
+	return ordered, nil
+}
+
+// ShuffleUsers randomizes the rotation order and returns the new order
+func (s *rotationService) ShuffleUsers(ctx context.Context, rotationID int64) ([]*entity.User, error) {
+	users, err := s.dm.User().GetActiveUsersByRotation(rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	if len(users) == 0 {
+		return nil, fmt.Errorf("no active users in rotation")
+	}
+
+	rand.Shuffle(len(users), func(i, j int) {
+		users[i], users[j] = users[j], users[i]
+	})
+
+	if err := s.saveOrder(ctx, users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// saveOrder persists the positions of users in a single transaction
+func (s *rotationService) saveOrder(ctx context.Context, users []*entity.User) error {
+	userIDs := make([]int64, len(users))
+	for i, user := range users {
+		userIDs[i] = user.ID
+		user.Position = i + 1
+	}
+
+	err := s.dm.WithTransaction(ctx, func(tx contract.DataManager) error {
+		return tx.User().UpdatePositions(userIDs)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save rotation order: %w", err)
+	}
+
+	return nil
+}
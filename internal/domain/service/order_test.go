@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func orderTestUsers() []*entity.User {
+	return []*entity.User{
+		{ID: 1, SlackUserID: "U1", Position: 1},
+		{ID: 2, SlackUserID: "U2", Position: 2},
+		{ID: 3, SlackUserID: "U3", Position: 3},
+		{ID: 4, SlackUserID: "U4", Position: 4},
+	}
+}
+
+func expectSaveOrder(mocks allMocks, userIDs []int64) {
+	mocks.mockDataManager.EXPECT().
+		WithTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+			return fn(mocks.mockDataManager)
+		}).Times(1)
+
+	mocks.mockUserRepo.EXPECT().
+		UpdatePositions(userIDs).
+		Return(nil).Times(1)
+}
+
+func Test_rotationService_MoveUser(t *testing.T) {
+	type args struct {
+		rotationID  int64
+		slackUserID string
+		position    int
+	}
+	tests := []struct {
+		name      string
+		buildMock func(mocks allMocks, args args)
+		args      args
+		wantIDs   []int64
+		wantErr   bool
+	}{
+		{
+			name: "Should move user forward",
+			args: args{rotationID: 1, slackUserID: "U4", position: 2},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(orderTestUsers(), nil).Times(1)
+
+				expectSaveOrder(mocks, []int64{1, 4, 2, 3})
+			},
+			wantIDs: []int64{1, 4, 2, 3},
+		},
+		{
+			name: "Should move user to the end",
+			args: args{rotationID: 1, slackUserID: "U1", position: 4},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(orderTestUsers(), nil).Times(1)
+
+				expectSaveOrder(mocks, []int64{2, 3, 4, 1})
+			},
+			wantIDs: []int64{2, 3, 4, 1},
+		},
+		{
+			name: "Should return error for position out of range",
+			args: args{rotationID: 1, slackUserID: "U1", position: 5},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(orderTestUsers(), nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when user is not in rotation",
+			args: args{rotationID: 1, slackUserID: "U9", position: 1},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(orderTestUsers(), nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when order cannot be saved",
+			args: args{rotationID: 1, slackUserID: "U2", position: 1},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(orderTestUsers(), nil).Times(1)
+
+				mocks.mockDataManager.EXPECT().
+					WithTransaction(gomock.Any(), gomock.Any()).
+					Return(assert.AnError).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+			if tt.buildMock != nil {
+				tt.buildMock(m, tt.args)
+			}
+
+			got, err := s.MoveUser(context.Background(), tt.args.rotationID, tt.args.slackUserID, tt.args.position)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.wantIDs))
+			for i, user := range got {
+				assert.Equal(t, tt.wantIDs[i], user.ID)
+				assert.Equal(t, i+1, user.Position)
+			}
+		})
+	}
+}
+
+func Test_rotationService_SetOrder(t *testing.T) {
+	type args struct {
+		rotationID   int64
+		slackUserIDs []string
+	}
+	tests := []struct {
+		name      string
+		buildMock func(mocks allMocks, args args)
+		args      args
+		wantIDs   []int64
+		wantErr   bool
+	}{
+		{
+			name: "Should set full order",
+			args: args{rotationID: 1, slackUserIDs: []string{"U3", "U1", "U4", "U2"}},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(orderTestUsers(), nil).Times(1)
+
+				expectSaveOrder(mocks, []int64{3, 1, 4, 2})
+			},
+			wantIDs: []int64{3, 1, 4, 2},
+		},
+		{
+			name: "Should keep members not listed after the listed ones",
+			args: args{rotationID: 1, slackUserIDs: []string{"U4", "U2"}},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(orderTestUsers(), nil).Times(1)
+
+				expectSaveOrder(mocks, []int64{4, 2, 1, 3})
+			},
+			wantIDs: []int64{4, 2, 1, 3},
+		},
+		{
+			name: "Should return error for unknown member",
+			args: args{rotationID: 1, slackUserIDs: []string{"U1", "U9"}},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(orderTestUsers(), nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error for duplicated member",
+			args: args{rotationID: 1, slackUserIDs: []string{"U1", "U1"}},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(orderTestUsers(), nil).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+			if tt.buildMock != nil {
+				tt.buildMock(m, tt.args)
+			}
+
+			got, err := s.SetOrder(context.Background(), tt.args.rotationID, tt.args.slackUserIDs)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.wantIDs))
+			for i, user := range got {
+				assert.Equal(t, tt.wantIDs[i], user.ID)
+			}
+		})
+	}
+}
+
+func Test_rotationService_ShuffleUsers(t *testing.T) {
+	t.Run("Should save a permutation of the members", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+		m.mockUserRepo.EXPECT().
+			GetActiveUsersByRotation(int64(1)).
+			Return(orderTestUsers(), nil).Times(1)
+
+		m.mockDataManager.EXPECT().
+			WithTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+				return fn(m.mockDataManager)
+			}).Times(1)
+
+		var saved []int64
+		m.mockUserRepo.EXPECT().
+			UpdatePositions(gomock.Any()).
+			DoAndReturn(func(userIDs []int64) error {
+				saved = userIDs
+				return nil
+			}).Times(1)
+
+		got, err := s.ShuffleUsers(context.Background(), 1)
+
+		require.NoError(t, err)
+		require.Len(t, got, 4)
+		assert.ElementsMatch(t, []int64{1, 2, 3, 4}, saved)
+		for i, user := range got {
+			assert.Equal(t, saved[i], user.ID)
+		}
+	})
+
+	t.Run("Should return error when rotation is empty", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+		m.mockUserRepo.EXPECT().
+			GetActiveUsersByRotation(int64(1)).
+			Return([]*entity.User{}, nil).Times(1)
+
+		_, err := s.ShuffleUsers(context.Background(), 1)
+
+		require.Error(t, err)
+	})
+}
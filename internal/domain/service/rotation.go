@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,16 +15,18 @@ import (
 )
 
 type rotationService struct {
-	dm          contract.DataManager
-	slackClient contract.SlackClient
-	scheduler   *scheduler
+	dm           contract.DataManager
+	slackClients contract.SlackClientFactory
+	clock        contract.Clock
+	scheduler    *scheduler
 }
 
-func newRotation(dm contract.DataManager, slackClient contract.SlackClient) *rotationService {
+func newRotation(dm contract.DataManager, slackClients contract.SlackClientFactory, clock contract.Clock) *rotationService {
 	return &rotationService{
-		dm:          dm,
-		slackClient: slackClient,
-		scheduler:   nil, // Will be set later to avoid circular dependency
+		dm:           dm,
+		slackClients: slackClients,
+		clock:        clock,
+		scheduler:    nil, // Will be set later to avoid circular dependency
 	}
 }
 
@@ -54,32 +57,59 @@ func (s *rotationService) SetupChannel(slackChannelID, slackChannelName, slackTe
 		return nil, false, fmt.Errorf("failed to create channel: %w", err)
 	}
 
-	// Create default scheduler config
-	scheduler := &entity.Scheduler{
-		ChannelID:        channel.ID,
-		NotificationTime: "09:00",
-		ActiveDays:       domain.DefaultActiveDays, // Monday-Friday in ISO format
-		IsEnabled:        true,
-		Role:             domain.DefaultRole, // Default role
+	// Create the primary rotation, used by the commands that do not name a rotation
+	rotation := &entity.Rotation{
+		ChannelID: channel.ID,
+		Name:      domain.DefaultRotationName,
+		IsPrimary: true,
 	}
 
-	if err := s.dm.Scheduler().Create(scheduler); err != nil {
+	if err := s.dm.Rotation().Create(rotation); err != nil {
+		return nil, false, fmt.Errorf("failed to create rotation: %w", err)
+	}
+
+	// Create default scheduler config
+	if err := s.dm.Scheduler().Create(newDefaultScheduler(rotation)); err != nil {
 		return nil, false, fmt.Errorf("failed to create scheduler config: %w", err)
 	}
 
 	// Notify scheduler of new channel
 	if s.scheduler != nil {
-		s.scheduler.NotifyConfigChange()
+		s.scheduler.NotifyConfigChange(channel.ID)
 	}
 
 	return channel, true, nil // Channel was auto-created
 }
 
-func (s *rotationService) AddUser(channelID int64, slackUserID string) error {
-	log.Printf("DEBUG AddUser: channelID=%d, slackUserID=%s", channelID, slackUserID)
+func (s *rotationService) AddUser(ctx context.Context, rotationID int64, slackUserID string) error {
+	log.Printf("DEBUG AddUser: rotationID=%d, slackUserID=%s", rotationID, slackUserID)
+
+	rotation, err := s.dm.Rotation().GetByID(rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to get rotation: %w", err)
+	}
+
+	if rotation == nil {
+		return fmt.Errorf("rotation not found")
+	}
+
+	// The user is looked up in the workspace of the channel
+	channel, err := s.dm.Channel().GetByID(rotation.ChannelID)
+	if err != nil {
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	if channel == nil {
+		return fmt.Errorf("channel not found")
+	}
+
+	slackClient, err := s.slackClients.ForTeam(channel.SlackTeamID)
+	if err != nil {
+		return fmt.Errorf("failed to get Slack client: %w", err)
+	}
 
 	// Get user info from Slack
-	userInfo, err := s.slackClient.GetUserInfo(slackUserID)
+	userInfo, err := slackClient.GetUserInfoContext(ctx, slackUserID)
 	if err != nil {
 		log.Printf("ERROR getting user info from Slack API for %s: %v", slackUserID, err)
 		return fmt.Errorf("failed to get user info from Slack: %w", err)
@@ -89,7 +119,7 @@ func (s *rotationService) AddUser(channelID int64, slackUserID string) error {
 		userInfo.Name, userInfo.Profile.DisplayName, userInfo.Profile.RealName)
 
 	// Check if user already exists
-	existingUser, err := s.dm.User().GetByChannelAndSlackID(channelID, slackUserID)
+	existingUser, err := s.dm.User().GetByRotationAndSlackID(rotationID, slackUserID)
 	if err != nil {
 		return fmt.Errorf("failed to check existing user: %w", err)
 	}
@@ -108,7 +138,8 @@ func (s *rotationService) AddUser(channelID int64, slackUserID string) error {
 	}
 
 	user := &entity.User{
-		ChannelID:     channelID,
+		ChannelID:     rotation.ChannelID,
+		RotationID:    rotation.ID,
 		SlackUserID:   slackUserID,
 		SlackUserName: userInfo.Name,
 		DisplayName:   displayName,
@@ -118,8 +149,8 @@ func (s *rotationService) AddUser(channelID int64, slackUserID string) error {
 	return s.dm.User().Create(user)
 }
 
-func (s *rotationService) RemoveUser(channelID int64, slackUserID string) error {
-	user, err := s.dm.User().GetByChannelAndSlackID(channelID, slackUserID)
+func (s *rotationService) RemoveUser(rotationID int64, slackUserID string) error {
+	user, err := s.dm.User().GetByRotationAndSlackID(rotationID, slackUserID)
 	if err != nil {
 		return fmt.Errorf("failed to find user: %w", err)
 	}
@@ -131,90 +162,180 @@ func (s *rotationService) RemoveUser(channelID int64, slackUserID string) error
 	return s.dm.User().Delete(user.ID)
 }
 
-func (s *rotationService) ListUsers(channelID int64) ([]*entity.User, error) {
-	return s.dm.User().GetActiveUsersByChannel(channelID)
+func (s *rotationService) ListUsers(rotationID int64) ([]*entity.User, error) {
+	return s.dm.User().GetActiveUsersByRotation(rotationID)
 }
 
-func (s *rotationService) GetNextPresenter(channelID int64) (*entity.User, error) {
-	// Get all active users ordered by joined_at (rotation order)
-	users, err := s.dm.User().GetActiveUsersByChannel(channelID)
+// GetNextAssignees returns who takes the next turn according to the rotation strategy,
+// the presenter first followed by the backups, skipping members that are away today
+// in the rotation timezone
+func (s *rotationService) GetNextAssignees(rotationID int64) ([]*entity.User, error) {
+	scheduler, err := s.dm.Scheduler().GetByRotationID(rotationID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get users: %w", err)
+		return nil, fmt.Errorf("failed to get scheduler config: %w", err)
+	}
+
+	loc := time.UTC
+	strategy := domain.DefaultStrategy
+	assignees := 1
+	if scheduler != nil {
+		loc = scheduler.GetLocation()
+		if scheduler.Strategy != "" {
+			strategy = scheduler.Strategy
+		}
+		assignees = scheduler.GetAssignees()
+	}
+
+	users, err := selectAssignees(s.dm, rotationID, strategy, assignees, s.clock.Now().In(loc))
+	if err != nil {
+		return nil, err
 	}
 
 	if len(users) == 0 {
 		return nil, fmt.Errorf("no active users in rotation")
 	}
 
-	// Get last presenter
-	lastPresenter, err := s.dm.User().GetLastPresenter(channelID)
+	return users, nil
+}
+
+// RecordPresentation gives the current turn to users, users[0] as the presenter and the
+// others as backups, and stores the turn in the history. kind is one of the
+// domain.HistoryKind* values and triggeredBy the Slack ID of who asked for it.
+func (s *rotationService) RecordPresentation(ctx context.Context, rotationID int64, users []*entity.User, kind, triggeredBy string) error {
+	schedulerConfig, err := s.dm.Scheduler().GetByRotationID(rotationID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get last presenter: %w", err)
+		return fmt.Errorf("failed to get scheduler config: %w", err)
 	}
 
-	// If no one has presented yet, return first user
-	if lastPresenter == nil {
-		return users[0], nil
+	strategy := domain.DefaultStrategy
+	if schedulerConfig != nil && schedulerConfig.Strategy != "" {
+		strategy = schedulerConfig.Strategy
 	}
 
-	// Find the index of last presenter in the current rotation order
-	lastPresenterIndex := -1
-	for i, user := range users {
-		if user.ID == lastPresenter.ID {
-			lastPresenterIndex = i
-			break
-		}
+	err = s.dm.WithTransaction(ctx, func(tx contract.DataManager) error {
+		return recordTurn(tx, rotationID, users, strategy, kind, triggeredBy, s.clock.Now())
+	})
+	if err != nil {
+		return err
+	}
+
+	s.syncTurn(ctx, rotationID, schedulerConfig, users)
+	return nil
+}
+
+// syncTurn shows the new assignees of the rotation in the channel topic and makes them the members
+// of the linked user group, when the rotation asked for it. Failures are only logged, the turn is
+// already recorded.
+func (s *rotationService) syncTurn(ctx context.Context, rotationID int64, schedulerConfig *entity.Scheduler, users []*entity.User) {
+	if schedulerConfig == nil || (!schedulerConfig.TopicSync && schedulerConfig.UserGroupID == "") {
+		return
 	}
 
-	// If last presenter not found (maybe removed), start from beginning
-	if lastPresenterIndex == -1 {
-		return users[0], nil
+	rotation, err := s.dm.Rotation().GetByID(rotationID)
+	if err != nil || rotation == nil {
+		log.Printf("Failed to get rotation %d: %v", rotationID, err)
+		return
 	}
 
-	// Return next user in rotation
-	nextIndex := (lastPresenterIndex + 1) % len(users)
-	return users[nextIndex], nil
+	channel, err := s.dm.Channel().GetByID(rotation.ChannelID)
+	if err != nil || channel == nil {
+		log.Printf("Failed to get channel %d: %v", rotation.ChannelID, err)
+		return
+	}
+
+	slackClient, err := s.slackClients.ForTeam(channel.SlackTeamID)
+	if err != nil {
+		log.Printf("Failed to get Slack client of team %s: %v", channel.SlackTeamID, err)
+		return
+	}
+
+	syncTopic(ctx, s.dm, slackClient, rotation, channel, schedulerConfig, users)
+	syncUserGroup(ctx, slackClient, schedulerConfig, users)
 }
 
-func (s *rotationService) RecordPresentation(ctx context.Context, channelID, userID int64) error {
-	return s.dm.WithTransaction(ctx, func(tx contract.DataManager) error {
-		// Clear previous presenter
-		if err := tx.User().ClearLastPresenter(channelID); err != nil {
-			return fmt.Errorf("failed to clear last presenter: %w", err)
+// GetHistory returns the last limit turns of the rotation, most recent first
+func (s *rotationService) GetHistory(rotationID int64, limit int) ([]*entity.RotationHistory, error) {
+	entries, err := s.dm.History().GetByRotationID(rotationID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history: %w", err)
+	}
+
+	return entries, nil
+}
+
+// recordTurn saves the rotation order chosen by the strategy, moves the last_presenter flag to
+// users[0] and the last_backup flag to the other users, then appends the turn to the history
+// dated at presentedAt. It must run inside a transaction so all changes are kept together.
+func recordTurn(tx contract.DataManager, rotationID int64, users []*entity.User, strategy, kind, triggeredBy string, presentedAt time.Time) error {
+	if len(users) == 0 {
+		return fmt.Errorf("no users to record")
+	}
+
+	// Before the turn moves, which decides whether a new cycle starts
+	if err := saveOrder(tx, rotationID, strategy); err != nil {
+		return err
+	}
+
+	// Clear previous presenter and backups
+	if err := tx.User().ClearLastPresenter(rotationID); err != nil {
+		return fmt.Errorf("failed to clear last presenter: %w", err)
+	}
+
+	if err := tx.User().ClearLastBackups(rotationID); err != nil {
+		return fmt.Errorf("failed to clear last backups: %w", err)
+	}
+
+	// Set new presenter and backups
+	if err := tx.User().SetLastPresenter(users[0].ID); err != nil {
+		return fmt.Errorf("failed to set last presenter: %w", err)
+	}
+
+	for _, backup := range users[1:] {
+		if err := tx.User().SetLastBackup(backup.ID); err != nil {
+			return fmt.Errorf("failed to set last backup: %w", err)
 		}
+	}
 
-		// Set new presenter
-		if err := tx.User().SetLastPresenter(userID); err != nil {
-			return fmt.Errorf("failed to set last presenter: %w", err)
+	presentedAt = presentedAt.UTC()
+	for i, user := range users {
+		entry := &entity.RotationHistory{
+			ChannelID:   user.ChannelID,
+			RotationID:  rotationID,
+			UserID:      user.ID,
+			SlackUserID: user.SlackUserID,
+			DisplayName: user.GetDisplayName(),
+			PresentedAt: presentedAt,
+			Kind:        kind,
+			TriggeredBy: triggeredBy,
+			IsBackup:    i > 0,
 		}
 
-		return nil
-	})
+		if err := tx.History().Create(entry); err != nil {
+			return fmt.Errorf("failed to record history: %w", err)
+		}
+	}
+
+	return nil
 }
 
-func (s *rotationService) GetCurrentPresenter(channelID int64) (*entity.User, error) {
-	return s.dm.User().GetLastPresenter(channelID)
+func (s *rotationService) GetCurrentPresenter(rotationID int64) (*entity.User, error) {
+	return s.dm.User().GetLastPresenter(rotationID)
 }
 
-func (s *rotationService) UpdateChannelConfig(channelID int64, configType, value string) error {
-	// Get or create scheduler config
-	scheduler, err := s.dm.Scheduler().GetByChannelID(channelID)
+// GetCurrentBackups returns the members backing up the current presenter
+func (s *rotationService) GetCurrentBackups(rotationID int64) ([]*entity.User, error) {
+	backups, err := s.dm.User().GetLastBackups(rotationID)
 	if err != nil {
-		return fmt.Errorf("failed to get scheduler config: %w", err)
+		return nil, fmt.Errorf("failed to get backups: %w", err)
 	}
 
-	if scheduler == nil {
-		// Create default scheduler config if it doesn't exist
-		scheduler = &entity.Scheduler{
-			ChannelID:        channelID,
-			NotificationTime: "09:00",
-			ActiveDays:       domain.DefaultActiveDays,
-			IsEnabled:        true,
-			Role:             domain.DefaultRole, // Default role
-		}
-		if err := s.dm.Scheduler().Create(scheduler); err != nil {
-			return fmt.Errorf("failed to create scheduler config: %w", err)
-		}
+	return backups, nil
+}
+
+func (s *rotationService) UpdateChannelConfig(rotationID int64, configType, value string) error {
+	scheduler, err := s.getOrCreateScheduler(rotationID)
+	if err != nil {
+		return err
 	}
 
 	switch configType {
@@ -239,22 +360,154 @@ func (s *rotationService) UpdateChannelConfig(channelID int64, configType, value
 		}
 
 		scheduler.Role = cleanValue
+	case "timezone", "tz":
+		// Validate IANA timezone name
+		timezone, err := parseTimezone(value)
+		if err != nil {
+			return err
+		}
+		scheduler.Timezone = timezone
+	case "strategy":
+		// Validate rotation strategy name
+		strategy, err := parseStrategy(value)
+		if err != nil {
+			return err
+		}
+		scheduler.Strategy = strategy
+	case "assignees":
+		// Validate number of members per turn
+		assignees, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || assignees < 1 || assignees > domain.MaxAssignees {
+			return fmt.Errorf("invalid assignees. Use a number from 1 to %d. Example: 2 for a presenter and a backup", domain.MaxAssignees)
+		}
+		scheduler.Assignees = assignees
+	case "backup":
+		// Set custom backup role name
+		cleanValue := cleanRoleName(value)
+		if cleanValue == "" {
+			return fmt.Errorf("backup role cannot be empty. Example: backup, pair, shadow")
+		}
+
+		scheduler.BackupRole = cleanValue
+	case "dm":
+		// Validate DM reminders of the members that did not choose
+		dmReminders, err := parseDMReminders(value)
+		if err != nil {
+			return err
+		}
+		scheduler.DMReminders = dmReminders
+	case "topic":
+		// Keep the channel topic saying who is on duty
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "on":
+			scheduler.TopicSync = true
+		case "off":
+			scheduler.TopicSync = false
+		default:
+			return fmt.Errorf("invalid topic setting. Use on or off")
+		}
+	case "usergroup":
+		// Link the Slack user group kept with the assignees as members
+		userGroupID, err := parseUserGroup(value)
+		if err != nil {
+			return err
+		}
+		if userGroupID != "" {
+			if err := s.linkUserGroup(context.Background(), rotationID, userGroupID); err != nil {
+				return err
+			}
+		}
+		scheduler.UserGroupID = userGroupID
 	default:
-		return fmt.Errorf("invalid configuration type. Use 'time', 'days', or 'role'")
+		return fmt.Errorf("invalid configuration type. Use 'time', 'days', 'role', 'timezone', 'strategy', 'assignees', 'backup', 'dm', 'topic', or 'usergroup'")
+	}
+
+	if err := s.dm.Scheduler().Update(scheduler); err != nil {
+		return err
+	}
+
+	// Notify scheduler of configuration change
+	if s.scheduler != nil {
+		s.scheduler.NotifyConfigChange(scheduler.ChannelID)
+	}
+
+	return nil
+}
+
+// UpdateSchedulerSettings applies the values of the configuration editor in a single update.
+// Nothing is changed when a value is invalid, the returned domain.FieldErrors tells which.
+func (s *rotationService) UpdateSchedulerSettings(rotationID int64, settings entity.SchedulerSettings) error {
+	fieldErrors := domain.FieldErrors{}
+
+	notificationTime := strings.TrimSpace(settings.NotificationTime)
+	if _, err := time.Parse("15:04", notificationTime); err != nil {
+		fieldErrors[domain.ConfigFieldTime] = "invalid time format. Use HH:MM (24-hour format). Example: 09:30"
+	}
+
+	days, err := parseDayValues(settings.ActiveDays)
+	if err != nil {
+		fieldErrors[domain.ConfigFieldDays] = err.Error()
+	}
+
+	role := cleanRoleName(settings.Role)
+	if role == "" {
+		fieldErrors[domain.ConfigFieldRole] = "role cannot be empty. Example: presenter, reviewer, facilitator"
 	}
 
+	if len(fieldErrors) > 0 {
+		return fieldErrors
+	}
+
+	scheduler, err := s.getOrCreateScheduler(rotationID)
+	if err != nil {
+		return err
+	}
+
+	scheduler.NotificationTime = notificationTime
+	scheduler.ActiveDays = days
+	scheduler.Role = role
+	scheduler.IsEnabled = settings.IsEnabled
+
 	if err := s.dm.Scheduler().Update(scheduler); err != nil {
 		return err
 	}
 
 	// Notify scheduler of configuration change
 	if s.scheduler != nil {
-		s.scheduler.NotifyConfigChange()
+		s.scheduler.NotifyConfigChange(scheduler.ChannelID)
 	}
 
 	return nil
 }
 
+// getOrCreateScheduler returns the scheduler config of the rotation, creating the default
+// one when the rotation has none yet
+func (s *rotationService) getOrCreateScheduler(rotationID int64) (*entity.Scheduler, error) {
+	scheduler, err := s.dm.Scheduler().GetByRotationID(rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduler config: %w", err)
+	}
+
+	if scheduler != nil {
+		return scheduler, nil
+	}
+
+	rotation, err := s.dm.Rotation().GetByID(rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rotation: %w", err)
+	}
+	if rotation == nil {
+		return nil, fmt.Errorf("rotation not found")
+	}
+
+	scheduler = newDefaultScheduler(rotation)
+	if err := s.dm.Scheduler().Create(scheduler); err != nil {
+		return nil, fmt.Errorf("failed to create scheduler config: %w", err)
+	}
+
+	return scheduler, nil
+}
+
 func (s *rotationService) GetChannelConfig(channelID int64) (*entity.Channel, error) {
 	channel, err := s.dm.Channel().GetByID(channelID)
 	if err != nil {
@@ -270,38 +523,65 @@ func (s *rotationService) GetChannelConfig(channelID int64) (*entity.Channel, er
 	return channel, nil
 }
 
-func (s *rotationService) GetSchedulerConfig(channelID int64) (*entity.Scheduler, error) {
-	return s.dm.Scheduler().GetByChannelID(channelID)
+func (s *rotationService) GetSchedulerConfig(rotationID int64) (*entity.Scheduler, error) {
+	return s.dm.Scheduler().GetByRotationID(rotationID)
 }
 
-func (s *rotationService) PauseScheduler(channelID int64) error {
-	err := s.dm.Scheduler().SetEnabled(channelID, false)
+func (s *rotationService) PauseScheduler(rotationID int64) error {
+	err := s.dm.Scheduler().SetEnabled(rotationID, false)
 	if err != nil {
 		return fmt.Errorf("failed to pause scheduler: %w", err)
 	}
 
-	// Notify scheduler of configuration change
-	if s.scheduler != nil {
-		s.scheduler.NotifyConfigChange()
-	}
+	s.notifyRotationChange(rotationID)
 
 	return nil
 }
 
-func (s *rotationService) ResumeScheduler(channelID int64) error {
-	err := s.dm.Scheduler().SetEnabled(channelID, true)
+func (s *rotationService) ResumeScheduler(rotationID int64) error {
+	err := s.dm.Scheduler().SetEnabled(rotationID, true)
 	if err != nil {
 		return fmt.Errorf("failed to resume scheduler: %w", err)
 	}
 
-	// Notify scheduler of configuration change
-	if s.scheduler != nil {
-		s.scheduler.NotifyConfigChange()
-	}
+	s.notifyRotationChange(rotationID)
 
 	return nil
 }
 
+// notifyRotationChange tells the scheduler the config of the rotation changed. When the channel
+// of the rotation cannot be found the scheduler picks the change up on its next resync.
+func (s *rotationService) notifyRotationChange(rotationID int64) {
+	if s.scheduler == nil {
+		return
+	}
+
+	rotation, err := s.dm.Rotation().GetByID(rotationID)
+	if err != nil || rotation == nil {
+		log.Printf("Failed to get rotation %d to notify the scheduler: %v", rotationID, err)
+		return
+	}
+
+	s.scheduler.NotifyConfigChange(rotation.ChannelID)
+}
+
+// newDefaultScheduler returns the scheduler config a new rotation starts with
+func newDefaultScheduler(rotation *entity.Rotation) *entity.Scheduler {
+	return &entity.Scheduler{
+		ChannelID:        rotation.ChannelID,
+		RotationID:       rotation.ID,
+		NotificationTime: "09:00",
+		ActiveDays:       domain.DefaultActiveDays, // Monday-Friday in ISO format
+		IsEnabled:        true,
+		Role:             domain.DefaultRole, // Default role
+		Timezone:         domain.DefaultTimezone,
+		Strategy:         domain.DefaultStrategy,
+		Assignees:        1,
+		BackupRole:       domain.DefaultBackupRole,
+		DMReminders:      domain.DMRemindersOff,
+	}
+}
+
 func parseDays(input string) []int {
 	parts := strings.Split(strings.TrimSpace(input), ",")
 	var days []int
@@ -318,6 +598,46 @@ func parseDays(input string) []int {
 	return days
 }
 
+// parseDayValues converts weekday numbers ("1" for Monday to "7" for Sunday) in week order.
+// Unlike parseDays, an unknown value is an error instead of being dropped.
+func parseDayValues(values []string) ([]int, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("select at least one day")
+	}
+
+	days := make([]int, 0, len(values))
+	for _, value := range values {
+		dayNum, ok := domain.WeekdayNumbers[strings.TrimSpace(value)]
+		if !ok {
+			return nil, fmt.Errorf("unknown day %q. Use numbers 1-7 (1=Mon, 2=Tue, 3=Wed, 4=Thu, 5=Fri, 6=Sat, 7=Sun)", value)
+		}
+		days = append(days, dayNum)
+	}
+
+	sort.Ints(days)
+	return days, nil
+}
+
+// parseTimezone validates an IANA timezone name such as "America/Sao_Paulo"
+func parseTimezone(input string) (string, error) {
+	name := strings.TrimSpace(input)
+	if strings.EqualFold(name, "utc") {
+		return domain.DefaultTimezone, nil
+	}
+
+	// "Local" depends on the server environment, so it is not a valid channel setting
+	if name == "" || name == "Local" {
+		return "", fmt.Errorf("invalid timezone. Use an IANA timezone name. Example: America/Sao_Paulo, Europe/Berlin, UTC")
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid timezone. Use an IANA timezone name. Example: America/Sao_Paulo, Europe/Berlin, UTC")
+	}
+
+	return loc.String(), nil
+}
+
 // indexOf function removed - no longer needed with int sorting
 
 func (s *rotationService) GetChannelStatus(channelID int) (*entity.Channel, error) {
@@ -326,6 +646,14 @@ func (s *rotationService) GetChannelStatus(channelID int) (*entity.Channel, erro
 	return nil, fmt.Errorf("not implemented")
 }
 
+// backupRoleOf returns the role name of the backups, "backup" when the rotation has none
+func backupRoleOf(scheduler *entity.Scheduler) string {
+	if scheduler == nil || scheduler.BackupRole == "" {
+		return domain.DefaultBackupRole
+	}
+	return scheduler.BackupRole
+}
+
 // cleanRoleName removes problematic characters from role names
 func cleanRoleName(input string) string {
 	// Trim whitespace
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/diegoclair/slack-rotation-bot/internal/domain"
 	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
@@ -52,12 +53,24 @@ func Test_rotationService_SetupChannel(t *testing.T) {
 						return nil
 					}).Times(1)
 
+				// Create primary rotation
+				mocks.mockRotationRepo.EXPECT().
+					Create(gomock.Any()).
+					DoAndReturn(func(rotation *entity.Rotation) error {
+						rotation.ID = 5
+						require.Equal(t, int64(1), rotation.ChannelID)
+						require.Equal(t, domain.DefaultRotationName, rotation.Name)
+						require.True(t, rotation.IsPrimary)
+						return nil
+					}).Times(1)
+
 				// Create scheduler config
 				mocks.mockSchedulerRepo.EXPECT().
 					Create(gomock.Any()).
 					DoAndReturn(func(scheduler *entity.Scheduler) error {
 						scheduler.ID = 1
 						require.Equal(t, int64(1), scheduler.ChannelID)
+						require.Equal(t, int64(5), scheduler.RotationID)
 						require.Equal(t, "09:00", scheduler.NotificationTime)
 						require.Equal(t, domain.DefaultActiveDays, scheduler.ActiveDays)
 						require.True(t, scheduler.IsEnabled)
@@ -141,6 +154,33 @@ func Test_rotationService_SetupChannel(t *testing.T) {
 			wantCreated: false,
 			wantErr:     true,
 		},
+		{
+			name: "Should return error when rotation creation fails",
+			args: args{
+				slackChannelID:   "C123456789",
+				slackChannelName: "test-channel",
+				slackTeamID:      "T123456789",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockChannelRepo.EXPECT().
+					GetBySlackID(args.slackChannelID).
+					Return(nil, nil).Times(1)
+
+				mocks.mockChannelRepo.EXPECT().
+					Create(gomock.Any()).
+					DoAndReturn(func(c *entity.Channel) error {
+						c.ID = 1
+						return nil
+					}).Times(1)
+
+				mocks.mockRotationRepo.EXPECT().
+					Create(gomock.Any()).
+					Return(assert.AnError).Times(1)
+			},
+			wantChannel: nil,
+			wantCreated: false,
+			wantErr:     true,
+		},
 		{
 			name: "Should return error when scheduler creation fails",
 			args: args{
@@ -160,6 +200,10 @@ func Test_rotationService_SetupChannel(t *testing.T) {
 						return nil
 					}).Times(1)
 
+				mocks.mockRotationRepo.EXPECT().
+					Create(gomock.Any()).
+					Return(nil).Times(1)
+
 				mocks.mockSchedulerRepo.EXPECT().
 					Create(gomock.Any()).
 					Return(assert.AnError).Times(1)
@@ -175,7 +219,7 @@ func Test_rotationService_SetupChannel(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
@@ -197,9 +241,11 @@ func Test_rotationService_SetupChannel(t *testing.T) {
 
 func Test_rotationService_AddUser(t *testing.T) {
 	type args struct {
-		channelID   int64
+		rotationID  int64
 		slackUserID string
 	}
+	rotation := &entity.Rotation{ID: 1, ChannelID: 7}
+	channel := &entity.Channel{ID: 7, SlackChannelID: "C123456789", SlackTeamID: "T123456789"}
 	tests := []struct {
 		name      string
 		buildMock func(mocks allMocks, args args)
@@ -209,7 +255,7 @@ func Test_rotationService_AddUser(t *testing.T) {
 		{
 			name: "Should add new user successfully",
 			args: args{
-				channelID:   1,
+				rotationID:  1,
 				slackUserID: "U123456789",
 			},
 			buildMock: func(mocks allMocks, args args) {
@@ -223,18 +269,27 @@ func Test_rotationService_AddUser(t *testing.T) {
 				}
 
 				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(rotation, nil).Times(1),
+
+					mocks.mockChannelRepo.EXPECT().
+						GetByID(rotation.ChannelID).
+						Return(channel, nil).Times(1),
+
 					mocks.mockSlackClient.EXPECT().
-						GetUserInfo(args.slackUserID).
+						GetUserInfoContext(gomock.Any(), args.slackUserID).
 						Return(slackUser, nil).Times(1),
 
 					mocks.mockUserRepo.EXPECT().
-						GetByChannelAndSlackID(args.channelID, args.slackUserID).
+						GetByRotationAndSlackID(args.rotationID, args.slackUserID).
 						Return(nil, nil).Times(1),
 
 					mocks.mockUserRepo.EXPECT().
 						Create(gomock.Any()).
 						DoAndReturn(func(user *entity.User) error {
-							require.Equal(t, args.channelID, user.ChannelID)
+							require.Equal(t, int64(7), user.ChannelID)
+							require.Equal(t, args.rotationID, user.RotationID)
 							require.Equal(t, args.slackUserID, user.SlackUserID)
 							require.Equal(t, slackUser.Name, user.SlackUserName)
 							require.Equal(t, slackUser.Profile.RealName, user.DisplayName)
@@ -249,7 +304,7 @@ func Test_rotationService_AddUser(t *testing.T) {
 		{
 			name: "Should return error when user already exists",
 			args: args{
-				channelID:   1,
+				rotationID:  1,
 				slackUserID: "U123456789",
 			},
 			buildMock: func(mocks allMocks, args args) {
@@ -263,7 +318,7 @@ func Test_rotationService_AddUser(t *testing.T) {
 
 				existingUser := &entity.User{
 					ID:            1,
-					ChannelID:     args.channelID,
+					RotationID:    args.rotationID,
 					SlackUserID:   args.slackUserID,
 					SlackUserName: "testuser",
 					DisplayName:   "Test User",
@@ -271,12 +326,20 @@ func Test_rotationService_AddUser(t *testing.T) {
 				}
 
 				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(rotation, nil).Times(1),
+
+					mocks.mockChannelRepo.EXPECT().
+						GetByID(rotation.ChannelID).
+						Return(channel, nil).Times(1),
+
 					mocks.mockSlackClient.EXPECT().
-						GetUserInfo(args.slackUserID).
+						GetUserInfoContext(gomock.Any(), args.slackUserID).
 						Return(slackUser, nil).Times(1),
 
 					mocks.mockUserRepo.EXPECT().
-						GetByChannelAndSlackID(args.channelID, args.slackUserID).
+						GetByRotationAndSlackID(args.rotationID, args.slackUserID).
 						Return(existingUser, nil).Times(1),
 				)
 			},
@@ -285,20 +348,30 @@ func Test_rotationService_AddUser(t *testing.T) {
 		{
 			name: "Should return error when Slack API fails",
 			args: args{
-				channelID:   1,
+				rotationID:  1,
 				slackUserID: "U123456789",
 			},
 			buildMock: func(mocks allMocks, args args) {
-				mocks.mockSlackClient.EXPECT().
-					GetUserInfo(args.slackUserID).
-					Return(nil, assert.AnError).Times(1)
+				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(rotation, nil).Times(1),
+
+					mocks.mockChannelRepo.EXPECT().
+						GetByID(rotation.ChannelID).
+						Return(channel, nil).Times(1),
+
+					mocks.mockSlackClient.EXPECT().
+						GetUserInfoContext(gomock.Any(), args.slackUserID).
+						Return(nil, assert.AnError).Times(1),
+				)
 			},
 			wantErr: true,
 		},
 		{
-			name: "Should return error when user repository GetByChannelAndSlackID fails",
+			name: "Should return error when user repository GetByRotationAndSlackID fails",
 			args: args{
-				channelID:   1,
+				rotationID:  1,
 				slackUserID: "U123456789",
 			},
 			buildMock: func(mocks allMocks, args args) {
@@ -311,21 +384,61 @@ func Test_rotationService_AddUser(t *testing.T) {
 				}
 
 				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(rotation, nil).Times(1),
+
+					mocks.mockChannelRepo.EXPECT().
+						GetByID(rotation.ChannelID).
+						Return(channel, nil).Times(1),
+
 					mocks.mockSlackClient.EXPECT().
-						GetUserInfo(args.slackUserID).
+						GetUserInfoContext(gomock.Any(), args.slackUserID).
 						Return(slackUser, nil).Times(1),
 
 					mocks.mockUserRepo.EXPECT().
-						GetByChannelAndSlackID(args.channelID, args.slackUserID).
+						GetByRotationAndSlackID(args.rotationID, args.slackUserID).
 						Return(nil, assert.AnError).Times(1),
 				)
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should return error when rotation not found",
+			args: args{
+				rotationID:  99,
+				slackUserID: "U123456789",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockRotationRepo.EXPECT().
+					GetByID(args.rotationID).
+					Return(nil, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when channel not found",
+			args: args{
+				rotationID:  1,
+				slackUserID: "U123456789",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(rotation, nil).Times(1),
+
+					mocks.mockChannelRepo.EXPECT().
+						GetByID(rotation.ChannelID).
+						Return(nil, nil).Times(1),
+				)
+			},
+			wantErr: true,
+		},
 		{
 			name: "Should return error when user repository Create fails",
 			args: args{
-				channelID:   1,
+				rotationID:  1,
 				slackUserID: "U123456789",
 			},
 			buildMock: func(mocks allMocks, args args) {
@@ -338,12 +451,20 @@ func Test_rotationService_AddUser(t *testing.T) {
 				}
 
 				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(rotation, nil).Times(1),
+
+					mocks.mockChannelRepo.EXPECT().
+						GetByID(rotation.ChannelID).
+						Return(channel, nil).Times(1),
+
 					mocks.mockSlackClient.EXPECT().
-						GetUserInfo(args.slackUserID).
+						GetUserInfoContext(gomock.Any(), args.slackUserID).
 						Return(slackUser, nil).Times(1),
 
 					mocks.mockUserRepo.EXPECT().
-						GetByChannelAndSlackID(args.channelID, args.slackUserID).
+						GetByRotationAndSlackID(args.rotationID, args.slackUserID).
 						Return(nil, nil).Times(1),
 
 					mocks.mockUserRepo.EXPECT().
@@ -360,13 +481,13 @@ func Test_rotationService_AddUser(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			err := s.AddUser(tt.args.channelID, tt.args.slackUserID)
+			err := s.AddUser(context.Background(), tt.args.rotationID, tt.args.slackUserID)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -379,7 +500,7 @@ func Test_rotationService_AddUser(t *testing.T) {
 
 func Test_rotationService_RemoveUser(t *testing.T) {
 	type args struct {
-		channelID   int64
+		rotationID  int64
 		slackUserID string
 	}
 	tests := []struct {
@@ -391,13 +512,13 @@ func Test_rotationService_RemoveUser(t *testing.T) {
 		{
 			name: "Should remove user successfully",
 			args: args{
-				channelID:   1,
+				rotationID:  1,
 				slackUserID: "U123456789",
 			},
 			buildMock: func(mocks allMocks, args args) {
 				existingUser := &entity.User{
 					ID:            1,
-					ChannelID:     args.channelID,
+					RotationID:    args.rotationID,
 					SlackUserID:   args.slackUserID,
 					SlackUserName: "testuser",
 					DisplayName:   "Test User",
@@ -406,7 +527,7 @@ func Test_rotationService_RemoveUser(t *testing.T) {
 
 				gomock.InOrder(
 					mocks.mockUserRepo.EXPECT().
-						GetByChannelAndSlackID(args.channelID, args.slackUserID).
+						GetByRotationAndSlackID(args.rotationID, args.slackUserID).
 						Return(existingUser, nil).Times(1),
 
 					mocks.mockUserRepo.EXPECT().
@@ -419,12 +540,12 @@ func Test_rotationService_RemoveUser(t *testing.T) {
 		{
 			name: "Should return error when user not found",
 			args: args{
-				channelID:   1,
+				rotationID:  1,
 				slackUserID: "U999999999",
 			},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockUserRepo.EXPECT().
-					GetByChannelAndSlackID(args.channelID, args.slackUserID).
+					GetByRotationAndSlackID(args.rotationID, args.slackUserID).
 					Return(nil, nil).Times(1)
 			},
 			wantErr: true,
@@ -436,13 +557,13 @@ func Test_rotationService_RemoveUser(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			err := s.RemoveUser(tt.args.channelID, tt.args.slackUserID)
+			err := s.RemoveUser(tt.args.rotationID, tt.args.slackUserID)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -453,71 +574,226 @@ func Test_rotationService_RemoveUser(t *testing.T) {
 	}
 }
 
-func Test_rotationService_GetNextPresenter(t *testing.T) {
+func Test_rotationService_GetNextAssignees(t *testing.T) {
 	type args struct {
-		channelID int64
+		rotationID int64
 	}
 	tests := []struct {
 		name      string
 		buildMock func(mocks allMocks, args args)
 		args      args
-		want      *entity.User
+		want      []*entity.User
 		wantErr   bool
 	}{
 		{
 			name: "Should return first user when no last presenter",
-			args: args{channelID: 1},
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
 				users := []*entity.User{
-					{ID: 1, SlackUserID: "U123456789", SlackUserName: "user1"},
-					{ID: 2, SlackUserID: "U987654321", SlackUserName: "user2"},
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", SlackUserName: "user1"},
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321", SlackUserName: "user2"},
 				}
 
 				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(nil, nil).Times(1),
+
 					mocks.mockUserRepo.EXPECT().
-						GetActiveUsersByChannel(args.channelID).
+						GetActiveUsersByRotation(args.rotationID).
 						Return(users, nil).Times(1),
 
-					mocks.mockUserRepo.EXPECT().
-						GetLastPresenter(args.channelID).
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(int64(1), gomock.Any()).
 						Return(nil, nil).Times(1),
 				)
 			},
-			want: &entity.User{ID: 1, SlackUserID: "U123456789", SlackUserName: "user1"},
+			want:    []*entity.User{{ID: 1, ChannelID: 1, SlackUserID: "U123456789", SlackUserName: "user1"}},
 			wantErr: false,
 		},
 		{
 			name: "Should return next user in rotation",
-			args: args{channelID: 1},
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
 				users := []*entity.User{
-					{ID: 1, SlackUserID: "U123456789", SlackUserName: "user1"},
-					{ID: 2, SlackUserID: "U987654321", SlackUserName: "user2"},
-					{ID: 3, SlackUserID: "U555555555", SlackUserName: "user3"},
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", SlackUserName: "user1"},
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321", SlackUserName: "user2"},
+					{ID: 3, ChannelID: 1, SlackUserID: "U555555555", SlackUserName: "user3"},
+				}
+
+				users[0].LastPresenter = true // First user was last
+
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(nil, nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(users, nil).Times(1),
+
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(int64(1), gomock.Any()).
+						Return(nil, nil).Times(1),
+				)
+			},
+			want:    []*entity.User{{ID: 2, ChannelID: 1, SlackUserID: "U987654321", SlackUserName: "user2"}},
+			wantErr: false,
+		},
+		{
+			name: "Should skip away user keeping rotation order",
+			args: args{rotationID: 1},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", SlackUserName: "user1"},
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321", SlackUserName: "user2"},
+					{ID: 3, ChannelID: 1, SlackUserID: "U555555555", SlackUserName: "user3"},
+				}
+
+				users[0].LastPresenter = true // First user was last
+
+				// Second user is away today in the channel timezone
+				today := time.Now().UTC()
+				away := []*entity.Availability{
+					{
+						UserID:      2,
+						SlackUserID: "U987654321",
+						StartDate:   time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC),
+						EndDate:     time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC),
+					},
 				}
 
-				lastPresenter := users[0] // First user was last
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(&entity.Scheduler{RotationID: args.rotationID, Timezone: "UTC"}, nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(users, nil).Times(1),
+
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(int64(1), time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)).
+						Return(away, nil).Times(1),
+				)
+			},
+			want:    []*entity.User{{ID: 3, ChannelID: 1, SlackUserID: "U555555555", SlackUserName: "user3"}},
+			wantErr: false,
+		},
+		{
+			name: "Should use the strategy configured for the channel",
+			args: args{rotationID: 1},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", SlackUserName: "user1", LastPresenter: true},
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321", SlackUserName: "user2"},
+					{ID: 3, ChannelID: 1, SlackUserID: "U555555555", SlackUserName: "user3"},
+				}
 
 				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(&entity.Scheduler{RotationID: args.rotationID, Strategy: domain.StrategyFair}, nil).Times(1),
+
 					mocks.mockUserRepo.EXPECT().
-						GetActiveUsersByChannel(args.channelID).
+						GetActiveUsersByRotation(args.rotationID).
 						Return(users, nil).Times(1),
 
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(int64(1), gomock.Any()).
+						Return(nil, nil).Times(1),
+
+					// Round-robin would pick user2, fair picks who had the fewest turns
+					mocks.mockHistoryRepo.EXPECT().
+						GetTurnStats(args.rotationID).
+						Return([]*entity.TurnStats{
+							{UserID: 1, Turns: 2},
+							{UserID: 2, Turns: 2},
+							{UserID: 3, Turns: 1},
+						}, nil).Times(1),
+				)
+			},
+			want:    []*entity.User{{ID: 3, ChannelID: 1, SlackUserID: "U555555555", SlackUserName: "user3"}},
+			wantErr: false,
+		},
+		{
+			name: "Should return backups following the presenter",
+			args: args{rotationID: 1},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", SlackUserName: "user1"},
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321", SlackUserName: "user2", LastPresenter: true},
+					{ID: 3, ChannelID: 1, SlackUserID: "U555555555", SlackUserName: "user3"},
+				}
+
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(&entity.Scheduler{RotationID: args.rotationID, Assignees: 2}, nil).Times(1),
+
 					mocks.mockUserRepo.EXPECT().
-						GetLastPresenter(args.channelID).
-						Return(lastPresenter, nil).Times(1),
+						GetActiveUsersByRotation(args.rotationID).
+						Return(users, nil).Times(1),
+
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(int64(1), gomock.Any()).
+						Return(nil, nil).Times(1),
 				)
 			},
-			want: &entity.User{ID: 2, SlackUserID: "U987654321", SlackUserName: "user2"},
+			want: []*entity.User{
+				{ID: 3, ChannelID: 1, SlackUserID: "U555555555", SlackUserName: "user3"},
+				{ID: 1, ChannelID: 1, SlackUserID: "U123456789", SlackUserName: "user1"},
+			},
 			wantErr: false,
 		},
+		{
+			name: "Should return error when everyone is away",
+			args: args{rotationID: 1},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", SlackUserName: "user1"},
+				}
+
+				today := time.Now().UTC()
+				away := []*entity.Availability{
+					{
+						UserID:      1,
+						SlackUserID: "U123456789",
+						StartDate:   time.Date(today.Year(), today.Month(), today.Day()-1, 0, 0, 0, 0, time.UTC),
+						EndDate:     time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.UTC),
+					},
+				}
+
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(nil, nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(users, nil).Times(1),
+
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(int64(1), gomock.Any()).
+						Return(away, nil).Times(1),
+				)
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "Should return error when no users",
-			args: args{channelID: 1},
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
-				mocks.mockUserRepo.EXPECT().
-					GetActiveUsersByChannel(args.channelID).
-					Return([]*entity.User{}, nil).Times(1)
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(nil, nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return([]*entity.User{}, nil).Times(1),
+				)
 			},
 			want:    nil,
 			wantErr: true,
@@ -529,13 +805,13 @@ func Test_rotationService_GetNextPresenter(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			got, err := s.GetNextPresenter(tt.args.channelID)
+			got, err := s.GetNextAssignees(tt.args.rotationID)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -550,9 +826,9 @@ func Test_rotationService_GetNextPresenter(t *testing.T) {
 
 func Test_rotationService_RecordPresentation(t *testing.T) {
 	type args struct {
-		ctx       context.Context
-		channelID int64
-		userID    int64
+		ctx        context.Context
+		rotationID int64
+		userID     int64
 	}
 	tests := []struct {
 		name      string
@@ -563,9 +839,9 @@ func Test_rotationService_RecordPresentation(t *testing.T) {
 		{
 			name: "Should record presentation successfully",
 			args: args{
-				ctx:       context.Background(),
-				channelID: 1,
-				userID:    2,
+				ctx:        context.Background(),
+				rotationID: 1,
+				userID:     2,
 			},
 			buildMock: func(mocks allMocks, args args) {
 				// Mock transaction
@@ -576,8 +852,19 @@ func Test_rotationService_RecordPresentation(t *testing.T) {
 						return fn(mocks.mockDataManager)
 					}).Times(1)
 
-				mocks.mockUserRepo.EXPECT().ClearLastPresenter(args.channelID).Return(nil).Times(1)
+				mocks.mockUserRepo.EXPECT().ClearLastPresenter(args.rotationID).Return(nil).Times(1)
+				mocks.mockUserRepo.EXPECT().ClearLastBackups(args.rotationID).Return(nil).Times(1)
 				mocks.mockUserRepo.EXPECT().SetLastPresenter(args.userID).Return(nil).Times(1)
+				mocks.mockHistoryRepo.EXPECT().
+					Create(gomock.Any()).
+					DoAndReturn(func(entry *entity.RotationHistory) error {
+						require.Equal(t, args.rotationID, entry.RotationID)
+						require.Equal(t, args.userID, entry.UserID)
+						require.Equal(t, domain.HistoryKindSkip, entry.Kind)
+						require.Equal(t, "U999999999", entry.TriggeredBy)
+						return nil
+					}).Times(1)
+				mocks.mockSchedulerRepo.EXPECT().GetByRotationID(args.rotationID).Return(nil, nil).Times(1)
 			},
 			wantErr: false,
 		},
@@ -588,13 +875,14 @@ func Test_rotationService_RecordPresentation(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			err := s.RecordPresentation(tt.args.ctx, tt.args.channelID, tt.args.userID)
+			user := &entity.User{ID: tt.args.userID, SlackUserID: "U123456789", DisplayName: "Test User"}
+			err := s.RecordPresentation(tt.args.ctx, tt.args.rotationID, []*entity.User{user}, domain.HistoryKindSkip, "U999999999")
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -607,7 +895,7 @@ func Test_rotationService_RecordPresentation(t *testing.T) {
 
 func Test_rotationService_UpdateChannelConfig(t *testing.T) {
 	type args struct {
-		channelID  int64
+		rotationID int64
 		configType string
 		value      string
 	}
@@ -620,14 +908,14 @@ func Test_rotationService_UpdateChannelConfig(t *testing.T) {
 		{
 			name: "Should update notification time",
 			args: args{
-				channelID:  1,
+				rotationID: 1,
 				configType: "time",
 				value:      "14:30",
 			},
 			buildMock: func(mocks allMocks, args args) {
 				scheduler := &entity.Scheduler{
 					ID:               1,
-					ChannelID:        args.channelID,
+					RotationID:       args.rotationID,
 					NotificationTime: "09:00",
 					ActiveDays:       domain.DefaultActiveDays,
 					IsEnabled:        true,
@@ -636,7 +924,7 @@ func Test_rotationService_UpdateChannelConfig(t *testing.T) {
 
 				gomock.InOrder(
 					mocks.mockSchedulerRepo.EXPECT().
-						GetByChannelID(args.channelID).
+						GetByRotationID(args.rotationID).
 						Return(scheduler, nil).Times(1),
 
 					mocks.mockSchedulerRepo.EXPECT().
@@ -652,14 +940,14 @@ func Test_rotationService_UpdateChannelConfig(t *testing.T) {
 		{
 			name: "Should update role",
 			args: args{
-				channelID:  1,
+				rotationID: 1,
 				configType: "role",
 				value:      "presenter",
 			},
 			buildMock: func(mocks allMocks, args args) {
 				scheduler := &entity.Scheduler{
 					ID:               1,
-					ChannelID:        args.channelID,
+					RotationID:       args.rotationID,
 					NotificationTime: "09:00",
 					ActiveDays:       domain.DefaultActiveDays,
 					IsEnabled:        true,
@@ -668,7 +956,7 @@ func Test_rotationService_UpdateChannelConfig(t *testing.T) {
 
 				gomock.InOrder(
 					mocks.mockSchedulerRepo.EXPECT().
-						GetByChannelID(args.channelID).
+						GetByRotationID(args.rotationID).
 						Return(scheduler, nil).Times(1),
 
 					mocks.mockSchedulerRepo.EXPECT().
@@ -684,14 +972,14 @@ func Test_rotationService_UpdateChannelConfig(t *testing.T) {
 		{
 			name: "Should return error for invalid time format",
 			args: args{
-				channelID:  1,
+				rotationID: 1,
 				configType: "time",
 				value:      "25:99",
 			},
 			buildMock: func(mocks allMocks, args args) {
 				scheduler := &entity.Scheduler{
 					ID:               1,
-					ChannelID:        args.channelID,
+					RotationID:       args.rotationID,
 					NotificationTime: "09:00",
 					ActiveDays:       domain.DefaultActiveDays,
 					IsEnabled:        true,
@@ -699,7 +987,7 @@ func Test_rotationService_UpdateChannelConfig(t *testing.T) {
 				}
 
 				mocks.mockSchedulerRepo.EXPECT().
-					GetByChannelID(args.channelID).
+					GetByRotationID(args.rotationID).
 					Return(scheduler, nil).Times(1)
 			},
 			wantErr: true,
@@ -711,13 +999,13 @@ func Test_rotationService_UpdateChannelConfig(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			err := s.UpdateChannelConfig(tt.args.channelID, tt.args.configType, tt.args.value)
+			err := s.UpdateChannelConfig(tt.args.rotationID, tt.args.configType, tt.args.value)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -730,7 +1018,7 @@ func Test_rotationService_UpdateChannelConfig(t *testing.T) {
 
 func Test_rotationService_ListUsers(t *testing.T) {
 	type args struct {
-		channelID int64
+		rotationID int64
 	}
 	tests := []struct {
 		name      string
@@ -741,7 +1029,7 @@ func Test_rotationService_ListUsers(t *testing.T) {
 	}{
 		{
 			name: "Should return list of users successfully",
-			args: args{channelID: 1},
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
 				expectedUsers := []*entity.User{
 					{ID: 1, SlackUserID: "U123456789", SlackUserName: "user1"},
@@ -749,7 +1037,7 @@ func Test_rotationService_ListUsers(t *testing.T) {
 				}
 
 				mocks.mockUserRepo.EXPECT().
-					GetActiveUsersByChannel(args.channelID).
+					GetActiveUsersByRotation(args.rotationID).
 					Return(expectedUsers, nil).Times(1)
 			},
 			want: []*entity.User{
@@ -760,10 +1048,10 @@ func Test_rotationService_ListUsers(t *testing.T) {
 		},
 		{
 			name: "Should return empty list when no users",
-			args: args{channelID: 1},
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockUserRepo.EXPECT().
-					GetActiveUsersByChannel(args.channelID).
+					GetActiveUsersByRotation(args.rotationID).
 					Return([]*entity.User{}, nil).Times(1)
 			},
 			want:    []*entity.User{},
@@ -771,10 +1059,10 @@ func Test_rotationService_ListUsers(t *testing.T) {
 		},
 		{
 			name: "Should return error when repository fails",
-			args: args{channelID: 1},
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockUserRepo.EXPECT().
-					GetActiveUsersByChannel(args.channelID).
+					GetActiveUsersByRotation(args.rotationID).
 					Return(nil, assert.AnError).Times(1)
 			},
 			want:    nil,
@@ -787,13 +1075,13 @@ func Test_rotationService_ListUsers(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			got, err := s.ListUsers(tt.args.channelID)
+			got, err := s.ListUsers(tt.args.rotationID)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -808,7 +1096,7 @@ func Test_rotationService_ListUsers(t *testing.T) {
 
 func Test_rotationService_GetCurrentPresenter(t *testing.T) {
 	type args struct {
-		channelID int64
+		rotationID int64
 	}
 	tests := []struct {
 		name      string
@@ -819,7 +1107,7 @@ func Test_rotationService_GetCurrentPresenter(t *testing.T) {
 	}{
 		{
 			name: "Should return current presenter successfully",
-			args: args{channelID: 1},
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
 				currentPresenter := &entity.User{
 					ID:            1,
@@ -829,7 +1117,7 @@ func Test_rotationService_GetCurrentPresenter(t *testing.T) {
 				}
 
 				mocks.mockUserRepo.EXPECT().
-					GetLastPresenter(args.channelID).
+					GetLastPresenter(args.rotationID).
 					Return(currentPresenter, nil).Times(1)
 			},
 			want: &entity.User{
@@ -842,10 +1130,10 @@ func Test_rotationService_GetCurrentPresenter(t *testing.T) {
 		},
 		{
 			name: "Should return nil when no current presenter",
-			args: args{channelID: 1},
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockUserRepo.EXPECT().
-					GetLastPresenter(args.channelID).
+					GetLastPresenter(args.rotationID).
 					Return(nil, nil).Times(1)
 			},
 			want:    nil,
@@ -853,10 +1141,10 @@ func Test_rotationService_GetCurrentPresenter(t *testing.T) {
 		},
 		{
 			name: "Should return error when repository fails",
-			args: args{channelID: 1},
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockUserRepo.EXPECT().
-					GetLastPresenter(args.channelID).
+					GetLastPresenter(args.rotationID).
 					Return(nil, assert.AnError).Times(1)
 			},
 			want:    nil,
@@ -869,13 +1157,13 @@ func Test_rotationService_GetCurrentPresenter(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			got, err := s.GetCurrentPresenter(tt.args.channelID)
+			got, err := s.GetCurrentPresenter(tt.args.rotationID)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -890,7 +1178,7 @@ func Test_rotationService_GetCurrentPresenter(t *testing.T) {
 
 func Test_rotationService_PauseScheduler(t *testing.T) {
 	type args struct {
-		channelID int64
+		rotationID int64
 	}
 	tests := []struct {
 		name      string
@@ -900,20 +1188,20 @@ func Test_rotationService_PauseScheduler(t *testing.T) {
 	}{
 		{
 			name: "Should pause scheduler successfully",
-			args: args{channelID: 1},
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockSchedulerRepo.EXPECT().
-					SetEnabled(args.channelID, false).
+					SetEnabled(args.rotationID, false).
 					Return(nil).Times(1)
 			},
 			wantErr: false,
 		},
 		{
 			name: "Should return error when repository fails",
-			args: args{channelID: 1},
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockSchedulerRepo.EXPECT().
-					SetEnabled(args.channelID, false).
+					SetEnabled(args.rotationID, false).
 					Return(assert.AnError).Times(1)
 			},
 			wantErr: true,
@@ -925,13 +1213,13 @@ func Test_rotationService_PauseScheduler(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			err := s.PauseScheduler(tt.args.channelID)
+			err := s.PauseScheduler(tt.args.rotationID)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -944,7 +1232,7 @@ func Test_rotationService_PauseScheduler(t *testing.T) {
 
 func Test_rotationService_ResumeScheduler(t *testing.T) {
 	type args struct {
-		channelID int64
+		rotationID int64
 	}
 	tests := []struct {
 		name      string
@@ -954,20 +1242,20 @@ func Test_rotationService_ResumeScheduler(t *testing.T) {
 	}{
 		{
 			name: "Should resume scheduler successfully",
-			args: args{channelID: 1},
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockSchedulerRepo.EXPECT().
-					SetEnabled(args.channelID, true).
+					SetEnabled(args.rotationID, true).
 					Return(nil).Times(1)
 			},
 			wantErr: false,
 		},
 		{
 			name: "Should return error when repository fails",
-			args: args{channelID: 1},
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockSchedulerRepo.EXPECT().
-					SetEnabled(args.channelID, true).
+					SetEnabled(args.rotationID, true).
 					Return(assert.AnError).Times(1)
 			},
 			wantErr: true,
@@ -979,13 +1267,13 @@ func Test_rotationService_ResumeScheduler(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			err := s.ResumeScheduler(tt.args.channelID)
+			err := s.ResumeScheduler(tt.args.rotationID)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -1061,7 +1349,7 @@ func Test_rotationService_GetChannelConfig(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
@@ -1082,7 +1370,7 @@ func Test_rotationService_GetChannelConfig(t *testing.T) {
 
 func Test_rotationService_GetSchedulerConfig(t *testing.T) {
 	type args struct {
-		channelID int64
+		rotationID int64
 	}
 	tests := []struct {
 		name      string
@@ -1093,7 +1381,7 @@ func Test_rotationService_GetSchedulerConfig(t *testing.T) {
 	}{
 		{
 			name: "Should return scheduler config successfully",
-			args: args{channelID: 1},
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
 				schedulerConfig := &entity.Scheduler{
 					ID:               1,
@@ -1105,7 +1393,7 @@ func Test_rotationService_GetSchedulerConfig(t *testing.T) {
 				}
 
 				mocks.mockSchedulerRepo.EXPECT().
-					GetByChannelID(args.channelID).
+					GetByRotationID(args.rotationID).
 					Return(schedulerConfig, nil).Times(1)
 			},
 			want: &entity.Scheduler{
@@ -1120,10 +1408,10 @@ func Test_rotationService_GetSchedulerConfig(t *testing.T) {
 		},
 		{
 			name: "Should return nil when scheduler config not found",
-			args: args{channelID: 999},
+			args: args{rotationID: 999},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockSchedulerRepo.EXPECT().
-					GetByChannelID(args.channelID).
+					GetByRotationID(args.rotationID).
 					Return(nil, nil).Times(1)
 			},
 			want:    nil,
@@ -1131,10 +1419,10 @@ func Test_rotationService_GetSchedulerConfig(t *testing.T) {
 		},
 		{
 			name: "Should return error when repository fails",
-			args: args{channelID: 1},
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockSchedulerRepo.EXPECT().
-					GetByChannelID(args.channelID).
+					GetByRotationID(args.rotationID).
 					Return(nil, assert.AnError).Times(1)
 			},
 			want:    nil,
@@ -1147,13 +1435,13 @@ func Test_rotationService_GetSchedulerConfig(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			got, err := s.GetSchedulerConfig(tt.args.channelID)
+			got, err := s.GetSchedulerConfig(tt.args.rotationID)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -1189,7 +1477,7 @@ func Test_rotationService_GetChannelStatus(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			got, err := s.GetChannelStatus(tt.args.channelID)
 
@@ -1207,7 +1495,7 @@ func Test_rotationService_GetChannelStatus(t *testing.T) {
 
 func Test_rotationService_UpdateChannelConfig_MoreScenarios(t *testing.T) {
 	type args struct {
-		channelID  int64
+		rotationID int64
 		configType string
 		value      string
 	}
@@ -1220,14 +1508,14 @@ func Test_rotationService_UpdateChannelConfig_MoreScenarios(t *testing.T) {
 		{
 			name: "Should update active days successfully",
 			args: args{
-				channelID:  1,
+				rotationID: 1,
 				configType: "days",
 				value:      "1,3,5",
 			},
 			buildMock: func(mocks allMocks, args args) {
 				scheduler := &entity.Scheduler{
 					ID:               1,
-					ChannelID:        args.channelID,
+					RotationID:       args.rotationID,
 					NotificationTime: "09:00",
 					ActiveDays:       domain.DefaultActiveDays,
 					IsEnabled:        true,
@@ -1236,7 +1524,7 @@ func Test_rotationService_UpdateChannelConfig_MoreScenarios(t *testing.T) {
 
 				gomock.InOrder(
 					mocks.mockSchedulerRepo.EXPECT().
-						GetByChannelID(args.channelID).
+						GetByRotationID(args.rotationID).
 						Return(scheduler, nil).Times(1),
 
 					mocks.mockSchedulerRepo.EXPECT().
@@ -1251,103 +1539,90 @@ func Test_rotationService_UpdateChannelConfig_MoreScenarios(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "Should return error for invalid config type",
+			name: "Should update timezone successfully",
 			args: args{
-				channelID:  1,
-				configType: "invalid",
-				value:      "test",
+				rotationID: 1,
+				configType: "timezone",
+				value:      "America/Sao_Paulo",
 			},
 			buildMock: func(mocks allMocks, args args) {
 				scheduler := &entity.Scheduler{
 					ID:               1,
-					ChannelID:        args.channelID,
+					RotationID:       args.rotationID,
 					NotificationTime: "09:00",
 					ActiveDays:       domain.DefaultActiveDays,
 					IsEnabled:        true,
 					Role:             "On duty",
+					Timezone:         "UTC",
 				}
 
-				mocks.mockSchedulerRepo.EXPECT().
-					GetByChannelID(args.channelID).
-					Return(scheduler, nil).Times(1)
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(scheduler, nil).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						Update(gomock.Any()).
+						DoAndReturn(func(s *entity.Scheduler) error {
+							require.Equal(t, "America/Sao_Paulo", s.Timezone)
+							return nil
+						}).Times(1),
+				)
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
-			name: "Should return error for empty role",
+			name: "Should return error for invalid timezone",
 			args: args{
-				channelID:  1,
-				configType: "role",
-				value:      "   ",
+				rotationID: 1,
+				configType: "timezone",
+				value:      "Mars/Olympus_Mons",
 			},
 			buildMock: func(mocks allMocks, args args) {
 				scheduler := &entity.Scheduler{
 					ID:               1,
-					ChannelID:        args.channelID,
+					RotationID:       args.rotationID,
 					NotificationTime: "09:00",
 					ActiveDays:       domain.DefaultActiveDays,
 					IsEnabled:        true,
 					Role:             "On duty",
+					Timezone:         "UTC",
 				}
 
 				mocks.mockSchedulerRepo.EXPECT().
-					GetByChannelID(args.channelID).
+					GetByRotationID(args.rotationID).
 					Return(scheduler, nil).Times(1)
 			},
 			wantErr: true,
 		},
 		{
-			name: "Should return error for invalid days",
+			name: "Should update strategy successfully",
 			args: args{
-				channelID:  1,
-				configType: "days",
-				value:      "invalid,8,9",
+				rotationID: 1,
+				configType: "strategy",
+				value:      "Fair",
 			},
 			buildMock: func(mocks allMocks, args args) {
 				scheduler := &entity.Scheduler{
 					ID:               1,
-					ChannelID:        args.channelID,
+					RotationID:       args.rotationID,
 					NotificationTime: "09:00",
 					ActiveDays:       domain.DefaultActiveDays,
 					IsEnabled:        true,
 					Role:             "On duty",
+					Timezone:         "UTC",
+					Strategy:         domain.StrategyRoundRobin,
 				}
 
-				mocks.mockSchedulerRepo.EXPECT().
-					GetByChannelID(args.channelID).
-					Return(scheduler, nil).Times(1)
-			},
-			wantErr: true,
-		},
-		{
-			name: "Should create scheduler config when not exists",
-			args: args{
-				channelID:  1,
-				configType: "time",
-				value:      "10:30",
-			},
-			buildMock: func(mocks allMocks, args args) {
 				gomock.InOrder(
 					mocks.mockSchedulerRepo.EXPECT().
-						GetByChannelID(args.channelID).
-						Return(nil, nil).Times(1),
-
-					mocks.mockSchedulerRepo.EXPECT().
-						Create(gomock.Any()).
-						DoAndReturn(func(scheduler *entity.Scheduler) error {
-							require.Equal(t, args.channelID, scheduler.ChannelID)
-							require.Equal(t, "09:00", scheduler.NotificationTime)
-							require.Equal(t, domain.DefaultActiveDays, scheduler.ActiveDays)
-							require.True(t, scheduler.IsEnabled)
-							require.Equal(t, "On duty", scheduler.Role)
-							scheduler.ID = 1
-							return nil
-						}).Times(1),
+						GetByRotationID(args.rotationID).
+						Return(scheduler, nil).Times(1),
 
 					mocks.mockSchedulerRepo.EXPECT().
 						Update(gomock.Any()).
 						DoAndReturn(func(s *entity.Scheduler) error {
-							require.Equal(t, args.value, s.NotificationTime)
+							require.Equal(t, domain.StrategyFair, s.Strategy)
 							return nil
 						}).Times(1),
 				)
@@ -1355,32 +1630,276 @@ func Test_rotationService_UpdateChannelConfig_MoreScenarios(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "Should return error when get scheduler fails",
+			name: "Should return error for invalid strategy",
 			args: args{
-				channelID:  1,
-				configType: "time",
-				value:      "10:30",
+				rotationID: 1,
+				configType: "strategy",
+				value:      "alphabetical",
 			},
 			buildMock: func(mocks allMocks, args args) {
+				scheduler := &entity.Scheduler{
+					ID:               1,
+					RotationID:       args.rotationID,
+					NotificationTime: "09:00",
+					ActiveDays:       domain.DefaultActiveDays,
+					IsEnabled:        true,
+					Role:             "On duty",
+					Timezone:         "UTC",
+				}
+
 				mocks.mockSchedulerRepo.EXPECT().
-					GetByChannelID(args.channelID).
-					Return(nil, assert.AnError).Times(1)
+					GetByRotationID(args.rotationID).
+					Return(scheduler, nil).Times(1)
 			},
 			wantErr: true,
 		},
 		{
-			name: "Should return error when create scheduler fails",
+			name: "Should update assignees successfully",
 			args: args{
-				channelID:  1,
-				configType: "time",
-				value:      "10:30",
+				rotationID: 1,
+				configType: "assignees",
+				value:      "2",
 			},
 			buildMock: func(mocks allMocks, args args) {
-				gomock.InOrder(
+				scheduler := &entity.Scheduler{
+					ID:               1,
+					RotationID:       args.rotationID,
+					NotificationTime: "09:00",
+					ActiveDays:       domain.DefaultActiveDays,
+					IsEnabled:        true,
+					Role:             "On duty",
+					Assignees:        1,
+				}
+
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(scheduler, nil).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						Update(gomock.Any()).
+						DoAndReturn(func(s *entity.Scheduler) error {
+							require.Equal(t, 2, s.Assignees)
+							return nil
+						}).Times(1),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error for too many assignees",
+			args: args{
+				rotationID: 1,
+				configType: "assignees",
+				value:      "6",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockSchedulerRepo.EXPECT().
+					GetByRotationID(args.rotationID).
+					Return(&entity.Scheduler{ID: 1, RotationID: args.rotationID}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should update backup role successfully",
+			args: args{
+				rotationID: 1,
+				configType: "backup",
+				value:      `"shadow"`,
+			},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(&entity.Scheduler{ID: 1, RotationID: args.rotationID}, nil).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						Update(gomock.Any()).
+						DoAndReturn(func(s *entity.Scheduler) error {
+							require.Equal(t, "shadow", s.BackupRole)
+							return nil
+						}).Times(1),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should turn the topic sync on",
+			args: args{
+				rotationID: 1,
+				configType: "topic",
+				value:      "ON",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(&entity.Scheduler{ID: 1, RotationID: args.rotationID}, nil).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						Update(gomock.Any()).
+						DoAndReturn(func(s *entity.Scheduler) error {
+							require.True(t, s.TopicSync)
+							return nil
+						}).Times(1),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error for invalid topic setting",
+			args: args{
+				rotationID: 1,
+				configType: "topic",
+				value:      "always",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockSchedulerRepo.EXPECT().
+					GetByRotationID(args.rotationID).
+					Return(&entity.Scheduler{ID: 1, RotationID: args.rotationID, TopicSync: true}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error for invalid config type",
+			args: args{
+				rotationID: 1,
+				configType: "invalid",
+				value:      "test",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				scheduler := &entity.Scheduler{
+					ID:               1,
+					RotationID:       args.rotationID,
+					NotificationTime: "09:00",
+					ActiveDays:       domain.DefaultActiveDays,
+					IsEnabled:        true,
+					Role:             "On duty",
+				}
+
+				mocks.mockSchedulerRepo.EXPECT().
+					GetByRotationID(args.rotationID).
+					Return(scheduler, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error for empty role",
+			args: args{
+				rotationID: 1,
+				configType: "role",
+				value:      "   ",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				scheduler := &entity.Scheduler{
+					ID:               1,
+					RotationID:       args.rotationID,
+					NotificationTime: "09:00",
+					ActiveDays:       domain.DefaultActiveDays,
+					IsEnabled:        true,
+					Role:             "On duty",
+				}
+
+				mocks.mockSchedulerRepo.EXPECT().
+					GetByRotationID(args.rotationID).
+					Return(scheduler, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error for invalid days",
+			args: args{
+				rotationID: 1,
+				configType: "days",
+				value:      "invalid,8,9",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				scheduler := &entity.Scheduler{
+					ID:               1,
+					RotationID:       args.rotationID,
+					NotificationTime: "09:00",
+					ActiveDays:       domain.DefaultActiveDays,
+					IsEnabled:        true,
+					Role:             "On duty",
+				}
+
+				mocks.mockSchedulerRepo.EXPECT().
+					GetByRotationID(args.rotationID).
+					Return(scheduler, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should create scheduler config when not exists",
+			args: args{
+				rotationID: 1,
+				configType: "time",
+				value:      "10:30",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
 					mocks.mockSchedulerRepo.EXPECT().
-						GetByChannelID(args.channelID).
+						GetByRotationID(args.rotationID).
 						Return(nil, nil).Times(1),
 
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(&entity.Rotation{ID: args.rotationID, ChannelID: 7}, nil).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						Create(gomock.Any()).
+						DoAndReturn(func(scheduler *entity.Scheduler) error {
+							require.Equal(t, int64(7), scheduler.ChannelID)
+							require.Equal(t, args.rotationID, scheduler.RotationID)
+							require.Equal(t, "09:00", scheduler.NotificationTime)
+							require.Equal(t, domain.DefaultActiveDays, scheduler.ActiveDays)
+							require.True(t, scheduler.IsEnabled)
+							require.Equal(t, "On duty", scheduler.Role)
+							scheduler.ID = 1
+							return nil
+						}).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						Update(gomock.Any()).
+						DoAndReturn(func(s *entity.Scheduler) error {
+							require.Equal(t, args.value, s.NotificationTime)
+							return nil
+						}).Times(1),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error when get scheduler fails",
+			args: args{
+				rotationID: 1,
+				configType: "time",
+				value:      "10:30",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockSchedulerRepo.EXPECT().
+					GetByRotationID(args.rotationID).
+					Return(nil, assert.AnError).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when create scheduler fails",
+			args: args{
+				rotationID: 1,
+				configType: "time",
+				value:      "10:30",
+			},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(nil, nil).Times(1),
+
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(&entity.Rotation{ID: args.rotationID, ChannelID: 7}, nil).Times(1),
+
 					mocks.mockSchedulerRepo.EXPECT().
 						Create(gomock.Any()).
 						Return(assert.AnError).Times(1),
@@ -1391,14 +1910,14 @@ func Test_rotationService_UpdateChannelConfig_MoreScenarios(t *testing.T) {
 		{
 			name: "Should return error when update scheduler fails",
 			args: args{
-				channelID:  1,
+				rotationID: 1,
 				configType: "time",
 				value:      "10:30",
 			},
 			buildMock: func(mocks allMocks, args args) {
 				scheduler := &entity.Scheduler{
 					ID:               1,
-					ChannelID:        args.channelID,
+					RotationID:       args.rotationID,
 					NotificationTime: "09:00",
 					ActiveDays:       domain.DefaultActiveDays,
 					IsEnabled:        true,
@@ -1407,7 +1926,7 @@ func Test_rotationService_UpdateChannelConfig_MoreScenarios(t *testing.T) {
 
 				gomock.InOrder(
 					mocks.mockSchedulerRepo.EXPECT().
-						GetByChannelID(args.channelID).
+						GetByRotationID(args.rotationID).
 						Return(scheduler, nil).Times(1),
 
 					mocks.mockSchedulerRepo.EXPECT().
@@ -1424,13 +1943,13 @@ func Test_rotationService_UpdateChannelConfig_MoreScenarios(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			err := s.UpdateChannelConfig(tt.args.channelID, tt.args.configType, tt.args.value)
+			err := s.UpdateChannelConfig(tt.args.rotationID, tt.args.configType, tt.args.value)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -1596,7 +2115,7 @@ func Test_cleanRoleName(t *testing.T) {
 // Additional error scenarios for RemoveUser
 func Test_rotationService_RemoveUser_ErrorScenarios(t *testing.T) {
 	type args struct {
-		channelID   int64
+		rotationID  int64
 		slackUserID string
 	}
 	tests := []struct {
@@ -1606,14 +2125,14 @@ func Test_rotationService_RemoveUser_ErrorScenarios(t *testing.T) {
 		wantErr   bool
 	}{
 		{
-			name: "Should return error when user repository GetByChannelAndSlackID fails",
+			name: "Should return error when user repository GetByRotationAndSlackID fails",
 			args: args{
-				channelID:   1,
+				rotationID:  1,
 				slackUserID: "U123456789",
 			},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockUserRepo.EXPECT().
-					GetByChannelAndSlackID(args.channelID, args.slackUserID).
+					GetByRotationAndSlackID(args.rotationID, args.slackUserID).
 					Return(nil, assert.AnError).Times(1)
 			},
 			wantErr: true,
@@ -1621,13 +2140,13 @@ func Test_rotationService_RemoveUser_ErrorScenarios(t *testing.T) {
 		{
 			name: "Should return error when user repository Delete fails",
 			args: args{
-				channelID:   1,
+				rotationID:  1,
 				slackUserID: "U123456789",
 			},
 			buildMock: func(mocks allMocks, args args) {
 				existingUser := &entity.User{
 					ID:            1,
-					ChannelID:     args.channelID,
+					RotationID:    args.rotationID,
 					SlackUserID:   args.slackUserID,
 					SlackUserName: "testuser",
 					DisplayName:   "Test User",
@@ -1636,7 +2155,7 @@ func Test_rotationService_RemoveUser_ErrorScenarios(t *testing.T) {
 
 				gomock.InOrder(
 					mocks.mockUserRepo.EXPECT().
-						GetByChannelAndSlackID(args.channelID, args.slackUserID).
+						GetByRotationAndSlackID(args.rotationID, args.slackUserID).
 						Return(existingUser, nil).Times(1),
 
 					mocks.mockUserRepo.EXPECT().
@@ -1653,13 +2172,13 @@ func Test_rotationService_RemoveUser_ErrorScenarios(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			err := s.RemoveUser(tt.args.channelID, tt.args.slackUserID)
+			err := s.RemoveUser(tt.args.rotationID, tt.args.slackUserID)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -1670,45 +2189,65 @@ func Test_rotationService_RemoveUser_ErrorScenarios(t *testing.T) {
 	}
 }
 
-// Additional error scenarios for GetNextPresenter
-func Test_rotationService_GetNextPresenter_ErrorScenarios(t *testing.T) {
+// Additional error scenarios for GetNextAssignees
+func Test_rotationService_GetNextAssignees_ErrorScenarios(t *testing.T) {
 	type args struct {
-		channelID int64
+		rotationID int64
 	}
 	tests := []struct {
 		name      string
 		buildMock func(mocks allMocks, args args)
 		args      args
-		want      *entity.User
+		want      []*entity.User
 		wantErr   bool
 	}{
 		{
 			name: "Should return error when GetActiveUsersByChannel fails",
-			args: args{channelID: 1},
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
-				mocks.mockUserRepo.EXPECT().
-					GetActiveUsersByChannel(args.channelID).
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(nil, nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(nil, assert.AnError).Times(1),
+				)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Should return error when scheduler config cannot be loaded",
+			args: args{rotationID: 1},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockSchedulerRepo.EXPECT().
+					GetByRotationID(args.rotationID).
 					Return(nil, assert.AnError).Times(1)
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name: "Should return error when GetLastPresenter fails",
-			args: args{channelID: 1},
+			name: "Should return error when away periods cannot be loaded",
+			args: args{rotationID: 1},
 			buildMock: func(mocks allMocks, args args) {
 				users := []*entity.User{
-					{ID: 1, SlackUserID: "U123456789", SlackUserName: "user1"},
-					{ID: 2, SlackUserID: "U987654321", SlackUserName: "user2"},
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", SlackUserName: "user1"},
 				}
 
 				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(nil, nil).Times(1),
+
 					mocks.mockUserRepo.EXPECT().
-						GetActiveUsersByChannel(args.channelID).
+						GetActiveUsersByRotation(args.rotationID).
 						Return(users, nil).Times(1),
 
-					mocks.mockUserRepo.EXPECT().
-						GetLastPresenter(args.channelID).
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(int64(1), gomock.Any()).
 						Return(nil, assert.AnError).Times(1),
 				)
 			},
@@ -1722,13 +2261,13 @@ func Test_rotationService_GetNextPresenter_ErrorScenarios(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			got, err := s.GetNextPresenter(tt.args.channelID)
+			got, err := s.GetNextAssignees(tt.args.rotationID)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -1744,9 +2283,9 @@ func Test_rotationService_GetNextPresenter_ErrorScenarios(t *testing.T) {
 // Improved RecordPresentation tests following go_boilerplate patterns
 func Test_rotationService_RecordPresentation_Enhanced(t *testing.T) {
 	type args struct {
-		ctx       context.Context
-		channelID int64
-		userID    int64
+		ctx        context.Context
+		rotationID int64
+		userID     int64
 	}
 	tests := []struct {
 		name      string
@@ -1757,9 +2296,9 @@ func Test_rotationService_RecordPresentation_Enhanced(t *testing.T) {
 		{
 			name: "Should record presentation successfully",
 			args: args{
-				ctx:       context.Background(),
-				channelID: 1,
-				userID:    2,
+				ctx:        context.Background(),
+				rotationID: 1,
+				userID:     2,
 			},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockDataManager.EXPECT().
@@ -1768,17 +2307,27 @@ func Test_rotationService_RecordPresentation_Enhanced(t *testing.T) {
 						return fn(mocks.mockDataManager)
 					}).Times(1)
 
-				mocks.mockUserRepo.EXPECT().ClearLastPresenter(args.channelID).Return(nil).Times(1)
+				mocks.mockUserRepo.EXPECT().ClearLastPresenter(args.rotationID).Return(nil).Times(1)
+				mocks.mockUserRepo.EXPECT().ClearLastBackups(args.rotationID).Return(nil).Times(1)
 				mocks.mockUserRepo.EXPECT().SetLastPresenter(args.userID).Return(nil).Times(1)
+				mocks.mockHistoryRepo.EXPECT().
+					Create(gomock.Any()).
+					DoAndReturn(func(entry *entity.RotationHistory) error {
+						require.Equal(t, args.rotationID, entry.RotationID)
+						require.Equal(t, args.userID, entry.UserID)
+						require.Equal(t, domain.HistoryKindSkip, entry.Kind)
+						require.Equal(t, "U999999999", entry.TriggeredBy)
+						return nil
+					}).Times(1)
 			},
 			wantErr: false,
 		},
 		{
 			name: "Should return error when transaction fails",
 			args: args{
-				ctx:       context.Background(),
-				channelID: 1,
-				userID:    2,
+				ctx:        context.Background(),
+				rotationID: 1,
+				userID:     2,
 			},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockDataManager.EXPECT().
@@ -1790,9 +2339,9 @@ func Test_rotationService_RecordPresentation_Enhanced(t *testing.T) {
 		{
 			name: "Should return error when ClearLastPresenter fails",
 			args: args{
-				ctx:       context.Background(),
-				channelID: 1,
-				userID:    2,
+				ctx:        context.Background(),
+				rotationID: 1,
+				userID:     2,
 			},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockDataManager.EXPECT().
@@ -1801,16 +2350,16 @@ func Test_rotationService_RecordPresentation_Enhanced(t *testing.T) {
 						return fn(mocks.mockDataManager)
 					}).Times(1)
 
-				mocks.mockUserRepo.EXPECT().ClearLastPresenter(args.channelID).Return(assert.AnError).Times(1)
+				mocks.mockUserRepo.EXPECT().ClearLastPresenter(args.rotationID).Return(assert.AnError).Times(1)
 			},
 			wantErr: true,
 		},
 		{
 			name: "Should return error when SetLastPresenter fails",
 			args: args{
-				ctx:       context.Background(),
-				channelID: 1,
-				userID:    2,
+				ctx:        context.Background(),
+				rotationID: 1,
+				userID:     2,
 			},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockDataManager.EXPECT().
@@ -1820,12 +2369,36 @@ func Test_rotationService_RecordPresentation_Enhanced(t *testing.T) {
 					}).Times(1)
 
 				gomock.InOrder(
-					mocks.mockUserRepo.EXPECT().ClearLastPresenter(args.channelID).Return(nil).Times(1),
+					mocks.mockUserRepo.EXPECT().ClearLastPresenter(args.rotationID).Return(nil).Times(1),
+					mocks.mockUserRepo.EXPECT().ClearLastBackups(args.rotationID).Return(nil).Times(1),
 					mocks.mockUserRepo.EXPECT().SetLastPresenter(args.userID).Return(assert.AnError).Times(1),
 				)
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should return error when history Create fails",
+			args: args{
+				ctx:        context.Background(),
+				rotationID: 1,
+				userID:     2,
+			},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockDataManager.EXPECT().
+					WithTransaction(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+						return fn(mocks.mockDataManager)
+					}).Times(1)
+
+				gomock.InOrder(
+					mocks.mockUserRepo.EXPECT().ClearLastPresenter(args.rotationID).Return(nil).Times(1),
+					mocks.mockUserRepo.EXPECT().ClearLastBackups(args.rotationID).Return(nil).Times(1),
+					mocks.mockUserRepo.EXPECT().SetLastPresenter(args.userID).Return(nil).Times(1),
+					mocks.mockHistoryRepo.EXPECT().Create(gomock.Any()).Return(assert.AnError).Times(1),
+				)
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1833,13 +2406,15 @@ func Test_rotationService_RecordPresentation_Enhanced(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newRotation(m.mockDataManager, m.mockSlackClient)
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
 
+			m.mockSchedulerRepo.EXPECT().GetByRotationID(tt.args.rotationID).Return(nil, nil).Times(1)
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			err := s.RecordPresentation(tt.args.ctx, tt.args.channelID, tt.args.userID)
+			user := &entity.User{ID: tt.args.userID, SlackUserID: "U123456789", DisplayName: "Test User"}
+			err := s.RecordPresentation(tt.args.ctx, tt.args.rotationID, []*entity.User{user}, domain.HistoryKindSkip, "U999999999")
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -1848,4 +2423,279 @@ func Test_rotationService_RecordPresentation_Enhanced(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func Test_rotationService_RecordPresentation_WithBackups(t *testing.T) {
+	m, ctrl := newServiceTestMock(t)
+	defer ctrl.Finish()
+
+	s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+	users := []*entity.User{
+		{ID: 2, ChannelID: 1, SlackUserID: "U987654321", DisplayName: "Presenter"},
+		{ID: 3, ChannelID: 1, SlackUserID: "U555555555", DisplayName: "Backup"},
+	}
+
+	var entries []*entity.RotationHistory
+	gomock.InOrder(
+		m.mockSchedulerRepo.EXPECT().GetByRotationID(int64(1)).Return(nil, nil).Times(1),
+		m.mockDataManager.EXPECT().
+			WithTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+				return fn(m.mockDataManager)
+			}).Times(1),
+
+		m.mockUserRepo.EXPECT().ClearLastPresenter(int64(1)).Return(nil).Times(1),
+		m.mockUserRepo.EXPECT().ClearLastBackups(int64(1)).Return(nil).Times(1),
+		m.mockUserRepo.EXPECT().SetLastPresenter(int64(2)).Return(nil).Times(1),
+		m.mockUserRepo.EXPECT().SetLastBackup(int64(3)).Return(nil).Times(1),
+		m.mockHistoryRepo.EXPECT().
+			Create(gomock.Any()).
+			DoAndReturn(func(entry *entity.RotationHistory) error {
+				entries = append(entries, entry)
+				return nil
+			}).Times(2),
+	)
+
+	err := s.RecordPresentation(context.Background(), 1, users, domain.HistoryKindAutomatic, "")
+
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, int64(2), entries[0].UserID)
+	assert.False(t, entries[0].IsBackup)
+	assert.Equal(t, int64(3), entries[1].UserID)
+	assert.True(t, entries[1].IsBackup)
+	assert.Equal(t, entries[0].PresentedAt, entries[1].PresentedAt)
+}
+
+func Test_rotationService_GetHistory(t *testing.T) {
+	type args struct {
+		rotationID int64
+		limit      int
+	}
+	tests := []struct {
+		name      string
+		buildMock func(mocks allMocks, args args)
+		args      args
+		wantLen   int
+		wantErr   bool
+	}{
+		{
+			name: "Should return history entries",
+			args: args{rotationID: 1, limit: 5},
+			buildMock: func(mocks allMocks, args args) {
+				entries := []*entity.RotationHistory{
+					{ID: 2, RotationID: args.rotationID, Kind: domain.HistoryKindSkip},
+					{ID: 1, RotationID: args.rotationID, Kind: domain.HistoryKindAutomatic},
+				}
+
+				mocks.mockHistoryRepo.EXPECT().
+					GetByRotationID(args.rotationID, args.limit).
+					Return(entries, nil).Times(1)
+			},
+			wantLen: 2,
+			wantErr: false,
+		},
+		{
+			name: "Should return error when repository fails",
+			args: args{rotationID: 1, limit: 5},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockHistoryRepo.EXPECT().
+					GetByRotationID(args.rotationID, args.limit).
+					Return(nil, assert.AnError).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+			if tt.buildMock != nil {
+				tt.buildMock(m, tt.args)
+			}
+
+			got, err := s.GetHistory(tt.args.rotationID, tt.args.limit)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, got, tt.wantLen)
+		})
+	}
+}
+
+func Test_rotationService_UpdateSchedulerSettings(t *testing.T) {
+	type args struct {
+		rotationID int64
+		settings   entity.SchedulerSettings
+	}
+	tests := []struct {
+		name            string
+		buildMock       func(mocks allMocks, args args)
+		args            args
+		wantFieldErrors []string
+		wantErr         bool
+	}{
+		{
+			name: "Should apply every setting in a single update",
+			args: args{
+				rotationID: 1,
+				settings: entity.SchedulerSettings{
+					NotificationTime: "10:30",
+					ActiveDays:       []string{"5", "1", "3"},
+					Role:             "presenter",
+					IsEnabled:        false,
+				},
+			},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(&entity.Scheduler{ID: 1, RotationID: args.rotationID, NotificationTime: "09:00", ActiveDays: domain.DefaultActiveDays, IsEnabled: true, Role: "On duty"}, nil).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						Update(gomock.Any()).
+						DoAndReturn(func(s *entity.Scheduler) error {
+							require.Equal(t, "10:30", s.NotificationTime)
+							require.Equal(t, []int{1, 3, 5}, s.ActiveDays)
+							require.Equal(t, "presenter", s.Role)
+							require.False(t, s.IsEnabled)
+							return nil
+						}).Times(1),
+				)
+			},
+		},
+		{
+			name: "Should create default scheduler config before applying the settings",
+			args: args{
+				rotationID: 2,
+				settings: entity.SchedulerSettings{
+					NotificationTime: "08:00",
+					ActiveDays:       []string{"1"},
+					Role:             "reviewer",
+					IsEnabled:        true,
+				},
+			},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(nil, nil).Times(1),
+
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(&entity.Rotation{ID: args.rotationID, ChannelID: 1, Name: "reviewers"}, nil).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						Create(gomock.Any()).
+						Return(nil).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						Update(gomock.Any()).
+						DoAndReturn(func(s *entity.Scheduler) error {
+							require.Equal(t, "08:00", s.NotificationTime)
+							require.Equal(t, []int{1}, s.ActiveDays)
+							return nil
+						}).Times(1),
+				)
+			},
+		},
+		{
+			name: "Should return field errors without updating anything",
+			args: args{
+				rotationID: 1,
+				settings: entity.SchedulerSettings{
+					NotificationTime: "25:00",
+					ActiveDays:       []string{"1", "x"},
+					Role:             "  ",
+				},
+			},
+			wantFieldErrors: []string{domain.ConfigFieldTime, domain.ConfigFieldDays, domain.ConfigFieldRole},
+			wantErr:         true,
+		},
+		{
+			name: "Should require at least one day",
+			args: args{
+				rotationID: 1,
+				settings: entity.SchedulerSettings{
+					NotificationTime: "09:00",
+					Role:             "presenter",
+				},
+			},
+			wantFieldErrors: []string{domain.ConfigFieldDays},
+			wantErr:         true,
+		},
+		{
+			name: "Should return error when update fails",
+			args: args{
+				rotationID: 1,
+				settings: entity.SchedulerSettings{
+					NotificationTime: "09:00",
+					ActiveDays:       []string{"1"},
+					Role:             "presenter",
+				},
+			},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(&entity.Scheduler{ID: 1, RotationID: args.rotationID}, nil).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						Update(gomock.Any()).
+						Return(assert.AnError).Times(1),
+				)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+			if tt.buildMock != nil {
+				tt.buildMock(m, tt.args)
+			}
+
+			err := s.UpdateSchedulerSettings(tt.args.rotationID, tt.args.settings)
+
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			if len(tt.wantFieldErrors) > 0 {
+				var fieldErrors domain.FieldErrors
+				require.ErrorAs(t, err, &fieldErrors)
+				assert.Len(t, fieldErrors, len(tt.wantFieldErrors))
+				for _, field := range tt.wantFieldErrors {
+					assert.Contains(t, fieldErrors, field)
+				}
+			}
+		})
+	}
+}
+
+func Test_parseDayValues(t *testing.T) {
+	days, err := parseDayValues([]string{"7", "1", " 3 "})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3, 7}, days)
+
+	_, err = parseDayValues([]string{"1", "8"})
+	assert.Error(t, err)
+
+	_, err = parseDayValues(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+)
+
+// rotationNamePattern limits rotation names to a single word that is easy to type after /rotation
+var rotationNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// GetRotation returns the rotation of the channel called name, the primary rotation when name is empty
+func (s *rotationService) GetRotation(channelID int64, name string) (*entity.Rotation, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		rotation, err := s.dm.Rotation().GetPrimary(channelID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rotation: %w", err)
+		}
+
+		if rotation == nil {
+			return nil, fmt.Errorf("primary rotation not found")
+		}
+
+		return rotation, nil
+	}
+
+	rotation, err := s.dm.Rotation().GetByChannelAndName(channelID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rotation: %w", err)
+	}
+
+	if rotation == nil {
+		return nil, fmt.Errorf("rotation %s not found. Use `/rotation create %s` to create it or `/rotation rotations` to see the rotations of this channel", name, name)
+	}
+
+	return rotation, nil
+}
+
+// GetRotationByID returns the rotation with the given id, as carried by the reminder buttons
+func (s *rotationService) GetRotationByID(rotationID int64) (*entity.Rotation, error) {
+	rotation, err := s.dm.Rotation().GetByID(rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rotation: %w", err)
+	}
+
+	if rotation == nil {
+		return nil, fmt.Errorf("rotation not found")
+	}
+
+	return rotation, nil
+}
+
+// CreateRotation adds a named rotation to the channel with its own members and schedule.
+// The new schedule starts with the defaults and the timezone of the primary rotation.
+func (s *rotationService) CreateRotation(channelID int64, name string) (*entity.Rotation, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !rotationNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid rotation name. Use up to 32 lowercase letters, numbers, '-' or '_'. Example: reviewers")
+	}
+
+	if domain.CommandWords[name] {
+		return nil, fmt.Errorf("%s is a command name, please choose another name", name)
+	}
+
+	existing, err := s.dm.Rotation().GetByChannelAndName(channelID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check rotation: %w", err)
+	}
+
+	if existing != nil {
+		return nil, fmt.Errorf("rotation %s already exists", name)
+	}
+
+	loc, err := s.channelLocation(channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	rotation := &entity.Rotation{
+		ChannelID: channelID,
+		Name:      name,
+	}
+
+	err = s.dm.WithTransaction(context.Background(), func(tx contract.DataManager) error {
+		if err := tx.Rotation().Create(rotation); err != nil {
+			return fmt.Errorf("failed to create rotation: %w", err)
+		}
+
+		scheduler := newDefaultScheduler(rotation)
+		scheduler.Timezone = loc.String()
+		if err := tx.Scheduler().Create(scheduler); err != nil {
+			return fmt.Errorf("failed to create scheduler config: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Notify scheduler of new rotation
+	if s.scheduler != nil {
+		s.scheduler.NotifyConfigChange(channelID)
+	}
+
+	return rotation, nil
+}
+
+// DeleteRotation removes a named rotation with its members, schedule and history.
+// The primary rotation cannot be deleted.
+func (s *rotationService) DeleteRotation(channelID int64, name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	rotation, err := s.dm.Rotation().GetByChannelAndName(channelID, name)
+	if err != nil {
+		return fmt.Errorf("failed to get rotation: %w", err)
+	}
+
+	if rotation == nil {
+		return fmt.Errorf("rotation %s not found", name)
+	}
+
+	if rotation.IsPrimary {
+		return fmt.Errorf("the primary rotation of the channel cannot be deleted")
+	}
+
+	err = s.dm.WithTransaction(context.Background(), func(tx contract.DataManager) error {
+		// Remove the schedule explicitly so the scheduler stops right away
+		if err := tx.Scheduler().Delete(rotation.ID); err != nil {
+			return fmt.Errorf("failed to delete scheduler config: %w", err)
+		}
+
+		if err := tx.Rotation().Delete(rotation.ID); err != nil {
+			return fmt.Errorf("failed to delete rotation: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Notify scheduler so the deleted rotation is not notified
+	if s.scheduler != nil {
+		s.scheduler.NotifyConfigChange(channelID)
+	}
+
+	return nil
+}
+
+// ListRotations returns every rotation of the channel, the primary one first
+func (s *rotationService) ListRotations(channelID int64) ([]*entity.Rotation, error) {
+	rotations, err := s.dm.Rotation().GetByChannelID(channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rotations: %w", err)
+	}
+
+	return rotations, nil
+}
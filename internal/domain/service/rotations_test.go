@@ -0,0 +1,384 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_rotationService_GetRotation(t *testing.T) {
+	primary := &entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}
+	reviewers := &entity.Rotation{ID: 2, ChannelID: 1, Name: "reviewers"}
+
+	type args struct {
+		channelID int64
+		name      string
+	}
+	tests := []struct {
+		name      string
+		buildMock func(mocks allMocks, args args)
+		args      args
+		want      *entity.Rotation
+		wantErr   bool
+	}{
+		{
+			name: "Should return primary rotation when name is empty",
+			args: args{channelID: 1, name: ""},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockRotationRepo.EXPECT().
+					GetPrimary(args.channelID).
+					Return(primary, nil).Times(1)
+			},
+			want: primary,
+		},
+		{
+			name: "Should return named rotation ignoring case",
+			args: args{channelID: 1, name: "Reviewers"},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockRotationRepo.EXPECT().
+					GetByChannelAndName(args.channelID, "reviewers").
+					Return(reviewers, nil).Times(1)
+			},
+			want: reviewers,
+		},
+		{
+			name: "Should return error when named rotation not found",
+			args: args{channelID: 1, name: "oncall"},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockRotationRepo.EXPECT().
+					GetByChannelAndName(args.channelID, "oncall").
+					Return(nil, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when primary rotation not found",
+			args: args{channelID: 1, name: ""},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockRotationRepo.EXPECT().
+					GetPrimary(args.channelID).
+					Return(nil, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when repository fails",
+			args: args{channelID: 1, name: ""},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockRotationRepo.EXPECT().
+					GetPrimary(args.channelID).
+					Return(nil, assert.AnError).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+			if tt.buildMock != nil {
+				tt.buildMock(m, tt.args)
+			}
+
+			got, err := s.GetRotation(tt.args.channelID, tt.args.name)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_rotationService_GetRotationByID(t *testing.T) {
+	m, ctrl := newServiceTestMock(t)
+	defer ctrl.Finish()
+
+	s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+	reviewers := &entity.Rotation{ID: 2, ChannelID: 1, Name: "reviewers"}
+	gomock.InOrder(
+		m.mockRotationRepo.EXPECT().GetByID(int64(2)).Return(reviewers, nil).Times(1),
+		m.mockRotationRepo.EXPECT().GetByID(int64(99)).Return(nil, nil).Times(1),
+	)
+
+	got, err := s.GetRotationByID(2)
+	require.NoError(t, err)
+	assert.Equal(t, reviewers, got)
+
+	_, err = s.GetRotationByID(99)
+	require.Error(t, err)
+}
+
+func Test_rotationService_CreateRotation(t *testing.T) {
+	type args struct {
+		channelID int64
+		name      string
+	}
+	tests := []struct {
+		name      string
+		buildMock func(mocks allMocks, args args)
+		args      args
+		wantErr   bool
+	}{
+		{
+			name: "Should create rotation with default schedule in the channel timezone",
+			args: args{channelID: 1, name: "Reviewers"},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByChannelAndName(args.channelID, "reviewers").
+						Return(nil, nil).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByChannelID(args.channelID).
+						Return(&entity.Scheduler{Timezone: "America/Sao_Paulo"}, nil).Times(1),
+
+					mocks.mockDataManager.EXPECT().
+						WithTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+							return fn(mocks.mockDataManager)
+						}).Times(1),
+
+					mocks.mockRotationRepo.EXPECT().
+						Create(gomock.Any()).
+						DoAndReturn(func(rotation *entity.Rotation) error {
+							require.Equal(t, args.channelID, rotation.ChannelID)
+							require.Equal(t, "reviewers", rotation.Name)
+							require.False(t, rotation.IsPrimary)
+							rotation.ID = 2
+							return nil
+						}).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						Create(gomock.Any()).
+						DoAndReturn(func(scheduler *entity.Scheduler) error {
+							require.Equal(t, args.channelID, scheduler.ChannelID)
+							require.Equal(t, int64(2), scheduler.RotationID)
+							require.Equal(t, "09:00", scheduler.NotificationTime)
+							require.Equal(t, "America/Sao_Paulo", scheduler.Timezone)
+							require.True(t, scheduler.IsEnabled)
+							return nil
+						}).Times(1),
+				)
+			},
+		},
+		{
+			name:    "Should return error for invalid name",
+			args:    args{channelID: 1, name: "code reviewers"},
+			wantErr: true,
+		},
+		{
+			name:    "Should return error for a command name",
+			args:    args{channelID: 1, name: "next"},
+			wantErr: true,
+		},
+		{
+			name:    "Should return error for a command alias",
+			args:    args{channelID: 1, name: "Holiday"},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when rotation already exists",
+			args: args{channelID: 1, name: "reviewers"},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockRotationRepo.EXPECT().
+					GetByChannelAndName(args.channelID, args.name).
+					Return(&entity.Rotation{ID: 2, ChannelID: args.channelID, Name: args.name}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when scheduler creation fails",
+			args: args{channelID: 1, name: "reviewers"},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByChannelAndName(args.channelID, args.name).
+						Return(nil, nil).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByChannelID(args.channelID).
+						Return(nil, nil).Times(1),
+
+					mocks.mockDataManager.EXPECT().
+						WithTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+							return fn(mocks.mockDataManager)
+						}).Times(1),
+
+					mocks.mockRotationRepo.EXPECT().
+						Create(gomock.Any()).
+						Return(nil).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						Create(gomock.Any()).
+						Return(assert.AnError).Times(1),
+				)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+			if tt.buildMock != nil {
+				tt.buildMock(m, tt.args)
+			}
+
+			got, err := s.CreateRotation(tt.args.channelID, tt.args.name)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), got.ID)
+		})
+	}
+}
+
+func Test_rotationService_DeleteRotation(t *testing.T) {
+	type args struct {
+		channelID int64
+		name      string
+	}
+	tests := []struct {
+		name      string
+		buildMock func(mocks allMocks, args args)
+		args      args
+		wantErr   bool
+	}{
+		{
+			name: "Should delete rotation with its schedule",
+			args: args{channelID: 1, name: "reviewers"},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByChannelAndName(args.channelID, args.name).
+						Return(&entity.Rotation{ID: 2, ChannelID: args.channelID, Name: args.name}, nil).Times(1),
+
+					mocks.mockDataManager.EXPECT().
+						WithTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+							return fn(mocks.mockDataManager)
+						}).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						Delete(int64(2)).
+						Return(nil).Times(1),
+
+					mocks.mockRotationRepo.EXPECT().
+						Delete(int64(2)).
+						Return(nil).Times(1),
+				)
+			},
+		},
+		{
+			name: "Should return error when rotation not found",
+			args: args{channelID: 1, name: "reviewers"},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockRotationRepo.EXPECT().
+					GetByChannelAndName(args.channelID, args.name).
+					Return(nil, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should not delete the primary rotation",
+			args: args{channelID: 1, name: domain.DefaultRotationName},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockRotationRepo.EXPECT().
+					GetByChannelAndName(args.channelID, args.name).
+					Return(&entity.Rotation{ID: 1, ChannelID: args.channelID, Name: args.name, IsPrimary: true}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when delete fails",
+			args: args{channelID: 1, name: "reviewers"},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByChannelAndName(args.channelID, args.name).
+						Return(&entity.Rotation{ID: 2, ChannelID: args.channelID, Name: args.name}, nil).Times(1),
+
+					mocks.mockDataManager.EXPECT().
+						WithTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+							return fn(mocks.mockDataManager)
+						}).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						Delete(int64(2)).
+						Return(nil).Times(1),
+
+					mocks.mockRotationRepo.EXPECT().
+						Delete(int64(2)).
+						Return(assert.AnError).Times(1),
+				)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+			if tt.buildMock != nil {
+				tt.buildMock(m, tt.args)
+			}
+
+			err := s.DeleteRotation(tt.args.channelID, tt.args.name)
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_rotationService_ListRotations(t *testing.T) {
+	m, ctrl := newServiceTestMock(t)
+	defer ctrl.Finish()
+
+	s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+	rotations := []*entity.Rotation{
+		{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true},
+		{ID: 2, ChannelID: 1, Name: "reviewers"},
+	}
+	m.mockRotationRepo.EXPECT().
+		GetByChannelID(int64(1)).
+		Return(rotations, nil).Times(1)
+
+	got, err := s.ListRotations(1)
+
+	require.NoError(t, err)
+	assert.Equal(t, rotations, got)
+}
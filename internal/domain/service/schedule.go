@@ -0,0 +1,222 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+)
+
+// GetUserRotations returns every rotation the Slack user belongs to, in all channels, with
+// who is on duty and when the user is expected to take the next turn. Archived channels
+// are left out.
+func (s *rotationService) GetUserRotations(slackUserID string) ([]*entity.UserRotation, error) {
+	members, err := s.dm.User().GetBySlackUserID(slackUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user rotations: %w", err)
+	}
+
+	now := s.clock.Now()
+
+	var rotations []*entity.UserRotation
+	for _, member := range members {
+		channel, err := s.dm.Channel().GetByID(member.ChannelID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get channel: %w", err)
+		}
+
+		if channel == nil || !channel.IsActive {
+			continue
+		}
+
+		rotation, err := s.dm.Rotation().GetByID(member.RotationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rotation: %w", err)
+		}
+
+		if rotation == nil {
+			continue
+		}
+
+		scheduler, err := s.dm.Scheduler().GetByRotationID(rotation.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get scheduler config: %w", err)
+		}
+
+		if scheduler == nil {
+			scheduler = newDefaultScheduler(rotation)
+		}
+
+		users, err := s.dm.User().GetActiveUsersByRotation(rotation.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get users: %w", err)
+		}
+
+		holidays, err := s.dm.Holiday().GetByChannelID(channel.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get holidays: %w", err)
+		}
+
+		today := now.In(scheduler.GetLocation())
+		absences, err := s.dm.Availability().GetUpcomingByChannel(channel.ID, dateOf(today))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get away periods: %w", err)
+		}
+
+		swaps, err := s.dm.Swap().GetPendingByRotation(rotation.ID, dateOf(today).AddDate(1, 0, 0))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pending swaps: %w", err)
+		}
+
+		userRotation := &entity.UserRotation{
+			Channel:   channel,
+			Rotation:  rotation,
+			Scheduler: scheduler,
+			Member:    member,
+			AwayToday: awayUserIDs([]*entity.User{member}, absences, today)[member.ID],
+		}
+
+		for _, user := range users {
+			if user.LastPresenter {
+				userRotation.OnDuty = user
+			}
+		}
+
+		// Everyone takes a turn within two cycles unless they are away for long
+		turns, err := projectTurns(s.dm, scheduler, users, holidays, absences, swaps, now, 2*len(users))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, turn := range turns {
+			if turn.Presenter != nil && turn.Presenter.ID == member.ID {
+				userRotation.NextTurn = turn.Date
+				break
+			}
+		}
+
+		rotations = append(rotations, userRotation)
+	}
+
+	return rotations, nil
+}
+
+// GetSchedule returns the next count occurrences of the rotation with who is expected on duty,
+// none while the rotation is paused
+func (s *rotationService) GetSchedule(rotationID int64, count int) ([]entity.ScheduledTurn, error) {
+	scheduler, err := s.dm.Scheduler().GetByRotationID(rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduler config: %w", err)
+	}
+
+	if scheduler == nil || !scheduler.IsEnabled {
+		return nil, nil
+	}
+
+	users, err := s.dm.User().GetActiveUsersByRotation(rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	holidays, err := s.dm.Holiday().GetByChannelID(scheduler.ChannelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get holidays: %w", err)
+	}
+
+	now := s.clock.Now()
+	today := dateOf(now.In(scheduler.GetLocation()))
+
+	absences, err := s.dm.Availability().GetUpcomingByChannel(scheduler.ChannelID, today)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get away periods: %w", err)
+	}
+
+	swaps, err := s.dm.Swap().GetPendingByRotation(rotationID, today.AddDate(1, 0, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending swaps: %w", err)
+	}
+
+	return projectTurns(s.dm, scheduler, users, holidays, absences, swaps, now, count)
+}
+
+// projectTurns lists the next count occurrences of the rotation after now with the members
+// expected on duty. The strategy of the rotation picks each presenter from a copy of the members,
+// as if the previous turns had been taken, skipping holidays and the members away on each date
+// and applying the dated swaps on their day like the scheduler. Nothing is written. Paused
+// rotations have no occurrence. Manual skips can still change who actually takes a turn.
+func projectTurns(dm contract.DataManager, scheduler *entity.Scheduler, users []*entity.User, holidays []*entity.Holiday, absences []*entity.Availability, swaps []*entity.Swap, now time.Time, count int) ([]entity.ScheduledTurn, error) {
+	if !scheduler.IsEnabled {
+		return nil, nil
+	}
+
+	strategyName := domain.DefaultStrategy
+	if scheduler.Strategy != "" {
+		strategyName = scheduler.Strategy
+	}
+	strategy := getStrategy(strategyName)
+
+	// Taking turns and swaps change the members, keep the caller's ones untouched
+	state := &rotationState{dm: dm, rotationID: scheduler.RotationID}
+	for _, user := range users {
+		member := *user
+		state.users = append(state.users, &member)
+	}
+
+	var turns []entity.ScheduledTurn
+	for len(turns) < count {
+		date := nextOccurrence(scheduler, holidays, now)
+		if date.IsZero() {
+			break
+		}
+		now = date
+
+		// The current turn moves with a swap, so the presenter keeps the same place
+		for len(swaps) > 0 && !swaps[0].SwapDate.After(dateOf(date)) {
+			swapPositions(state.users, swaps[0].UserAID, swaps[0].UserBID)
+			swaps = swaps[1:]
+		}
+
+		turn := entity.ScheduledTurn{Date: date}
+		if len(state.users) > 0 {
+			state.away = awayUserIDs(state.users, absences, date)
+
+			presenter, err := strategy.Next(state)
+			if err != nil {
+				return nil, err
+			}
+
+			if presenter != nil {
+				turn.Presenter = presenter
+				turn.Backups = pickBackups(state, presenter, scheduler.GetAssignees()-1)
+				state.takeTurn(presenter, date)
+			}
+		}
+
+		turns = append(turns, turn)
+	}
+
+	return turns, nil
+}
+
+// swapPositions exchanges the places of two members in users, and the current turn with them
+// like exchangeTurns. Members not found are ignored.
+func swapPositions(users []*entity.User, userAID, userBID int64) {
+	indexA, indexB := -1, -1
+	for i, user := range users {
+		switch user.ID {
+		case userAID:
+			indexA = i
+		case userBID:
+			indexB = i
+		}
+	}
+
+	if indexA == -1 || indexB == -1 {
+		return
+	}
+
+	users[indexA], users[indexB] = users[indexB], users[indexA]
+	users[indexA].LastPresenter, users[indexB].LastPresenter = users[indexB].LastPresenter, users[indexA].LastPresenter
+}
@@ -0,0 +1,319 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_projectTurns(t *testing.T) {
+	alice := &entity.User{ID: 1, SlackUserID: "U1"}
+	bob := &entity.User{ID: 2, SlackUserID: "U2", LastPresenter: true}
+	carol := &entity.User{ID: 3, SlackUserID: "U3"}
+	users := []*entity.User{alice, bob, carol}
+
+	weekdays := &entity.Scheduler{NotificationTime: "09:00", ActiveDays: []int{1, 2, 3, 4, 5}, IsEnabled: true}
+	monday := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC) // After the Monday reminder
+
+	day := func(d int) time.Time {
+		return time.Date(2024, 1, d, 9, 0, 0, 0, time.UTC)
+	}
+
+	type args struct {
+		scheduler *entity.Scheduler
+		users     []*entity.User
+		holidays  []*entity.Holiday
+		absences  []*entity.Availability
+		swaps     []*entity.Swap
+		count     int
+	}
+	tests := []struct {
+		name      string
+		args      args
+		buildMock func(m allMocks)
+		want      []entity.ScheduledTurn
+		wantErr   bool
+	}{
+		{
+			name: "Should follow the rotation order after the current presenter",
+			args: args{scheduler: weekdays, users: users, count: 4},
+			want: []entity.ScheduledTurn{
+				{Date: day(2), Presenter: carol},
+				{Date: day(3), Presenter: alice},
+				{Date: day(4), Presenter: bob},
+				{Date: day(5), Presenter: carol},
+			},
+		},
+		{
+			name: "Should skip weekends and holidays",
+			args: args{
+				scheduler: weekdays,
+				users:     users,
+				holidays:  []*entity.Holiday{{StartDate: dateOf(day(4)), EndDate: dateOf(day(5))}},
+				count:     3,
+			},
+			want: []entity.ScheduledTurn{
+				{Date: day(2), Presenter: carol},
+				{Date: day(3), Presenter: alice},
+				{Date: day(8), Presenter: bob},
+			},
+		},
+		{
+			name: "Should skip members away on the date",
+			args: args{
+				scheduler: weekdays,
+				users:     users,
+				absences:  []*entity.Availability{{SlackUserID: "U3", StartDate: dateOf(day(2)), EndDate: dateOf(day(2))}},
+				count:     3,
+			},
+			want: []entity.ScheduledTurn{
+				{Date: day(2), Presenter: alice},
+				{Date: day(3), Presenter: bob},
+				{Date: day(4), Presenter: carol},
+			},
+		},
+		{
+			name: "Should apply dated swaps on their day",
+			args: args{
+				scheduler: weekdays,
+				users:     users,
+				swaps:     []*entity.Swap{{UserAID: 1, UserBID: 2, SwapDate: dateOf(day(3))}},
+				count:     4,
+			},
+			want: []entity.ScheduledTurn{
+				{Date: day(2), Presenter: carol},
+				{Date: day(3), Presenter: bob},
+				{Date: day(4), Presenter: alice},
+				{Date: day(5), Presenter: carol},
+			},
+		},
+		{
+			name: "Should pick backups after the presenter",
+			args: args{
+				scheduler: &entity.Scheduler{NotificationTime: "09:00", ActiveDays: []int{1, 2, 3, 4, 5}, IsEnabled: true, Assignees: 2},
+				users:     users,
+				absences:  []*entity.Availability{{SlackUserID: "U1", StartDate: dateOf(day(3)), EndDate: dateOf(day(3))}},
+				count:     2,
+			},
+			want: []entity.ScheduledTurn{
+				{Date: day(2), Presenter: carol, Backups: []*entity.User{alice}},
+				{Date: day(3), Presenter: bob, Backups: []*entity.User{carol}},
+			},
+		},
+		{
+			name: "Should have no presenter when everyone is away",
+			args: args{
+				scheduler: weekdays,
+				users:     []*entity.User{carol},
+				absences:  []*entity.Availability{{SlackUserID: "U3", StartDate: dateOf(day(2)), EndDate: dateOf(day(2))}},
+				count:     2,
+			},
+			want: []entity.ScheduledTurn{
+				{Date: day(2)},
+				{Date: day(3), Presenter: carol},
+			},
+		},
+		{
+			name: "Should list dates without presenter for an empty rotation",
+			args: args{scheduler: weekdays, count: 1},
+			want: []entity.ScheduledTurn{
+				{Date: day(2)},
+			},
+		},
+		{
+			name: "Should give the turns to who has taken the fewest with the fair strategy",
+			args: args{
+				scheduler: &entity.Scheduler{RotationID: 1, NotificationTime: "09:00", ActiveDays: []int{1, 2, 3, 4, 5}, IsEnabled: true, Strategy: domain.StrategyFair},
+				users:     users,
+				count:     4,
+			},
+			buildMock: func(m allMocks) {
+				m.mockHistoryRepo.EXPECT().GetTurnStats(int64(1)).Return([]*entity.TurnStats{
+					{UserID: 1, Turns: 3, LastTurnAt: day(1).AddDate(0, 0, -3)},
+					{UserID: 2, Turns: 1, LastTurnAt: day(1)},
+				}, nil).Times(1)
+			},
+			want: []entity.ScheduledTurn{
+				{Date: day(2), Presenter: carol},
+				{Date: day(3), Presenter: bob},
+				{Date: day(4), Presenter: carol},
+				{Date: day(5), Presenter: bob},
+			},
+		},
+		{
+			name: "Should return error when the turn stats cannot be read",
+			args: args{
+				scheduler: &entity.Scheduler{RotationID: 1, NotificationTime: "09:00", ActiveDays: []int{1, 2, 3, 4, 5}, IsEnabled: true, Strategy: domain.StrategyFair},
+				users:     users,
+				count:     1,
+			},
+			buildMock: func(m allMocks) {
+				m.mockHistoryRepo.EXPECT().GetTurnStats(int64(1)).Return(nil, assert.AnError).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should have no turns while paused",
+			args: args{
+				scheduler: &entity.Scheduler{NotificationTime: "09:00", ActiveDays: []int{1, 2, 3, 4, 5}},
+				users:     users,
+				count:     3,
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			if tt.buildMock != nil {
+				tt.buildMock(m)
+			}
+
+			got, err := projectTurns(m.mockDataManager, tt.args.scheduler, tt.args.users, tt.args.holidays, tt.args.absences, tt.args.swaps, monday, tt.args.count)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, projectedIDs(tt.want), projectedIDs(got))
+			// The members of the caller are left as they were
+			assert.True(t, bob.LastPresenter)
+			assert.False(t, alice.LastPresenter || carol.LastPresenter)
+		})
+	}
+
+	t.Run("Should project the order the shuffled strategy draws", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		scheduler := &entity.Scheduler{RotationID: 1, NotificationTime: "09:00", ActiveDays: []int{1, 2, 3, 4, 5}, IsEnabled: true, Strategy: domain.StrategyShuffled}
+		last := &entity.User{ID: 3, SlackUserID: "U3", LastPresenter: true}
+		members := []*entity.User{{ID: 1, SlackUserID: "U1"}, {ID: 2, SlackUserID: "U2"}, last}
+
+		m.mockHistoryRepo.EXPECT().GetTurnStats(int64(1)).Return([]*entity.TurnStats{{UserID: 3, Turns: 4}}, nil).Times(1)
+
+		got, err := projectTurns(m.mockDataManager, scheduler, members, nil, nil, nil, monday, 6)
+		require.NoError(t, err)
+
+		// The first cycle is drawn from the turns taken so far, the second one after three more
+		first := shuffleCycle(members, 1, 4)
+		second := shuffleCycle([]*entity.User{
+			{ID: first[0].ID}, {ID: first[1].ID}, {ID: first[2].ID, LastPresenter: true},
+		}, 1, 7)
+
+		var want []int64
+		for _, user := range append(first, second...) {
+			want = append(want, user.ID)
+		}
+
+		var presenters []int64
+		for _, turn := range got {
+			presenters = append(presenters, turn.Presenter.ID)
+		}
+		assert.Equal(t, want, presenters)
+	})
+}
+
+// projectedTurnIDs is a scheduled turn with the IDs of its members, the projection working on
+// copies of the members
+type projectedTurnIDs struct {
+	Date      time.Time
+	Presenter int64
+	Backups   []int64
+}
+
+func projectedIDs(turns []entity.ScheduledTurn) []projectedTurnIDs {
+	var ids []projectedTurnIDs
+	for _, turn := range turns {
+		turnIDs := projectedTurnIDs{Date: turn.Date}
+		if turn.Presenter != nil {
+			turnIDs.Presenter = turn.Presenter.ID
+		}
+		for _, backup := range turn.Backups {
+			turnIDs.Backups = append(turnIDs.Backups, backup.ID)
+		}
+		ids = append(ids, turnIDs)
+	}
+	return ids
+}
+
+func Test_rotationService_GetUserRotations(t *testing.T) {
+	m, ctrl := newServiceTestMock(t)
+	defer ctrl.Finish()
+
+	s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+	active := &entity.Channel{ID: 1, SlackChannelID: "C1", IsActive: true}
+	archived := &entity.Channel{ID: 2, SlackChannelID: "C2"}
+	rotation := &entity.Rotation{ID: 1, ChannelID: 1, IsPrimary: true}
+	scheduler := &entity.Scheduler{RotationID: 1, NotificationTime: "09:00", ActiveDays: []int{1, 2, 3, 4, 5, 6, 7}, IsEnabled: true}
+
+	member := &entity.User{ID: 1, ChannelID: 1, RotationID: 1, SlackUserID: "U1"}
+	presenter := &entity.User{ID: 2, ChannelID: 1, RotationID: 1, SlackUserID: "U2", LastPresenter: true}
+
+	gomock.InOrder(
+		m.mockUserRepo.EXPECT().
+			GetBySlackUserID("U1").
+			Return([]*entity.User{member, {ID: 9, ChannelID: 2, RotationID: 2, SlackUserID: "U1"}}, nil).Times(1),
+		m.mockChannelRepo.EXPECT().GetByID(int64(1)).Return(active, nil).Times(1),
+		m.mockRotationRepo.EXPECT().GetByID(int64(1)).Return(rotation, nil).Times(1),
+		m.mockSchedulerRepo.EXPECT().GetByRotationID(int64(1)).Return(scheduler, nil).Times(1),
+		m.mockUserRepo.EXPECT().GetActiveUsersByRotation(int64(1)).Return([]*entity.User{member, presenter}, nil).Times(1),
+		m.mockHolidayRepo.EXPECT().GetByChannelID(int64(1)).Return(nil, nil).Times(1),
+		m.mockAvailabilityRepo.EXPECT().GetUpcomingByChannel(int64(1), gomock.Any()).Return(nil, nil).Times(1),
+		m.mockSwapRepo.EXPECT().GetPendingByRotation(int64(1), gomock.Any()).Return(nil, nil).Times(1),
+		m.mockChannelRepo.EXPECT().GetByID(int64(2)).Return(archived, nil).Times(1),
+	)
+
+	got, err := s.GetUserRotations("U1")
+
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, active, got[0].Channel)
+	assert.Equal(t, member, got[0].Member)
+	assert.Equal(t, presenter, got[0].OnDuty)
+	assert.False(t, got[0].AwayToday)
+
+	// Every day is active and the member follows the presenter, so the next turn is within a day
+	require.False(t, got[0].NextTurn.IsZero())
+	assert.WithinDuration(t, time.Now(), got[0].NextTurn, 24*time.Hour)
+}
+
+func Test_rotationService_GetSchedule(t *testing.T) {
+	m, ctrl := newServiceTestMock(t)
+	defer ctrl.Finish()
+
+	s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+	scheduler := &entity.Scheduler{ChannelID: 1, RotationID: 1, NotificationTime: "09:00", ActiveDays: []int{1, 2, 3, 4, 5, 6, 7}, IsEnabled: true}
+	alice := &entity.User{ID: 1, SlackUserID: "U1", LastPresenter: true}
+	bob := &entity.User{ID: 2, SlackUserID: "U2"}
+
+	gomock.InOrder(
+		m.mockSchedulerRepo.EXPECT().GetByRotationID(int64(1)).Return(scheduler, nil).Times(1),
+		m.mockUserRepo.EXPECT().GetActiveUsersByRotation(int64(1)).Return([]*entity.User{alice, bob}, nil).Times(1),
+		m.mockHolidayRepo.EXPECT().GetByChannelID(int64(1)).Return(nil, nil).Times(1),
+		m.mockAvailabilityRepo.EXPECT().GetUpcomingByChannel(int64(1), gomock.Any()).Return(nil, nil).Times(1),
+		m.mockSwapRepo.EXPECT().GetPendingByRotation(int64(1), gomock.Any()).Return(nil, nil).Times(1),
+		m.mockSchedulerRepo.EXPECT().GetByRotationID(int64(2)).Return(&entity.Scheduler{RotationID: 2}, nil).Times(1),
+	)
+
+	got, err := s.GetSchedule(1, 3)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, []int64{bob.ID, alice.ID, bob.ID}, []int64{got[0].Presenter.ID, got[1].Presenter.ID, got[2].Presenter.ID})
+
+	// Paused rotations have nothing scheduled
+	got, err = s.GetSchedule(2, 3)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
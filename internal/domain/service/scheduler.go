@@ -2,180 +2,422 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/diegoclair/slack-rotation-bot/internal/domain"
 	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
 	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	slackcmd "github.com/diegoclair/slack-rotation-bot/internal/domain/slack"
 	"github.com/slack-go/slack"
 )
 
+const (
+	// defaultGracePeriod is how late a missed reminder can still be sent
+	defaultGracePeriod = 1 * time.Hour
+	// delayedAfter is how late a reminder is marked as delayed
+	delayedAfter = 1 * time.Minute
+	// resyncInterval is how often every rotation is loaded again from the database, which picks
+	// up the changes made through other instances of the bot
+	resyncInterval = 1 * time.Hour
+	// defaultWorkers is how many reminders are sent at the same time
+	defaultWorkers = 8
+	// defaultSendInterval spaces the reminders due at the same time to stay within the Slack
+	// rate limits, which allow bursts but not thousands of messages at once
+	defaultSendInterval = 50 * time.Millisecond
+	// sendTimeout is how long a reminder can take to be sent before it is given up
+	sendTimeout = 30 * time.Second
+)
+
 type NotificationEvent struct {
 	ChannelID int64
 	Time      time.Time
 }
 
+// notificationJob is a reminder handed to the workers
+type notificationJob struct {
+	rotationID int64
+	channelID  int64
+	occurrence time.Time
+	// dueAt is when the job was due, occurrence for a reminder and the day before for a heads-up
+	dueAt time.Time
+	// headsUp is set for the DM sent the day before occurrence instead of the reminder
+	headsUp bool
+}
+
 type scheduler struct {
-	dm            contract.DataManager
-	slackClient   contract.SlackClient
-	configChanged chan struct{}
-	stopChan      chan struct{}
-	running       bool
+	dm           contract.DataManager
+	slackClients contract.SlackClientFactory
+	clock        contract.Clock
+	gracePeriod  time.Duration
+	workers      int
+	sendInterval time.Duration
+
+	// queue, headsUps and resyncAt are only used by the main loop. headsUps holds the time of
+	// the DMs sent the day before a reminder.
+	queue    *notificationQueue
+	headsUps *notificationQueue
+	resyncAt time.Time
+
+	// mu guards changedChannels, the channels to load again on the next loop
+	mu              sync.Mutex
+	changedChannels map[int64]bool
+	configChanged   chan struct{}
+
+	jobs chan notificationJob
+	// dispatching counts the goroutines handing jobs to the workers, sending the workers, which
+	// return once jobs is closed
+	dispatching sync.WaitGroup
+	sending     sync.WaitGroup
+	// loopDone is closed once the main loop returned after stopChan was closed
+	loopDone chan struct{}
+	stopChan chan struct{}
+	running  bool
 }
 
-func newScheduler(dm contract.DataManager, slackClient contract.SlackClient) *scheduler {
+func newScheduler(dm contract.DataManager, slackClients contract.SlackClientFactory, clock contract.Clock) *scheduler {
 	return &scheduler{
-		dm:            dm,
-		slackClient:   slackClient,
-		configChanged: make(chan struct{}, 1),
-		stopChan:      make(chan struct{}),
-		running:       false,
+		dm:              dm,
+		slackClients:    slackClients,
+		clock:           clock,
+		gracePeriod:     defaultGracePeriod,
+		workers:         defaultWorkers,
+		sendInterval:    defaultSendInterval,
+		queue:           newNotificationQueue(),
+		headsUps:        newNotificationQueue(),
+		changedChannels: make(map[int64]bool),
+		configChanged:   make(chan struct{}, 1),
+		loopDone:        make(chan struct{}),
+		stopChan:        make(chan struct{}),
+		running:         false,
 	}
 }
 
+// SetGracePeriod sets how late a reminder can still be sent, e.g. after the bot was down at the
+// notification time. Zero only sends the reminders missed by less than a minute.
+func (s *scheduler) SetGracePeriod(gracePeriod time.Duration) {
+	s.gracePeriod = gracePeriod
+}
+
+// SetWorkers sets how many reminders are sent at the same time, it must be called before Start
+func (s *scheduler) SetWorkers(workers int) {
+	if workers > 0 {
+		s.workers = workers
+	}
+}
+
+// SetSendInterval sets how long to wait between two reminders due at the same time, zero sends
+// them all at once
+func (s *scheduler) SetSendInterval(interval time.Duration) {
+	s.sendInterval = interval
+}
+
 func (s *scheduler) Start() {
 	if s.running {
 		return
 	}
 	s.running = true
 	log.Println("Scheduler starting...")
-	go s.mainLoop()
+	s.startWorkers()
+	go func() {
+		defer close(s.loopDone)
+		s.mainLoop()
+	}()
 }
 
+// Stop stops the main loop and waits for the workers to finish the reminders they are
+// sending. The reminders not picked up yet are dropped, they are caught up on the next start
+// within the grace period.
 func (s *scheduler) Stop() {
 	if !s.running {
 		return
 	}
 	log.Println("Scheduler stopping...")
 	close(s.stopChan)
+	<-s.loopDone
+	s.stopWorkers()
 	s.running = false
 }
 
-func (s *scheduler) NotifyConfigChange() {
+// NotifyConfigChange tells the scheduler the rotations of the channel changed, so only their
+// next notification is calculated again
+func (s *scheduler) NotifyConfigChange(channelID int64) {
+	s.mu.Lock()
+	s.changedChannels[channelID] = true
+	s.mu.Unlock()
+
 	// Non-blocking send to config change channel
 	select {
 	case s.configChanged <- struct{}{}:
 	default:
-		// Channel is full, scheduler will recalculate eventually
+		// Channel is full, the main loop hasn't picked up the previous change yet
 	}
 }
 
-func (s *scheduler) mainLoop() {
-	for {
-		nextTime, channelIDs := s.findNextNotification()
+// startWorkers starts the goroutines sending the reminders, which run until the scheduler stops
+func (s *scheduler) startWorkers() {
+	s.jobs = make(chan notificationJob, s.workers)
+	for range s.workers {
+		go s.worker()
+	}
 
-		if len(channelIDs) == 0 {
-			// No active non-paused channels - wait 1 hour and check again
-			log.Println("No active channels found, waiting 1 hour...")
-			timer := time.NewTimer(1 * time.Hour)
-			select {
-			case <-timer.C:
-				continue
-			case <-s.configChanged:
-				timer.Stop()
-				continue
-			case <-s.stopChan:
-				timer.Stop()
-				return
-			}
-		}
+	s.sending.Add(s.workers)
+}
+
+// stopWorkers waits for the jobs being handed to the workers, then for the workers to be done
+// with them
+func (s *scheduler) stopWorkers() {
+	s.dispatching.Wait()
+	close(s.jobs)
+	s.sending.Wait()
+}
 
-		log.Printf("Next notification at %s for %d channels", nextTime.Format("2006-01-02 15:04:05 UTC"), len(channelIDs))
+func (s *scheduler) worker() {
+	defer s.sending.Done()
 
-		waitDuration := time.Until(nextTime)
-		if waitDuration <= 0 {
-			// Time has already passed, send notifications immediately
-			s.sendNotifications(channelIDs)
-			// Wait 1 minute to prevent re-processing the same time
-			log.Println("Sent notifications, waiting 1 minute to prevent re-processing...")
-			time.Sleep(1 * time.Minute)
+	for job := range s.jobs {
+		select {
+		case <-s.stopChan:
+			log.Printf("Scheduler stopping, dropping the notification of rotation %d", job.rotationID)
 			continue
+		default:
 		}
 
-		timer := time.NewTimer(waitDuration)
+		s.notify(job)
+	}
+}
 
-		select {
-		case <-timer.C:
-			// Time to send notifications
-			s.sendNotifications(channelIDs)
-			// Wait 1 minute to prevent re-processing the same time
-			log.Println("Sent notifications, waiting 1 minute to prevent re-processing...")
-			time.Sleep(1 * time.Minute)
-
-		case <-s.configChanged:
-			// Configuration changed, recalculate
-			timer.Stop()
-			log.Println("Configuration changed, recalculating schedule...")
-			continue
+func (s *scheduler) mainLoop() {
+	for {
+		// One time for the whole iteration, so a reminder due while it runs isn't skipped
+		now := s.clock.Now()
 
-		case <-s.stopChan:
-			timer.Stop()
+		// Reminders go out before any reload, which would calculate the time after now
+		s.sendDue(now)
+		s.sendDueHeadsUps(now)
+
+		if !now.Before(s.resyncAt) {
+			s.reloadAll(now)
+		}
+		s.applyConfigChanges(now)
+
+		if !s.wait(now) {
 			return
 		}
 	}
 }
 
-func (s *scheduler) findNextNotification() (time.Time, []int64) {
+// wait blocks until the next reminder is due, the next resync, a config change or the
+// scheduler stops, reporting false when it stopped
+func (s *scheduler) wait(now time.Time) bool {
+	resync := s.clock.NewTimer(s.resyncAt.Sub(now))
+	defer resync.Stop()
+
+	if next := s.queue.peek(); next != nil {
+		log.Printf("Next notification at %s, %d rotations scheduled", next.next.UTC().Format("2006-01-02 15:04:05 UTC"), s.queue.Len())
+	} else {
+		// No active non-paused rotations - check again on the next resync
+		log.Printf("No active rotations found, checking again at %s", s.resyncAt.UTC().Format("2006-01-02 15:04:05 UTC"))
+	}
+
+	var due <-chan time.Time
+	if next := s.nextDue(); !next.IsZero() {
+		timer := s.clock.NewTimer(next.Sub(now))
+		defer timer.Stop()
+		due = timer.C()
+	}
+
+	select {
+	case <-due:
+	case <-resync.C():
+	case <-s.configChanged:
+		log.Println("Configuration changed, recalculating schedule...")
+	case <-s.stopChan:
+		return false
+	}
+
+	return true
+}
+
+// nextDue returns when the next reminder or heads-up is due, the zero time when none is queued
+func (s *scheduler) nextDue() time.Time {
+	var next time.Time
+	for _, queue := range []*notificationQueue{s.queue, s.headsUps} {
+		if item := queue.peek(); item != nil && (next.IsZero() || item.next.Before(next)) {
+			next = item.next
+		}
+	}
+	return next
+}
+
+// reloadAll rebuilds the queue from every enabled scheduler. The queue is kept as it is when
+// the schedulers cannot be loaded.
+func (s *scheduler) reloadAll(now time.Time) {
+	s.resyncAt = now.Add(resyncInterval)
+
 	schedulers, err := s.dm.Scheduler().GetEnabled()
 	if err != nil {
-		log.Printf("Error getting active channels: %v", err)
-		return time.Time{}, nil
+		log.Printf("Error getting active rotations: %v", err)
+		return
 	}
 
-	if len(schedulers) == 0 {
-		return time.Time{}, nil
+	queue := newNotificationQueue()
+	headsUps := newNotificationQueue()
+	holidaysByChannel := make(map[int64][]*entity.Holiday)
+	failedChannels := make(map[int64]bool)
+
+	for _, scheduler := range schedulers {
+		if failedChannels[scheduler.ChannelID] {
+			continue
+		}
+
+		holidays, ok := holidaysByChannel[scheduler.ChannelID]
+		if !ok {
+			holidays, err = s.dm.Holiday().GetByChannelID(scheduler.ChannelID)
+			if err != nil {
+				// Skip the channel rather than risk notifying on a holiday
+				log.Printf("Error getting holidays for channel %d: %v", scheduler.ChannelID, err)
+				failedChannels[scheduler.ChannelID] = true
+				continue
+			}
+			holidaysByChannel[scheduler.ChannelID] = holidays
+		}
+
+		if item := s.schedule(scheduler, holidays, now); item != nil {
+			queue.set(item)
+		}
+		if item := scheduleHeadsUp(scheduler, holidays, now); item != nil {
+			headsUps.set(item)
+		}
 	}
 
-	now := time.Now().UTC()
+	s.queue = queue
+	s.headsUps = headsUps
+}
+
+// applyConfigChanges loads again the channels reported by NotifyConfigChange
+func (s *scheduler) applyConfigChanges(now time.Time) {
+	s.mu.Lock()
+	changed := s.changedChannels
+	s.changedChannels = make(map[int64]bool)
+	s.mu.Unlock()
+
+	for channelID := range changed {
+		s.reloadChannel(channelID, now)
+	}
+}
 
-	type channelNext struct {
-		channelID int64
-		nextTime  time.Time
+// reloadChannel replaces the rotations of the channel in the queue with the enabled ones
+func (s *scheduler) reloadChannel(channelID int64, now time.Time) {
+	schedulers, err := s.dm.Scheduler().GetEnabledByChannelID(channelID)
+	if err != nil {
+		log.Printf("Error getting active rotations of channel %d: %v", channelID, err)
+		return
 	}
 
-	var allNext []channelNext
+	var holidays []*entity.Holiday
+	if len(schedulers) > 0 {
+		holidays, err = s.dm.Holiday().GetByChannelID(channelID)
+		if err != nil {
+			// Skip the channel rather than risk notifying on a holiday
+			log.Printf("Error getting holidays for channel %d: %v", channelID, err)
+			schedulers = nil
+		}
+	}
 
+	// Scheduled before the old entries are removed, which tell the occurrences already sent
+	var items, headsUps []*queuedRotation
 	for _, scheduler := range schedulers {
-		nextTime := s.calculateNextForScheduler(scheduler, now)
-		if !nextTime.IsZero() {
-			allNext = append(allNext, channelNext{
-				channelID: scheduler.ChannelID,
-				nextTime:  nextTime,
-			})
+		if item := s.schedule(scheduler, holidays, now); item != nil {
+			items = append(items, item)
+		}
+		if item := scheduleHeadsUp(scheduler, holidays, now); item != nil {
+			headsUps = append(headsUps, item)
 		}
 	}
 
-	if len(allNext) == 0 {
-		return time.Time{}, nil
+	for _, rotationID := range s.queue.channelRotations(channelID) {
+		s.queue.remove(rotationID)
+	}
+	for _, item := range items {
+		s.queue.set(item)
 	}
 
-	// Sort by time
-	sort.Slice(allNext, func(i, j int) bool {
-		return allNext[i].nextTime.Before(allNext[j].nextTime)
-	})
+	for _, rotationID := range s.headsUps.channelRotations(channelID) {
+		s.headsUps.remove(rotationID)
+	}
+	for _, item := range headsUps {
+		s.headsUps.set(item)
+	}
+}
+
+// schedule returns the queue entry of the rotation with its next notification time, or nil when
+// it has none. An occurrence already handed to the workers is not scheduled again.
+func (s *scheduler) schedule(scheduler *entity.Scheduler, holidays []*entity.Holiday, now time.Time) *queuedRotation {
+	item := &queuedRotation{config: scheduler, holidays: holidays}
+	if queued := s.queue.get(scheduler.RotationID); queued != nil {
+		item.sent = queued.sent
+	}
+
+	// A reminder missed while the bot was down goes first, then the regular schedule
+	item.next = missedOccurrence(scheduler, holidays, now, s.gracePeriod)
+	if item.next.IsZero() || !item.next.After(item.sent) {
+		item.next = s.calculateNextForScheduler(scheduler, holidays, now)
+	}
+
+	if item.next.IsZero() {
+		return nil
+	}
+
+	return item
+}
 
-	// Get earliest time
-	earliestTime := allNext[0].nextTime
+// sendDue sends the reminders due by now and queues the following reminder of their rotations
+func (s *scheduler) sendDue(now time.Time) {
+	for due := s.queue.popDue(now); len(due) > 0; due = s.queue.popDue(now) {
+		occurrence := due[0].next
 
-	// Collect all channels at the earliest time
-	var channelIDs []int64
-	for _, cn := range allNext {
-		if cn.nextTime.Equal(earliestTime) {
-			channelIDs = append(channelIDs, cn.channelID)
-		} else {
-			break // Since it's sorted, we can break early
+		for _, item := range due {
+			item.sent = occurrence
+			item.next = nextOccurrence(item.config, item.holidays, occurrence)
+			if !item.next.IsZero() {
+				s.queue.set(item)
+			}
+		}
+
+		s.sendNotifications(occurrence, due)
+	}
+}
+
+// sendDueHeadsUps sends the heads-ups due by now and queues the following heads-up of their
+// rotations
+func (s *scheduler) sendDueHeadsUps(now time.Time) {
+	for due := s.headsUps.popDue(now); len(due) > 0; due = s.headsUps.popDue(now) {
+		dueAt := due[0].next
+
+		for _, item := range due {
+			item.next = nextHeadsUp(item.config, item.holidays, dueAt)
+			if !item.next.IsZero() {
+				s.headsUps.set(item)
+			}
 		}
+
+		s.sendHeadsUps(dueAt, due)
 	}
+}
 
-	return earliestTime, channelIDs
+func (s *scheduler) calculateNextForScheduler(scheduler *entity.Scheduler, holidays []*entity.Holiday, now time.Time) time.Time {
+	return nextOccurrence(scheduler, holidays, now)
 }
 
-func (s *scheduler) calculateNextForScheduler(scheduler *entity.Scheduler, now time.Time) time.Time {
+// nextOccurrence returns the first notification time of the scheduler after now, skipping
+// holidays, or the zero time when there is none
+func nextOccurrence(scheduler *entity.Scheduler, holidays []*entity.Holiday, now time.Time) time.Time {
 	// Parse notification time
 	parts := strings.Split(scheduler.NotificationTime, ":")
 	if len(parts) != 2 {
@@ -207,51 +449,211 @@ func (s *scheduler) calculateNextForScheduler(scheduler *entity.Scheduler, now t
 		activeDaysMap[day] = true
 	}
 
-	// Try today first
-	today := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, time.UTC)
-	todayWeekday := int(today.Weekday())
-	if todayWeekday == 0 { // Sunday = 0 in Go, but we want 7 for ISO 8601
-		todayWeekday = 7
+	// Work in the channel's wall-clock time so DST changes keep the configured HH:MM
+	loc := scheduler.GetLocation()
+	local := now.In(loc)
+
+	// Try today first, then the following days. Each candidate is rebuilt with
+	// time.Date so the UTC offset is resolved for that specific day.
+	// Holidays can span several weeks, so look ahead up to a year plus a week.
+	for i := 0; i <= maxDateRangeDays+7; i++ {
+		candidate := time.Date(local.Year(), local.Month(), local.Day()+i, hour, minute, 0, 0, loc)
+		weekday := int(candidate.Weekday())
+		if weekday == 0 { // Sunday = 0 in Go, but we want 7 for ISO 8601
+			weekday = 7
+		}
+
+		// Only active days whose time hasn't passed yet and that aren't holidays
+		if activeDaysMap[weekday] && candidate.After(now) && !isHoliday(holidays, candidate) {
+			return candidate
+		}
+	}
+
+	// Only reachable when every active day in the look-ahead window is a holiday
+	log.Printf("Could not find next notification time for scheduler %d", scheduler.ID)
+	return time.Time{}
+}
+
+// missedOccurrence returns the last notification time of the scheduler within the grace period
+// before now that wasn't notified, or the zero time when there is none. Schedulers that never
+// notified have nothing to catch up, and a day that already had its reminder gets no other,
+// e.g. when the notification time is moved earlier after the reminder was sent.
+func missedOccurrence(scheduler *entity.Scheduler, holidays []*entity.Holiday, now time.Time, gracePeriod time.Duration) time.Time {
+	if gracePeriod <= 0 || scheduler.LastNotifiedAt.IsZero() {
+		return time.Time{}
+	}
+
+	var missed time.Time
+	for next := nextOccurrence(scheduler, holidays, now.Add(-gracePeriod)); !next.IsZero() && !next.After(now); next = nextOccurrence(scheduler, holidays, next) {
+		missed = next
 	}
 
-	// If today is an active day and the time hasn't passed yet
-	if activeDaysMap[todayWeekday] && today.After(now) {
-		return today
+	if missed.IsZero() {
+		return time.Time{}
 	}
 
-	// Find next active day
-	for i := 1; i <= 7; i++ {
-		nextDay := today.AddDate(0, 0, i)
-		nextWeekday := int(nextDay.Weekday())
-		if nextWeekday == 0 {
-			nextWeekday = 7
+	loc := scheduler.GetLocation()
+	if !dateOf(missed.In(loc)).After(dateOf(scheduler.LastNotifiedAt.In(loc))) {
+		return time.Time{}
+	}
+
+	return missed
+}
+
+// sendNotifications hands the reminders of the rotations due at occurrence to the workers,
+// spaced by the send interval
+func (s *scheduler) sendNotifications(occurrence time.Time, rotations []*queuedRotation) {
+	log.Printf("Sending notifications to %d rotations", len(rotations))
+
+	jobs := make([]notificationJob, len(rotations))
+	for i, rotation := range rotations {
+		jobs[i] = notificationJob{
+			rotationID: rotation.config.RotationID,
+			channelID:  rotation.config.ChannelID,
+			occurrence: occurrence,
+			dueAt:      occurrence,
 		}
+	}
+
+	s.dispatch(jobs)
+}
 
-		if activeDaysMap[nextWeekday] {
-			return nextDay
+// sendHeadsUps hands the heads-ups of the rotations due at dueAt to the workers, each for the
+// reminder of the following day
+func (s *scheduler) sendHeadsUps(dueAt time.Time, rotations []*queuedRotation) {
+	jobs := make([]notificationJob, 0, len(rotations))
+	for _, rotation := range rotations {
+		occurrence := nextOccurrence(rotation.config, rotation.holidays, dueAt)
+		if occurrence.IsZero() {
+			continue
 		}
+
+		jobs = append(jobs, notificationJob{
+			rotationID: rotation.config.RotationID,
+			channelID:  rotation.config.ChannelID,
+			occurrence: occurrence,
+			dueAt:      dueAt,
+			headsUp:    true,
+		})
 	}
 
-	// Should never reach here if there's at least one active day
-	log.Printf("Could not find next notification time for scheduler %d", scheduler.ID)
-	return time.Time{}
+	s.dispatch(jobs)
 }
 
-func (s *scheduler) sendNotifications(channelIDs []int64) {
-	log.Printf("Sending notifications to %d channels", len(channelIDs))
+// dispatch hands the jobs to the workers in the background, spaced by the send interval, so
+// the main loop keeps handling the timers and config changes meanwhile
+func (s *scheduler) dispatch(jobs []notificationJob) {
+	s.dispatching.Add(1)
+	go func() {
+		defer s.dispatching.Done()
+
+		for i, job := range jobs {
+			if i > 0 && s.sendInterval > 0 {
+				timer := s.clock.NewTimer(s.sendInterval)
+				select {
+				case <-timer.C():
+				case <-s.stopChan:
+					timer.Stop()
+					return
+				}
+			}
 
-	for _, channelID := range channelIDs {
-		go func(cID int64) {
-			if err := s.sendNotificationToChannel(cID); err != nil {
-				log.Printf("Failed to send notification to channel %d: %v", cID, err)
+			select {
+			case s.jobs <- job:
+			case <-s.stopChan:
+				return
 			}
-		}(channelID)
+		}
+	}()
+}
+
+// notify sends the reminder of the job unless another instance of the bot already did, giving
+// up after sendTimeout. How late the job is is checked when it is sent, as the jobs due at the
+// same time go out one after the other: a reminder sent late is marked as delayed and the jobs
+// later than the grace period are dropped.
+func (s *scheduler) notify(job notificationJob) {
+	late := s.clock.Now().Sub(job.dueAt)
+	if late > max(s.gracePeriod, delayedAfter) {
+		log.Printf("Skipping notification of rotation %d due at %s, %s late is past the grace period", job.rotationID, job.dueAt.UTC().Format("2006-01-02 15:04:05 UTC"), late.Round(time.Second))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+
+	if job.headsUp {
+		if err := s.sendHeadsUp(ctx, job.rotationID, job.occurrence); err != nil {
+			log.Printf("Failed to send heads-up of rotation %d: %v", job.rotationID, err)
+		}
+		return
+	}
+
+	claimed, err := s.claimOccurrence(job.rotationID, job.occurrence)
+	if err != nil {
+		log.Printf("Failed to claim notification of rotation %d: %v", job.rotationID, err)
+		return
+	}
+
+	if !claimed {
+		log.Printf("Rotation %d is no longer due or was already notified for %s, skipping", job.rotationID, job.occurrence.UTC().Format("2006-01-02 15:04:05 UTC"))
+		// The config may have been changed through another instance, load it again
+		s.NotifyConfigChange(job.channelID)
+		return
+	}
+
+	if err := s.sendNotificationToRotation(ctx, job.rotationID, job.occurrence, late >= delayedAfter); err != nil {
+		log.Printf("Failed to send notification to rotation %d: %v", job.rotationID, err)
 	}
 }
 
-func (s *scheduler) sendNotificationToChannel(channelID int64) error {
+// claimOccurrence records that the reminder of the rotation due at occurrence is being sent,
+// reporting false when another instance of the bot sharing the database already sent it. The
+// config is read again because it may have been changed through another instance, which does
+// not wake this scheduler up, so a reminder picked from an outdated config is not sent.
+func (s *scheduler) claimOccurrence(rotationID int64, occurrence time.Time) (bool, error) {
+	schedulerConfig, err := s.dm.Scheduler().GetByRotationID(rotationID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get scheduler config: %w", err)
+	}
+
+	if schedulerConfig == nil || !schedulerConfig.IsEnabled {
+		return false, nil
+	}
+
+	holidays, err := s.dm.Holiday().GetByChannelID(schedulerConfig.ChannelID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get holidays: %w", err)
+	}
+
+	if !nextOccurrence(schedulerConfig, holidays, occurrence.Add(-time.Nanosecond)).Equal(occurrence) {
+		return false, nil
+	}
+
+	claimed, err := s.dm.Scheduler().MarkNotified(rotationID, occurrence)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark scheduler as notified: %w", err)
+	}
+
+	return claimed, nil
+}
+
+// sendNotificationToRotation posts the reminder of the rotation due at occurrence, with a
+// "(delayed)" marker in the title when it is sent after its time. The swaps and away periods of
+// the day of occurrence apply, even when the reminder is sent after midnight. ctx bounds the
+// calls to Slack.
+func (s *scheduler) sendNotificationToRotation(ctx context.Context, rotationID int64, occurrence time.Time, delayed bool) error {
+	// Get rotation info
+	rotation, err := s.dm.Rotation().GetByID(rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to get rotation: %w", err)
+	}
+
+	if rotation == nil {
+		return fmt.Errorf("rotation not found")
+	}
+
 	// Get channel info
-	channel, err := s.dm.Channel().GetByID(channelID)
+	channel, err := s.dm.Channel().GetByID(rotation.ChannelID)
 	if err != nil {
 		return fmt.Errorf("failed to get channel: %w", err)
 	}
@@ -260,29 +662,73 @@ func (s *scheduler) sendNotificationToChannel(channelID int64) error {
 		return fmt.Errorf("channel not found")
 	}
 
+	slackClient, err := s.slackClients.ForTeam(channel.SlackTeamID)
+	if err != nil {
+		return fmt.Errorf("failed to get Slack client: %w", err)
+	}
+
+	title := rotation.ReminderTitle()
+	if delayed {
+		title += " (delayed)"
+	}
+
 	// Get scheduler info for role
-	schedulerConfig, err := s.dm.Scheduler().GetByChannelID(channelID)
+	schedulerConfig, err := s.dm.Scheduler().GetByRotationID(rotationID)
 	if err != nil {
 		return fmt.Errorf("failed to get scheduler config: %w", err)
 	}
 
-	// Default role if no scheduler config
+	// Default role, timezone, strategy and assignees if no scheduler config
 	role := domain.DefaultRole
-	if schedulerConfig != nil && schedulerConfig.Role != "" {
-		role = schedulerConfig.Role
+	backupRole := domain.DefaultBackupRole
+	loc := time.UTC
+	strategy := domain.DefaultStrategy
+	assignees := 1
+	if schedulerConfig != nil {
+		if schedulerConfig.Role != "" {
+			role = schedulerConfig.Role
+		}
+		if schedulerConfig.BackupRole != "" {
+			backupRole = schedulerConfig.BackupRole
+		}
+		loc = schedulerConfig.GetLocation()
+		if schedulerConfig.Strategy != "" {
+			strategy = schedulerConfig.Strategy
+		}
+		assignees = schedulerConfig.GetAssignees()
+	}
+
+	// Apply the swaps planned for the day of the turn before picking the presenter
+	today := occurrence.In(loc)
+	if err := applyDueSwaps(s.dm, rotationID, today); err != nil {
+		log.Printf("Failed to apply swaps for rotation %d: %v", rotationID, err)
+		// Continue anyway, the presenter is picked from the current order
 	}
 
-	// Get next presenter
-	nextUser, err := s.getNextPresenter(channelID)
+	// Get next presenter and backups
+	nextUsers, err := selectAssignees(s.dm, rotationID, strategy, assignees, today)
+	if errors.Is(err, domain.ErrEveryoneAway) {
+		// Nobody can take the turn today, keep the rotation where it is
+		message := fmt.Sprintf("🤖 *%s*\n\nEveryone in the rotation is away today, so nobody was picked.", title)
+
+		_, _, err = slackClient.PostMessageContext(
+			ctx,
+			channel.SlackChannelID,
+			slack.MsgOptionText(message, false),
+			slack.MsgOptionAsUser(false),
+		)
+		return err
+	}
 	if err != nil {
 		return fmt.Errorf("failed to get next presenter: %w", err)
 	}
 
-	if nextUser == nil {
+	if len(nextUsers) == 0 {
 		// No users in rotation, send a message about it
-		message := "🤖 *Rotation Reminder*\n\nNo users found in rotation. Use `/rotation add @user` to add team members!"
+		message := fmt.Sprintf("🤖 *%s*\n\nNo users found in rotation. Use `%s add @user` to add team members!", title, rotation.CommandPrefix())
 
-		_, _, err = s.slackClient.PostMessage(
+		_, _, err = slackClient.PostMessageContext(
+			ctx,
 			channel.SlackChannelID,
 			slack.MsgOptionText(message, false),
 			slack.MsgOptionAsUser(false),
@@ -290,18 +736,25 @@ func (s *scheduler) sendNotificationToChannel(channelID int64) error {
 		return err
 	}
 
+	nextUser := nextUsers[0]
+
 	// Record the presentation
-	if err := s.recordPresentation(channelID, nextUser.ID); err != nil {
-		log.Printf("Failed to record presentation for channel %d, user %d: %v", channelID, nextUser.ID, err)
+	if err := s.recordPresentation(ctx, rotationID, nextUsers, strategy); err != nil {
+		log.Printf("Failed to record presentation for rotation %d, user %d: %v", rotationID, nextUser.ID, err)
 		// Continue anyway, better to send notification than fail completely
+	} else {
+		// Before the reminder, so paging the user group reaches the new assignees even if it fails
+		syncUserGroup(ctx, slackClient, schedulerConfig, nextUsers)
 	}
 
-	// Send notification with configurable role
-	message := fmt.Sprintf("🎯 *Rotation Reminder*\n\n%s today: <@%s>\n\nUse `/rotation next` to skip to the next person if needed.", role, nextUser.SlackUserID)
+	// Send notification with configurable role and buttons to acknowledge, skip or report being away
+	message := slackcmd.ReminderText(title, role, slackcmd.FormatAssignees(nextUsers, backupRole))
 
-	_, _, err = s.slackClient.PostMessage(
+	_, _, err = slackClient.PostMessageContext(
+		ctx,
 		channel.SlackChannelID,
 		slack.MsgOptionText(message, false),
+		slack.MsgOptionBlocks(slackcmd.ReminderBlocks(message, "", rotationID, true)...),
 		slack.MsgOptionAsUser(false),
 	)
 
@@ -310,45 +763,14 @@ func (s *scheduler) sendNotificationToChannel(channelID int64) error {
 	}
 
 	log.Printf("Notification sent to channel %s for user %s", channel.SlackChannelID, nextUser.SlackUserID)
-	return nil
-}
-
-func (s *scheduler) getNextPresenter(channelID int64) (*entity.User, error) {
-	users, err := s.dm.User().GetActiveUsersByChannel(channelID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get users: %w", err)
-	}
-
-	if len(users) == 0 {
-		return nil, nil
-	}
-
-	// Find current presenter (last_presenter = true)
-	var currentPresenterIndex = -1
-	for i, user := range users {
-		if user.LastPresenter {
-			currentPresenterIndex = i
-			break
-		}
-	}
 
-	// Calculate next presenter index
-	nextIndex := (currentPresenterIndex + 1) % len(users)
-	return users[nextIndex], nil
+	sendDirectReminders(ctx, slackClient, rotation, channel, schedulerConfig, nextUsers, role, backupRole)
+	syncTopic(ctx, s.dm, slackClient, rotation, channel, schedulerConfig, nextUsers)
+	return nil
 }
 
-func (s *scheduler) recordPresentation(channelID, userID int64) error {
-	return s.dm.WithTransaction(context.Background(), func(tx contract.DataManager) error {
-		// Clear all last_presenter flags for this channel
-		if err := tx.User().ClearLastPresenter(channelID); err != nil {
-			return fmt.Errorf("failed to clear last presenter: %w", err)
-		}
-
-		// Set the new presenter
-		if err := tx.User().SetLastPresenter(userID); err != nil {
-			return fmt.Errorf("failed to set last presenter: %w", err)
-		}
-
-		return nil
+func (s *scheduler) recordPresentation(ctx context.Context, rotationID int64, users []*entity.User, strategy string) error {
+	return s.dm.WithTransaction(ctx, func(tx contract.DataManager) error {
+		return recordTurn(tx, rotationID, users, strategy, domain.HistoryKindAutomatic, "", s.clock.Now())
 	})
 }
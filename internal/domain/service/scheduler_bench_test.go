@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/diegoclair/slack-rotation-bot/mocks"
+	"github.com/slack-go/slack"
+	"go.uber.org/mock/gomock"
+)
+
+// benchChannels is how many channels the benchmarks simulate, each with one rotation
+const benchChannels = 10000
+
+// benchOccurrence is when every simulated rotation is due, a Monday at 09:00
+var benchOccurrence = time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+// setupSchedulerBenchmark returns a scheduler over mocks holding benchChannels channels reminded
+// every day at 09:00, with one holiday each. Reminders sent are counted in posted.
+func setupSchedulerBenchmark(b *testing.B) (*scheduler, []*entity.Scheduler, *atomic.Int64) {
+	b.Helper()
+
+	ctrl := gomock.NewController(b)
+	dm := mocks.NewMockDataManager(ctrl)
+	schedulerRepo := mocks.NewMockSchedulerRepo(ctrl)
+	holidayRepo := mocks.NewMockHolidayRepo(ctrl)
+	rotationRepo := mocks.NewMockRotationRepo(ctrl)
+	channelRepo := mocks.NewMockChannelRepo(ctrl)
+	swapRepo := mocks.NewMockSwapRepo(ctrl)
+	userRepo := mocks.NewMockUserRepo(ctrl)
+	availabilityRepo := mocks.NewMockAvailabilityRepo(ctrl)
+	slackClient := mocks.NewMockSlackClient(ctrl)
+
+	dm.EXPECT().Scheduler().Return(schedulerRepo).AnyTimes()
+	dm.EXPECT().Holiday().Return(holidayRepo).AnyTimes()
+	dm.EXPECT().Rotation().Return(rotationRepo).AnyTimes()
+	dm.EXPECT().Channel().Return(channelRepo).AnyTimes()
+	dm.EXPECT().Swap().Return(swapRepo).AnyTimes()
+	dm.EXPECT().User().Return(userRepo).AnyTimes()
+	dm.EXPECT().Availability().Return(availabilityRepo).AnyTimes()
+
+	configs := make([]*entity.Scheduler, benchChannels)
+	byRotation := make(map[int64]*entity.Scheduler, benchChannels)
+	for i := range configs {
+		id := int64(i + 1)
+		configs[i] = &entity.Scheduler{
+			ID:               id,
+			ChannelID:        id,
+			RotationID:       id,
+			NotificationTime: "09:00",
+			ActiveDays:       []int{1, 2, 3, 4, 5, 6, 7},
+			IsEnabled:        true,
+			Role:             "presenter",
+		}
+		byRotation[id] = configs[i]
+	}
+	holidays := []*entity.Holiday{{
+		StartDate: benchOccurrence.AddDate(0, 1, 0),
+		EndDate:   benchOccurrence.AddDate(0, 1, 7),
+	}}
+
+	schedulerRepo.EXPECT().GetEnabled().Return(configs, nil).AnyTimes()
+	schedulerRepo.EXPECT().
+		GetEnabledByChannelID(gomock.Any()).
+		DoAndReturn(func(channelID int64) ([]*entity.Scheduler, error) {
+			return []*entity.Scheduler{byRotation[channelID]}, nil
+		}).AnyTimes()
+	schedulerRepo.EXPECT().
+		GetByRotationID(gomock.Any()).
+		DoAndReturn(func(rotationID int64) (*entity.Scheduler, error) {
+			return byRotation[rotationID], nil
+		}).AnyTimes()
+	schedulerRepo.EXPECT().MarkNotified(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	holidayRepo.EXPECT().GetByChannelID(gomock.Any()).Return(holidays, nil).AnyTimes()
+
+	rotationRepo.EXPECT().
+		GetByID(gomock.Any()).
+		DoAndReturn(func(rotationID int64) (*entity.Rotation, error) {
+			return &entity.Rotation{ID: rotationID, ChannelID: rotationID, IsPrimary: true}, nil
+		}).AnyTimes()
+	channelRepo.EXPECT().
+		GetByID(gomock.Any()).
+		DoAndReturn(func(channelID int64) (*entity.Channel, error) {
+			return &entity.Channel{ID: channelID, SlackChannelID: fmt.Sprintf("C%d", channelID)}, nil
+		}).AnyTimes()
+	swapRepo.EXPECT().GetPendingByRotation(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().
+		GetActiveUsersByRotation(gomock.Any()).
+		DoAndReturn(func(rotationID int64) ([]*entity.User, error) {
+			return []*entity.User{{ID: rotationID, ChannelID: rotationID, SlackUserID: fmt.Sprintf("U%d", rotationID)}}, nil
+		}).AnyTimes()
+	availabilityRepo.EXPECT().GetUpcomingByChannel(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	dm.EXPECT().
+		WithTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+			return nil
+		}).AnyTimes()
+
+	posted := &atomic.Int64{}
+	slackClient.EXPECT().
+		PostMessageContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, channelID string, _ ...slack.MsgOption) (string, string, error) {
+			posted.Add(1)
+			return channelID, "", nil
+		}).AnyTimes()
+
+	slackClients := mocks.NewMockSlackClientFactory(ctrl)
+	slackClients.EXPECT().ForTeam(gomock.Any()).Return(slackClient, nil).AnyTimes()
+
+	s := newScheduler(dm, slackClients, newFakeClock(benchOccurrence))
+	return s, configs, posted
+}
+
+// BenchmarkScheduler_reloadAll measures the hourly resync, rebuilding the queue of every channel
+func BenchmarkScheduler_reloadAll(b *testing.B) {
+	s, _, _ := setupSchedulerBenchmark(b)
+	now := benchOccurrence.Add(-time.Hour)
+
+	for b.Loop() {
+		s.reloadAll(now)
+	}
+}
+
+// BenchmarkScheduler_configChange measures a config change of one channel among all the others
+func BenchmarkScheduler_configChange(b *testing.B) {
+	s, _, _ := setupSchedulerBenchmark(b)
+	now := benchOccurrence.Add(-time.Hour)
+	s.reloadAll(now)
+
+	channelID := int64(0)
+	for b.Loop() {
+		channelID = channelID%benchChannels + 1
+		s.NotifyConfigChange(channelID)
+		s.applyConfigChanges(now)
+	}
+}
+
+// BenchmarkScheduler_sendDue measures sending the reminders of every channel due at the same
+// time through the worker pool, without the spacing between reminders
+func BenchmarkScheduler_sendDue(b *testing.B) {
+	s, configs, posted := setupSchedulerBenchmark(b)
+	s.SetSendInterval(0)
+
+	for b.Loop() {
+		b.StopTimer()
+		s.queue = newNotificationQueue()
+		for _, config := range configs {
+			s.queue.set(&queuedRotation{config: config, next: benchOccurrence})
+		}
+		s.startWorkers()
+		b.StartTimer()
+
+		s.sendDue(benchOccurrence)
+		s.stopWorkers()
+	}
+
+	if posted.Load() < benchChannels {
+		b.Fatalf("sent %d reminders, want at least %d", posted.Load(), benchChannels)
+	}
+}
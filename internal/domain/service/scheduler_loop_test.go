@@ -0,0 +1,427 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/database"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/diegoclair/slack-rotation-bot/migrator/sqlite"
+	"github.com/diegoclair/slack-rotation-bot/mocks"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// Test_scheduler_mainLoop_week runs the scheduler against a real database for a week of
+// reminders, moving a fake clock forward instead of waiting, with config changes made
+// while the scheduler waits for the next reminder
+func Test_scheduler_mainLoop_week(t *testing.T) {
+	// Monday, January 1st 2024
+	clock := newFakeClock(time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC))
+	dm, slackClients, reminders := setupSchedulerLoop(t)
+	rotationService, channel, rotation := setupLoopRotation(t, dm, slackClients, clock)
+
+	s := newScheduler(dm, slackClients, clock)
+	rotationService.SetScheduler(s)
+	s.Start()
+	defer s.Stop()
+
+	// expectReminder lets the reminder due at due go out
+	expectReminder := func(due time.Time, userID string) {
+		t.Helper()
+
+		clock.waitForTimer(t, due)
+		clock.advanceTo(due)
+		assert.Contains(t, receiveReminder(t, reminders), "<@"+userID+">", "reminder of %s", due.Format(time.RFC1123))
+	}
+
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, 1, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	expectReminder(at(1, 9), "U1")
+
+	// Moving the time on Tuesday morning while waiting reschedules Tuesday's reminder
+	clock.waitForTimer(t, at(2, 9))
+	clock.advanceTo(at(2, 8))
+	require.NoError(t, rotationService.UpdateChannelConfig(rotation.ID, "time", "14:00"))
+	expectReminder(at(2, 14), "U2")
+
+	// Paused on Tuesday evening, Wednesday is skipped and the scheduler checks back every hour
+	clock.waitForTimer(t, at(3, 14))
+	clock.advanceTo(at(2, 20))
+	require.NoError(t, rotationService.PauseScheduler(rotation.ID))
+	clock.waitForTimer(t, at(2, 21))
+	clock.advanceTo(at(4, 10))
+
+	// Resumed on Thursday morning, the rotation continues where it stopped
+	clock.waitForTimer(t, at(4, 11))
+	require.NoError(t, rotationService.ResumeScheduler(rotation.ID))
+	expectReminder(at(4, 14), "U3")
+
+	// A holiday added on Friday morning moves the reminder to Monday
+	clock.waitForTimer(t, at(5, 14))
+	clock.advanceTo(at(5, 8))
+	_, err := rotationService.AddHoliday(channel.ID, "2024-01-05", "Team day off")
+	require.NoError(t, err)
+	expectReminder(at(8, 14), "U1")
+
+	assertNoReminder(t, reminders)
+}
+
+// Test_scheduler_mainLoop_restart restarts the bot around the notification time: the reminder
+// missed while it was down is sent late once, and never again on the next restart
+func Test_scheduler_mainLoop_restart(t *testing.T) {
+	// Monday, January 1st 2024
+	clock := newFakeClock(time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC))
+	dm, slackClients, reminders := setupSchedulerLoop(t)
+	rotationService, _, _ := setupLoopRotation(t, dm, slackClients, clock)
+
+	start := func() *scheduler {
+		s := newScheduler(dm, slackClients, clock)
+		rotationService.SetScheduler(s)
+		s.Start()
+		return s
+	}
+	stop := func(s *scheduler) {
+		s.Stop()
+		require.Eventually(t, func() bool { return clock.pendingTimers() == 0 }, time.Second, time.Millisecond)
+	}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	s := start()
+	clock.waitForTimer(t, at(1, 9, 0))
+	clock.advanceTo(at(1, 9, 0))
+	assert.Contains(t, receiveReminder(t, reminders), "<@U1>")
+	clock.waitForTimer(t, at(2, 9, 0))
+	stop(s)
+
+	// Down on Tuesday at 09:00, back twenty minutes later
+	clock.advanceTo(at(2, 9, 20))
+	s = start()
+	text := receiveReminder(t, reminders)
+	assert.Contains(t, text, "<@U2>")
+	assert.Contains(t, text, "(delayed)")
+	clock.waitForTimer(t, at(3, 9, 0))
+	stop(s)
+
+	// Restarting again does not send Tuesday's reminder twice
+	s = start()
+	clock.waitForTimer(t, at(3, 9, 0))
+	stop(s)
+
+	// Down for longer than the grace period on Wednesday, the reminder is skipped
+	clock.advanceTo(at(3, 10, 30))
+	s = start()
+	clock.waitForTimer(t, at(4, 9, 0))
+	stop(s)
+
+	assertNoReminder(t, reminders)
+}
+
+// Test_scheduler_mainLoop_spacing sends the reminders of three rotations due at the same time
+// one minute apart: each is checked when it goes out, so the second one is marked as delayed
+// and the third one, past the grace period, is dropped, while the scheduler keeps waiting for
+// the next reminders
+func Test_scheduler_mainLoop_spacing(t *testing.T) {
+	// Monday, January 1st 2024
+	clock := newFakeClock(time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC))
+	dm, slackClients, reminders := setupSchedulerLoop(t)
+	rotationService, channel, _ := setupLoopRotation(t, dm, slackClients, clock)
+	for _, name := range []string{"reviewers", "oncall"} {
+		rotation, err := rotationService.CreateRotation(channel.ID, name)
+		require.NoError(t, err)
+		require.NoError(t, rotationService.AddUser(context.Background(), rotation.ID, "U1"))
+	}
+
+	s := newScheduler(dm, slackClients, clock)
+	s.SetGracePeriod(0)
+	s.SetSendInterval(delayedAfter)
+	rotationService.SetScheduler(s)
+	s.Start()
+	defer s.Stop()
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	clock.waitForTimer(t, at(1, 9, 0))
+	clock.advanceTo(at(1, 9, 0))
+	assert.NotContains(t, receiveReminder(t, reminders), "(delayed)")
+
+	// The main loop already waits for Tuesday while the reminders are spaced out
+	clock.waitForTimer(t, at(1, 9, 1))
+	clock.waitForTimer(t, at(2, 9, 0))
+	clock.advanceTo(at(1, 9, 1))
+	assert.Contains(t, receiveReminder(t, reminders), "(delayed)")
+
+	clock.waitForTimer(t, at(1, 9, 2))
+	clock.advanceTo(at(1, 9, 2))
+	assert.Never(t, func() bool { return len(reminders) > 0 }, 100*time.Millisecond, 5*time.Millisecond, "reminder sent past the grace period")
+}
+
+// Test_scheduler_mainLoop_replicas runs two instances of the bot against one database file:
+// each reminder is sent by only one of them, and config changes made through one instance
+// are honored by the other
+func Test_scheduler_mainLoop_replicas(t *testing.T) {
+	// Monday, January 1st 2024
+	clock := newFakeClock(time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC))
+	path := filepath.Join(t.TempDir(), "bot.db")
+	slackClients, reminders := newLoopSlackClient(t)
+
+	dmA := openLoopDatabase(t, path)
+	rotationServiceA, _, rotation := setupLoopRotation(t, dmA, slackClients, clock)
+	schedulerA := newScheduler(dmA, slackClients, clock)
+	rotationServiceA.SetScheduler(schedulerA)
+
+	dmB := openLoopDatabase(t, path)
+	rotationServiceB := newRotation(dmB, slackClients, clock)
+	schedulerB := newScheduler(dmB, slackClients, clock)
+	rotationServiceB.SetScheduler(schedulerB)
+
+	schedulerA.Start()
+	defer schedulerA.Stop()
+	schedulerB.Start()
+	defer schedulerB.Stop()
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+	// expectReminders lets both instances reach due
+	expectReminders := func(due time.Time, userIDs ...string) {
+		t.Helper()
+
+		clock.waitForTimers(t, due, 2)
+		clock.advanceTo(due)
+		for _, userID := range userIDs {
+			assert.Contains(t, receiveReminder(t, reminders), "<@"+userID+">", "reminder of %s", due.Format(time.RFC1123))
+		}
+
+		// The instance that lost the reminder gives up in the background
+		assert.Never(t, func() bool { return len(reminders) > 0 }, 100*time.Millisecond, 5*time.Millisecond, "reminder of %s sent twice", due.Format(time.RFC1123))
+	}
+
+	expectReminders(at(1, 9, 0), "U1")
+
+	// Moved on Tuesday morning through the first instance, the second one still waits for 09:00
+	clock.waitForTimers(t, at(2, 9, 0), 2)
+	clock.advanceTo(at(2, 8, 0))
+	require.NoError(t, rotationServiceA.UpdateChannelConfig(rotation.ID, "time", "14:00"))
+	clock.waitForTimer(t, at(2, 14, 0))
+	clock.advanceTo(at(2, 9, 0))
+	assert.Never(t, func() bool { return len(reminders) > 0 }, 100*time.Millisecond, 5*time.Millisecond, "reminder sent at the old time")
+	expectReminders(at(2, 14, 0), "U2")
+
+	// Paused on Tuesday evening through the second instance, the first one drops the rotation on
+	// its next resync or when it finds it paused on Wednesday
+	clock.waitForTimers(t, at(3, 14, 0), 2)
+	clock.advanceTo(at(2, 20, 0))
+	require.NoError(t, rotationServiceB.PauseScheduler(rotation.ID))
+	clock.waitForTimer(t, at(2, 21, 0))
+	clock.advanceTo(at(3, 14, 0))
+	assert.Never(t, func() bool { return len(reminders) > 0 }, 100*time.Millisecond, 5*time.Millisecond, "reminder sent while paused")
+}
+
+// setupSchedulerLoop returns a data manager over a new database and Slack clients sending the
+// text of the messages posted to reminders
+func setupSchedulerLoop(t *testing.T) (contract.DataManager, contract.SlackClientFactory, chan string) {
+	t.Helper()
+
+	slackClients, reminders := newLoopSlackClient(t)
+	return openLoopDatabase(t, filepath.Join(t.TempDir(), "bot.db")), slackClients, reminders
+}
+
+// openLoopDatabase returns a data manager over the database file, creating it when missing
+func openLoopDatabase(t *testing.T, path string) contract.DataManager {
+	t.Helper()
+
+	db, err := database.New(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, sqlite.Migrate(db.DB()))
+
+	return database.NewInstance(db)
+}
+
+// newLoopSlackClient returns Slack clients sending the text of the messages posted to reminders
+func newLoopSlackClient(t *testing.T) (contract.SlackClientFactory, chan string) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	slackClient := mocks.NewMockSlackClient(ctrl)
+	slackClient.EXPECT().
+		GetUserInfoContext(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, userID string) (*slack.User, error) {
+			return &slack.User{ID: userID, Name: userID}, nil
+		}).AnyTimes()
+
+	reminders := make(chan string, 10)
+	slackClient.EXPECT().
+		PostMessageContext(gomock.Any(), "C123456789", gomock.Any()).
+		DoAndReturn(func(_ context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
+			_, values, err := slack.UnsafeApplyMsgOptions("", channelID, "", options...)
+			require.NoError(t, err)
+			reminders <- values.Get("text")
+			return channelID, "", nil
+		}).AnyTimes()
+
+	slackClients := mocks.NewMockSlackClientFactory(ctrl)
+	slackClients.EXPECT().ForTeam(gomock.Any()).Return(slackClient, nil).AnyTimes()
+
+	return slackClients, reminders
+}
+
+// setupLoopRotation sets up a channel reminded at 09:00 UTC on weekdays with three members
+func setupLoopRotation(t *testing.T, dm contract.DataManager, slackClients contract.SlackClientFactory, clock contract.Clock) (*rotationService, *entity.Channel, *entity.Rotation) {
+	t.Helper()
+
+	rotationService := newRotation(dm, slackClients, clock)
+
+	channel, _, err := rotationService.SetupChannel("C123456789", "standup", "T123456789")
+	require.NoError(t, err)
+	rotation, err := rotationService.GetRotation(channel.ID, "")
+	require.NoError(t, err)
+	for _, userID := range []string{"U1", "U2", "U3"} {
+		require.NoError(t, rotationService.AddUser(context.Background(), rotation.ID, userID))
+	}
+
+	return rotationService, channel, rotation
+}
+
+func receiveReminder(t *testing.T, reminders chan string) string {
+	t.Helper()
+
+	select {
+	case text := <-reminders:
+		return text
+	case <-time.After(time.Second):
+		require.FailNow(t, "no reminder sent")
+		return ""
+	}
+}
+
+func assertNoReminder(t *testing.T, reminders chan string) {
+	t.Helper()
+
+	select {
+	case text := <-reminders:
+		assert.Failf(t, "unexpected reminder", "%s", text)
+	default:
+	}
+}
+
+// fakeClock is a contract.Clock that only moves when the test advances it
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) contract.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timer := &fakeTimer{clock: c, due: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		timer.c <- c.now
+		return timer
+	}
+
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	<-c.NewTimer(d).C()
+}
+
+// advanceTo moves the clock to now, firing the timers due by then
+func (c *fakeClock) advanceTo(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+
+	var pending []*fakeTimer
+	for _, timer := range c.timers {
+		if timer.due.After(now) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.c <- now
+	}
+	c.timers = pending
+}
+
+// pendingTimers returns how many timers are waiting to fire
+func (c *fakeClock) pendingTimers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// waitForTimer waits until something waits for a timer due at due, which tells the
+// scheduler has done its work and is idle until then
+func (c *fakeClock) waitForTimer(t *testing.T, due time.Time) {
+	t.Helper()
+	c.waitForTimers(t, due, 1)
+}
+
+// waitForTimers waits until at least count timers are due at due, one per running scheduler
+func (c *fakeClock) waitForTimers(t *testing.T, due time.Time, count int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		found := 0
+		for _, timer := range c.timers {
+			if timer.due.Equal(due) {
+				found++
+			}
+		}
+		return found >= count
+	}, time.Second, time.Millisecond, "no %d timers due at %s", count, due.Format(time.RFC1123))
+}
+
+type fakeTimer struct {
+	clock *fakeClock
+	due   time.Time
+	c     chan time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	for i, timer := range t.clock.timers {
+		if timer == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
 	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
 	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
 	"github.com/slack-go/slack"
@@ -18,12 +19,14 @@ func Test_newScheduler(t *testing.T) {
 	m, ctrl := newServiceTestMock(t)
 	defer ctrl.Finish()
 
-	scheduler := newScheduler(m.mockDataManager, m.mockSlackClient)
+	scheduler := newScheduler(m.mockDataManager, m.mockSlackClients, m.clock)
 
 	require.NotNil(t, scheduler)
 	assert.Equal(t, m.mockDataManager, scheduler.dm)
-	assert.Equal(t, m.mockSlackClient, scheduler.slackClient)
+	assert.Equal(t, m.mockSlackClients, scheduler.slackClients)
 	assert.NotNil(t, scheduler.configChanged)
+	assert.NotNil(t, scheduler.queue)
+	assert.Equal(t, defaultWorkers, scheduler.workers)
 	assert.NotNil(t, scheduler.stopChan)
 	assert.False(t, scheduler.running)
 }
@@ -31,6 +34,7 @@ func Test_newScheduler(t *testing.T) {
 func Test_scheduler_calculateNextForScheduler(t *testing.T) {
 	type args struct {
 		scheduler *entity.Scheduler
+		holidays  []*entity.Holiday
 		now       time.Time
 	}
 	tests := []struct {
@@ -82,6 +86,98 @@ func Test_scheduler_calculateNextForScheduler(t *testing.T) {
 			},
 			want: time.Date(2024, 1, 7, 9, 0, 0, 0, time.UTC), // Sunday 09:00
 		},
+		{
+			name: "Should use the channel timezone wall-clock time",
+			args: args{
+				scheduler: &entity.Scheduler{
+					NotificationTime: "09:00",
+					ActiveDays:       []int{1, 2, 3, 4, 5},
+					Timezone:         "America/Sao_Paulo",
+				},
+				now: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), // Monday 07:00 in São Paulo
+			},
+			want: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), // Monday 09:00 in São Paulo (UTC-3)
+		},
+		{
+			name: "Should use the local date when it differs from the UTC date",
+			args: args{
+				scheduler: &entity.Scheduler{
+					NotificationTime: "09:00",
+					ActiveDays:       []int{1}, // Monday only
+					Timezone:         "Asia/Tokyo",
+				},
+				now: time.Date(2024, 1, 7, 23, 0, 0, 0, time.UTC), // Sunday in UTC, Monday 08:00 in Tokyo
+			},
+			want: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), // Monday 09:00 in Tokyo (UTC+9)
+		},
+		{
+			name: "Should keep local time across DST start",
+			args: args{
+				scheduler: &entity.Scheduler{
+					NotificationTime: "09:00",
+					ActiveDays:       []int{1, 2, 3, 4, 5},
+					Timezone:         "Europe/Berlin",
+				},
+				now: time.Date(2024, 3, 29, 10, 0, 0, 0, time.UTC), // Friday, CET (UTC+1)
+			},
+			want: time.Date(2024, 4, 1, 7, 0, 0, 0, time.UTC), // Monday 09:00 CEST (UTC+2)
+		},
+		{
+			name: "Should keep local time across DST end",
+			args: args{
+				scheduler: &entity.Scheduler{
+					NotificationTime: "09:00",
+					ActiveDays:       []int{1, 2, 3, 4, 5},
+					Timezone:         "Europe/Berlin",
+				},
+				now: time.Date(2024, 10, 25, 10, 0, 0, 0, time.UTC), // Friday, CEST (UTC+2)
+			},
+			want: time.Date(2024, 10, 28, 8, 0, 0, 0, time.UTC), // Monday 09:00 CET (UTC+1)
+		},
+		{
+			name: "Should skip a single holiday",
+			args: args{
+				scheduler: &entity.Scheduler{
+					NotificationTime: "09:00",
+					ActiveDays:       []int{1, 2, 3, 4, 5},
+				},
+				holidays: []*entity.Holiday{
+					{StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				},
+				now: time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC), // Monday 08:00 (holiday)
+			},
+			want: time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC), // Tuesday 09:00
+		},
+		{
+			name: "Should skip a holiday range over a weekend",
+			args: args{
+				scheduler: &entity.Scheduler{
+					NotificationTime: "09:00",
+					ActiveDays:       []int{1, 2, 3, 4, 5},
+				},
+				holidays: []*entity.Holiday{
+					{StartDate: time.Date(2024, 12, 24, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2024, 12, 26, 0, 0, 0, 0, time.UTC)},
+					{StartDate: time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+				},
+				now: time.Date(2024, 12, 23, 10, 0, 0, 0, time.UTC), // Monday 10:00
+			},
+			want: time.Date(2024, 12, 27, 9, 0, 0, 0, time.UTC), // Friday 09:00
+		},
+		{
+			name: "Should check holidays against the local date",
+			args: args{
+				scheduler: &entity.Scheduler{
+					NotificationTime: "09:00",
+					ActiveDays:       []int{1, 2, 3, 4, 5},
+					Timezone:         "Asia/Tokyo",
+				},
+				holidays: []*entity.Holiday{
+					{StartDate: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+				},
+				now: time.Date(2024, 1, 7, 23, 0, 0, 0, time.UTC), // Monday 08:00 in Tokyo (holiday)
+			},
+			want: time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC), // Tuesday 09:00 in Tokyo
+		},
 		{
 			name: "Should return zero time for invalid time format",
 			args: args{
@@ -111,118 +207,99 @@ func Test_scheduler_calculateNextForScheduler(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newScheduler(m.mockDataManager, m.mockSlackClient)
-			got := s.calculateNextForScheduler(tt.args.scheduler, tt.args.now)
+			s := newScheduler(m.mockDataManager, m.mockSlackClients, m.clock)
+			got := s.calculateNextForScheduler(tt.args.scheduler, tt.args.holidays, tt.args.now)
 
 			if tt.want.IsZero() {
 				assert.True(t, got.IsZero(), "Expected zero time but got %v", got)
 			} else {
-				assert.Equal(t, tt.want, got)
+				assert.True(t, tt.want.Equal(got), "Expected %v but got %v", tt.want, got)
 			}
 		})
 	}
 }
 
-func Test_scheduler_getNextPresenter(t *testing.T) {
+func Test_missedOccurrence(t *testing.T) {
+	// Monday, January 1st 2024, reminders at 09:00 UTC on weekdays
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+	weekdays := func(lastNotifiedAt time.Time) *entity.Scheduler {
+		return &entity.Scheduler{NotificationTime: "09:00", ActiveDays: []int{1, 2, 3, 4, 5}, IsEnabled: true, LastNotifiedAt: lastNotifiedAt}
+	}
+
 	type args struct {
-		channelID int64
+		scheduler   *entity.Scheduler
+		holidays    []*entity.Holiday
+		now         time.Time
+		gracePeriod time.Duration
 	}
 	tests := []struct {
-		name      string
-		buildMock func(mocks allMocks, args args)
-		args      args
-		want      *entity.User
-		wantErr   bool
+		name string
+		args args
+		want time.Time
 	}{
 		{
-			name: "Should return first user when no current presenter",
-			args: args{channelID: 1},
-			buildMock: func(mocks allMocks, args args) {
-				users := []*entity.User{
-					{ID: 1, SlackUserID: "U123456789", LastPresenter: false},
-					{ID: 2, SlackUserID: "U987654321", LastPresenter: false},
-				}
-
-				mocks.mockUserRepo.EXPECT().
-					GetActiveUsersByChannel(args.channelID).
-					Return(users, nil).Times(1)
-			},
-			want: &entity.User{ID: 1, SlackUserID: "U123456789", LastPresenter: false},
-			wantErr: false,
+			name: "Should return today's reminder missed within the grace period",
+			args: args{scheduler: weekdays(at(1, 9, 0)), now: at(2, 9, 30), gracePeriod: time.Hour},
+			want: at(2, 9, 0),
 		},
 		{
-			name: "Should return next user after current presenter",
-			args: args{channelID: 1},
-			buildMock: func(mocks allMocks, args args) {
-				users := []*entity.User{
-					{ID: 1, SlackUserID: "U123456789", LastPresenter: true},  // Current presenter
-					{ID: 2, SlackUserID: "U987654321", LastPresenter: false},
-					{ID: 3, SlackUserID: "U555555555", LastPresenter: false},
-				}
-
-				mocks.mockUserRepo.EXPECT().
-					GetActiveUsersByChannel(args.channelID).
-					Return(users, nil).Times(1)
-			},
-			want: &entity.User{ID: 2, SlackUserID: "U987654321", LastPresenter: false},
-			wantErr: false,
+			name: "Should return nothing when the reminder is past the grace period",
+			args: args{scheduler: weekdays(at(1, 9, 0)), now: at(2, 10, 30), gracePeriod: time.Hour},
 		},
 		{
-			name: "Should wrap around to first user",
-			args: args{channelID: 1},
-			buildMock: func(mocks allMocks, args args) {
-				users := []*entity.User{
-					{ID: 1, SlackUserID: "U123456789", LastPresenter: false},
-					{ID: 2, SlackUserID: "U987654321", LastPresenter: true}, // Last user is current
-				}
-
-				mocks.mockUserRepo.EXPECT().
-					GetActiveUsersByChannel(args.channelID).
-					Return(users, nil).Times(1)
+			name: "Should return nothing when the reminder was already sent",
+			args: args{scheduler: weekdays(at(2, 9, 0)), now: at(2, 9, 30), gracePeriod: time.Hour},
+		},
+		{
+			name: "Should return nothing when the day already had its reminder at another time",
+			args: args{
+				scheduler:   &entity.Scheduler{NotificationTime: "08:00", ActiveDays: []int{1, 2, 3, 4, 5}, IsEnabled: true, LastNotifiedAt: at(2, 9, 0)},
+				now:         at(2, 9, 30),
+				gracePeriod: 2 * time.Hour,
 			},
-			want: &entity.User{ID: 1, SlackUserID: "U123456789", LastPresenter: false},
-			wantErr: false,
 		},
 		{
-			name: "Should return nil when no users",
-			args: args{channelID: 1},
-			buildMock: func(mocks allMocks, args args) {
-				mocks.mockUserRepo.EXPECT().
-					GetActiveUsersByChannel(args.channelID).
-					Return([]*entity.User{}, nil).Times(1)
+			name: "Should return nothing for a scheduler that never notified",
+			args: args{scheduler: weekdays(time.Time{}), now: at(2, 9, 30), gracePeriod: time.Hour},
+		},
+		{
+			name: "Should return nothing when catching up is disabled",
+			args: args{scheduler: weekdays(at(1, 9, 0)), now: at(2, 9, 30)},
+		},
+		{
+			name: "Should return nothing for a holiday",
+			args: args{
+				scheduler:   weekdays(at(1, 9, 0)),
+				holidays:    []*entity.Holiday{{StartDate: at(2, 0, 0), EndDate: at(2, 0, 0)}},
+				now:         at(2, 9, 30),
+				gracePeriod: time.Hour,
 			},
-			want:    nil,
-			wantErr: false,
+		},
+		{
+			name: "Should return only the last reminder missed within a long grace period",
+			args: args{scheduler: weekdays(at(1, 9, 0)), now: at(3, 9, 30), gracePeriod: 48 * time.Hour},
+			want: at(3, 9, 0),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m, ctrl := newServiceTestMock(t)
-			defer ctrl.Finish()
-
-			s := newScheduler(m.mockDataManager, m.mockSlackClient)
-
-			if tt.buildMock != nil {
-				tt.buildMock(m, tt.args)
-			}
+			got := missedOccurrence(tt.args.scheduler, tt.args.holidays, tt.args.now, tt.args.gracePeriod)
 
-			got, err := s.getNextPresenter(tt.args.channelID)
-
-			if tt.wantErr {
-				require.Error(t, err)
-				return
-			}
-
-			require.NoError(t, err)
-			assert.Equal(t, tt.want, got)
+			assert.True(t, tt.want.Equal(got), "Expected %v but got %v", tt.want, got)
 		})
 	}
 }
 
-func Test_scheduler_sendNotificationToChannel(t *testing.T) {
+func Test_scheduler_sendNotificationToRotation(t *testing.T) {
+	rotation := &entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}
+	// Monday 23:59, the reminder is sent a few minutes later on Tuesday
+	occurrence := time.Date(2026, 10, 19, 23, 59, 0, 0, time.UTC)
+
 	type args struct {
-		channelID int64
+		rotationID int64
 	}
 	tests := []struct {
 		name      string
@@ -232,37 +309,49 @@ func Test_scheduler_sendNotificationToChannel(t *testing.T) {
 	}{
 		{
 			name: "Should send notification successfully with next presenter",
-			args: args{channelID: 1},
+			args: args{rotationID: rotation.ID},
 			buildMock: func(mocks allMocks, args args) {
 				channel := &entity.Channel{
-					ID:             args.channelID,
+					ID:             rotation.ChannelID,
 					SlackChannelID: "C123456789",
 				}
 
 				schedulerConfig := &entity.Scheduler{
 					ID:        1,
-					ChannelID: args.channelID,
+					ChannelID: rotation.ChannelID,
 					Role:      "Daily presenter",
 				}
 
 				users := []*entity.User{
-					{ID: 1, SlackUserID: "U123456789", LastPresenter: false},
-					{ID: 2, SlackUserID: "U987654321", LastPresenter: false},
+					{ID: 1, ChannelID: rotation.ChannelID, SlackUserID: "U123456789", LastPresenter: false},
+					{ID: 2, ChannelID: rotation.ChannelID, SlackUserID: "U987654321", LastPresenter: false},
 				}
 
 				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(rotation, nil).Times(1),
+
 					mocks.mockChannelRepo.EXPECT().
-						GetByID(args.channelID).
+						GetByID(rotation.ChannelID).
 						Return(channel, nil).Times(1),
 
 					mocks.mockSchedulerRepo.EXPECT().
-						GetByChannelID(args.channelID).
+						GetByRotationID(args.rotationID).
 						Return(schedulerConfig, nil).Times(1),
 
+					mocks.mockSwapRepo.EXPECT().
+						GetPendingByRotation(args.rotationID, gomock.Any()).
+						Return(nil, nil).Times(1),
+
 					mocks.mockUserRepo.EXPECT().
-						GetActiveUsersByChannel(args.channelID).
+						GetActiveUsersByRotation(args.rotationID).
 						Return(users, nil).Times(1),
 
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(rotation.ChannelID, gomock.Any()).
+						Return(nil, nil).Times(1),
+
 					mocks.mockDataManager.EXPECT().
 						WithTransaction(gomock.Any(), gomock.Any()).
 						DoAndReturn(func(ctx interface{}, fn interface{}) error {
@@ -271,7 +360,152 @@ func Test_scheduler_sendNotificationToChannel(t *testing.T) {
 						}).Times(1),
 
 					mocks.mockSlackClient.EXPECT().
-						PostMessage(channel.SlackChannelID, gomock.Any(), gomock.Any()).
+						PostMessageContext(gomock.Any(), channel.SlackChannelID, gomock.Any(), gomock.Any(), gomock.Any()).
+						Return("", "", nil).Times(1),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should record presenter and backup when the turn is shared",
+			args: args{rotationID: rotation.ID},
+			buildMock: func(mocks allMocks, args args) {
+				channel := &entity.Channel{
+					ID:             rotation.ChannelID,
+					SlackChannelID: "C123456789",
+				}
+
+				schedulerConfig := &entity.Scheduler{
+					ID:         1,
+					ChannelID:  rotation.ChannelID,
+					Role:       "On duty",
+					Assignees:  2,
+					BackupRole: "backup",
+				}
+
+				users := []*entity.User{
+					{ID: 1, ChannelID: rotation.ChannelID, SlackUserID: "U123456789", LastPresenter: true},
+					{ID: 2, ChannelID: rotation.ChannelID, SlackUserID: "U987654321"},
+					{ID: 3, ChannelID: rotation.ChannelID, SlackUserID: "U555555555"},
+				}
+
+				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(rotation, nil).Times(1),
+
+					mocks.mockChannelRepo.EXPECT().
+						GetByID(rotation.ChannelID).
+						Return(channel, nil).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(schedulerConfig, nil).Times(1),
+
+					mocks.mockSwapRepo.EXPECT().
+						GetPendingByRotation(args.rotationID, gomock.Any()).
+						Return(nil, nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(users, nil).Times(1),
+
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(rotation.ChannelID, gomock.Any()).
+						Return(nil, nil).Times(1),
+
+					mocks.mockDataManager.EXPECT().
+						WithTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+							return fn(mocks.mockDataManager)
+						}).Times(1),
+
+					mocks.mockUserRepo.EXPECT().ClearLastPresenter(args.rotationID).Return(nil).Times(1),
+					mocks.mockUserRepo.EXPECT().ClearLastBackups(args.rotationID).Return(nil).Times(1),
+					mocks.mockUserRepo.EXPECT().SetLastPresenter(int64(2)).Return(nil).Times(1),
+					mocks.mockUserRepo.EXPECT().SetLastBackup(int64(3)).Return(nil).Times(1),
+					mocks.mockHistoryRepo.EXPECT().Create(gomock.Any()).Return(nil).Times(2),
+
+					mocks.mockSlackClient.EXPECT().
+						PostMessageContext(gomock.Any(), channel.SlackChannelID, gomock.Any(), gomock.Any(), gomock.Any()).
+						Return("", "", nil).Times(1),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should apply pending swap before picking the presenter",
+			args: args{rotationID: rotation.ID},
+			buildMock: func(mocks allMocks, args args) {
+				channel := &entity.Channel{
+					ID:             rotation.ChannelID,
+					SlackChannelID: "C123456789",
+				}
+
+				schedulerConfig := &entity.Scheduler{
+					ID:        1,
+					ChannelID: rotation.ChannelID,
+					Role:      "Daily presenter",
+				}
+
+				users := []*entity.User{
+					{ID: 1, ChannelID: rotation.ChannelID, SlackUserID: "U123456789", LastPresenter: true},
+					{ID: 2, ChannelID: rotation.ChannelID, SlackUserID: "U987654321"},
+					{ID: 3, ChannelID: rotation.ChannelID, SlackUserID: "U555555555"},
+				}
+				swapped := []*entity.User{users[0], users[2], users[1]}
+
+				swaps := []*entity.Swap{{ID: 7, ChannelID: rotation.ChannelID, UserAID: 2, UserBID: 3}}
+
+				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(rotation, nil).Times(1),
+
+					mocks.mockChannelRepo.EXPECT().
+						GetByID(rotation.ChannelID).
+						Return(channel, nil).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(schedulerConfig, nil).Times(1),
+
+					mocks.mockSwapRepo.EXPECT().
+						GetPendingByRotation(args.rotationID, gomock.Any()).
+						Return(swaps, nil).Times(1),
+
+					mocks.mockDataManager.EXPECT().
+						WithTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+							return fn(mocks.mockDataManager)
+						}).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(users, nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						UpdatePositions([]int64{1, 3, 2}).
+						Return(nil).Times(1),
+
+					mocks.mockSwapRepo.EXPECT().
+						MarkApplied(int64(7), gomock.Any()).
+						Return(nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(swapped, nil).Times(1),
+
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(rotation.ChannelID, gomock.Any()).
+						Return(nil, nil).Times(1),
+
+					mocks.mockDataManager.EXPECT().
+						WithTransaction(gomock.Any(), gomock.Any()).
+						Return(nil).Times(1),
+
+					mocks.mockSlackClient.EXPECT().
+						PostMessageContext(gomock.Any(), channel.SlackChannelID, gomock.Any(), gomock.Any(), gomock.Any()).
 						Return("", "", nil).Times(1),
 				)
 			},
@@ -279,75 +513,171 @@ func Test_scheduler_sendNotificationToChannel(t *testing.T) {
 		},
 		{
 			name: "Should send no users message when rotation is empty",
-			args: args{channelID: 1},
+			args: args{rotationID: rotation.ID},
 			buildMock: func(mocks allMocks, args args) {
 				channel := &entity.Channel{
-					ID:             args.channelID,
+					ID:             rotation.ChannelID,
 					SlackChannelID: "C123456789",
 				}
 
 				schedulerConfig := &entity.Scheduler{
 					ID:        1,
-					ChannelID: args.channelID,
+					ChannelID: rotation.ChannelID,
 					Role:      "Daily presenter",
 				}
 
 				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(rotation, nil).Times(1),
+
 					mocks.mockChannelRepo.EXPECT().
-						GetByID(args.channelID).
+						GetByID(rotation.ChannelID).
 						Return(channel, nil).Times(1),
 
 					mocks.mockSchedulerRepo.EXPECT().
-						GetByChannelID(args.channelID).
+						GetByRotationID(args.rotationID).
 						Return(schedulerConfig, nil).Times(1),
 
+					mocks.mockSwapRepo.EXPECT().
+						GetPendingByRotation(args.rotationID, gomock.Any()).
+						Return(nil, nil).Times(1),
+
 					mocks.mockUserRepo.EXPECT().
-						GetActiveUsersByChannel(args.channelID).
+						GetActiveUsersByRotation(args.rotationID).
 						Return([]*entity.User{}, nil).Times(1),
 
 					mocks.mockSlackClient.EXPECT().
-						PostMessage(channel.SlackChannelID, gomock.Any(), gomock.Any()).
+						PostMessageContext(gomock.Any(), channel.SlackChannelID, gomock.Any(), gomock.Any()).
 						Return("", "", nil).Times(1),
 				)
 			},
 			wantErr: false,
 		},
+		{
+			name: "Should send everyone away message without recording presentation",
+			args: args{rotationID: rotation.ID},
+			buildMock: func(mocks allMocks, args args) {
+				channel := &entity.Channel{
+					ID:             rotation.ChannelID,
+					SlackChannelID: "C123456789",
+				}
+
+				schedulerConfig := &entity.Scheduler{
+					ID:        1,
+					ChannelID: rotation.ChannelID,
+					Role:      "Daily presenter",
+				}
+
+				users := []*entity.User{
+					{ID: 1, ChannelID: rotation.ChannelID, SlackUserID: "U123456789", LastPresenter: false},
+				}
+
+				// Away on the day of the turn only, not on the day the reminder is sent. The swaps of
+				// that day are applied too.
+				away := []*entity.Availability{
+					{
+						UserID:      1,
+						SlackUserID: "U123456789",
+						StartDate:   time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+						EndDate:     time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+					},
+				}
+
+				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(rotation, nil).Times(1),
+
+					mocks.mockChannelRepo.EXPECT().
+						GetByID(rotation.ChannelID).
+						Return(channel, nil).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(schedulerConfig, nil).Times(1),
+
+					mocks.mockSwapRepo.EXPECT().
+						GetPendingByRotation(args.rotationID, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)).
+						Return(nil, nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(users, nil).Times(1),
+
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(rotation.ChannelID, gomock.Any()).
+						Return(away, nil).Times(1),
+
+					mocks.mockSlackClient.EXPECT().
+						PostMessageContext(gomock.Any(), channel.SlackChannelID, gomock.Any(), gomock.Any()).
+						Return("", "", nil).Times(1),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error when rotation not found",
+			args: args{rotationID: 999},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockRotationRepo.EXPECT().
+					GetByID(args.rotationID).
+					Return(nil, nil).Times(1)
+			},
+			wantErr: true,
+		},
 		{
 			name: "Should return error when channel not found",
-			args: args{channelID: 999},
+			args: args{rotationID: rotation.ID},
 			buildMock: func(mocks allMocks, args args) {
+				mocks.mockRotationRepo.EXPECT().
+					GetByID(args.rotationID).
+					Return(rotation, nil).Times(1)
+
 				mocks.mockChannelRepo.EXPECT().
-					GetByID(args.channelID).
+					GetByID(rotation.ChannelID).
 					Return(nil, nil).Times(1)
 			},
 			wantErr: true,
 		},
 		{
 			name: "Should use default role when scheduler config is nil",
-			args: args{channelID: 1},
+			args: args{rotationID: rotation.ID},
 			buildMock: func(mocks allMocks, args args) {
 				channel := &entity.Channel{
-					ID:             args.channelID,
+					ID:             rotation.ChannelID,
 					SlackChannelID: "C123456789",
 				}
 
 				users := []*entity.User{
-					{ID: 1, SlackUserID: "U123456789", LastPresenter: false},
+					{ID: 1, ChannelID: rotation.ChannelID, SlackUserID: "U123456789", LastPresenter: false},
 				}
 
 				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(rotation, nil).Times(1),
+
 					mocks.mockChannelRepo.EXPECT().
-						GetByID(args.channelID).
+						GetByID(rotation.ChannelID).
 						Return(channel, nil).Times(1),
 
 					mocks.mockSchedulerRepo.EXPECT().
-						GetByChannelID(args.channelID).
+						GetByRotationID(args.rotationID).
+						Return(nil, nil).Times(1),
+
+					mocks.mockSwapRepo.EXPECT().
+						GetPendingByRotation(args.rotationID, gomock.Any()).
 						Return(nil, nil).Times(1),
 
 					mocks.mockUserRepo.EXPECT().
-						GetActiveUsersByChannel(args.channelID).
+						GetActiveUsersByRotation(args.rotationID).
 						Return(users, nil).Times(1),
 
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(rotation.ChannelID, gomock.Any()).
+						Return(nil, nil).Times(1),
+
 					mocks.mockDataManager.EXPECT().
 						WithTransaction(gomock.Any(), gomock.Any()).
 						DoAndReturn(func(ctx interface{}, fn interface{}) error {
@@ -355,8 +685,8 @@ func Test_scheduler_sendNotificationToChannel(t *testing.T) {
 						}).Times(1),
 
 					mocks.mockSlackClient.EXPECT().
-						PostMessage(channel.SlackChannelID, gomock.Any(), gomock.Any()).
-						DoAndReturn(func(channelID string, options ...slack.MsgOption) (string, string, error) {
+						PostMessageContext(gomock.Any(), channel.SlackChannelID, gomock.Any(), gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
 							// Verify default role "On duty" is used in message
 							return "", "", nil
 						}).Times(1),
@@ -366,61 +696,77 @@ func Test_scheduler_sendNotificationToChannel(t *testing.T) {
 		},
 		{
 			name: "Should return error when channel repository GetByID fails",
-			args: args{channelID: 1},
+			args: args{rotationID: rotation.ID},
 			buildMock: func(mocks allMocks, args args) {
+				mocks.mockRotationRepo.EXPECT().
+					GetByID(args.rotationID).
+					Return(rotation, nil).Times(1)
+
 				mocks.mockChannelRepo.EXPECT().
-					GetByID(args.channelID).
+					GetByID(rotation.ChannelID).
 					Return(nil, assert.AnError).Times(1)
 			},
 			wantErr: true,
 		},
 		{
-			name: "Should return error when scheduler repository GetByChannelID fails",
-			args: args{channelID: 1},
+			name: "Should return error when scheduler repository GetByRotationID fails",
+			args: args{rotationID: rotation.ID},
 			buildMock: func(mocks allMocks, args args) {
 				channel := &entity.Channel{
-					ID:             args.channelID,
+					ID:             rotation.ChannelID,
 					SlackChannelID: "C123456789",
 				}
 
 				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(rotation, nil).Times(1),
+
 					mocks.mockChannelRepo.EXPECT().
-						GetByID(args.channelID).
+						GetByID(rotation.ChannelID).
 						Return(channel, nil).Times(1),
 
 					mocks.mockSchedulerRepo.EXPECT().
-						GetByChannelID(args.channelID).
+						GetByRotationID(args.rotationID).
 						Return(nil, assert.AnError).Times(1),
 				)
 			},
 			wantErr: true,
 		},
 		{
-			name: "Should return error when getNextPresenter fails (GetActiveUsersByChannel error)",
-			args: args{channelID: 1},
+			name: "Should return error when getNextPresenter fails (GetActiveUsersByRotation error)",
+			args: args{rotationID: rotation.ID},
 			buildMock: func(mocks allMocks, args args) {
 				channel := &entity.Channel{
-					ID:             args.channelID,
+					ID:             rotation.ChannelID,
 					SlackChannelID: "C123456789",
 				}
 
 				schedulerConfig := &entity.Scheduler{
 					ID:        1,
-					ChannelID: args.channelID,
+					ChannelID: rotation.ChannelID,
 					Role:      "Daily presenter",
 				}
 
 				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(rotation, nil).Times(1),
+
 					mocks.mockChannelRepo.EXPECT().
-						GetByID(args.channelID).
+						GetByID(rotation.ChannelID).
 						Return(channel, nil).Times(1),
 
 					mocks.mockSchedulerRepo.EXPECT().
-						GetByChannelID(args.channelID).
+						GetByRotationID(args.rotationID).
 						Return(schedulerConfig, nil).Times(1),
 
+					mocks.mockSwapRepo.EXPECT().
+						GetPendingByRotation(args.rotationID, gomock.Any()).
+						Return(nil, nil).Times(1),
+
 					mocks.mockUserRepo.EXPECT().
-						GetActiveUsersByChannel(args.channelID).
+						GetActiveUsersByRotation(args.rotationID).
 						Return(nil, assert.AnError).Times(1),
 				)
 			},
@@ -428,36 +774,48 @@ func Test_scheduler_sendNotificationToChannel(t *testing.T) {
 		},
 		{
 			name: "Should return error when Slack PostMessage fails for user notification",
-			args: args{channelID: 1},
+			args: args{rotationID: rotation.ID},
 			buildMock: func(mocks allMocks, args args) {
 				channel := &entity.Channel{
-					ID:             args.channelID,
+					ID:             rotation.ChannelID,
 					SlackChannelID: "C123456789",
 				}
 
 				schedulerConfig := &entity.Scheduler{
 					ID:        1,
-					ChannelID: args.channelID,
+					ChannelID: rotation.ChannelID,
 					Role:      "Daily presenter",
 				}
 
 				users := []*entity.User{
-					{ID: 1, SlackUserID: "U123456789", LastPresenter: false},
+					{ID: 1, ChannelID: rotation.ChannelID, SlackUserID: "U123456789", LastPresenter: false},
 				}
 
 				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(rotation, nil).Times(1),
+
 					mocks.mockChannelRepo.EXPECT().
-						GetByID(args.channelID).
+						GetByID(rotation.ChannelID).
 						Return(channel, nil).Times(1),
 
 					mocks.mockSchedulerRepo.EXPECT().
-						GetByChannelID(args.channelID).
+						GetByRotationID(args.rotationID).
 						Return(schedulerConfig, nil).Times(1),
 
+					mocks.mockSwapRepo.EXPECT().
+						GetPendingByRotation(args.rotationID, gomock.Any()).
+						Return(nil, nil).Times(1),
+
 					mocks.mockUserRepo.EXPECT().
-						GetActiveUsersByChannel(args.channelID).
+						GetActiveUsersByRotation(args.rotationID).
 						Return(users, nil).Times(1),
 
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(rotation.ChannelID, gomock.Any()).
+						Return(nil, nil).Times(1),
+
 					mocks.mockDataManager.EXPECT().
 						WithTransaction(gomock.Any(), gomock.Any()).
 						DoAndReturn(func(ctx interface{}, fn interface{}) error {
@@ -465,7 +823,7 @@ func Test_scheduler_sendNotificationToChannel(t *testing.T) {
 						}).Times(1),
 
 					mocks.mockSlackClient.EXPECT().
-						PostMessage(channel.SlackChannelID, gomock.Any(), gomock.Any()).
+						PostMessageContext(gomock.Any(), channel.SlackChannelID, gomock.Any(), gomock.Any(), gomock.Any()).
 						Return("", "", assert.AnError).Times(1),
 				)
 			},
@@ -473,34 +831,42 @@ func Test_scheduler_sendNotificationToChannel(t *testing.T) {
 		},
 		{
 			name: "Should return error when Slack PostMessage fails for no users message",
-			args: args{channelID: 1},
+			args: args{rotationID: rotation.ID},
 			buildMock: func(mocks allMocks, args args) {
 				channel := &entity.Channel{
-					ID:             args.channelID,
+					ID:             rotation.ChannelID,
 					SlackChannelID: "C123456789",
 				}
 
 				schedulerConfig := &entity.Scheduler{
 					ID:        1,
-					ChannelID: args.channelID,
+					ChannelID: rotation.ChannelID,
 					Role:      "Daily presenter",
 				}
 
 				gomock.InOrder(
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(args.rotationID).
+						Return(rotation, nil).Times(1),
+
 					mocks.mockChannelRepo.EXPECT().
-						GetByID(args.channelID).
+						GetByID(rotation.ChannelID).
 						Return(channel, nil).Times(1),
 
 					mocks.mockSchedulerRepo.EXPECT().
-						GetByChannelID(args.channelID).
+						GetByRotationID(args.rotationID).
 						Return(schedulerConfig, nil).Times(1),
 
+					mocks.mockSwapRepo.EXPECT().
+						GetPendingByRotation(args.rotationID, gomock.Any()).
+						Return(nil, nil).Times(1),
+
 					mocks.mockUserRepo.EXPECT().
-						GetActiveUsersByChannel(args.channelID).
+						GetActiveUsersByRotation(args.rotationID).
 						Return([]*entity.User{}, nil).Times(1),
 
 					mocks.mockSlackClient.EXPECT().
-						PostMessage(channel.SlackChannelID, gomock.Any(), gomock.Any()).
+						PostMessageContext(gomock.Any(), channel.SlackChannelID, gomock.Any(), gomock.Any()).
 						Return("", "", assert.AnError).Times(1),
 				)
 			},
@@ -513,13 +879,13 @@ func Test_scheduler_sendNotificationToChannel(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newScheduler(m.mockDataManager, m.mockSlackClient)
+			s := newScheduler(m.mockDataManager, m.mockSlackClients, newFakeClock(occurrence.Add(5*time.Minute)))
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			err := s.sendNotificationToChannel(tt.args.channelID)
+			err := s.sendNotificationToRotation(context.Background(), tt.args.rotationID, occurrence, false)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -530,42 +896,86 @@ func Test_scheduler_sendNotificationToChannel(t *testing.T) {
 	}
 }
 
-func Test_scheduler_findNextNotification(t *testing.T) {
+func Test_scheduler_reloadAll(t *testing.T) {
+	// Monday 08:00
+	now := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	// everyDay is the config of a rotation reminded every day at 09:00
+	everyDay := func(channelID, rotationID int64) *entity.Scheduler {
+		return &entity.Scheduler{
+			ID:               rotationID,
+			ChannelID:        channelID,
+			RotationID:       rotationID,
+			NotificationTime: "09:00",
+			ActiveDays:       []int{1, 2, 3, 4, 5, 6, 7},
+			IsEnabled:        true,
+		}
+	}
+
 	tests := []struct {
-		name             string
-		buildMock        func(mocks allMocks)
-		wantTime         bool // Whether we expect a valid time
-		wantChannelCount int
+		name          string
+		buildMock     func(mocks allMocks)
+		wantRotations []int64
 	}{
 		{
-			name: "Should return next notification time for enabled schedulers",
+			name: "Should queue every enabled scheduler",
 			buildMock: func(mocks allMocks) {
-				now := time.Now().UTC()
-				tomorrow := now.AddDate(0, 0, 1)
+				mocks.mockSchedulerRepo.EXPECT().
+					GetEnabled().
+					Return([]*entity.Scheduler{everyDay(1, 1), everyDay(2, 2)}, nil).Times(1)
 
-				schedulers := []*entity.Scheduler{
-					{
-						ID:               1,
-						ChannelID:        1,
-						NotificationTime: "09:00",
-						ActiveDays:       []int{int(tomorrow.Weekday())}, // Tomorrow's weekday
-						IsEnabled:        true,
-					},
-					{
-						ID:               2,
-						ChannelID:        2,
-						NotificationTime: "09:00",
-						ActiveDays:       []int{int(tomorrow.Weekday())}, // Same time tomorrow
-						IsEnabled:        true,
-					},
-				}
+				mocks.mockHolidayRepo.EXPECT().
+					GetByChannelID(gomock.Any()).
+					Return(nil, nil).Times(2)
+			},
+			wantRotations: []int64{1, 2},
+		},
+		{
+			name: "Should skip channel when holidays cannot be loaded",
+			buildMock: func(mocks allMocks) {
+				mocks.mockSchedulerRepo.EXPECT().
+					GetEnabled().
+					Return([]*entity.Scheduler{everyDay(1, 1), everyDay(1, 3), everyDay(2, 2)}, nil).Times(1)
 
+				gomock.InOrder(
+					mocks.mockHolidayRepo.EXPECT().
+						GetByChannelID(int64(1)).
+						Return(nil, assert.AnError).Times(1),
+
+					mocks.mockHolidayRepo.EXPECT().
+						GetByChannelID(int64(2)).
+						Return(nil, nil).Times(1),
+				)
+			},
+			wantRotations: []int64{2},
+		},
+		{
+			name: "Should load the holidays once for every rotation of a channel",
+			buildMock: func(mocks allMocks) {
 				mocks.mockSchedulerRepo.EXPECT().
 					GetEnabled().
-					Return(schedulers, nil).Times(1)
+					Return([]*entity.Scheduler{everyDay(1, 1), everyDay(1, 3)}, nil).Times(1)
+
+				mocks.mockHolidayRepo.EXPECT().
+					GetByChannelID(int64(1)).
+					Return(nil, nil).Times(1)
+			},
+			wantRotations: []int64{1, 3},
+		},
+		{
+			name: "Should leave out schedulers without a next notification",
+			buildMock: func(mocks allMocks) {
+				noDays := everyDay(1, 1)
+				noDays.ActiveDays = nil
+
+				mocks.mockSchedulerRepo.EXPECT().
+					GetEnabled().
+					Return([]*entity.Scheduler{noDays}, nil).Times(1)
+
+				mocks.mockHolidayRepo.EXPECT().
+					GetByChannelID(int64(1)).
+					Return(nil, nil).Times(1)
 			},
-			wantTime:         true,
-			wantChannelCount: 2, // Both channels at same time
 		},
 		{
 			name: "Should return empty when no enabled schedulers",
@@ -574,18 +984,15 @@ func Test_scheduler_findNextNotification(t *testing.T) {
 					GetEnabled().
 					Return([]*entity.Scheduler{}, nil).Times(1)
 			},
-			wantTime:         false,
-			wantChannelCount: 0,
 		},
 		{
-			name: "Should handle error from repository",
+			name: "Should keep the queue on error from repository",
 			buildMock: func(mocks allMocks) {
 				mocks.mockSchedulerRepo.EXPECT().
 					GetEnabled().
 					Return(nil, assert.AnError).Times(1)
 			},
-			wantTime:         false,
-			wantChannelCount: 0,
+			wantRotations: []int64{9},
 		},
 	}
 
@@ -594,34 +1001,138 @@ func Test_scheduler_findNextNotification(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newScheduler(m.mockDataManager, m.mockSlackClient)
+			s := newScheduler(m.mockDataManager, m.mockSlackClients, m.clock)
+			s.queue.set(&queuedRotation{config: everyDay(9, 9), next: now.Add(time.Hour)})
 
 			if tt.buildMock != nil {
 				tt.buildMock(m)
 			}
 
-			nextTime, channelIDs := s.findNextNotification()
+			s.reloadAll(now)
 
-			if tt.wantTime {
-				assert.False(t, nextTime.IsZero(), "Expected valid time but got zero time")
-			} else {
-				assert.True(t, nextTime.IsZero(), "Expected zero time but got %v", nextTime)
+			assert.Equal(t, now.Add(resyncInterval), s.resyncAt)
+			assert.Equal(t, len(tt.wantRotations), s.queue.Len())
+			for _, rotationID := range tt.wantRotations {
+				item := s.queue.get(rotationID)
+				require.NotNil(t, item, "rotation %d not queued", rotationID)
+				assert.Equal(t, now.Add(time.Hour), item.next)
 			}
-
-			assert.Len(t, channelIDs, tt.wantChannelCount)
 		})
 	}
 }
 
+func Test_scheduler_reloadChannel(t *testing.T) {
+	// Monday 08:00
+	now := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	config := func(channelID, rotationID int64, notificationTime string) *entity.Scheduler {
+		return &entity.Scheduler{
+			ID:               rotationID,
+			ChannelID:        channelID,
+			RotationID:       rotationID,
+			NotificationTime: notificationTime,
+			ActiveDays:       []int{1, 2, 3, 4, 5, 6, 7},
+			IsEnabled:        true,
+		}
+	}
+
+	t.Run("Should only replace the rotations of the channel", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		s := newScheduler(m.mockDataManager, m.mockSlackClients, m.clock)
+		s.queue.set(&queuedRotation{config: config(1, 1, "09:00"), next: now.Add(time.Hour)})
+		s.queue.set(&queuedRotation{config: config(1, 3, "09:00"), next: now.Add(time.Hour)})
+		s.queue.set(&queuedRotation{config: config(2, 2, "09:00"), next: now.Add(time.Hour)})
+
+		// Rotation 1 moved to 14:00, rotation 3 paused
+		m.mockSchedulerRepo.EXPECT().
+			GetEnabledByChannelID(int64(1)).
+			Return([]*entity.Scheduler{config(1, 1, "14:00")}, nil).Times(1)
+		m.mockHolidayRepo.EXPECT().
+			GetByChannelID(int64(1)).
+			Return(nil, nil).Times(1)
+
+		s.reloadChannel(1, now)
+
+		assert.Equal(t, 2, s.queue.Len())
+		require.NotNil(t, s.queue.get(1))
+		assert.Equal(t, now.Add(6*time.Hour), s.queue.get(1).next)
+		assert.Nil(t, s.queue.get(3))
+		require.NotNil(t, s.queue.get(2))
+		assert.Equal(t, now.Add(time.Hour), s.queue.get(2).next)
+		assert.Equal(t, int64(2), s.queue.peek().config.RotationID)
+	})
+
+	t.Run("Should not queue again an occurrence handed to the workers", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		s := newScheduler(m.mockDataManager, m.mockSlackClients, m.clock)
+		sent := now.Add(-10 * time.Minute)
+		s.queue.set(&queuedRotation{config: config(1, 1, "07:50"), next: sent.AddDate(0, 0, 1), sent: sent})
+
+		// Not recorded as notified yet, so it looks missed
+		notified := config(1, 1, "07:50")
+		notified.LastNotifiedAt = sent.AddDate(0, 0, -1)
+		m.mockSchedulerRepo.EXPECT().
+			GetEnabledByChannelID(int64(1)).
+			Return([]*entity.Scheduler{notified}, nil).Times(1)
+		m.mockHolidayRepo.EXPECT().
+			GetByChannelID(int64(1)).
+			Return(nil, nil).Times(1)
+
+		s.reloadChannel(1, now)
+
+		require.NotNil(t, s.queue.get(1))
+		assert.Equal(t, sent.AddDate(0, 0, 1), s.queue.get(1).next)
+	})
+
+	t.Run("Should drop the channel when holidays cannot be loaded", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		s := newScheduler(m.mockDataManager, m.mockSlackClients, m.clock)
+		s.queue.set(&queuedRotation{config: config(1, 1, "09:00"), next: now.Add(time.Hour)})
+
+		m.mockSchedulerRepo.EXPECT().
+			GetEnabledByChannelID(int64(1)).
+			Return([]*entity.Scheduler{config(1, 1, "09:00")}, nil).Times(1)
+		m.mockHolidayRepo.EXPECT().
+			GetByChannelID(int64(1)).
+			Return(nil, assert.AnError).Times(1)
+
+		s.reloadChannel(1, now)
+
+		assert.Equal(t, 0, s.queue.Len())
+	})
+
+	t.Run("Should keep the channel on error from repository", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		s := newScheduler(m.mockDataManager, m.mockSlackClients, m.clock)
+		s.queue.set(&queuedRotation{config: config(1, 1, "09:00"), next: now.Add(time.Hour)})
+
+		m.mockSchedulerRepo.EXPECT().
+			GetEnabledByChannelID(int64(1)).
+			Return(nil, assert.AnError).Times(1)
+
+		s.reloadChannel(1, now)
+
+		assert.NotNil(t, s.queue.get(1))
+	})
+}
+
 func Test_scheduler_NotifyConfigChange(t *testing.T) {
 	m, ctrl := newServiceTestMock(t)
 	defer ctrl.Finish()
 
-	s := newScheduler(m.mockDataManager, m.mockSlackClient)
+	s := newScheduler(m.mockDataManager, m.mockSlackClients, m.clock)
 
 	// Should not block even if channel is full
-	s.NotifyConfigChange()
-	s.NotifyConfigChange() // Second call should not block
+	s.NotifyConfigChange(1)
+	s.NotifyConfigChange(2) // Second call should not block
 
 	// Verify channel received at least one notification
 	select {
@@ -630,13 +1141,16 @@ func Test_scheduler_NotifyConfigChange(t *testing.T) {
 	default:
 		t.Error("Expected config change notification but channel was empty")
 	}
+
+	// Both channels are reloaded on the next loop
+	assert.Equal(t, map[int64]bool{1: true, 2: true}, s.changedChannels)
 }
 
 func Test_scheduler_Start_Stop(t *testing.T) {
 	m, ctrl := newServiceTestMock(t)
 	defer ctrl.Finish()
 
-	s := newScheduler(m.mockDataManager, m.mockSlackClient)
+	s := newScheduler(m.mockDataManager, m.mockSlackClients, m.clock)
 
 	// Mock the scheduler repo to return empty result so mainLoop doesn't panic
 	m.mockSchedulerRepo.EXPECT().
@@ -669,8 +1183,8 @@ func Test_scheduler_Start_Stop(t *testing.T) {
 
 func Test_scheduler_recordPresentation(t *testing.T) {
 	type args struct {
-		channelID int64
-		userID    int64
+		rotationID int64
+		userID     int64
 	}
 	tests := []struct {
 		name      string
@@ -681,8 +1195,8 @@ func Test_scheduler_recordPresentation(t *testing.T) {
 		{
 			name: "Should record presentation successfully",
 			args: args{
-				channelID: 1,
-				userID:    2,
+				rotationID: 1,
+				userID:     2,
 			},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockDataManager.EXPECT().
@@ -692,8 +1206,17 @@ func Test_scheduler_recordPresentation(t *testing.T) {
 					}).Times(1)
 
 				gomock.InOrder(
-					mocks.mockUserRepo.EXPECT().ClearLastPresenter(args.channelID).Return(nil).Times(1),
+					mocks.mockUserRepo.EXPECT().ClearLastPresenter(args.rotationID).Return(nil).Times(1),
+					mocks.mockUserRepo.EXPECT().ClearLastBackups(args.rotationID).Return(nil).Times(1),
 					mocks.mockUserRepo.EXPECT().SetLastPresenter(args.userID).Return(nil).Times(1),
+					mocks.mockHistoryRepo.EXPECT().
+						Create(gomock.Any()).
+						DoAndReturn(func(entry *entity.RotationHistory) error {
+							require.Equal(t, args.userID, entry.UserID)
+							require.Equal(t, domain.HistoryKindAutomatic, entry.Kind)
+							require.Empty(t, entry.TriggeredBy)
+							return nil
+						}).Times(1),
 				)
 			},
 			wantErr: false,
@@ -701,8 +1224,8 @@ func Test_scheduler_recordPresentation(t *testing.T) {
 		{
 			name: "Should return error when transaction fails",
 			args: args{
-				channelID: 1,
-				userID:    2,
+				rotationID: 1,
+				userID:     2,
 			},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockDataManager.EXPECT().
@@ -714,8 +1237,8 @@ func Test_scheduler_recordPresentation(t *testing.T) {
 		{
 			name: "Should return error when ClearLastPresenter fails",
 			args: args{
-				channelID: 1,
-				userID:    2,
+				rotationID: 1,
+				userID:     2,
 			},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockDataManager.EXPECT().
@@ -724,15 +1247,15 @@ func Test_scheduler_recordPresentation(t *testing.T) {
 						return fn(mocks.mockDataManager)
 					}).Times(1)
 
-				mocks.mockUserRepo.EXPECT().ClearLastPresenter(args.channelID).Return(assert.AnError).Times(1)
+				mocks.mockUserRepo.EXPECT().ClearLastPresenter(args.rotationID).Return(assert.AnError).Times(1)
 			},
 			wantErr: true,
 		},
 		{
 			name: "Should return error when SetLastPresenter fails",
 			args: args{
-				channelID: 1,
-				userID:    2,
+				rotationID: 1,
+				userID:     2,
 			},
 			buildMock: func(mocks allMocks, args args) {
 				mocks.mockDataManager.EXPECT().
@@ -742,7 +1265,8 @@ func Test_scheduler_recordPresentation(t *testing.T) {
 					}).Times(1)
 
 				gomock.InOrder(
-					mocks.mockUserRepo.EXPECT().ClearLastPresenter(args.channelID).Return(nil).Times(1),
+					mocks.mockUserRepo.EXPECT().ClearLastPresenter(args.rotationID).Return(nil).Times(1),
+					mocks.mockUserRepo.EXPECT().ClearLastBackups(args.rotationID).Return(nil).Times(1),
 					mocks.mockUserRepo.EXPECT().SetLastPresenter(args.userID).Return(assert.AnError).Times(1),
 				)
 			},
@@ -755,13 +1279,13 @@ func Test_scheduler_recordPresentation(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newScheduler(m.mockDataManager, m.mockSlackClient)
+			s := newScheduler(m.mockDataManager, m.mockSlackClients, m.clock)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			err := s.recordPresentation(tt.args.channelID, tt.args.userID)
+			err := s.recordPresentation(context.Background(), tt.args.rotationID, []*entity.User{{ID: tt.args.userID, SlackUserID: "U123456789"}}, domain.StrategyRoundRobin)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -774,46 +1298,80 @@ func Test_scheduler_recordPresentation(t *testing.T) {
 
 func Test_scheduler_sendNotifications(t *testing.T) {
 	type args struct {
-		channelIDs []int64
+		rotationIDs []int64
+		late        time.Duration
+		occurrence  time.Time // Set from late when running the test
+	}
+
+	// dueConfig is the config of a rotation reminded every day at the occurrence
+	dueConfig := func(rotationID int64, args args) *entity.Scheduler {
+		return &entity.Scheduler{
+			ID:               rotationID,
+			ChannelID:        rotationID,
+			RotationID:       rotationID,
+			NotificationTime: args.occurrence.Format("15:04"),
+			ActiveDays:       []int{1, 2, 3, 4, 5, 6, 7},
+			IsEnabled:        true,
+			Role:             "Daily presenter",
+		}
 	}
+
 	tests := []struct {
 		name      string
 		buildMock func(mocks allMocks, args args)
 		args      args
 	}{
 		{
-			name: "Should send notifications to multiple channels successfully",
-			args: args{channelIDs: []int64{1, 2}},
+			name: "Should send notifications to multiple rotations successfully",
+			args: args{rotationIDs: []int64{1, 2}},
 			buildMock: func(mocks allMocks, args args) {
-				// For each channel, we expect the sendNotificationToChannel flow
-				for _, channelID := range args.channelIDs {
+				// For each rotation, we expect the sendNotificationToRotation flow
+				for _, rotationID := range args.rotationIDs {
+					rotation := &entity.Rotation{ID: rotationID, ChannelID: rotationID, IsPrimary: true}
+
 					channel := &entity.Channel{
-						ID:             channelID,
-						SlackChannelID: fmt.Sprintf("C%d", channelID),
+						ID:             rotation.ChannelID,
+						SlackChannelID: fmt.Sprintf("C%d", rotation.ChannelID),
 					}
 
-					schedulerConfig := &entity.Scheduler{
-						ID:        channelID,
-						ChannelID: channelID,
-						Role:      "Daily presenter",
-					}
+					schedulerConfig := dueConfig(rotationID, args)
 
 					users := []*entity.User{
-						{ID: channelID, SlackUserID: fmt.Sprintf("U%d", channelID), LastPresenter: false},
+						{ID: rotationID, ChannelID: rotation.ChannelID, SlackUserID: fmt.Sprintf("U%d", rotationID), LastPresenter: false},
 					}
 
+					mocks.mockHolidayRepo.EXPECT().
+						GetByChannelID(rotation.ChannelID).
+						Return(nil, nil).Times(1)
+
+					mocks.mockSchedulerRepo.EXPECT().
+						MarkNotified(rotationID, args.occurrence).
+						Return(true, nil).Times(1)
+
+					mocks.mockRotationRepo.EXPECT().
+						GetByID(rotationID).
+						Return(rotation, nil).AnyTimes()
+
 					mocks.mockChannelRepo.EXPECT().
-						GetByID(channelID).
+						GetByID(rotation.ChannelID).
 						Return(channel, nil).AnyTimes()
 
 					mocks.mockSchedulerRepo.EXPECT().
-						GetByChannelID(channelID).
+						GetByRotationID(rotationID).
 						Return(schedulerConfig, nil).AnyTimes()
 
+					mocks.mockSwapRepo.EXPECT().
+						GetPendingByRotation(rotationID, gomock.Any()).
+						Return(nil, nil).AnyTimes()
+
 					mocks.mockUserRepo.EXPECT().
-						GetActiveUsersByChannel(channelID).
+						GetActiveUsersByRotation(rotationID).
 						Return(users, nil).AnyTimes()
 
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(rotation.ChannelID, gomock.Any()).
+						Return(nil, nil).AnyTimes()
+
 					mocks.mockDataManager.EXPECT().
 						WithTransaction(gomock.Any(), gomock.Any()).
 						DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
@@ -821,41 +1379,53 @@ func Test_scheduler_sendNotifications(t *testing.T) {
 						}).AnyTimes()
 
 					mocks.mockSlackClient.EXPECT().
-						PostMessage(channel.SlackChannelID, gomock.Any(), gomock.Any()).
+						PostMessageContext(gomock.Any(), channel.SlackChannelID, gomock.Any(), gomock.Any(), gomock.Any()).
 						Return("", "", nil).AnyTimes()
 				}
 			},
 		},
 		{
-			name: "Should handle empty channel list",
-			args: args{channelIDs: []int64{}},
+			name: "Should handle empty rotation list",
+			args: args{rotationIDs: []int64{}},
 			buildMock: func(mocks allMocks, args args) {
 				// No expectations needed for empty list
 			},
 		},
 		{
-			name: "Should continue sending to other channels even if one fails",
-			args: args{channelIDs: []int64{1, 2}},
+			name: "Should continue sending to other rotations even if one fails",
+			args: args{rotationIDs: []int64{1, 2}},
 			buildMock: func(mocks allMocks, args args) {
-				// Channel 1 will fail (channel not found)
-				mocks.mockChannelRepo.EXPECT().
+				mocks.mockSchedulerRepo.EXPECT().
+					GetByRotationID(int64(1)).
+					Return(dueConfig(1, args), nil).AnyTimes()
+
+				mocks.mockHolidayRepo.EXPECT().
+					GetByChannelID(gomock.Any()).
+					Return(nil, nil).Times(2)
+
+				mocks.mockSchedulerRepo.EXPECT().
+					MarkNotified(gomock.Any(), args.occurrence).
+					Return(true, nil).Times(2)
+
+				// Rotation 1 will fail (rotation not found)
+				mocks.mockRotationRepo.EXPECT().
 					GetByID(int64(1)).
 					Return(nil, nil).AnyTimes()
 
-				// Channel 2 will succeed
+				// Rotation 2 will succeed
+				mocks.mockRotationRepo.EXPECT().
+					GetByID(int64(2)).
+					Return(&entity.Rotation{ID: 2, ChannelID: 2, IsPrimary: true}, nil).AnyTimes()
+
 				channel2 := &entity.Channel{
 					ID:             2,
 					SlackChannelID: "C2",
 				}
 
-				schedulerConfig2 := &entity.Scheduler{
-					ID:        2,
-					ChannelID: 2,
-					Role:      "Daily presenter",
-				}
+				schedulerConfig2 := dueConfig(2, args)
 
 				users2 := []*entity.User{
-					{ID: 2, SlackUserID: "U2", LastPresenter: false},
+					{ID: 2, ChannelID: 2, SlackUserID: "U2", LastPresenter: false},
 				}
 
 				mocks.mockChannelRepo.EXPECT().
@@ -863,13 +1433,21 @@ func Test_scheduler_sendNotifications(t *testing.T) {
 					Return(channel2, nil).AnyTimes()
 
 				mocks.mockSchedulerRepo.EXPECT().
-					GetByChannelID(int64(2)).
+					GetByRotationID(int64(2)).
 					Return(schedulerConfig2, nil).AnyTimes()
 
+				mocks.mockSwapRepo.EXPECT().
+					GetPendingByRotation(int64(2), gomock.Any()).
+					Return(nil, nil).AnyTimes()
+
 				mocks.mockUserRepo.EXPECT().
-					GetActiveUsersByChannel(int64(2)).
+					GetActiveUsersByRotation(int64(2)).
 					Return(users2, nil).AnyTimes()
 
+				mocks.mockAvailabilityRepo.EXPECT().
+					GetUpcomingByChannel(int64(2), gomock.Any()).
+					Return(nil, nil).AnyTimes()
+
 				mocks.mockDataManager.EXPECT().
 					WithTransaction(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
@@ -877,10 +1455,71 @@ func Test_scheduler_sendNotifications(t *testing.T) {
 					}).AnyTimes()
 
 				mocks.mockSlackClient.EXPECT().
-					PostMessage(channel2.SlackChannelID, gomock.Any(), gomock.Any()).
+					PostMessageContext(gomock.Any(), channel2.SlackChannelID, gomock.Any(), gomock.Any(), gomock.Any()).
 					Return("", "", nil).AnyTimes()
 			},
 		},
+		{
+			name: "Should skip rotations already notified for the occurrence",
+			args: args{rotationIDs: []int64{1}},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().GetByRotationID(int64(1)).Return(dueConfig(1, args), nil).Times(1),
+					mocks.mockHolidayRepo.EXPECT().GetByChannelID(int64(1)).Return(nil, nil).Times(1),
+				)
+
+				mocks.mockSchedulerRepo.EXPECT().
+					MarkNotified(int64(1), gomock.Any()).
+					Return(false, nil).Times(1)
+			},
+		},
+		{
+			name: "Should skip rotations that cannot be marked as notified",
+			args: args{rotationIDs: []int64{1}},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().GetByRotationID(int64(1)).Return(dueConfig(1, args), nil).Times(1),
+					mocks.mockHolidayRepo.EXPECT().GetByChannelID(int64(1)).Return(nil, nil).Times(1),
+				)
+
+				mocks.mockSchedulerRepo.EXPECT().
+					MarkNotified(int64(1), gomock.Any()).
+					Return(false, assert.AnError).Times(1)
+			},
+		},
+		{
+			name: "Should skip rotations whose time changed since the occurrence was picked",
+			args: args{rotationIDs: []int64{1}},
+			buildMock: func(mocks allMocks, args args) {
+				// Changed through another instance of the bot, which does not wake this scheduler up
+				moved := dueConfig(1, args)
+				moved.NotificationTime = args.occurrence.Add(time.Hour).Format("15:04")
+
+				gomock.InOrder(
+					mocks.mockSchedulerRepo.EXPECT().GetByRotationID(int64(1)).Return(moved, nil).Times(1),
+					mocks.mockHolidayRepo.EXPECT().GetByChannelID(int64(1)).Return(nil, nil).Times(1),
+				)
+			},
+		},
+		{
+			name: "Should skip rotations paused since the occurrence was picked",
+			args: args{rotationIDs: []int64{1}},
+			buildMock: func(mocks allMocks, args args) {
+				paused := dueConfig(1, args)
+				paused.IsEnabled = false
+
+				mocks.mockSchedulerRepo.EXPECT().
+					GetByRotationID(int64(1)).
+					Return(paused, nil).Times(1)
+			},
+		},
+		{
+			name: "Should drop notifications later than the grace period",
+			args: args{rotationIDs: []int64{1}, late: 2 * time.Hour},
+			buildMock: func(mocks allMocks, args args) {
+				// No expectations, nothing is sent
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -888,17 +1527,25 @@ func Test_scheduler_sendNotifications(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
 
-			s := newScheduler(m.mockDataManager, m.mockSlackClient)
+			s := newScheduler(m.mockDataManager, m.mockSlackClients, m.clock)
+
+			tt.args.occurrence = m.clock.Now().UTC().Add(-tt.args.late).Truncate(time.Minute)
 
 			if tt.buildMock != nil {
 				tt.buildMock(m, tt.args)
 			}
 
-			// Since sendNotifications uses goroutines, we need to give it time to complete
-			s.sendNotifications(tt.args.channelIDs)
-			
-			// Give goroutines time to complete
-			time.Sleep(50 * time.Millisecond)
+			s.startWorkers()
+
+			rotations := make([]*queuedRotation, 0, len(tt.args.rotationIDs))
+			for _, rotationID := range tt.args.rotationIDs {
+				rotations = append(rotations, &queuedRotation{config: &entity.Scheduler{ChannelID: rotationID, RotationID: rotationID}})
+			}
+
+			s.sendNotifications(tt.args.occurrence, rotations)
+
+			// Wait for the workers to be done with the reminders
+			s.stopWorkers()
 		})
 	}
 }
@@ -912,127 +1559,138 @@ func Test_scheduler_mainLoop(t *testing.T) {
 		{
 			name: "Should handle no active channels and respond to config change",
 			buildMock: func(mocks allMocks) {
-				// First call returns no channels
-				// Second call (after config change) also returns no channels
+				// First load returns no rotations, then the changed channel has none either
 				mocks.mockSchedulerRepo.EXPECT().
 					GetEnabled().
 					Return([]*entity.Scheduler{}, nil).
-					Times(2)
+					Times(1)
+
+				mocks.mockSchedulerRepo.EXPECT().
+					GetEnabledByChannelID(int64(1)).
+					Return([]*entity.Scheduler{}, nil).
+					Times(1)
 			},
 			testFunc: func(t *testing.T, s *scheduler) {
-				// Start the mainLoop in a goroutine
-				go s.mainLoop()
-				
+				s.Start()
+
 				// Give it a moment to enter the first loop
 				time.Sleep(10 * time.Millisecond)
-				
+
 				// Trigger config change
-				s.NotifyConfigChange()
-				
+				s.NotifyConfigChange(1)
+
 				// Give it time to process
 				time.Sleep(50 * time.Millisecond)
-				
-				// Stop the scheduler
-				close(s.stopChan)
-				
+
+				s.Stop()
+
 				// Wait for goroutine to finish
 				time.Sleep(10 * time.Millisecond)
 			},
 		},
 		{
-			name: "Should send notifications immediately when time has passed",
+			name: "Should send a missed notification immediately",
 			buildMock: func(mocks allMocks) {
-				// Use a simple time that already passed today (early morning)
+				now := time.Now().UTC()
+
+				// Due five minutes ago, notified for the last time two days ago
 				scheduler := &entity.Scheduler{
 					ID:               1,
 					ChannelID:        1,
-					NotificationTime: "06:00", // 6 AM - likely already passed
-					ActiveDays:       []int{1, 2, 3, 4, 5, 6, 7}, // All days
+					RotationID:       1,
+					NotificationTime: now.Add(-5 * time.Minute).Format("15:04"),
+					ActiveDays:       []int{1, 2, 3, 4, 5, 6, 7},
 					IsEnabled:        true,
+					Role:             "Daily presenter",
+					LastNotifiedAt:   now.AddDate(0, 0, -2),
 				}
-				
-				// Mock for findNextNotification (time already passed, returns next day)
+
+				mocks.mockHolidayRepo.EXPECT().
+					GetByChannelID(scheduler.ChannelID).
+					Return(nil, nil).
+					AnyTimes()
+
 				mocks.mockSchedulerRepo.EXPECT().
 					GetEnabled().
 					Return([]*entity.Scheduler{scheduler}, nil).
-					AnyTimes()
-				
-				// Mock for sendNotificationToChannel
-				channel := &entity.Channel{
-					ID:             1,
-					SlackChannelID: "C123456789",
-				}
-				
-				schedulerConfig := &entity.Scheduler{
-					ID:        1,
-					ChannelID: 1,
-					Role:      "Daily presenter",
-				}
-				
-				users := []*entity.User{
-					{ID: 1, SlackUserID: "U123456789", LastPresenter: false},
-				}
-				
+					Times(1)
+
+				mocks.mockSchedulerRepo.EXPECT().
+					GetByRotationID(int64(1)).
+					Return(scheduler, nil).AnyTimes()
+
+				mocks.mockSchedulerRepo.EXPECT().
+					MarkNotified(int64(1), gomock.Any()).
+					Return(true, nil).Times(1)
+
+				mocks.mockRotationRepo.EXPECT().
+					GetByID(int64(1)).
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, IsPrimary: true}, nil).AnyTimes()
+
 				mocks.mockChannelRepo.EXPECT().
 					GetByID(int64(1)).
-					Return(channel, nil).AnyTimes()
-				
-				mocks.mockSchedulerRepo.EXPECT().
-					GetByChannelID(int64(1)).
-					Return(schedulerConfig, nil).AnyTimes()
-				
+					Return(&entity.Channel{ID: 1, SlackChannelID: "C123456789"}, nil).AnyTimes()
+
+				mocks.mockSwapRepo.EXPECT().
+					GetPendingByRotation(int64(1), gomock.Any()).
+					Return(nil, nil).AnyTimes()
+
 				mocks.mockUserRepo.EXPECT().
-					GetActiveUsersByChannel(int64(1)).
-					Return(users, nil).AnyTimes()
-				
+					GetActiveUsersByRotation(int64(1)).
+					Return([]*entity.User{{ID: 1, ChannelID: 1, SlackUserID: "U123456789"}}, nil).AnyTimes()
+
+				mocks.mockAvailabilityRepo.EXPECT().
+					GetUpcomingByChannel(int64(1), gomock.Any()).
+					Return(nil, nil).AnyTimes()
+
 				mocks.mockDataManager.EXPECT().
 					WithTransaction(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
 						return nil
 					}).AnyTimes()
-				
+
 				mocks.mockSlackClient.EXPECT().
-					PostMessage(channel.SlackChannelID, gomock.Any(), gomock.Any()).
-					Return("", "", nil).AnyTimes()
+					PostMessageContext(gomock.Any(), "C123456789", gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("", "", nil).Times(1)
 			},
 			testFunc: func(t *testing.T, s *scheduler) {
-				go s.mainLoop()
-				
+				s.Start()
+
 				// Wait for processing
 				time.Sleep(200 * time.Millisecond)
-				
-				close(s.stopChan)
+
+				s.Stop()
 				time.Sleep(50 * time.Millisecond)
 			},
 		},
 		{
 			name: "Should handle scheduler errors gracefully",
 			buildMock: func(mocks allMocks) {
-				// First call returns error, then we should get another call during the 1-hour wait
+				// First load returns error, the queue stays empty until the next resync
 				mocks.mockSchedulerRepo.EXPECT().
 					GetEnabled().
 					Return(nil, assert.AnError).
 					Times(1)
-				
-				// During the 1-hour wait, there will be another call when we trigger config change
+
+				// A config change still loads the channel
 				mocks.mockSchedulerRepo.EXPECT().
-					GetEnabled().
+					GetEnabledByChannelID(int64(1)).
 					Return([]*entity.Scheduler{}, nil).
-					AnyTimes()
+					Times(1)
 			},
 			testFunc: func(t *testing.T, s *scheduler) {
-				go s.mainLoop()
-				
-				// Wait for error handling - goes into 1-hour wait
+				s.Start()
+
+				// Wait for error handling - waits for the next resync
 				time.Sleep(50 * time.Millisecond)
-				
+
 				// Trigger config change to interrupt the wait
-				s.NotifyConfigChange()
-				
+				s.NotifyConfigChange(1)
+
 				// Wait for config change processing
 				time.Sleep(50 * time.Millisecond)
-				
-				close(s.stopChan)
+
+				s.Stop()
 				time.Sleep(50 * time.Millisecond)
 			},
 		},
@@ -1041,40 +1699,46 @@ func Test_scheduler_mainLoop(t *testing.T) {
 			buildMock: func(mocks allMocks) {
 				now := time.Now().UTC()
 				futureTime := now.Add(1 * time.Hour) // 1 hour in future
-				
+
 				scheduler := &entity.Scheduler{
 					ID:               1,
 					ChannelID:        1,
+					RotationID:       1,
 					NotificationTime: futureTime.Format("15:04"),
-					ActiveDays:       []int{int(now.Weekday())}, // Today
+					ActiveDays:       []int{1, 2, 3, 4, 5, 6, 7},
 					IsEnabled:        true,
 				}
-				
-				// First call - returns scheduler with long wait time
+
+				mocks.mockHolidayRepo.EXPECT().
+					GetByChannelID(scheduler.ChannelID).
+					Return(nil, nil).
+					AnyTimes()
+
+				// First load - returns scheduler with long wait time
 				mocks.mockSchedulerRepo.EXPECT().
 					GetEnabled().
 					Return([]*entity.Scheduler{scheduler}, nil).
 					Times(1)
-				
-				// Second call after config change - return empty to exit loop
+
+				// Only the changed channel is loaded again, now paused
 				mocks.mockSchedulerRepo.EXPECT().
-					GetEnabled().
+					GetEnabledByChannelID(int64(1)).
 					Return([]*entity.Scheduler{}, nil).
 					Times(1)
 			},
 			testFunc: func(t *testing.T, s *scheduler) {
-				go s.mainLoop()
-				
+				s.Start()
+
 				// Give timer time to start
 				time.Sleep(50 * time.Millisecond)
-				
+
 				// Trigger config change to interrupt timer
-				s.NotifyConfigChange()
-				
+				s.NotifyConfigChange(1)
+
 				// Wait for config change to be processed
 				time.Sleep(50 * time.Millisecond)
-				
-				close(s.stopChan)
+
+				s.Stop()
 				time.Sleep(10 * time.Millisecond)
 			},
 		},
@@ -1083,29 +1747,35 @@ func Test_scheduler_mainLoop(t *testing.T) {
 			buildMock: func(mocks allMocks) {
 				now := time.Now().UTC()
 				futureTime := now.Add(1 * time.Hour) // 1 hour in future
-				
+
 				scheduler := &entity.Scheduler{
 					ID:               1,
 					ChannelID:        1,
+					RotationID:       1,
 					NotificationTime: futureTime.Format("15:04"),
-					ActiveDays:       []int{int(now.Weekday())}, // Today
+					ActiveDays:       []int{1, 2, 3, 4, 5, 6, 7},
 					IsEnabled:        true,
 				}
-				
+
+				mocks.mockHolidayRepo.EXPECT().
+					GetByChannelID(scheduler.ChannelID).
+					Return(nil, nil).
+					AnyTimes()
+
 				mocks.mockSchedulerRepo.EXPECT().
 					GetEnabled().
 					Return([]*entity.Scheduler{scheduler}, nil).
 					Times(1)
 			},
 			testFunc: func(t *testing.T, s *scheduler) {
-				go s.mainLoop()
-				
+				s.Start()
+
 				// Give timer time to start
 				time.Sleep(50 * time.Millisecond)
-				
+
 				// Stop scheduler while timer is waiting
-				close(s.stopChan)
-				
+				s.Stop()
+
 				// Wait for goroutine to exit
 				time.Sleep(50 * time.Millisecond)
 			},
@@ -1113,41 +1783,41 @@ func Test_scheduler_mainLoop(t *testing.T) {
 		{
 			name: "Should handle stop signal during no-channels wait",
 			buildMock: func(mocks allMocks) {
-				// Return no channels to trigger 1-hour wait
+				// Return no channels to wait for the next resync
 				mocks.mockSchedulerRepo.EXPECT().
 					GetEnabled().
 					Return([]*entity.Scheduler{}, nil).
 					Times(1)
 			},
 			testFunc: func(t *testing.T, s *scheduler) {
-				go s.mainLoop()
-				
+				s.Start()
+
 				// Give it time to enter no-channels wait
 				time.Sleep(50 * time.Millisecond)
-				
+
 				// Stop scheduler during no-channels wait
-				close(s.stopChan)
-				
+				s.Stop()
+
 				// Wait for goroutine to exit
 				time.Sleep(50 * time.Millisecond)
 			},
 		},
 	}
-	
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m, ctrl := newServiceTestMock(t)
 			defer ctrl.Finish()
-			
-			s := newScheduler(m.mockDataManager, m.mockSlackClient)
-			
+
+			s := newScheduler(m.mockDataManager, m.mockSlackClients, m.clock)
+
 			if tt.buildMock != nil {
 				tt.buildMock(m)
 			}
-			
+
 			if tt.testFunc != nil {
 				tt.testFunc(t, s)
 			}
 		})
 	}
-}
\ No newline at end of file
+}
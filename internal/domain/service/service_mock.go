@@ -3,17 +3,26 @@ package service
 import (
 	"testing"
 
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
 	"github.com/diegoclair/slack-rotation-bot/mocks"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
 type allMocks struct {
-	mockDataManager   *mocks.MockDataManager
-	mockChannelRepo   *mocks.MockChannelRepo
-	mockUserRepo      *mocks.MockUserRepo
-	mockSchedulerRepo *mocks.MockSchedulerRepo
-	mockSlackClient   *mocks.MockSlackClient
+	mockDataManager      *mocks.MockDataManager
+	mockChannelRepo      *mocks.MockChannelRepo
+	mockUserRepo         *mocks.MockUserRepo
+	mockSchedulerRepo    *mocks.MockSchedulerRepo
+	mockHolidayRepo      *mocks.MockHolidayRepo
+	mockAvailabilityRepo *mocks.MockAvailabilityRepo
+	mockHistoryRepo      *mocks.MockHistoryRepo
+	mockSwapRepo         *mocks.MockSwapRepo
+	mockRotationRepo     *mocks.MockRotationRepo
+	mockInstallationRepo *mocks.MockInstallationRepo
+	mockSlackClient      *mocks.MockSlackClient
+	mockSlackClients     *mocks.MockSlackClientFactory
+	clock                contract.Clock
 }
 
 func newServiceTestMock(t *testing.T) (m allMocks, ctrl *gomock.Controller) {
@@ -32,18 +41,46 @@ func newServiceTestMock(t *testing.T) (m allMocks, ctrl *gomock.Controller) {
 	schedulerRepo := mocks.NewMockSchedulerRepo(ctrl)
 	dm.EXPECT().Scheduler().Return(schedulerRepo).AnyTimes()
 
+	holidayRepo := mocks.NewMockHolidayRepo(ctrl)
+	dm.EXPECT().Holiday().Return(holidayRepo).AnyTimes()
+
+	availabilityRepo := mocks.NewMockAvailabilityRepo(ctrl)
+	dm.EXPECT().Availability().Return(availabilityRepo).AnyTimes()
+
+	historyRepo := mocks.NewMockHistoryRepo(ctrl)
+	dm.EXPECT().History().Return(historyRepo).AnyTimes()
+
+	swapRepo := mocks.NewMockSwapRepo(ctrl)
+	dm.EXPECT().Swap().Return(swapRepo).AnyTimes()
+
+	rotationRepo := mocks.NewMockRotationRepo(ctrl)
+	dm.EXPECT().Rotation().Return(rotationRepo).AnyTimes()
+
+	installationRepo := mocks.NewMockInstallationRepo(ctrl)
+	dm.EXPECT().Installation().Return(installationRepo).AnyTimes()
+
 	slackClient := mocks.NewMockSlackClient(ctrl)
+	slackClients := mocks.NewMockSlackClientFactory(ctrl)
+	slackClients.EXPECT().ForTeam(gomock.Any()).Return(slackClient, nil).AnyTimes()
 
 	m = allMocks{
-		mockDataManager:   dm,
-		mockChannelRepo:   channelRepo,
-		mockUserRepo:      userRepo,
-		mockSchedulerRepo: schedulerRepo,
-		mockSlackClient:   slackClient,
+		mockDataManager:      dm,
+		mockChannelRepo:      channelRepo,
+		mockUserRepo:         userRepo,
+		mockSchedulerRepo:    schedulerRepo,
+		mockHolidayRepo:      holidayRepo,
+		mockAvailabilityRepo: availabilityRepo,
+		mockHistoryRepo:      historyRepo,
+		mockSwapRepo:         swapRepo,
+		mockRotationRepo:     rotationRepo,
+		mockInstallationRepo: installationRepo,
+		mockSlackClient:      slackClient,
+		mockSlackClients:     slackClients,
+		clock:                NewSystemClock(),
 	}
 
 	// validate service creation
-	rotationService := newRotation(dm, slackClient)
+	rotationService := newRotation(dm, slackClients, m.clock)
 	require.NotNil(t, rotationService)
 
 	return
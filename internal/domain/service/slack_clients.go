@@ -0,0 +1,103 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+)
+
+// slackClientTTL is how long the client of an installed workspace is used before its
+// installation is read again, so a token changed through another instance of the bot is picked
+// up. The client is kept when the token did not change.
+const slackClientTTL = 5 * time.Minute
+
+// slackClients is the contract.SlackClientFactory reading the bot token of each workspace from
+// its installation. The clients of the installed workspaces are kept for slackClientTTL or until
+// Forget is called, the workspaces without installation look it up on every call so they get
+// their own client as soon as they install the app.
+type slackClients struct {
+	dm            contract.DataManager
+	clock         contract.Clock
+	defaultClient contract.SlackClient
+	newClient     func(botToken string) contract.SlackClient
+
+	// mu guards clients, it is not held while the installation is read
+	mu      sync.Mutex
+	clients map[string]*cachedSlackClient
+}
+
+// cachedSlackClient is the client of an installed workspace with the token it was created with
+type cachedSlackClient struct {
+	client    contract.SlackClient
+	botToken  string
+	expiresAt time.Time
+}
+
+// NewSlackClients returns the factory creating the clients of the installed workspaces with
+// newClient. defaultClient is used for the workspaces without installation, like the one of
+// SLACK_BOT_TOKEN, it can be nil when every workspace installs the app through OAuth.
+func NewSlackClients(dm contract.DataManager, clock contract.Clock, defaultClient contract.SlackClient, newClient func(botToken string) contract.SlackClient) contract.SlackClientFactory {
+	return &slackClients{
+		dm:            dm,
+		clock:         clock,
+		defaultClient: defaultClient,
+		newClient:     newClient,
+		clients:       make(map[string]*cachedSlackClient),
+	}
+}
+
+func (f *slackClients) ForTeam(teamID string) (contract.SlackClient, error) {
+	if teamID == "" {
+		return f.fallback(teamID)
+	}
+
+	now := f.clock.Now()
+
+	f.mu.Lock()
+	cached := f.clients[teamID]
+	f.mu.Unlock()
+
+	if cached != nil && now.Before(cached.expiresAt) {
+		return cached.client, nil
+	}
+
+	installation, err := f.dm.Installation().GetByTeamID(teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installation: %w", err)
+	}
+
+	if installation == nil {
+		f.Forget(teamID)
+		return f.fallback(teamID)
+	}
+
+	var client contract.SlackClient
+	if cached != nil && cached.botToken == installation.BotToken {
+		client = cached.client
+	} else {
+		client = f.newClient(installation.BotToken)
+	}
+
+	f.mu.Lock()
+	f.clients[teamID] = &cachedSlackClient{client: client, botToken: installation.BotToken, expiresAt: now.Add(slackClientTTL)}
+	f.mu.Unlock()
+
+	return client, nil
+}
+
+// fallback returns the default client for a workspace without installation
+func (f *slackClients) fallback(teamID string) (contract.SlackClient, error) {
+	if f.defaultClient == nil {
+		return nil, fmt.Errorf("workspace %s has not installed the app", teamID)
+	}
+	return f.defaultClient, nil
+}
+
+func (f *slackClients) Forget(teamID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.clients, teamID)
+}
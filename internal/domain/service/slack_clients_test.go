@@ -0,0 +1,146 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/diegoclair/slack-rotation-bot/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_slackClients_ForTeam(t *testing.T) {
+	// The tokens the clients are created with are recorded, each client is a different mock.
+	// With withDefault the mocked Slack client is the default one.
+	setup := func(t *testing.T, withDefault bool) (allMocks, contract.SlackClientFactory, *[]string, *fakeClock) {
+		m, ctrl := newServiceTestMock(t)
+		t.Cleanup(ctrl.Finish)
+
+		var defaultClient contract.SlackClient
+		if withDefault {
+			defaultClient = m.mockSlackClient
+		}
+
+		clock := newFakeClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+		var tokens []string
+		factory := NewSlackClients(m.mockDataManager, clock, defaultClient, func(botToken string) contract.SlackClient {
+			tokens = append(tokens, botToken)
+			return mocks.NewMockSlackClient(ctrl)
+		})
+		return m, factory, &tokens, clock
+	}
+
+	t.Run("should create the client of an installed workspace once", func(t *testing.T) {
+		m, factory, tokens, _ := setup(t, false)
+
+		m.mockInstallationRepo.EXPECT().
+			GetByTeamID("T123456789").
+			Return(&entity.Installation{SlackTeamID: "T123456789", BotToken: "xoxb-team"}, nil).
+			Times(1)
+
+		first, err := factory.ForTeam("T123456789")
+		require.NoError(t, err)
+		second, err := factory.ForTeam("T123456789")
+		require.NoError(t, err)
+
+		assert.Same(t, first, second)
+		assert.Equal(t, []string{"xoxb-team"}, *tokens)
+	})
+
+	t.Run("should use the default client for a workspace without installation", func(t *testing.T) {
+		m, factory, tokens, _ := setup(t, true)
+
+		m.mockInstallationRepo.EXPECT().GetByTeamID("T123456789").Return(nil, nil).Times(1)
+
+		client, err := factory.ForTeam("T123456789")
+		require.NoError(t, err)
+		assert.Equal(t, m.mockSlackClient, client)
+
+		// Installed since then, maybe through another instance of the bot
+		m.mockInstallationRepo.EXPECT().
+			GetByTeamID("T123456789").
+			Return(&entity.Installation{SlackTeamID: "T123456789", BotToken: "xoxb-team"}, nil).
+			Times(1)
+
+		client, err = factory.ForTeam("T123456789")
+		require.NoError(t, err)
+		assert.NotSame(t, m.mockSlackClient, client)
+		assert.Equal(t, []string{"xoxb-team"}, *tokens)
+
+		// Requests without team skip the lookup
+		client, err = factory.ForTeam("")
+		require.NoError(t, err)
+		assert.Equal(t, m.mockSlackClient, client)
+	})
+
+	t.Run("should return error for a workspace without installation nor default client", func(t *testing.T) {
+		m, factory, _, _ := setup(t, false)
+
+		m.mockInstallationRepo.EXPECT().GetByTeamID("T123456789").Return(nil, nil).Times(1)
+
+		_, err := factory.ForTeam("T123456789")
+		require.Error(t, err)
+	})
+
+	t.Run("should return error when the installation lookup fails", func(t *testing.T) {
+		m, factory, _, _ := setup(t, false)
+
+		m.mockInstallationRepo.EXPECT().GetByTeamID("T123456789").Return(nil, assert.AnError).Times(1)
+
+		_, err := factory.ForTeam("T123456789")
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should create the client again after being forgotten", func(t *testing.T) {
+		m, factory, tokens, _ := setup(t, false)
+
+		m.mockInstallationRepo.EXPECT().
+			GetByTeamID("T123456789").
+			Return(&entity.Installation{SlackTeamID: "T123456789", BotToken: "xoxb-old"}, nil).
+			Times(1)
+		_, err := factory.ForTeam("T123456789")
+		require.NoError(t, err)
+
+		factory.Forget("T123456789")
+
+		m.mockInstallationRepo.EXPECT().
+			GetByTeamID("T123456789").
+			Return(&entity.Installation{SlackTeamID: "T123456789", BotToken: "xoxb-new"}, nil).
+			Times(1)
+		_, err = factory.ForTeam("T123456789")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"xoxb-old", "xoxb-new"}, *tokens)
+	})
+
+	t.Run("should read the installation again once the client expired", func(t *testing.T) {
+		m, factory, tokens, clock := setup(t, false)
+
+		installation := &entity.Installation{SlackTeamID: "T123456789", BotToken: "xoxb-old"}
+		m.mockInstallationRepo.EXPECT().GetByTeamID("T123456789").Return(installation, nil).Times(2)
+
+		first, err := factory.ForTeam("T123456789")
+		require.NoError(t, err)
+
+		// Same token, the client is kept
+		clock.advanceTo(clock.Now().Add(slackClientTTL))
+		second, err := factory.ForTeam("T123456789")
+		require.NoError(t, err)
+		assert.Same(t, first, second)
+
+		// Reinstalled through another instance of the bot
+		m.mockInstallationRepo.EXPECT().
+			GetByTeamID("T123456789").
+			Return(&entity.Installation{SlackTeamID: "T123456789", BotToken: "xoxb-new"}, nil).
+			Times(1)
+
+		clock.advanceTo(clock.Now().Add(slackClientTTL))
+		third, err := factory.ForTeam("T123456789")
+		require.NoError(t, err)
+		assert.NotSame(t, first, third)
+
+		assert.Equal(t, []string{"xoxb-old", "xoxb-new"}, *tokens)
+	})
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+)
+
+// Strategy decides which member takes the next turn of a rotation. Next must not change the
+// rotation, asking who is next does not give the turn to anyone.
+type Strategy interface {
+	// Next returns the member that takes the next turn, or nil when every member is away
+	Next(state *rotationState) (*entity.User, error)
+}
+
+// reorderingStrategy is a Strategy that also decides the rotation order. The order it returns
+// is saved when the next turn is recorded.
+type reorderingStrategy interface {
+	Strategy
+	// Order returns the rotation order of the next turn, nil to keep the current one
+	Order(state *rotationState) ([]*entity.User, error)
+}
+
+// rotationState is what a strategy can look at to pick the next presenter
+type rotationState struct {
+	dm         contract.DataManager
+	rotationID int64
+	users      []*entity.User // Active members in rotation order, updated when a strategy reorders them
+	away       map[int64]bool // Members that cannot take the turn
+
+	stats     map[int64]*entity.TurnStats // Presenter turns of each member, loaded on first use
+	projected []projectedTurn             // Turns taken by projectTurns, not in the history
+}
+
+// projectedTurn is a turn a projection gave to a member, counted in the turn stats
+type projectedTurn struct {
+	userID int64
+	date   time.Time
+}
+
+// turnStats returns the presenter turns taken by each member, with the projected turns
+func (state *rotationState) turnStats() (map[int64]*entity.TurnStats, error) {
+	if state.stats != nil {
+		return state.stats, nil
+	}
+
+	stats, err := state.dm.History().GetTurnStats(state.rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get turn stats: %w", err)
+	}
+
+	state.stats = make(map[int64]*entity.TurnStats)
+	for _, stat := range stats {
+		userStats := *stat
+		state.stats[stat.UserID] = &userStats
+	}
+
+	for _, turn := range state.projected {
+		countTurn(state.stats, turn)
+	}
+
+	return state.stats, nil
+}
+
+// takeTurn gives the turn to presenter without writing anything, so a projection can ask the
+// strategy for the following turns
+func (state *rotationState) takeTurn(presenter *entity.User, date time.Time) {
+	for _, user := range state.users {
+		user.LastPresenter = user.ID == presenter.ID
+	}
+
+	turn := projectedTurn{userID: presenter.ID, date: date}
+	state.projected = append(state.projected, turn)
+	if state.stats != nil {
+		countTurn(state.stats, turn)
+	}
+}
+
+// countTurn adds turn to the stats of its member
+func countTurn(stats map[int64]*entity.TurnStats, turn projectedTurn) {
+	userStats, ok := stats[turn.userID]
+	if !ok {
+		userStats = &entity.TurnStats{UserID: turn.userID}
+		stats[turn.userID] = userStats
+	}
+	userStats.Turns++
+	userStats.LastTurnAt = turn.date
+}
+
+// strategies holds the built-in strategies by their configuration name
+var strategies = map[string]Strategy{
+	domain.StrategyRoundRobin: roundRobinStrategy{},
+	domain.StrategyShuffled:   shuffledStrategy{},
+	domain.StrategyFair:       fairStrategy{},
+}
+
+// strategyAliases maps alternative names accepted by /rotation config strategy
+var strategyAliases = map[string]string{
+	"roundrobin": domain.StrategyRoundRobin,
+	"rr":         domain.StrategyRoundRobin,
+	"random":     domain.StrategyShuffled,
+	"shuffle":    domain.StrategyShuffled,
+	"lrs":        domain.StrategyFair,
+}
+
+// getStrategy returns the strategy registered under name, round-robin when unknown
+func getStrategy(name string) Strategy {
+	if strategy, ok := strategies[name]; ok {
+		return strategy
+	}
+	return strategies[domain.DefaultStrategy]
+}
+
+// parseStrategy validates a strategy name such as "fair", returning its canonical name
+func parseStrategy(input string) (string, error) {
+	name := strings.ToLower(strings.TrimSpace(input))
+	if alias, ok := strategyAliases[name]; ok {
+		name = alias
+	}
+
+	if _, ok := strategies[name]; !ok {
+		return "", fmt.Errorf("invalid strategy. Use %s, %s or %s", domain.StrategyRoundRobin, domain.StrategyShuffled, domain.StrategyFair)
+	}
+
+	return name, nil
+}
+
+// selectAssignees is the single place where the next turn of a rotation is assigned,
+// used by both the scheduler and the slash commands. The strategy picks the presenter,
+// returned first, and up to count-1 backups follow in rotation order so the presenter
+// sequence stays the same whatever the number of assignees. It returns nil when the
+// rotation is empty and domain.ErrEveryoneAway when every member is away on day.
+func selectAssignees(dm contract.DataManager, rotationID int64, strategyName string, count int, day time.Time) ([]*entity.User, error) {
+	users, err := dm.User().GetActiveUsersByRotation(rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	away, err := getAwayUserIDs(dm, users, day)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &rotationState{
+		dm:         dm,
+		rotationID: rotationID,
+		users:      users,
+		away:       away,
+	}
+
+	presenter, err := getStrategy(strategyName).Next(state)
+	if err != nil {
+		return nil, err
+	}
+
+	if presenter == nil {
+		return nil, domain.ErrEveryoneAway
+	}
+
+	return append([]*entity.User{presenter}, pickBackups(state, presenter, count-1)...), nil
+}
+
+// pickBackups returns up to count available members following presenter in the rotation
+// order. Fewer are returned when there are not enough members available.
+func pickBackups(state *rotationState, presenter *entity.User, count int) []*entity.User {
+	start := -1
+	for i, user := range state.users {
+		if user.ID == presenter.ID {
+			start = i
+			break
+		}
+	}
+
+	var backups []*entity.User
+	for i := 1; i < len(state.users) && len(backups) < count; i++ {
+		user := state.users[(start+i)%len(state.users)]
+		if user.ID == presenter.ID || state.away[user.ID] {
+			continue
+		}
+		backups = append(backups, user)
+	}
+
+	return backups
+}
+
+// lastPresenterIndex returns the position of the current presenter in users, -1 when there is none
+func lastPresenterIndex(users []*entity.User) int {
+	for i, user := range users {
+		if user.LastPresenter {
+			return i
+		}
+	}
+	return -1
+}
+
+// roundRobinStrategy follows the rotation order, starting after the current presenter
+type roundRobinStrategy struct{}
+
+func (roundRobinStrategy) Next(state *rotationState) (*entity.User, error) {
+	startIndex := (lastPresenterIndex(state.users) + 1) % len(state.users)
+	return nextAvailableUser(state.users, startIndex, state.away), nil
+}
+
+// shuffledStrategy goes through the members in a random order, once per cycle. A new order
+// is drawn when a cycle starts and saved as the rotation order when its first turn is recorded,
+// so /rotation list shows it.
+type shuffledStrategy struct{}
+
+func (strategy shuffledStrategy) Next(state *rotationState) (*entity.User, error) {
+	order, err := strategy.Order(state)
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		// In the middle of a cycle, keep following the order drawn when it started
+		return nextAvailableUser(state.users, lastPresenterIndex(state.users)+1, state.away), nil
+	}
+
+	state.users = order
+	return nextAvailableUser(order, 0, state.away), nil
+}
+
+// Order draws the order of a new cycle when the current one is over, nil in the middle of a cycle
+func (shuffledStrategy) Order(state *rotationState) ([]*entity.User, error) {
+	lastIndex := lastPresenterIndex(state.users)
+	if lastIndex >= 0 && lastIndex < len(state.users)-1 {
+		return nil, nil
+	}
+
+	// The seed only changes when a new turn is taken, so asking who is next several times
+	// before the turn is taken always gives the same answer, and a projection gets the
+	// order the rotation will draw
+	stats, err := state.turnStats()
+	if err != nil {
+		return nil, err
+	}
+	var turns int64
+	for _, userStats := range stats {
+		turns += int64(userStats.Turns)
+	}
+
+	return shuffleCycle(state.users, state.rotationID, turns), nil
+}
+
+// saveOrder stores the rotation order chosen by the strategy for the turn being recorded, before
+// the turn moves. It must run inside the transaction recording the turn.
+func saveOrder(tx contract.DataManager, rotationID int64, strategyName string) error {
+	strategy, ok := getStrategy(strategyName).(reorderingStrategy)
+	if !ok {
+		return nil
+	}
+
+	users, err := tx.User().GetActiveUsersByRotation(rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to get users: %w", err)
+	}
+
+	if len(users) == 0 {
+		return nil
+	}
+
+	order, err := strategy.Order(&rotationState{dm: tx, rotationID: rotationID, users: users})
+	if err != nil {
+		return err
+	}
+
+	if order == nil {
+		return nil
+	}
+
+	userIDs := make([]int64, len(order))
+	for i, user := range order {
+		userIDs[i] = user.ID
+	}
+
+	if err := tx.User().UpdatePositions(userIDs); err != nil {
+		return fmt.Errorf("failed to save rotation order: %w", err)
+	}
+
+	return nil
+}
+
+// shuffleCycle returns the members in a random order that only depends on the seed values.
+// The current presenter goes last so nobody takes two turns in a row between cycles.
+func shuffleCycle(users []*entity.User, rotationID, turns int64) []*entity.User {
+	order := append([]*entity.User{}, users...)
+	sort.Slice(order, func(i, j int) bool {
+		return order[i].ID < order[j].ID
+	})
+
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%d:%d", rotationID, turns)
+	random := rand.New(rand.NewPCG(hash.Sum64(), uint64(turns)))
+	random.Shuffle(len(order), func(i, j int) {
+		order[i], order[j] = order[j], order[i]
+	})
+
+	for i, user := range order {
+		if user.LastPresenter {
+			order = append(append(order[:i:i], order[i+1:]...), user)
+			break
+		}
+	}
+
+	return order
+}
+
+// fairStrategy picks the member with the fewest turns, breaking ties by who waited the
+// longest since their last turn and then by the rotation order
+type fairStrategy struct{}
+
+func (fairStrategy) Next(state *rotationState) (*entity.User, error) {
+	byUser, err := state.turnStats()
+	if err != nil {
+		return nil, err
+	}
+
+	var selected *entity.User
+	var selectedStats entity.TurnStats
+	for _, user := range state.users {
+		if state.away[user.ID] {
+			continue
+		}
+
+		var userStats entity.TurnStats
+		if stat, ok := byUser[user.ID]; ok {
+			userStats = *stat
+		}
+
+		if selected == nil ||
+			userStats.Turns < selectedStats.Turns ||
+			(userStats.Turns == selectedStats.Turns && userStats.LastTurnAt.Before(selectedStats.LastTurnAt)) {
+			selected = user
+			selectedStats = userStats
+		}
+	}
+
+	return selected, nil
+}
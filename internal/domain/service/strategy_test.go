@@ -0,0 +1,564 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_selectAssignees(t *testing.T) {
+	type args struct {
+		rotationID int64
+		strategy   string
+		assignees  int // One when zero
+		day        time.Time
+	}
+	tests := []struct {
+		name        string
+		buildMock   func(mocks allMocks, args args)
+		args        args
+		want        *entity.User // Presenter
+		wantBackups []int64
+		wantAnyOf   []int64 // Accepted user IDs when the pick is random
+		wantErr     bool
+	}{
+		{
+			name: "Should return first user when no current presenter",
+			args: args{rotationID: 1, strategy: domain.StrategyRoundRobin, day: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", LastPresenter: false},
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321", LastPresenter: false},
+				}
+
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(users, nil).Times(1)
+
+				mocks.mockAvailabilityRepo.EXPECT().
+					GetUpcomingByChannel(int64(1), time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)).
+					Return(nil, nil).Times(1)
+			},
+			want:    &entity.User{ID: 1, ChannelID: 1, SlackUserID: "U123456789", LastPresenter: false},
+			wantErr: false,
+		},
+		{
+			name: "Should return next user after current presenter",
+			args: args{rotationID: 1, strategy: domain.StrategyRoundRobin, day: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", LastPresenter: true}, // Current presenter
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321", LastPresenter: false},
+					{ID: 3, ChannelID: 1, SlackUserID: "U555555555", LastPresenter: false},
+				}
+
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(users, nil).Times(1)
+
+				mocks.mockAvailabilityRepo.EXPECT().
+					GetUpcomingByChannel(int64(1), time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)).
+					Return(nil, nil).Times(1)
+			},
+			want:    &entity.User{ID: 2, ChannelID: 1, SlackUserID: "U987654321", LastPresenter: false},
+			wantErr: false,
+		},
+		{
+			name: "Should wrap around to first user",
+			args: args{rotationID: 1, strategy: domain.StrategyRoundRobin, day: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", LastPresenter: false},
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321", LastPresenter: true}, // Last user is current
+				}
+
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(users, nil).Times(1)
+
+				mocks.mockAvailabilityRepo.EXPECT().
+					GetUpcomingByChannel(int64(1), time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)).
+					Return(nil, nil).Times(1)
+			},
+			want:    &entity.User{ID: 1, ChannelID: 1, SlackUserID: "U123456789", LastPresenter: false},
+			wantErr: false,
+		},
+		{
+			name: "Should skip away users and keep rotation order",
+			args: args{rotationID: 1, strategy: domain.StrategyRoundRobin, day: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", LastPresenter: true},
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321", LastPresenter: false},
+					{ID: 3, ChannelID: 1, SlackUserID: "U555555555", LastPresenter: false},
+				}
+
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(users, nil).Times(1)
+
+				mocks.mockAvailabilityRepo.EXPECT().
+					GetUpcomingByChannel(int64(1), time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)).
+					Return([]*entity.Availability{
+						{
+							UserID:      2,
+							SlackUserID: "U987654321",
+							StartDate:   time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+							EndDate:     time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC),
+						},
+						{
+							// Starts after the notification day, so user 3 is still available
+							UserID:      3,
+							SlackUserID: "U555555555",
+							StartDate:   time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC),
+							EndDate:     time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC),
+						},
+					}, nil).Times(1)
+			},
+			want:    &entity.User{ID: 3, ChannelID: 1, SlackUserID: "U555555555", LastPresenter: false},
+			wantErr: false,
+		},
+		{
+			name: "Should return backups following the presenter in rotation order",
+			args: args{rotationID: 1, strategy: domain.StrategyRoundRobin, assignees: 3, day: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789"},
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321", LastPresenter: true},
+					{ID: 3, ChannelID: 1, SlackUserID: "U555555555"},
+					{ID: 4, ChannelID: 1, SlackUserID: "U444444444"},
+				}
+
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(users, nil).Times(1)
+
+				mocks.mockAvailabilityRepo.EXPECT().
+					GetUpcomingByChannel(int64(1), time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)).
+					Return(nil, nil).Times(1)
+			},
+			want:        &entity.User{ID: 3, ChannelID: 1, SlackUserID: "U555555555"},
+			wantBackups: []int64{4, 1},
+		},
+		{
+			name: "Should skip away members when picking backups",
+			args: args{rotationID: 1, strategy: domain.StrategyRoundRobin, assignees: 2, day: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", LastPresenter: true},
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321"},
+					{ID: 3, ChannelID: 1, SlackUserID: "U555555555"},
+				}
+
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(users, nil).Times(1)
+
+				mocks.mockAvailabilityRepo.EXPECT().
+					GetUpcomingByChannel(int64(1), time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)).
+					Return([]*entity.Availability{
+						{
+							UserID:      3,
+							SlackUserID: "U555555555",
+							StartDate:   time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC),
+							EndDate:     time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC),
+						},
+					}, nil).Times(1)
+			},
+			want:        &entity.User{ID: 2, ChannelID: 1, SlackUserID: "U987654321"},
+			wantBackups: []int64{1},
+		},
+		{
+			name: "Should return fewer backups when not enough members are available",
+			args: args{rotationID: 1, strategy: domain.StrategyRoundRobin, assignees: 3, day: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789"},
+				}
+
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(users, nil).Times(1)
+
+				mocks.mockAvailabilityRepo.EXPECT().
+					GetUpcomingByChannel(int64(1), time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)).
+					Return(nil, nil).Times(1)
+			},
+			want: &entity.User{ID: 1, ChannelID: 1, SlackUserID: "U123456789"},
+		},
+		{
+			name: "Should return error when everyone is away",
+			args: args{rotationID: 1, strategy: domain.StrategyRoundRobin, day: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", LastPresenter: false},
+				}
+
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(users, nil).Times(1)
+
+				mocks.mockAvailabilityRepo.EXPECT().
+					GetUpcomingByChannel(int64(1), time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)).
+					Return([]*entity.Availability{
+						{
+							UserID:      1,
+							SlackUserID: "U123456789",
+							StartDate:   time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+							EndDate:     time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC),
+						},
+					}, nil).Times(1)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Should fall back to round-robin for an unknown strategy",
+			args: args{rotationID: 1, strategy: "", day: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", LastPresenter: true},
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321", LastPresenter: false},
+				}
+
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(users, nil).Times(1)
+
+				mocks.mockAvailabilityRepo.EXPECT().
+					GetUpcomingByChannel(int64(1), gomock.Any()).
+					Return(nil, nil).Times(1)
+			},
+			want: &entity.User{ID: 2, ChannelID: 1, SlackUserID: "U987654321", LastPresenter: false},
+		},
+		{
+			name: "Should pick member with fewest turns with fair strategy",
+			args: args{rotationID: 1, strategy: domain.StrategyFair, day: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", LastPresenter: true},
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321"},
+					{ID: 3, ChannelID: 1, SlackUserID: "U555555555"},
+				}
+
+				gomock.InOrder(
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(users, nil).Times(1),
+
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(int64(1), gomock.Any()).
+						Return(nil, nil).Times(1),
+
+					// User 3 joined recently and never had a turn
+					mocks.mockHistoryRepo.EXPECT().
+						GetTurnStats(args.rotationID).
+						Return([]*entity.TurnStats{
+							{UserID: 1, Turns: 3, LastTurnAt: time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)},
+							{UserID: 2, Turns: 2, LastTurnAt: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)},
+						}, nil).Times(1),
+				)
+			},
+			want: &entity.User{ID: 3, ChannelID: 1, SlackUserID: "U555555555"},
+		},
+		{
+			name: "Should pick member waiting the longest on a tie with fair strategy",
+			args: args{rotationID: 1, strategy: domain.StrategyFair, day: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", LastPresenter: true},
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321"},
+					{ID: 3, ChannelID: 1, SlackUserID: "U555555555"},
+				}
+
+				gomock.InOrder(
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(users, nil).Times(1),
+
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(int64(1), gomock.Any()).
+						Return(nil, nil).Times(1),
+
+					mocks.mockHistoryRepo.EXPECT().
+						GetTurnStats(args.rotationID).
+						Return([]*entity.TurnStats{
+							{UserID: 1, Turns: 2, LastTurnAt: time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)},
+							{UserID: 2, Turns: 2, LastTurnAt: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)},
+							{UserID: 3, Turns: 2, LastTurnAt: time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)},
+						}, nil).Times(1),
+				)
+			},
+			want: &entity.User{ID: 3, ChannelID: 1, SlackUserID: "U555555555"},
+		},
+		{
+			name: "Should skip away members with fair strategy",
+			args: args{rotationID: 1, strategy: domain.StrategyFair, day: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789", LastPresenter: true},
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321"},
+				}
+
+				gomock.InOrder(
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(users, nil).Times(1),
+
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(int64(1), gomock.Any()).
+						Return([]*entity.Availability{
+							{
+								UserID:      2,
+								SlackUserID: "U987654321",
+								StartDate:   time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+								EndDate:     time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC),
+							},
+						}, nil).Times(1),
+
+					mocks.mockHistoryRepo.EXPECT().
+						GetTurnStats(args.rotationID).
+						Return([]*entity.TurnStats{
+							{UserID: 1, Turns: 2, LastTurnAt: time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)},
+						}, nil).Times(1),
+				)
+			},
+			want: &entity.User{ID: 1, ChannelID: 1, SlackUserID: "U123456789", LastPresenter: true},
+		},
+		{
+			name: "Should return error when turn stats cannot be loaded",
+			args: args{rotationID: 1, strategy: domain.StrategyFair, day: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return([]*entity.User{{ID: 1, ChannelID: 1, SlackUserID: "U123456789"}}, nil).Times(1),
+
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(int64(1), gomock.Any()).
+						Return(nil, nil).Times(1),
+
+					mocks.mockHistoryRepo.EXPECT().
+						GetTurnStats(args.rotationID).
+						Return(nil, assert.AnError).Times(1),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should follow the drawn order in the middle of a cycle with shuffled strategy",
+			args: args{rotationID: 1, strategy: domain.StrategyShuffled, day: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 3, ChannelID: 1, SlackUserID: "U555555555", LastPresenter: true},
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789"},
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321"},
+				}
+
+				gomock.InOrder(
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(users, nil).Times(1),
+
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(int64(1), gomock.Any()).
+						Return(nil, nil).Times(1),
+				)
+			},
+			want: &entity.User{ID: 1, ChannelID: 1, SlackUserID: "U123456789"},
+		},
+		{
+			name: "Should draw a new order without saving it when a cycle ends with shuffled strategy",
+			args: args{rotationID: 1, strategy: domain.StrategyShuffled, day: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)},
+			buildMock: func(mocks allMocks, args args) {
+				users := []*entity.User{
+					{ID: 1, ChannelID: 1, SlackUserID: "U123456789"},
+					{ID: 2, ChannelID: 1, SlackUserID: "U987654321"},
+					{ID: 3, ChannelID: 1, SlackUserID: "U555555555", LastPresenter: true},
+				}
+
+				gomock.InOrder(
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(users, nil).Times(1),
+
+					mocks.mockAvailabilityRepo.EXPECT().
+						GetUpcomingByChannel(int64(1), gomock.Any()).
+						Return(nil, nil).Times(1),
+
+					mocks.mockHistoryRepo.EXPECT().
+						GetTurnStats(args.rotationID).
+						Return([]*entity.TurnStats{{UserID: 3, Turns: 42}}, nil).Times(1),
+				)
+				// The order is saved when the turn is recorded, not when asking who is next
+				mocks.mockUserRepo.EXPECT().UpdatePositions(gomock.Any()).Times(0)
+			},
+			wantAnyOf: []int64{1, 2},
+		},
+		{
+			name: "Should return nil when no users",
+			args: args{rotationID: 1, strategy: domain.StrategyRoundRobin, day: time.Date(2026, 10, 21, 9, 0, 0, 0, time.UTC)},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return([]*entity.User{}, nil).Times(1)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			if tt.buildMock != nil {
+				tt.buildMock(m, tt.args)
+			}
+
+			assignees := tt.args.assignees
+			if assignees == 0 {
+				assignees = 1
+			}
+
+			got, err := selectAssignees(m.mockDataManager, tt.args.rotationID, tt.args.strategy, assignees, tt.args.day)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.want == nil && len(tt.wantAnyOf) == 0 {
+				assert.Empty(t, got)
+				return
+			}
+
+			require.NotEmpty(t, got)
+			if len(tt.wantAnyOf) > 0 {
+				assert.Contains(t, tt.wantAnyOf, got[0].ID)
+				return
+			}
+			assert.Equal(t, tt.want, got[0])
+
+			var backupIDs []int64
+			for _, backup := range got[1:] {
+				backupIDs = append(backupIDs, backup.ID)
+			}
+			assert.Equal(t, tt.wantBackups, backupIDs)
+		})
+	}
+}
+
+func Test_saveOrder(t *testing.T) {
+	t.Run("Should save the order of a new cycle with shuffled strategy", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		users := []*entity.User{{ID: 1}, {ID: 2}, {ID: 3, LastPresenter: true}}
+		gomock.InOrder(
+			m.mockUserRepo.EXPECT().GetActiveUsersByRotation(int64(1)).Return(users, nil).Times(1),
+			m.mockHistoryRepo.EXPECT().GetTurnStats(int64(1)).Return([]*entity.TurnStats{{UserID: 1, Turns: 20}, {UserID: 3, Turns: 22}}, nil).Times(1),
+			m.mockUserRepo.EXPECT().
+				UpdatePositions(gomock.Any()).
+				DoAndReturn(func(userIDs []int64) error {
+					expected := shuffleCycle(users, 1, 42)
+					assert.Equal(t, []int64{expected[0].ID, expected[1].ID, expected[2].ID}, userIDs)
+					assert.Equal(t, int64(3), userIDs[2])
+					return nil
+				}).Times(1),
+		)
+
+		require.NoError(t, saveOrder(m.mockDataManager, 1, domain.StrategyShuffled))
+	})
+
+	t.Run("Should keep the order in the middle of a cycle with shuffled strategy", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		m.mockUserRepo.EXPECT().
+			GetActiveUsersByRotation(int64(1)).
+			Return([]*entity.User{{ID: 1, LastPresenter: true}, {ID: 2}, {ID: 3}}, nil).Times(1)
+
+		require.NoError(t, saveOrder(m.mockDataManager, 1, domain.StrategyShuffled))
+	})
+
+	t.Run("Should not touch the order with round-robin strategy", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		require.NoError(t, saveOrder(m.mockDataManager, 1, domain.StrategyRoundRobin))
+	})
+
+	t.Run("Should return error when the order cannot be saved", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		m.mockUserRepo.EXPECT().GetActiveUsersByRotation(int64(1)).Return([]*entity.User{{ID: 1}, {ID: 2}}, nil).Times(1)
+		m.mockHistoryRepo.EXPECT().GetTurnStats(int64(1)).Return(nil, nil).Times(1)
+		m.mockUserRepo.EXPECT().UpdatePositions(gomock.Any()).Return(assert.AnError).Times(1)
+
+		require.Error(t, saveOrder(m.mockDataManager, 1, domain.StrategyShuffled))
+	})
+}
+
+func Test_shuffleCycle(t *testing.T) {
+	users := []*entity.User{
+		{ID: 1}, {ID: 2}, {ID: 3, LastPresenter: true}, {ID: 4}, {ID: 5},
+	}
+
+	t.Run("Should give the same order for the same seed", func(t *testing.T) {
+		first := shuffleCycle(users, 1, 42)
+		reordered := []*entity.User{users[4], users[2], users[0], users[3], users[1]}
+		second := shuffleCycle(reordered, 1, 42)
+
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("Should keep every member and put the last presenter at the end", func(t *testing.T) {
+		for turns := int64(0); turns < 20; turns++ {
+			order := shuffleCycle(users, 1, turns)
+
+			require.Len(t, order, len(users))
+			assert.ElementsMatch(t, users, order)
+			assert.Equal(t, int64(3), order[len(order)-1].ID)
+		}
+	})
+}
+
+func Test_parseStrategy(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "round-robin", want: domain.StrategyRoundRobin},
+		{input: "RR", want: domain.StrategyRoundRobin},
+		{input: " shuffled ", want: domain.StrategyShuffled},
+		{input: "random", want: domain.StrategyShuffled},
+		{input: "fair", want: domain.StrategyFair},
+		{input: "lrs", want: domain.StrategyFair},
+		{input: "alphabetical", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseStrategy(tt.input)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
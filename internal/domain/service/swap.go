@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+)
+
+// SwapUsers exchanges the turns of two members. Without a date the swap changes the
+// rotation order right away, with a date (YYYY-MM-DD) it is kept pending and applied
+// by the scheduler before picking the presenter of that day.
+func (s *rotationService) SwapUsers(ctx context.Context, rotationID int64, slackUserIDA, slackUserIDB, date, requestedBy string) (*entity.Swap, error) {
+	if slackUserIDA == slackUserIDB {
+		return nil, fmt.Errorf("please mention two different members")
+	}
+
+	users, err := s.dm.User().GetActiveUsersByRotation(rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	swap := &entity.Swap{
+		RotationID: rotationID,
+		CreatedBy:  requestedBy,
+	}
+
+	for _, slackUserID := range []string{slackUserIDA, slackUserIDB} {
+		user := findUserBySlackID(users, slackUserID)
+		if user == nil {
+			return nil, fmt.Errorf("<@%s> is not in the rotation", slackUserID)
+		}
+		swap.ChannelID = user.ChannelID
+		if swap.UserAID == 0 {
+			swap.UserAID = user.ID
+		} else {
+			swap.UserBID = user.ID
+		}
+	}
+
+	if date = strings.TrimSpace(date); date != "" {
+		swapDate, err := time.Parse(domain.DateFormat, date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date. Use YYYY-MM-DD. Example: 2026-10-22")
+		}
+
+		loc, err := s.rotationLocation(rotationID)
+		if err != nil {
+			return nil, err
+		}
+
+		if swapDate.Before(dateOf(s.clock.Now().In(loc))) {
+			return nil, fmt.Errorf("the swap date must be today or later")
+		}
+
+		swap.SwapDate = swapDate
+		if err := s.dm.Swap().Create(swap); err != nil {
+			return nil, fmt.Errorf("failed to create swap: %w", err)
+		}
+
+		return swap, nil
+	}
+
+	err = s.dm.WithTransaction(ctx, func(tx contract.DataManager) error {
+		if err := exchangeTurns(tx, rotationID, users, swap.UserAID, swap.UserBID); err != nil {
+			return err
+		}
+
+		swap.AppliedAt = s.clock.Now().UTC()
+		if err := tx.Swap().Create(swap); err != nil {
+			return fmt.Errorf("failed to create swap: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return swap, nil
+}
+
+// applyDueSwaps applies the pending swaps of the rotation dated on or before the day of now,
+// given in the rotation timezone
+func applyDueSwaps(dm contract.DataManager, rotationID int64, now time.Time) error {
+	swaps, err := dm.Swap().GetPendingByRotation(rotationID, dateOf(now))
+	if err != nil {
+		return fmt.Errorf("failed to get pending swaps: %w", err)
+	}
+
+	for _, swap := range swaps {
+		err := dm.WithTransaction(context.Background(), func(tx contract.DataManager) error {
+			users, err := tx.User().GetActiveUsersByRotation(rotationID)
+			if err != nil {
+				return fmt.Errorf("failed to get users: %w", err)
+			}
+
+			if err := exchangeTurns(tx, rotationID, users, swap.UserAID, swap.UserBID); err != nil {
+				return err
+			}
+
+			if err := tx.Swap().MarkApplied(swap.ID, now.UTC()); err != nil {
+				return fmt.Errorf("failed to mark swap as applied: %w", err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply swap %d: %w", swap.ID, err)
+		}
+
+		log.Printf("Applied swap %d for rotation %d", swap.ID, rotationID)
+	}
+
+	return nil
+}
+
+// exchangeTurns swaps the positions of two members in the rotation order. When one of
+// them holds the current turn, as presenter or backup, the turn moves to the other so
+// the rotation continues from the same place. Members that left the rotation are ignored.
+func exchangeTurns(tx contract.DataManager, rotationID int64, users []*entity.User, userAID, userBID int64) error {
+	indexA, indexB := -1, -1
+	for i, user := range users {
+		switch user.ID {
+		case userAID:
+			indexA = i
+		case userBID:
+			indexB = i
+		}
+	}
+
+	if indexA == -1 || indexB == -1 {
+		return nil
+	}
+
+	userIDs := make([]int64, len(users))
+	for i, user := range users {
+		userIDs[i] = user.ID
+	}
+	userIDs[indexA], userIDs[indexB] = userIDs[indexB], userIDs[indexA]
+
+	if err := tx.User().UpdatePositions(userIDs); err != nil {
+		return fmt.Errorf("failed to save rotation order: %w", err)
+	}
+
+	// The current turn follows the exchange, so the presenter and backups keep their roles
+	var presenterID int64
+	var backupIDs []int64
+	for _, user := range users {
+		userID := user.ID
+		switch userID {
+		case userAID:
+			userID = userBID
+		case userBID:
+			userID = userAID
+		}
+
+		if user.LastPresenter {
+			presenterID = userID
+		}
+		if user.LastBackup {
+			backupIDs = append(backupIDs, userID)
+		}
+	}
+
+	if users[indexA].LastPresenter || users[indexB].LastPresenter {
+		if err := tx.User().ClearLastPresenter(rotationID); err != nil {
+			return fmt.Errorf("failed to clear last presenter: %w", err)
+		}
+
+		if err := tx.User().SetLastPresenter(presenterID); err != nil {
+			return fmt.Errorf("failed to set last presenter: %w", err)
+		}
+	}
+
+	if users[indexA].LastBackup != users[indexB].LastBackup {
+		if err := tx.User().ClearLastBackups(rotationID); err != nil {
+			return fmt.Errorf("failed to clear last backups: %w", err)
+		}
+
+		for _, backupID := range backupIDs {
+			if err := tx.User().SetLastBackup(backupID); err != nil {
+				return fmt.Errorf("failed to set last backup: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func findUserBySlackID(users []*entity.User, slackUserID string) *entity.User {
+	for _, user := range users {
+		if user.SlackUserID == slackUserID {
+			return user
+		}
+	}
+	return nil
+}
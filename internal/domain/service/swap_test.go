@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_rotationService_SwapUsers(t *testing.T) {
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format(domain.DateFormat)
+	yesterday := time.Now().UTC().AddDate(0, 0, -2).Format(domain.DateFormat)
+
+	type args struct {
+		rotationID   int64
+		slackUserIDA string
+		slackUserIDB string
+		date         string
+	}
+	tests := []struct {
+		name        string
+		buildMock   func(mocks allMocks, args args)
+		args        args
+		wantPending bool
+		wantErr     bool
+	}{
+		{
+			name: "Should exchange positions right away",
+			args: args{rotationID: 1, slackUserIDA: "U2", slackUserIDB: "U4"},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(orderTestUsers(), nil).Times(1),
+
+					mocks.mockDataManager.EXPECT().
+						WithTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+							return fn(mocks.mockDataManager)
+						}).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						UpdatePositions([]int64{1, 4, 3, 2}).
+						Return(nil).Times(1),
+
+					mocks.mockSwapRepo.EXPECT().
+						Create(gomock.Any()).
+						DoAndReturn(func(swap *entity.Swap) error {
+							assert.Equal(t, int64(2), swap.UserAID)
+							assert.Equal(t, int64(4), swap.UserBID)
+							assert.Equal(t, "U9", swap.CreatedBy)
+							assert.False(t, swap.IsPending())
+							return nil
+						}).Times(1),
+				)
+			},
+		},
+		{
+			name: "Should move the current turn when swapping the last presenter",
+			args: args{rotationID: 1, slackUserIDA: "U1", slackUserIDB: "U2"},
+			buildMock: func(mocks allMocks, args args) {
+				users := orderTestUsers()
+				users[0].LastPresenter = true
+
+				gomock.InOrder(
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(users, nil).Times(1),
+
+					mocks.mockDataManager.EXPECT().
+						WithTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+							return fn(mocks.mockDataManager)
+						}).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						UpdatePositions([]int64{2, 1, 3, 4}).
+						Return(nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						ClearLastPresenter(args.rotationID).
+						Return(nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						SetLastPresenter(int64(2)).
+						Return(nil).Times(1),
+
+					mocks.mockSwapRepo.EXPECT().
+						Create(gomock.Any()).
+						Return(nil).Times(1),
+				)
+			},
+		},
+		{
+			name: "Should exchange roles when swapping the presenter with the backup",
+			args: args{rotationID: 1, slackUserIDA: "U1", slackUserIDB: "U2"},
+			buildMock: func(mocks allMocks, args args) {
+				users := orderTestUsers()
+				users[0].LastPresenter = true
+				users[1].LastBackup = true
+
+				gomock.InOrder(
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(users, nil).Times(1),
+
+					mocks.mockDataManager.EXPECT().
+						WithTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+							return fn(mocks.mockDataManager)
+						}).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						UpdatePositions([]int64{2, 1, 3, 4}).
+						Return(nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						ClearLastPresenter(args.rotationID).
+						Return(nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						SetLastPresenter(int64(2)).
+						Return(nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						ClearLastBackups(args.rotationID).
+						Return(nil).Times(1),
+
+					mocks.mockUserRepo.EXPECT().
+						SetLastBackup(int64(1)).
+						Return(nil).Times(1),
+
+					mocks.mockSwapRepo.EXPECT().
+						Create(gomock.Any()).
+						Return(nil).Times(1),
+				)
+			},
+		},
+		{
+			name: "Should keep dated swap pending",
+			args: args{rotationID: 1, slackUserIDA: "U1", slackUserIDB: "U3", date: tomorrow},
+			buildMock: func(mocks allMocks, args args) {
+				gomock.InOrder(
+					mocks.mockUserRepo.EXPECT().
+						GetActiveUsersByRotation(args.rotationID).
+						Return(orderTestUsers(), nil).Times(1),
+
+					mocks.mockSchedulerRepo.EXPECT().
+						GetByRotationID(args.rotationID).
+						Return(nil, nil).Times(1),
+
+					mocks.mockSwapRepo.EXPECT().
+						Create(gomock.Any()).
+						DoAndReturn(func(swap *entity.Swap) error {
+							assert.Equal(t, tomorrow, swap.SwapDate.Format(domain.DateFormat))
+							assert.True(t, swap.IsPending())
+							return nil
+						}).Times(1),
+				)
+			},
+			wantPending: true,
+		},
+		{
+			name: "Should return error for a past date",
+			args: args{rotationID: 1, slackUserIDA: "U1", slackUserIDB: "U3", date: yesterday},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(orderTestUsers(), nil).Times(1)
+
+				mocks.mockSchedulerRepo.EXPECT().
+					GetByRotationID(args.rotationID).
+					Return(nil, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error for an invalid date",
+			args: args{rotationID: 1, slackUserIDA: "U1", slackUserIDB: "U3", date: "next-friday"},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(orderTestUsers(), nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Should return error when swapping a member with themselves",
+			args:    args{rotationID: 1, slackUserIDA: "U1", slackUserIDB: "U1"},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when user is not in rotation",
+			args: args{rotationID: 1, slackUserIDA: "U1", slackUserIDB: "U9"},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(orderTestUsers(), nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when swap cannot be saved",
+			args: args{rotationID: 1, slackUserIDA: "U1", slackUserIDB: "U2"},
+			buildMock: func(mocks allMocks, args args) {
+				mocks.mockUserRepo.EXPECT().
+					GetActiveUsersByRotation(args.rotationID).
+					Return(orderTestUsers(), nil).Times(1)
+
+				mocks.mockDataManager.EXPECT().
+					WithTransaction(gomock.Any(), gomock.Any()).
+					Return(assert.AnError).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+			if tt.buildMock != nil {
+				tt.buildMock(m, tt.args)
+			}
+
+			got, err := s.SwapUsers(context.Background(), tt.args.rotationID, tt.args.slackUserIDA, tt.args.slackUserIDB, tt.args.date, "U9")
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, got)
+			assert.Equal(t, tt.wantPending, got.IsPending())
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/slack-go/slack"
+)
+
+// topicSeparator splits the parts of a channel topic, the bot only replaces its own part
+const topicSeparator = " | "
+
+// topicPermissionErrors are the Slack errors telling the bot is not allowed to change the topic
+var topicPermissionErrors = map[string]bool{
+	"missing_scope":     true,
+	"not_in_channel":    true,
+	"channel_not_found": true,
+	"restricted_action": true,
+}
+
+// topicPrefix starts the part of the topic kept by the bot with the role of the rotation, naming
+// the rotation when the channel has several of them
+func topicPrefix(rotation *entity.Rotation, role string) string {
+	if rotation.IsPrimary {
+		return role + ": "
+	}
+	return fmt.Sprintf("%s (%s): ", role, rotation.Name)
+}
+
+// topicDuty returns the part of the topic naming the assignees, e.g.
+// "On duty: <@U1> (backup: <@U2>)"
+func topicDuty(rotation *entity.Rotation, assignees []*entity.User, role, backupRole string) string {
+	duty := topicPrefix(rotation, role) + fmt.Sprintf("<@%s>", assignees[0].SlackUserID)
+	if len(assignees) == 1 {
+		return duty
+	}
+
+	backups := make([]string, len(assignees)-1)
+	for i, user := range assignees[1:] {
+		backups[i] = fmt.Sprintf("<@%s>", user.SlackUserID)
+	}
+	return fmt.Sprintf("%s (%s: %s)", duty, backupRole, strings.Join(backups, ", "))
+}
+
+// replaceTopicDuty puts duty in the topic in place of the part starting with the first of
+// prefixes found, keeping the rest of the topic. duty goes first when the topic has no such
+// part yet.
+func replaceTopicDuty(topic string, prefixes []string, duty string) string {
+	if strings.TrimSpace(topic) == "" {
+		return duty
+	}
+
+	parts := strings.Split(topic, topicSeparator)
+	for _, prefix := range prefixes {
+		if prefix == "" {
+			continue
+		}
+
+		for i, part := range parts {
+			if strings.HasPrefix(strings.TrimSpace(part), prefix) {
+				parts[i] = duty
+				return strings.Join(parts, topicSeparator)
+			}
+		}
+	}
+
+	return duty + topicSeparator + topic
+}
+
+// syncTopic updates the channel topic to name the assignees of the turn when the rotation asked
+// for it. The part written with the current role is replaced, or else the part the bot last
+// wrote, whose prefix is saved so it is found again after the role changes. Failures are only
+// logged, the turn is recorded whether the topic changes or not.
+func syncTopic(ctx context.Context, dm contract.DataManager, slackClient contract.SlackClient, rotation *entity.Rotation, channel *entity.Channel, schedulerConfig *entity.Scheduler, assignees []*entity.User) {
+	if schedulerConfig == nil || !schedulerConfig.TopicSync || len(assignees) == 0 {
+		return
+	}
+
+	info, err := slackClient.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{ChannelID: channel.SlackChannelID})
+	if err != nil {
+		logTopicError(channel, err)
+		return
+	}
+
+	role := domain.DefaultRole
+	if schedulerConfig.Role != "" {
+		role = schedulerConfig.Role
+	}
+
+	prefix := topicPrefix(rotation, role)
+	duty := topicDuty(rotation, assignees, role, backupRoleOf(schedulerConfig))
+	topic := replaceTopicDuty(info.Topic.Value, []string{prefix, schedulerConfig.TopicPrefix}, duty)
+
+	// Slack posts a message each time the topic is set, even to the same value
+	if topic != info.Topic.Value {
+		if _, err := slackClient.SetTopicOfConversationContext(ctx, channel.SlackChannelID, topic); err != nil {
+			logTopicError(channel, err)
+			return
+		}
+	}
+
+	if prefix != schedulerConfig.TopicPrefix {
+		if err := dm.Scheduler().SetTopicPrefix(rotation.ID, prefix); err != nil {
+			log.Printf("Failed to save the topic prefix of rotation %d: %v", rotation.ID, err)
+		}
+	}
+}
+
+// logTopicError logs why the topic of the channel could not be updated, telling how to grant
+// the permission when that is the reason
+func logTopicError(channel *entity.Channel, err error) {
+	var slackErr slack.SlackErrorResponse
+	if errors.As(err, &slackErr) && topicPermissionErrors[slackErr.Err] {
+		log.Printf("Cannot update the topic of channel %s (%s): the bot must be a member of the channel and have the channels:write.topic scope", channel.SlackChannelID, slackErr.Err)
+		return
+	}
+
+	log.Printf("Failed to update the topic of channel %s: %v", channel.SlackChannelID, err)
+}
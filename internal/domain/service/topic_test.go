@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// topicChannel is the channel returned by conversations.info with its current topic
+func topicChannel(topic string) *slack.Channel {
+	channel := &slack.Channel{}
+	channel.Topic.Value = topic
+	return channel
+}
+
+func Test_replaceTopicDuty(t *testing.T) {
+	tests := []struct {
+		name  string
+		topic string
+		want  string
+	}{
+		{
+			name:  "Should use the duty when the topic is empty",
+			topic: "",
+			want:  "On duty: <@U2>",
+		},
+		{
+			name:  "Should put the duty first and keep the rest of the topic",
+			topic: "Escalations: #incidents",
+			want:  "On duty: <@U2> | Escalations: #incidents",
+		},
+		{
+			name:  "Should replace the previous duty in place",
+			topic: "Escalations: #incidents | On duty: <@U1> | Runbook in the bookmarks",
+			want:  "Escalations: #incidents | On duty: <@U2> | Runbook in the bookmarks",
+		},
+		{
+			name:  "Should keep the duty of another rotation",
+			topic: "On duty (reviewers): <@U9> | Escalations: #incidents",
+			want:  "On duty: <@U2> | On duty (reviewers): <@U9> | Escalations: #incidents",
+		},
+		{
+			name:  "Should replace the duty written with the previous role",
+			topic: "Escalations: #incidents | Presenter: <@U1> (backup: <@U3>)",
+			want:  "Escalations: #incidents | On duty: <@U2>",
+		},
+		{
+			name:  "Should replace the duty with the current role over one with the previous role",
+			topic: "Presenter: <@U5> | On duty: <@U1>",
+			want:  "Presenter: <@U5> | On duty: <@U2>",
+		},
+		{
+			name:  "Should keep the parts written by people that look like a duty",
+			topic: "Escalation: <@U7> | On duty: <@U1>",
+			want:  "Escalation: <@U7> | On duty: <@U2>",
+		},
+		{
+			name:  "Should put the duty first when the topic only has parts written by people",
+			topic: "Escalation: <@U7>",
+			want:  "On duty: <@U2> | Escalation: <@U7>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := replaceTopicDuty(tt.topic, []string{"On duty: ", "Presenter: "}, "On duty: <@U2>")
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_topicDuty(t *testing.T) {
+	assignees := []*entity.User{{SlackUserID: "U1"}, {SlackUserID: "U2"}, {SlackUserID: "U3"}}
+
+	assert.Equal(t, "On duty: <@U1>", topicDuty(&entity.Rotation{IsPrimary: true}, assignees[:1], "On duty", "backup"))
+	assert.Equal(t, "On duty: <@U1> (pair: <@U2>, <@U3>)", topicDuty(&entity.Rotation{IsPrimary: true}, assignees, "On duty", "pair"))
+	assert.Equal(t, "On duty (reviewers): <@U1>", topicDuty(&entity.Rotation{Name: "reviewers"}, assignees[:1], "On duty", "backup"))
+	assert.Equal(t, "Reviewer (reviewers): <@U1>", topicDuty(&entity.Rotation{Name: "reviewers"}, assignees[:1], "Reviewer", "backup"))
+}
+
+func Test_syncTopic(t *testing.T) {
+	rotation := &entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}
+	channel := &entity.Channel{ID: 1, SlackChannelID: "C123456789"}
+	config := &entity.Scheduler{RotationID: 1, TopicSync: true, TopicPrefix: "On duty: "}
+	assignees := []*entity.User{{ID: 1, SlackUserID: "U2"}}
+
+	tests := []struct {
+		name      string
+		config    *entity.Scheduler
+		buildMock func(m allMocks)
+	}{
+		{
+			name:   "Should update the topic keeping the rest of it",
+			config: config,
+			buildMock: func(m allMocks) {
+				gomock.InOrder(
+					m.mockSlackClient.EXPECT().
+						GetConversationInfoContext(gomock.Any(), &slack.GetConversationInfoInput{ChannelID: "C123456789"}).
+						Return(topicChannel("On duty: <@U1> | Escalations: #incidents"), nil).Times(1),
+					m.mockSlackClient.EXPECT().
+						SetTopicOfConversationContext(gomock.Any(), "C123456789", "On duty: <@U2> | Escalations: #incidents").
+						Return(nil, nil).Times(1),
+				)
+			},
+		},
+		{
+			name:   "Should use the role of the rotation, replace the duty written with the previous role and save the new prefix",
+			config: &entity.Scheduler{RotationID: 1, TopicSync: true, Role: "Presenter", TopicPrefix: "On duty: "},
+			buildMock: func(m allMocks) {
+				gomock.InOrder(
+					m.mockSlackClient.EXPECT().
+						GetConversationInfoContext(gomock.Any(), gomock.Any()).
+						Return(topicChannel("Escalation: <@U7> | On duty: <@U1>"), nil).Times(1),
+					m.mockSlackClient.EXPECT().
+						SetTopicOfConversationContext(gomock.Any(), "C123456789", "Escalation: <@U7> | Presenter: <@U2>").
+						Return(nil, nil).Times(1),
+					m.mockSchedulerRepo.EXPECT().SetTopicPrefix(int64(1), "Presenter: ").Return(nil).Times(1),
+				)
+			},
+		},
+		{
+			name:   "Should save the prefix the first time the topic is written",
+			config: &entity.Scheduler{RotationID: 1, TopicSync: true},
+			buildMock: func(m allMocks) {
+				gomock.InOrder(
+					m.mockSlackClient.EXPECT().
+						GetConversationInfoContext(gomock.Any(), gomock.Any()).
+						Return(topicChannel("Escalation: <@U7>"), nil).Times(1),
+					m.mockSlackClient.EXPECT().
+						SetTopicOfConversationContext(gomock.Any(), "C123456789", "On duty: <@U2> | Escalation: <@U7>").
+						Return(nil, nil).Times(1),
+					m.mockSchedulerRepo.EXPECT().SetTopicPrefix(int64(1), "On duty: ").Return(nil).Times(1),
+				)
+			},
+		},
+		{
+			name:   "Should not set the topic when it already names the assignees",
+			config: config,
+			buildMock: func(m allMocks) {
+				m.mockSlackClient.EXPECT().
+					GetConversationInfoContext(gomock.Any(), gomock.Any()).
+					Return(topicChannel("On duty: <@U2>"), nil).Times(1)
+			},
+		},
+		{
+			name:   "Should go on when the bot is not allowed to set the topic",
+			config: config,
+			buildMock: func(m allMocks) {
+				m.mockSlackClient.EXPECT().
+					GetConversationInfoContext(gomock.Any(), gomock.Any()).
+					Return(topicChannel(""), nil).Times(1)
+				m.mockSlackClient.EXPECT().
+					SetTopicOfConversationContext(gomock.Any(), "C123456789", "On duty: <@U2>").
+					Return(nil, slack.SlackErrorResponse{Err: "missing_scope"}).Times(1)
+			},
+		},
+		{
+			name:   "Should go on when the channel cannot be read",
+			config: config,
+			buildMock: func(m allMocks) {
+				m.mockSlackClient.EXPECT().
+					GetConversationInfoContext(gomock.Any(), gomock.Any()).
+					Return(nil, slack.SlackErrorResponse{Err: "not_in_channel"}).Times(1)
+			},
+		},
+		{
+			name:   "Should not touch the topic when the rotation did not ask for it",
+			config: &entity.Scheduler{RotationID: 1},
+		},
+		{
+			name: "Should not touch the topic when the rotation has no config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			if tt.buildMock != nil {
+				tt.buildMock(m)
+			}
+
+			syncTopic(context.Background(), m.mockDataManager, m.mockSlackClient, rotation, channel, tt.config, assignees)
+		})
+	}
+}
+
+func Test_rotationService_RecordPresentation_TopicSync(t *testing.T) {
+	m, ctrl := newServiceTestMock(t)
+	defer ctrl.Finish()
+
+	s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+	users := []*entity.User{{ID: 2, ChannelID: 1, SlackUserID: "U2"}}
+
+	m.mockDataManager.EXPECT().
+		WithTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(contract.DataManager) error) error {
+			return fn(m.mockDataManager)
+		}).Times(1)
+	m.mockUserRepo.EXPECT().ClearLastPresenter(int64(1)).Return(nil).Times(1)
+	m.mockUserRepo.EXPECT().ClearLastBackups(int64(1)).Return(nil).Times(1)
+	m.mockUserRepo.EXPECT().SetLastPresenter(int64(2)).Return(nil).Times(1)
+	m.mockHistoryRepo.EXPECT().Create(gomock.Any()).Return(nil).Times(1)
+
+	m.mockSchedulerRepo.EXPECT().GetByRotationID(int64(1)).Return(&entity.Scheduler{RotationID: 1, TopicSync: true, TopicPrefix: "On duty: "}, nil).Times(1)
+	m.mockRotationRepo.EXPECT().GetByID(int64(1)).Return(&entity.Rotation{ID: 1, ChannelID: 1, IsPrimary: true}, nil).Times(1)
+	m.mockChannelRepo.EXPECT().GetByID(int64(1)).Return(&entity.Channel{ID: 1, SlackChannelID: "C123456789", SlackTeamID: "T123456789"}, nil).Times(1)
+	m.mockSlackClient.EXPECT().GetConversationInfoContext(gomock.Any(), gomock.Any()).Return(topicChannel("Standup at 10"), nil).Times(1)
+	m.mockSlackClient.EXPECT().
+		SetTopicOfConversationContext(gomock.Any(), "C123456789", "On duty: <@U2> | Standup at 10").
+		Return(nil, assert.AnError).Times(1)
+
+	// The turn is recorded even when the topic cannot be updated
+	err := s.RecordPresentation(context.Background(), 1, users, domain.HistoryKindSkip, "U999999999")
+	require.NoError(t, err)
+}
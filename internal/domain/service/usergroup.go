@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/slack-go/slack"
+)
+
+// userGroupIDPattern matches the ID of a Slack user group, e.g. S0123ABCDEF
+var userGroupIDPattern = regexp.MustCompile(`^S[A-Z0-9]+$`)
+
+// parseUserGroup returns the ID of the user group mentioned in input, such as
+// "<!subteam^S0123ABCDEF|@team-oncall>" or "S0123ABCDEF". "off" and "none" unlink the user group,
+// returning an empty ID.
+func parseUserGroup(input string) (string, error) {
+	value := strings.TrimSpace(input)
+	switch strings.ToLower(value) {
+	case "off", "none":
+		return "", nil
+	}
+
+	if strings.HasPrefix(value, "<!subteam^") {
+		value = strings.TrimSuffix(strings.TrimPrefix(value, "<!subteam^"), ">")
+		// Handle format <!subteam^S123|@handle> - take only the ID part
+		if idx := strings.Index(value, "|"); idx != -1 {
+			value = value[:idx]
+		}
+	}
+
+	if !userGroupIDPattern.MatchString(value) {
+		return "", fmt.Errorf("invalid user group. Mention it, e.g. @team-oncall, or use off to unlink it")
+	}
+
+	return value, nil
+}
+
+// linkUserGroup checks the bot can update the user group by giving it the current assignees of
+// the rotation right away. Nothing is checked when the rotation has no current turn yet, the
+// group is then updated with the next turn.
+func (s *rotationService) linkUserGroup(ctx context.Context, rotationID int64, userGroupID string) error {
+	presenter, err := s.dm.User().GetLastPresenter(rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to get current presenter: %w", err)
+	}
+
+	if presenter == nil {
+		return nil
+	}
+
+	backups, err := s.dm.User().GetLastBackups(rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to get backups: %w", err)
+	}
+
+	rotation, err := s.dm.Rotation().GetByID(rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to get rotation: %w", err)
+	}
+
+	if rotation == nil {
+		return fmt.Errorf("rotation not found")
+	}
+
+	channel, err := s.dm.Channel().GetByID(rotation.ChannelID)
+	if err != nil {
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	if channel == nil {
+		return fmt.Errorf("channel not found")
+	}
+
+	slackClient, err := s.slackClients.ForTeam(channel.SlackTeamID)
+	if err != nil {
+		return fmt.Errorf("failed to get Slack client: %w", err)
+	}
+
+	return updateUserGroup(ctx, slackClient, userGroupID, append([]*entity.User{presenter}, backups...))
+}
+
+// syncUserGroup sets the members of the user group linked to the rotation to the assignees of
+// the turn. Failures are only logged, the turn is recorded whether the group changes or not.
+func syncUserGroup(ctx context.Context, slackClient contract.SlackClient, schedulerConfig *entity.Scheduler, assignees []*entity.User) {
+	if schedulerConfig == nil || schedulerConfig.UserGroupID == "" || len(assignees) == 0 {
+		return
+	}
+
+	if err := updateUserGroup(ctx, slackClient, schedulerConfig.UserGroupID, assignees); err != nil {
+		log.Printf("Failed to update user group %s of rotation %d: %v", schedulerConfig.UserGroupID, schedulerConfig.RotationID, err)
+	}
+}
+
+// updateUserGroup replaces the members of the user group with the assignees, telling how to fix
+// the errors caused by missing permissions
+func updateUserGroup(ctx context.Context, slackClient contract.SlackClient, userGroupID string, assignees []*entity.User) error {
+	members := make([]string, len(assignees))
+	for i, user := range assignees {
+		members[i] = user.SlackUserID
+	}
+
+	_, err := slackClient.UpdateUserGroupMembersContext(ctx, userGroupID, strings.Join(members, ","))
+	if err == nil {
+		return nil
+	}
+
+	var slackErr slack.SlackErrorResponse
+	if errors.As(err, &slackErr) {
+		switch slackErr.Err {
+		case "missing_scope":
+			return fmt.Errorf("the bot needs the usergroups:write scope to update the user group, add it and reinstall the app")
+		case "permission_denied":
+			return fmt.Errorf("the bot is not allowed to update the user group, check who can manage user groups in the workspace settings")
+		case "no_such_subteam", "subteam_not_found":
+			return fmt.Errorf("user group not found")
+		}
+	}
+
+	return fmt.Errorf("failed to update user group: %w", err)
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_parseUserGroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "Should parse a user group mention", input: "<!subteam^S0123ABCDEF|@team-oncall>", want: "S0123ABCDEF"},
+		{name: "Should parse a mention without handle", input: "<!subteam^S0123ABCDEF>", want: "S0123ABCDEF"},
+		{name: "Should accept a user group ID", input: " S0123ABCDEF ", want: "S0123ABCDEF"},
+		{name: "Should unlink with off", input: "OFF", want: ""},
+		{name: "Should unlink with none", input: "none", want: ""},
+		{name: "Should reject a handle that is not a mention", input: "@team-oncall", wantErr: true},
+		{name: "Should reject a user mention", input: "<@U123456789>", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseUserGroup(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_syncUserGroup(t *testing.T) {
+	assignees := []*entity.User{{ID: 1, SlackUserID: "U1"}, {ID: 2, SlackUserID: "U2"}}
+
+	t.Run("Should make the assignees the members of the user group", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		m.mockSlackClient.EXPECT().
+			UpdateUserGroupMembersContext(gomock.Any(), "S0123ABCDEF", "U1,U2").
+			Return(slack.UserGroup{}, nil).Times(1)
+
+		syncUserGroup(context.Background(), m.mockSlackClient, &entity.Scheduler{RotationID: 1, UserGroupID: "S0123ABCDEF"}, assignees)
+	})
+
+	t.Run("Should go on when the user group cannot be updated", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		m.mockSlackClient.EXPECT().
+			UpdateUserGroupMembersContext(gomock.Any(), "S0123ABCDEF", "U1,U2").
+			Return(slack.UserGroup{}, slack.SlackErrorResponse{Err: "missing_scope"}).Times(1)
+
+		syncUserGroup(context.Background(), m.mockSlackClient, &entity.Scheduler{RotationID: 1, UserGroupID: "S0123ABCDEF"}, assignees)
+	})
+
+	t.Run("Should not update anything when the rotation has no user group", func(t *testing.T) {
+		m, ctrl := newServiceTestMock(t)
+		defer ctrl.Finish()
+
+		syncUserGroup(context.Background(), m.mockSlackClient, &entity.Scheduler{RotationID: 1}, assignees)
+		syncUserGroup(context.Background(), m.mockSlackClient, nil, assignees)
+	})
+}
+
+func Test_updateUserGroup(t *testing.T) {
+	tests := []struct {
+		name      string
+		slackErr  error
+		wantError string
+	}{
+		{name: "Should tell the scope is missing", slackErr: slack.SlackErrorResponse{Err: "missing_scope"}, wantError: "usergroups:write"},
+		{name: "Should tell the workspace does not allow it", slackErr: slack.SlackErrorResponse{Err: "permission_denied"}, wantError: "not allowed"},
+		{name: "Should tell the user group does not exist", slackErr: slack.SlackErrorResponse{Err: "no_such_subteam"}, wantError: "user group not found"},
+		{name: "Should return other errors", slackErr: assert.AnError, wantError: assert.AnError.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			m.mockSlackClient.EXPECT().
+				UpdateUserGroupMembersContext(gomock.Any(), "S0123ABCDEF", "U1").
+				Return(slack.UserGroup{}, tt.slackErr).Times(1)
+
+			err := updateUserGroup(context.Background(), m.mockSlackClient, "S0123ABCDEF", []*entity.User{{SlackUserID: "U1"}})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantError)
+		})
+	}
+}
+
+func Test_rotationService_UpdateChannelConfig_UserGroup(t *testing.T) {
+	presenter := &entity.User{ID: 2, ChannelID: 1, SlackUserID: "U2"}
+	backup := &entity.User{ID: 3, ChannelID: 1, SlackUserID: "U3"}
+
+	tests := []struct {
+		name      string
+		value     string
+		buildMock func(m allMocks)
+		wantErr   string
+	}{
+		{
+			name:  "Should link the user group and give it the current assignees",
+			value: "<!subteam^S0123ABCDEF|@team-oncall>",
+			buildMock: func(m allMocks) {
+				m.mockUserRepo.EXPECT().GetLastPresenter(int64(1)).Return(presenter, nil).Times(1)
+				m.mockUserRepo.EXPECT().GetLastBackups(int64(1)).Return([]*entity.User{backup}, nil).Times(1)
+				m.mockRotationRepo.EXPECT().GetByID(int64(1)).Return(&entity.Rotation{ID: 1, ChannelID: 1, IsPrimary: true}, nil).Times(1)
+				m.mockChannelRepo.EXPECT().GetByID(int64(1)).Return(&entity.Channel{ID: 1, SlackTeamID: "T123456789"}, nil).Times(1)
+				m.mockSlackClient.EXPECT().
+					UpdateUserGroupMembersContext(gomock.Any(), "S0123ABCDEF", "U2,U3").
+					Return(slack.UserGroup{}, nil).Times(1)
+				m.mockSchedulerRepo.EXPECT().
+					Update(gomock.Any()).
+					DoAndReturn(func(s *entity.Scheduler) error {
+						require.Equal(t, "S0123ABCDEF", s.UserGroupID)
+						return nil
+					}).Times(1)
+			},
+		},
+		{
+			name:  "Should link the user group when the rotation has no turn yet",
+			value: "S0123ABCDEF",
+			buildMock: func(m allMocks) {
+				m.mockUserRepo.EXPECT().GetLastPresenter(int64(1)).Return(nil, nil).Times(1)
+				m.mockSchedulerRepo.EXPECT().Update(gomock.Any()).Return(nil).Times(1)
+			},
+		},
+		{
+			name:  "Should not link the user group when the bot lacks the scope",
+			value: "S0123ABCDEF",
+			buildMock: func(m allMocks) {
+				m.mockUserRepo.EXPECT().GetLastPresenter(int64(1)).Return(presenter, nil).Times(1)
+				m.mockUserRepo.EXPECT().GetLastBackups(int64(1)).Return(nil, nil).Times(1)
+				m.mockRotationRepo.EXPECT().GetByID(int64(1)).Return(&entity.Rotation{ID: 1, ChannelID: 1, IsPrimary: true}, nil).Times(1)
+				m.mockChannelRepo.EXPECT().GetByID(int64(1)).Return(&entity.Channel{ID: 1, SlackTeamID: "T123456789"}, nil).Times(1)
+				m.mockSlackClient.EXPECT().
+					UpdateUserGroupMembersContext(gomock.Any(), "S0123ABCDEF", "U2").
+					Return(slack.UserGroup{}, slack.SlackErrorResponse{Err: "missing_scope"}).Times(1)
+			},
+			wantErr: "usergroups:write",
+		},
+		{
+			name:  "Should unlink the user group",
+			value: "off",
+			buildMock: func(m allMocks) {
+				m.mockSchedulerRepo.EXPECT().
+					Update(gomock.Any()).
+					DoAndReturn(func(s *entity.Scheduler) error {
+						require.Empty(t, s.UserGroupID)
+						return nil
+					}).Times(1)
+			},
+		},
+		{
+			name:    "Should return error for an invalid user group",
+			value:   "@team-oncall",
+			wantErr: "invalid user group",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ctrl := newServiceTestMock(t)
+			defer ctrl.Finish()
+
+			s := newRotation(m.mockDataManager, m.mockSlackClients, m.clock)
+
+			m.mockSchedulerRepo.EXPECT().
+				GetByRotationID(int64(1)).
+				Return(&entity.Scheduler{ID: 1, ChannelID: 1, RotationID: 1, UserGroupID: "S0000OLD"}, nil).Times(1)
+			if tt.buildMock != nil {
+				tt.buildMock(m)
+			}
+
+			err := s.UpdateChannelConfig(1, "usergroup", tt.value)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
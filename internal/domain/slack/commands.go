@@ -3,26 +3,42 @@ package slack
 import (
 	"fmt"
 	"strings"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
 )
 
 type CommandType string
 
 const (
-	CmdAdd    CommandType = "add"
-	CmdRemove CommandType = "remove"
-	CmdList   CommandType = "list"
-	CmdConfig CommandType = "config"
-	CmdNext   CommandType = "next"
-	CmdPause  CommandType = "pause"
-	CmdResume CommandType = "resume"
-	CmdStatus CommandType = "status"
-	CmdHelp   CommandType = "help"
+	CmdAdd       CommandType = "add"
+	CmdRemove    CommandType = "remove"
+	CmdList      CommandType = "list"
+	CmdConfig    CommandType = "config"
+	CmdNext      CommandType = "next"
+	CmdPause     CommandType = "pause"
+	CmdResume    CommandType = "resume"
+	CmdStatus    CommandType = "status"
+	CmdHelp      CommandType = "help"
+	CmdHolidays  CommandType = "holidays"
+	CmdAway      CommandType = "away"
+	CmdHistory   CommandType = "history"
+	CmdMove      CommandType = "move"
+	CmdOrder     CommandType = "order"
+	CmdShuffle   CommandType = "shuffle"
+	CmdSwap      CommandType = "swap"
+	CmdCreate    CommandType = "create"
+	CmdDelete    CommandType = "delete"
+	CmdRotations CommandType = "rotations"
+	CmdMe        CommandType = "me"
+	CmdSchedule  CommandType = "schedule"
+	CmdNotify    CommandType = "notify"
 )
 
 type Command struct {
-	Type CommandType
-	Args []string
-	Raw  string
+	Type     CommandType
+	Args     []string
+	Raw      string
+	Rotation string // Rotation named before the command, empty for the primary rotation
 }
 
 func ParseCommand(text string) (*Command, error) {
@@ -35,6 +51,25 @@ func ParseCommand(text string) (*Command, error) {
 		Raw: text,
 	}
 
+	if cmd.parse(parts) {
+		return cmd, nil
+	}
+
+	// A leading word that is not a command names the rotation, e.g. "reviewers add @user"
+	if len(parts) > 1 && cmd.parse(parts[1:]) {
+		cmd.Rotation = strings.ToLower(parts[0])
+		return cmd, nil
+	}
+
+	return nil, fmt.Errorf("unknown command: %s", parts[0])
+}
+
+// parse sets the command type and arguments from parts, reporting whether parts[0] is a known command
+func (cmd *Command) parse(parts []string) bool {
+	if !domain.CommandWords[parts[0]] {
+		return false
+	}
+
 	switch parts[0] {
 	case "add":
 		cmd.Type = CmdAdd
@@ -48,6 +83,35 @@ func ParseCommand(text string) (*Command, error) {
 		}
 	case "list", "ls":
 		cmd.Type = CmdList
+	case "move":
+		cmd.Type = CmdMove
+		if len(parts) > 1 {
+			cmd.Args = parts[1:]
+		}
+	case "order":
+		cmd.Type = CmdOrder
+		if len(parts) > 1 {
+			cmd.Args = parts[1:]
+		}
+	case "shuffle":
+		cmd.Type = CmdShuffle
+	case "swap":
+		cmd.Type = CmdSwap
+		if len(parts) > 1 {
+			cmd.Args = parts[1:]
+		}
+	case "create":
+		cmd.Type = CmdCreate
+		if len(parts) > 1 {
+			cmd.Args = parts[1:]
+		}
+	case "delete":
+		cmd.Type = CmdDelete
+		if len(parts) > 1 {
+			cmd.Args = parts[1:]
+		}
+	case "rotations":
+		cmd.Type = CmdRotations
 	case "config":
 		cmd.Type = CmdConfig
 		if len(parts) > 1 {
@@ -55,27 +119,63 @@ func ParseCommand(text string) (*Command, error) {
 		}
 	case "next":
 		cmd.Type = CmdNext
+		if len(parts) > 1 {
+			cmd.Args = parts[1:]
+		}
 	case "pause":
 		cmd.Type = CmdPause
 	case "resume":
 		cmd.Type = CmdResume
 	case "status":
 		cmd.Type = CmdStatus
+	case "holidays", "holiday":
+		cmd.Type = CmdHolidays
+		if len(parts) > 1 {
+			cmd.Args = parts[1:]
+		}
+	case "history":
+		cmd.Type = CmdHistory
+		if len(parts) > 1 {
+			cmd.Args = parts[1:]
+		}
+	case "away":
+		cmd.Type = CmdAway
+		if len(parts) > 1 {
+			cmd.Args = parts[1:]
+		}
+	case "me":
+		cmd.Type = CmdMe
+	case "schedule":
+		cmd.Type = CmdSchedule
+		if len(parts) > 1 {
+			cmd.Args = parts[1:]
+		}
+	case "notify":
+		cmd.Type = CmdNotify
+		if len(parts) > 1 {
+			cmd.Args = parts[1:]
+		}
 	case "help", "":
 		cmd.Type = CmdHelp
 	default:
-		return nil, fmt.Errorf("unknown command: %s", parts[0])
+		return false
 	}
 
-	return cmd, nil
+	return true
 }
 
 func GetHelpText() string {
 	return `*🔄 People Rotation Bot - Commands*
 
 *⚙️ Configuration:*
-• ` + "`/rotation config time HH:MM`" + ` - Set daily notification time (24-hour format, UTC)
-  _Example: ` + "`/rotation config time 09:30`" + ` for 9:30 AM UTC or ` + "`/rotation config time 14:00`" + ` for 2:00 PM UTC_
+• ` + "`/rotation config`" + ` - Open an editor with the time, days, role and pause settings, all saved together
+  
+• ` + "`/rotation config time HH:MM`" + ` - Set daily notification time (24-hour format, channel timezone)
+  _Example: ` + "`/rotation config time 09:30`" + ` for 9:30 AM or ` + "`/rotation config time 14:00`" + ` for 2:00 PM_
+  
+• ` + "`/rotation config timezone ZONE`" + ` - Set the channel timezone (IANA name, DST handled automatically)
+  _Example: ` + "`/rotation config timezone America/Sao_Paulo`" + ` or ` + "`/rotation config timezone Europe/Berlin`" + `_
+  _Default: UTC_
   
 • ` + "`/rotation config days 1,2,3,4,5`" + ` - Choose active weekdays
   _Days: 1=Mon, 2=Tue, 3=Wed, 4=Thu, 5=Fri, 6=Sat, 7=Sun_
@@ -85,6 +185,29 @@ func GetHelpText() string {
   _Example: ` + "`/rotation config role presenter`" + ` → "presenter today: @user"_
   _Default: "On duty" → "On duty today: @user"_
   
+• ` + "`/rotation config strategy NAME`" + ` - Choose how the next person is picked
+  _` + "`round-robin`" + ` follows the rotation order, ` + "`shuffled`" + ` draws a random order every cycle, ` + "`fair`" + ` picks who had the fewest turns_
+  _Default: round-robin_
+  
+• ` + "`/rotation config assignees N`" + ` - Set how many people take each turn (1-5), the presenter plus backups
+  _Example: ` + "`/rotation config assignees 2`" + ` → "On duty today: @a (backup: @b)"_
+  _Default: 1_
+  
+• ` + "`/rotation config backup NAME`" + ` - Set the role name of the backups
+  _Example: ` + "`/rotation config backup pair`" + ` → "On duty today: @a (pair: @b)"_
+  _Default: "backup"_
+  
+• ` + "`/rotation config dm on|off|day-before`" + ` - Also remind the people on duty by direct message
+  _` + "`day-before`" + ` adds a heads-up the day before. Members can choose for themselves with ` + "`/rotation notify dm`" + `_
+  _Default: off_
+  
+• ` + "`/rotation config topic on|off`" + ` - Keep the channel topic saying who is on duty
+  _Example: "On duty: @a", the rest of the topic is kept_
+  _Default: off_
+  
+• ` + "`/rotation config usergroup @group`" + ` - Keep a user group, e.g. @team-oncall, with the people on duty as members
+  _Use ` + "`off`" + ` to unlink it_
+  
 • ` + "`/rotation config show`" + ` - Display current channel settings
 
 *👥 Member Management:*
@@ -95,16 +218,64 @@ func GetHelpText() string {
   _Example: ` + "`/rotation remove @jane.smith @john.doe`" + `_
   
 • ` + "`/rotation list`" + ` - Show all members in rotation order
-  _Current person on duty is marked with 👉 and role name_
+  _Current person on duty is marked with 👉 and role name, away members show until when_
+  
+• ` + "`/rotation move @user N`" + ` - Move a member to position N of the rotation order
+  _Example: ` + "`/rotation move @jane.smith 1`" + `_
+  
+• ` + "`/rotation order @user1 @user2 ...`" + ` - Set the rotation order
+  _Members not listed keep their relative order after the listed ones_
+  
+• ` + "`/rotation shuffle`" + ` - Randomize the rotation order
+  
+• ` + "`/rotation away @user YYYY-MM-DD[..YYYY-MM-DD] [reason]`" + ` - Mark a member as out of office
+  _Example: ` + "`/rotation away @jane.smith 2026-10-20..2026-10-24 vacation`" + `_
+  _Away members are skipped but keep their place in line. Omit @user to mark yourself_
 
 *🎯 Rotation Control:*
 • ` + "`/rotation next`" + ` - Manually skip to next person
   _Use when current person is unavailable (vacation, sick, etc.)_
+  
+• ` + "`/rotation next @user`" + ` - Hand the current turn to a specific member
+  
+• ` + "`/rotation swap @user1 @user2 [YYYY-MM-DD]`" + ` - Swap the turns of two members
+  _Example: ` + "`/rotation swap @jane.smith @john.doe 2026-10-22`" + `_
+  _Without a date they swap places right away, with a date the swap happens on that day_
+  
+• ` + "`/rotation history [n]`" + ` - Show the last N turns (default 10)
+  _Shows whether each turn was automatic, a manual skip or an override, and who triggered it_
+  
+• ` + "`/rotation me`" + ` - Show your next turns in every channel you rotate in
+  
+• ` + "`/rotation schedule [n]`" + ` - Preview the next N turns with who is on duty (default 5)
+  _Takes holidays, out-of-office periods and planned swaps into account_
+
+*🔄 Multiple Rotations:*
+• ` + "`/rotation create NAME`" + ` - Create another rotation in this channel with its own members and schedule
+  _Example: ` + "`/rotation create reviewers`" + `_
+  
+• ` + "`/rotation NAME command`" + ` - Run any command on that rotation
+  _Example: ` + "`/rotation reviewers add @jane.smith`" + ` or ` + "`/rotation reviewers config time 14:00`" + `_
+  _Commands without a rotation name use the primary rotation of the channel_
+  
+• ` + "`/rotation rotations`" + ` - Show the rotations of this channel
+• ` + "`/rotation delete NAME`" + ` - Delete a rotation with its members and history
+
+*🏖️ Holidays:*
+• ` + "`/rotation holidays add YYYY-MM-DD[..YYYY-MM-DD] [description]`" + ` - Skip notifications on a date or range
+  _Example: ` + "`/rotation holidays add 2026-12-24..2026-12-26 Christmas`" + `_
+  
+• ` + "`/rotation holidays list`" + ` - Show upcoming holidays
+• ` + "`/rotation holidays remove YYYY-MM-DD[..YYYY-MM-DD]`" + ` - Remove a holiday
+• ` + "`/rotation holidays import ICS`" + ` - Import all events from a pasted .ics file
+  _The rotation does not advance on holidays_
 
 *⏸️ Notification Control:*
 • ` + "`/rotation pause`" + ` - Temporarily stop daily notifications
 • ` + "`/rotation resume`" + ` - Restart daily notifications  
 • ` + "`/rotation status`" + ` - Check if bot is active & see current settings
+• ` + "`/rotation notify dm on|off|day-before`" + ` - Choose whether you get your reminders by direct message
+  _` + "`day-before`" + ` also sends you a heads-up the day before your turn_
 
 💡 *Quick Start:* Just add members with ` + "`/rotation add @user`" + ` and the bot auto-configures with defaults (9 AM, Mon-Fri)`
 }
@@ -0,0 +1,124 @@
+package slack
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	slackapi "github.com/slack-go/slack"
+)
+
+// ConfigModalCallbackID identifies the submissions of the configuration editor
+const ConfigModalCallbackID = "rotation_config"
+
+// configValueAction is the action ID of every input of the configuration editor, the
+// fields are told apart by their block ID
+const configValueAction = "value"
+
+// ConfigModal builds the configuration editor of the rotation, pre-filled with its current
+// scheduler settings. The rotation ID is kept in the modal so the submission knows what to update.
+func ConfigModal(rotation *entity.Rotation, scheduler *entity.Scheduler) slackapi.ModalViewRequest {
+	timePicker := slackapi.NewTimePickerBlockElement(configValueAction)
+	timePicker.InitialTime = scheduler.NotificationTime
+	timePicker.Timezone = scheduler.GetLocation().String()
+
+	var dayOptions, selectedDays []*slackapi.OptionBlockObject
+	for day := domain.Monday; day <= domain.Sunday; day++ {
+		option := plainOption(strconv.Itoa(day), domain.WeekdayNames[day])
+		dayOptions = append(dayOptions, option)
+		if isActiveDay(scheduler.ActiveDays, day) {
+			selectedDays = append(selectedDays, option)
+		}
+	}
+	days := slackapi.NewCheckboxGroupsBlockElement(configValueAction, dayOptions...)
+	days.InitialOptions = selectedDays
+
+	role := slackapi.NewPlainTextInputBlockElement(nil, configValueAction)
+	role.InitialValue = scheduler.Role
+
+	remindersOption := plainOption("enabled", "Send the scheduled reminders")
+	reminders := slackapi.NewCheckboxGroupsBlockElement(configValueAction, remindersOption)
+	if scheduler.IsEnabled {
+		reminders.InitialOptions = []*slackapi.OptionBlockObject{remindersOption}
+	}
+
+	// Unchecking the reminders pauses the rotation, so the field cannot be required
+	enabled := slackapi.NewInputBlock(domain.ConfigFieldEnabled, plainText("Reminders"), plainText("Uncheck to pause the rotation"), reminders)
+	enabled.Optional = true
+
+	title := "Rotation settings"
+	if !rotation.IsPrimary {
+		title = truncate("Settings: "+rotation.Name, 24)
+	}
+
+	return slackapi.ModalViewRequest{
+		Type:            slackapi.VTModal,
+		CallbackID:      ConfigModalCallbackID,
+		PrivateMetadata: strconv.FormatInt(rotation.ID, 10),
+		Title:           plainText(title),
+		Submit:          plainText("Save"),
+		Close:           plainText("Cancel"),
+		Blocks: slackapi.Blocks{BlockSet: []slackapi.Block{
+			slackapi.NewInputBlock(domain.ConfigFieldTime, plainText("Notification time"), plainText("In the rotation timezone, "+timePicker.Timezone), timePicker),
+			slackapi.NewInputBlock(domain.ConfigFieldDays, plainText("Active days"), nil, days),
+			slackapi.NewInputBlock(domain.ConfigFieldRole, plainText("Role"), plainText("How the person on duty is called, e.g. presenter"), role),
+			enabled,
+		}},
+	}
+}
+
+// ParseConfigModal reads the rotation ID and the values of the submitted configuration editor
+func ParseConfigModal(view slackapi.View) (int64, entity.SchedulerSettings, error) {
+	rotationID, err := strconv.ParseInt(view.PrivateMetadata, 10, 64)
+	if err != nil {
+		return 0, entity.SchedulerSettings{}, fmt.Errorf("invalid rotation id %q: %w", view.PrivateMetadata, err)
+	}
+
+	var values map[string]map[string]slackapi.BlockAction
+	if view.State != nil {
+		values = view.State.Values
+	}
+
+	value := func(field string) slackapi.BlockAction {
+		return values[field][configValueAction]
+	}
+
+	settings := entity.SchedulerSettings{
+		NotificationTime: value(domain.ConfigFieldTime).SelectedTime,
+		Role:             value(domain.ConfigFieldRole).Value,
+		IsEnabled:        len(value(domain.ConfigFieldEnabled).SelectedOptions) > 0,
+	}
+
+	for _, option := range value(domain.ConfigFieldDays).SelectedOptions {
+		settings.ActiveDays = append(settings.ActiveDays, option.Value)
+	}
+
+	return rotationID, settings, nil
+}
+
+func plainText(text string) *slackapi.TextBlockObject {
+	return slackapi.NewTextBlockObject(slackapi.PlainTextType, text, false, false)
+}
+
+func plainOption(value, text string) *slackapi.OptionBlockObject {
+	return slackapi.NewOptionBlockObject(value, plainText(text), nil)
+}
+
+func isActiveDay(activeDays []int, day int) bool {
+	for _, activeDay := range activeDays {
+		if activeDay == day {
+			return true
+		}
+	}
+	return false
+}
+
+// truncate shortens text to limit characters, Slack rejects longer modal titles
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,84 @@
+package slack
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	slackapi "github.com/slack-go/slack"
+)
+
+// ActionHomeAway is the action ID of the App Home button marking the user away today,
+// its value is the rotation ID
+const ActionHomeAway = "home_away"
+
+// HomeView builds the App Home tab of a user, one section per rotation they belong to
+func HomeView(rotations []*entity.UserRotation) slackapi.HomeTabViewRequest {
+	blocks := []slackapi.Block{
+		slackapi.NewHeaderBlock(plainText("Your rotations")),
+	}
+
+	if len(rotations) == 0 {
+		blocks = append(blocks, markdownSection("You are not in any rotation yet. Ask a teammate to run `/rotation add @you` in a channel."))
+	}
+
+	for _, rotation := range rotations {
+		section := slackapi.NewSectionBlock(markdownText(homeRotationText(rotation)), nil, nil)
+		if !rotation.AwayToday {
+			away := slackapi.NewButtonBlockElement(ActionHomeAway, strconv.FormatInt(rotation.Rotation.ID, 10), plainText("🏖️ I'm away today"))
+			section.Accessory = slackapi.NewAccessory(away)
+		}
+
+		blocks = append(blocks, slackapi.NewDividerBlock(), section)
+	}
+
+	return slackapi.HomeTabViewRequest{
+		Type:   slackapi.VTHomeTab,
+		Blocks: slackapi.Blocks{BlockSet: blocks},
+	}
+}
+
+// homeRotationText describes where the turn of the user stands in a rotation, e.g.
+// "*<#C123>* · reviewers\nRole: reviewer\nOn duty now: <@U1>\nYour next turn: Thu Oct 22"
+func homeRotationText(rotation *entity.UserRotation) string {
+	text := fmt.Sprintf("*<#%s>*", rotation.Channel.SlackChannelID)
+	if !rotation.Rotation.IsPrimary {
+		text += " · " + rotation.Rotation.Name
+	}
+
+	role := rotation.Scheduler.Role
+	if role == "" {
+		role = domain.DefaultRole
+	}
+	text += "\nRole: " + role
+
+	onDuty := "nobody yet"
+	if rotation.OnDuty != nil {
+		onDuty = fmt.Sprintf("<@%s>", rotation.OnDuty.SlackUserID)
+	}
+	text += "\nOn duty now: " + onDuty
+
+	switch {
+	case !rotation.Scheduler.IsEnabled:
+		text += "\nYour next turn: rotation paused"
+	case rotation.NextTurn.IsZero():
+		text += "\nYour next turn: not scheduled yet"
+	default:
+		text += "\nYour next turn: " + rotation.NextTurn.Format(domain.TurnDateFormat)
+	}
+
+	if rotation.AwayToday {
+		text += "\n🏖️ You are away today"
+	}
+
+	return text
+}
+
+func markdownText(text string) *slackapi.TextBlockObject {
+	return slackapi.NewTextBlockObject(slackapi.MarkdownType, text, false, false)
+}
+
+func markdownSection(text string) *slackapi.SectionBlock {
+	return slackapi.NewSectionBlock(markdownText(text), nil, nil)
+}
@@ -0,0 +1,63 @@
+package slack
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	slackapi "github.com/slack-go/slack"
+)
+
+// Action IDs of the buttons in the rotation reminder
+const (
+	ActionAcknowledge = "rotation_acknowledge"
+	ActionSkip        = "rotation_skip"
+	ActionAway        = "rotation_away"
+)
+
+// ReminderText is the text of the rotation reminder, e.g. "🎯 *Rotation Reminder*\n\nOn duty today: <@U123>".
+// It is also the notification fallback of the Block Kit message.
+func ReminderText(title, role, assignees string) string {
+	return fmt.Sprintf("🎯 *%s*\n\n%s today: %s", title, role, assignees)
+}
+
+// FormatAssignees mentions the presenter followed by the backups, e.g. "<@U1> (backup: <@U2>)"
+func FormatAssignees(users []*entity.User, backupRole string) string {
+	text := fmt.Sprintf("<@%s>", users[0].SlackUserID)
+	if len(users) == 1 {
+		return text
+	}
+
+	mentions := make([]string, len(users)-1)
+	for i, backup := range users[1:] {
+		mentions[i] = fmt.Sprintf("<@%s>", backup.SlackUserID)
+	}
+
+	return fmt.Sprintf("%s (%s: %s)", text, backupRole, strings.Join(mentions, ", "))
+}
+
+// ReminderBlocks builds the Block Kit rotation reminder. note, when set, tells what happened
+// to the turn since it was posted. The buttons carry rotationID as their value and are left
+// out once the turn is acknowledged.
+func ReminderBlocks(text, note string, rotationID int64, withActions bool) []slackapi.Block {
+	blocks := []slackapi.Block{
+		slackapi.NewSectionBlock(slackapi.NewTextBlockObject(slackapi.MarkdownType, text, false, false), nil, nil),
+	}
+
+	if note != "" {
+		blocks = append(blocks, slackapi.NewContextBlock("", slackapi.NewTextBlockObject(slackapi.MarkdownType, note, false, false)))
+	}
+
+	if !withActions {
+		return blocks
+	}
+
+	value := strconv.FormatInt(rotationID, 10)
+	acknowledge := slackapi.NewButtonBlockElement(ActionAcknowledge, value, slackapi.NewTextBlockObject(slackapi.PlainTextType, "✅ Acknowledge", true, false))
+	acknowledge.Style = slackapi.StylePrimary
+	skip := slackapi.NewButtonBlockElement(ActionSkip, value, slackapi.NewTextBlockObject(slackapi.PlainTextType, "⏭️ Skip to next", true, false))
+	away := slackapi.NewButtonBlockElement(ActionAway, value, slackapi.NewTextBlockObject(slackapi.PlainTextType, "🏖️ I'm away today", true, false))
+
+	return append(blocks, slackapi.NewActionBlock("rotation_actions", acknowledge, skip, away))
+}
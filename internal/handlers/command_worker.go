@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	slackcmd "github.com/diegoclair/slack-rotation-bot/internal/domain/slack"
+	"github.com/slack-go/slack"
+)
+
+const (
+	defaultCommandWorkers = 4
+	// commandQueueSize is how many slow commands wait for a worker before new ones are refused
+	commandQueueSize = 100
+	// commandTimeout bounds a command run in the background, Slack accepts responses for 30 minutes
+	commandTimeout = time.Minute
+	// responseTimeout bounds posting the result of a command to its response_url
+	responseTimeout = 10 * time.Second
+)
+
+// commandJob is a slash command waiting for a worker
+type commandJob struct {
+	cmd      *slackcmd.Command
+	slashCmd *slack.SlashCommand
+}
+
+// SetCommandWorkers sets how many slow commands run at the same time, call it before Start
+func (h *SlackHandler) SetCommandWorkers(workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	h.commandWorkers = workers
+}
+
+// Start runs the workers completing the slow commands in the background. Until it is called,
+// every command is answered before the request returns.
+func (h *SlackHandler) Start() {
+	h.ctx, h.cancel = context.WithCancel(context.Background())
+	h.commands = make(chan commandJob, commandQueueSize)
+
+	for range h.commandWorkers {
+		h.workers.Add(1)
+		go h.commandWorker()
+	}
+}
+
+// Stop waits for the commands being run. The commands still waiting for a worker are not run,
+// their users are told to try again.
+func (h *SlackHandler) Stop() {
+	if h.cancel == nil {
+		return
+	}
+
+	h.cancel()
+	h.workers.Wait()
+
+	for {
+		select {
+		case job := <-h.commands:
+			h.rejectCommand(job)
+		default:
+			return
+		}
+	}
+}
+
+// runsInBackground reports whether the command can take longer than the 3 seconds Slack waits
+// for the response, because it calls the Slack API once per mentioned user or imports a calendar
+func runsInBackground(cmd *slackcmd.Command) bool {
+	switch cmd.Type {
+	case slackcmd.CmdAdd, slackcmd.CmdRemove:
+		return len(cmd.Args) > 0
+	case slackcmd.CmdHolidays:
+		return len(cmd.Args) > 0 && cmd.Args[0] == "import"
+	default:
+		return false
+	}
+}
+
+// enqueueCommand hands the command to the workers, returning the acknowledgement to answer
+// with right away. The result is posted to the response_url of the command once done.
+func (h *SlackHandler) enqueueCommand(cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	if h.ctx.Err() != nil {
+		return h.createErrorResponse("The bot is restarting, please try again in a moment.")
+	}
+
+	select {
+	case h.commands <- commandJob{cmd: cmd, slashCmd: slashCmd}:
+		return &slack.Msg{
+			ResponseType: slack.ResponseTypeEphemeral,
+			Text:         "⏳ Working on it...",
+		}
+	default:
+		log.Printf("ERROR command queue is full, refusing: %s %s", slashCmd.Command, slashCmd.Text)
+		return h.createErrorResponse("The bot is busy right now, please try again in a moment.")
+	}
+}
+
+func (h *SlackHandler) commandWorker() {
+	defer h.workers.Done()
+
+	for {
+		select {
+		case <-h.ctx.Done():
+			return
+		case job := <-h.commands:
+			// Both cases can be ready once stopping, the command is then not run
+			if h.ctx.Err() != nil {
+				h.rejectCommand(job)
+				return
+			}
+			h.runBackgroundCommand(job)
+		}
+	}
+}
+
+// runBackgroundCommand handles the command and posts its result, or the reason it failed, to the
+// response_url of the command. The worker stays busy until the command returns, which its calls
+// to Slack do soon after commandTimeout, so no more commands run than there are workers.
+func (h *SlackHandler) runBackgroundCommand(job commandJob) {
+	// Not bound to h.ctx, so Stop lets the command complete instead of interrupting it
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	response := h.handleCommandSafely(ctx, job)
+	if ctx.Err() != nil {
+		log.Printf("ERROR command %s %s did not finish in time: %v", job.slashCmd.Command, job.slashCmd.Text, ctx.Err())
+		response.Text += "\n⚠️ The command took too long and may be incomplete, please check the result before trying again."
+	}
+
+	if err := h.postResponse(job.slashCmd.ResponseURL, response); err != nil {
+		log.Printf("ERROR posting the result of %s %s: %v", job.slashCmd.Command, job.slashCmd.Text, err)
+	}
+}
+
+// handleCommandSafely handles the command, answering with an error when it panics
+func (h *SlackHandler) handleCommandSafely(ctx context.Context, job commandJob) (response *slack.Msg) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("ERROR command %s %s panicked: %v", job.slashCmd.Command, job.slashCmd.Text, r)
+			response = h.createErrorResponse("Something went wrong, please try again.")
+		}
+	}()
+
+	return h.handleCommand(ctx, job.cmd, job.slashCmd)
+}
+
+// rejectCommand tells the user the command was not run because the bot is stopping
+func (h *SlackHandler) rejectCommand(job commandJob) {
+	log.Printf("ERROR bot stopping, not running: %s %s", job.slashCmd.Command, job.slashCmd.Text)
+
+	response := h.createErrorResponse(fmt.Sprintf("The bot restarted before running `%s %s`, please try again.", job.slashCmd.Command, job.cmd.Type))
+	if err := h.postResponse(job.slashCmd.ResponseURL, response); err != nil {
+		log.Printf("ERROR posting the result of %s %s: %v", job.slashCmd.Command, job.slashCmd.Text, err)
+	}
+}
+
+// postResponse sends the result of a command to its response_url
+func (h *SlackHandler) postResponse(responseURL string, response *slack.Msg) error {
+	body, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+
+	// Not bound to h.ctx, so the result of a command completed while stopping is still sent
+	ctx, cancel := context.WithTimeout(context.Background(), responseTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, responseURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post response: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("response_url returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/diegoclair/slack-rotation-bot/internal/handlers/test"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newResponseURLServer stands for the response_url of a slash command, sending the messages
+// posted to it to the returned channel
+func newResponseURLServer(t *testing.T) (*httptest.Server, chan slack.Msg) {
+	t.Helper()
+
+	responses := make(chan slack.Msg, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg slack.Msg
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		responses <- msg
+	}))
+	t.Cleanup(server.Close)
+
+	return server, responses
+}
+
+func receiveResponse(t *testing.T, responses chan slack.Msg) slack.Msg {
+	t.Helper()
+
+	select {
+	case msg := <-responses:
+		return msg
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no response posted to the response_url")
+		return slack.Msg{}
+	}
+}
+
+func TestSlackHandler_HandleSlashCommand_Background(t *testing.T) {
+	channel := &entity.Channel{ID: 1, SlackChannelID: "C123456789", SlackChannelName: "test-channel", SlackTeamID: "T123456789", IsActive: true}
+	rotation := &entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}
+
+	t.Run("Should acknowledge add and post the result to the response_url", func(t *testing.T) {
+		m, handler, ctrl := test.GetHandlerTest(t)
+		defer ctrl.Finish()
+		handler.Start()
+		defer handler.Stop()
+
+		server, responses := newResponseURLServer(t)
+
+		m.RotationServiceMock.EXPECT().SetupChannel("C123456789", "test-channel", "T123456789").Return(channel, false, nil).Times(1)
+		m.RotationServiceMock.EXPECT().GetRotation(int64(1), "").Return(rotation, nil).Times(1)
+		// The command runs with a deadline, so the calls to Slack give up once it is over
+		withDeadline := func(ctx context.Context, _ int64, _ string) error {
+			_, ok := ctx.Deadline()
+			assert.True(t, ok)
+			return nil
+		}
+		m.RotationServiceMock.EXPECT().AddUser(gomock.Any(), int64(1), "U111").DoAndReturn(withDeadline).Times(1)
+		m.RotationServiceMock.EXPECT().AddUser(gomock.Any(), int64(1), "U222").DoAndReturn(withDeadline).Times(1)
+
+		recorder := test.CreateTestRecorder()
+		req := test.CreateSlackRequestWithResponseURL(t, "/rotation", "add <@U111> <@U222>", "C123456789", "test-channel", "U987654321", "T123456789", server.URL, "test-signing-secret")
+		handler.HandleSlashCommand(recorder, req)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var ack slack.Msg
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &ack))
+		assert.Equal(t, slack.ResponseTypeEphemeral, ack.ResponseType)
+		assert.Contains(t, ack.Text, "Working on it")
+
+		response := receiveResponse(t, responses)
+		assert.Equal(t, slack.ResponseTypeInChannel, response.ResponseType)
+		assert.Contains(t, response.Text, "<@U111>")
+		assert.Contains(t, response.Text, "<@U222>")
+	})
+
+	t.Run("Should report a failure to the response_url", func(t *testing.T) {
+		m, handler, ctrl := test.GetHandlerTest(t)
+		defer ctrl.Finish()
+		handler.Start()
+		defer handler.Stop()
+
+		server, responses := newResponseURLServer(t)
+
+		m.RotationServiceMock.EXPECT().SetupChannel("C123456789", "test-channel", "T123456789").Return(nil, false, assert.AnError).Times(1)
+
+		recorder := test.CreateTestRecorder()
+		req := test.CreateSlackRequestWithResponseURL(t, "/rotation", "remove <@U111>", "C123456789", "test-channel", "U987654321", "T123456789", server.URL, "test-signing-secret")
+		handler.HandleSlashCommand(recorder, req)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		response := receiveResponse(t, responses)
+		assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+		assert.Contains(t, response.Text, "❌")
+	})
+
+	t.Run("Should answer quick commands right away", func(t *testing.T) {
+		m, handler, ctrl := test.GetHandlerTest(t)
+		defer ctrl.Finish()
+		handler.Start()
+		defer handler.Stop()
+
+		server, responses := newResponseURLServer(t)
+
+		m.RotationServiceMock.EXPECT().SetupChannel("C123456789", "test-channel", "T123456789").Return(channel, false, nil).Times(1)
+		m.RotationServiceMock.EXPECT().GetRotation(int64(1), "").Return(rotation, nil).Times(1)
+		m.RotationServiceMock.EXPECT().ListUsers(int64(1)).Return([]*entity.User{}, nil).Times(1)
+
+		recorder := test.CreateTestRecorder()
+		req := test.CreateSlackRequestWithResponseURL(t, "/rotation", "list", "C123456789", "test-channel", "U987654321", "T123456789", server.URL, "test-signing-secret")
+		handler.HandleSlashCommand(recorder, req)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var response slack.Msg
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.NotContains(t, response.Text, "Working on it")
+		assert.Empty(t, responses)
+	})
+	t.Run("Should tell the queued commands they were not run when stopping", func(t *testing.T) {
+		m, handler, ctrl := test.GetHandlerTest(t)
+		defer ctrl.Finish()
+		handler.SetCommandWorkers(1)
+		handler.Start()
+
+		server, responses := newResponseURLServer(t)
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		m.RotationServiceMock.EXPECT().SetupChannel("C123456789", "test-channel", "T123456789").Return(channel, false, nil).Times(1)
+		m.RotationServiceMock.EXPECT().GetRotation(int64(1), "").Return(rotation, nil).Times(1)
+		m.RotationServiceMock.EXPECT().
+			AddUser(gomock.Any(), int64(1), "U111").
+			DoAndReturn(func(context.Context, int64, string) error {
+				close(started)
+				<-release
+				return nil
+			}).Times(1)
+
+		send := func(text string) slack.Msg {
+			recorder := test.CreateTestRecorder()
+			req := test.CreateSlackRequestWithResponseURL(t, "/rotation", text, "C123456789", "test-channel", "U987654321", "T123456789", server.URL, "test-signing-secret")
+			handler.HandleSlashCommand(recorder, req)
+
+			require.Equal(t, http.StatusOK, recorder.Code)
+			var ack slack.Msg
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &ack))
+			return ack
+		}
+
+		// The only worker is busy with the first command, the second one waits for it
+		assert.Contains(t, send("add <@U111>").Text, "Working on it")
+		<-started
+		assert.Contains(t, send("add <@U222>").Text, "Working on it")
+
+		stopped := make(chan struct{})
+		go func() {
+			handler.Stop()
+			close(stopped)
+		}()
+
+		// Let Stop cancel the workers before the running command returns
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+
+		select {
+		case <-stopped:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "Stop did not return")
+		}
+
+		// The running command completes, the queued one is reported as not run
+		assert.Contains(t, receiveResponse(t, responses).Text, "<@U111>")
+		rejected := receiveResponse(t, responses)
+		assert.Equal(t, slack.ResponseTypeEphemeral, rejected.ResponseType)
+		assert.Contains(t, rejected.Text, "The bot restarted before running `/rotation add`")
+
+		// Commands received once stopped are refused right away
+		assert.Contains(t, send("add <@U333>").Text, "The bot is restarting")
+	})
+}
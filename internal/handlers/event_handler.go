@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/slack-go/slack/slackevents"
+)
+
+// HandleEvent receives the Events API callbacks that keep members and channels in sync
+// with Slack: members leaving or deactivated, and channels archived or renamed. It also
+// refreshes the App Home tab when a user opens it.
+func (h *SlackHandler) HandleEvent(w http.ResponseWriter, r *http.Request) {
+	// Verify request from Slack
+	if !h.verifyRequest(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("ERROR reading body: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	event, err := slackevents.ParseEvent(json.RawMessage(body), slackevents.OptionNoVerifyToken())
+	if err != nil {
+		log.Printf("ERROR parsing event: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Slack checks the Request URL by sending a challenge that must be echoed back
+	if event.Type == slackevents.URLVerification {
+		verification, ok := event.Data.(*slackevents.EventsAPIURLVerificationEvent)
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		if _, err := w.Write([]byte(verification.Challenge)); err != nil {
+			log.Printf("ERROR writing challenge: %v", err)
+		}
+		return
+	}
+
+	if event.Type == slackevents.CallbackEvent {
+		h.runEvent(event)
+	}
+
+	// Always acknowledge, otherwise Slack retries the event
+	w.WriteHeader(http.StatusOK)
+}
+
+// runEvent handles an Events API callback, whichever transport delivered it.
+// Errors are only logged because Slack has nothing to show for them.
+func (h *SlackHandler) runEvent(event slackevents.EventsAPIEvent) {
+	var err error
+
+	switch ev := event.InnerEvent.Data.(type) {
+	case *slackevents.MemberLeftChannelEvent:
+		log.Printf("Received event: %s for user: %s in channel: %s", ev.Type, ev.User, ev.Channel)
+		err = h.rotationService.RemoveLeftMember(ev.Channel, ev.User)
+	case *slackevents.UserChangeEvent:
+		// Only deactivated users matter, profile changes are read from Slack when needed
+		if !ev.User.Deleted {
+			return
+		}
+		log.Printf("Received event: %s for deactivated user: %s", ev.Type, ev.User.ID)
+		err = h.rotationService.RemoveDeactivatedUser(ev.User.ID)
+	case *slackevents.ChannelArchiveEvent:
+		log.Printf("Received event: %s in channel: %s", ev.Type, ev.Channel)
+		err = h.rotationService.SetChannelActive(ev.Channel, false)
+	case *slackevents.ChannelUnarchiveEvent:
+		log.Printf("Received event: %s in channel: %s", ev.Type, ev.Channel)
+		err = h.rotationService.SetChannelActive(ev.Channel, true)
+	case *slackevents.ChannelRenameEvent:
+		log.Printf("Received event: %s in channel: %s", ev.Type, ev.Channel.ID)
+		err = h.rotationService.RenameChannel(ev.Channel.ID, ev.Channel.Name)
+	case *slackevents.AppHomeOpenedEvent:
+		// The Messages tab has nothing to publish
+		if ev.Tab != "home" {
+			return
+		}
+		log.Printf("Received event: %s for user: %s", ev.Type, ev.User)
+		err = h.publishHome(event.TeamID, ev.User)
+	default:
+		return
+	}
+
+	if err != nil {
+		log.Printf("ERROR handling event %s: %v", event.InnerEvent.Type, err)
+	}
+}
@@ -0,0 +1,150 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/diegoclair/slack-rotation-bot/internal/handlers/test"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// callbackEvent wraps an inner event in the event_callback envelope sent by Slack
+func callbackEvent(inner string) string {
+	return `{"token":"test-token","team_id":"T123456789","type":"event_callback","event_id":"Ev123","event_time":1700000000,"event":` + inner + `}`
+}
+
+func TestSlackHandler_HandleEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		signingSecret string
+		buildMocks    func(ctx context.Context, m test.ServiceMocks)
+		wantStatus    int
+		wantBody      string
+	}{
+		{
+			name:       "Should answer the URL verification challenge",
+			body:       `{"token":"test-token","challenge":"challenge-123","type":"url_verification"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   "challenge-123",
+		},
+		{
+			name: "Should remove member that left the channel",
+			body: callbackEvent(`{"type":"member_left_channel","user":"U123","channel":"C123456789","channel_type":"C","team":"T123456789"}`),
+			buildMocks: func(ctx context.Context, m test.ServiceMocks) {
+				m.RotationServiceMock.EXPECT().
+					RemoveLeftMember("C123456789", "U123").
+					Return(nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Should remove deactivated user",
+			body: callbackEvent(`{"type":"user_change","user":{"id":"U123","deleted":true}}`),
+			buildMocks: func(ctx context.Context, m test.ServiceMocks) {
+				m.RotationServiceMock.EXPECT().
+					RemoveDeactivatedUser("U123").
+					Return(nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Should ignore profile changes",
+			body:       callbackEvent(`{"type":"user_change","user":{"id":"U123","deleted":false}}`),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Should deactivate archived channel",
+			body: callbackEvent(`{"type":"channel_archive","channel":"C123456789","user":"U123"}`),
+			buildMocks: func(ctx context.Context, m test.ServiceMocks) {
+				m.RotationServiceMock.EXPECT().
+					SetChannelActive("C123456789", false).
+					Return(nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Should reactivate unarchived channel",
+			body: callbackEvent(`{"type":"channel_unarchive","channel":"C123456789","user":"U123"}`),
+			buildMocks: func(ctx context.Context, m test.ServiceMocks) {
+				m.RotationServiceMock.EXPECT().
+					SetChannelActive("C123456789", true).
+					Return(nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Should update renamed channel",
+			body: callbackEvent(`{"type":"channel_rename","channel":{"id":"C123456789","name":"new-name","created":1700000000}}`),
+			buildMocks: func(ctx context.Context, m test.ServiceMocks) {
+				m.RotationServiceMock.EXPECT().
+					RenameChannel("C123456789", "new-name").
+					Return(nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Should publish the App Home of the user",
+			body: callbackEvent(`{"type":"app_home_opened","user":"U123","channel":"D123","tab":"home","event_ts":"1700000000.000100"}`),
+			buildMocks: func(ctx context.Context, m test.ServiceMocks) {
+				gomock.InOrder(
+					m.RotationServiceMock.EXPECT().
+						GetUserRotations("U123").
+						Return([]*entity.UserRotation{}, nil).Times(1),
+					m.SlackClientMock.EXPECT().
+						PublishView("U123", gomock.Any(), "").
+						Return(&slack.ViewResponse{}, nil).Times(1),
+				)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Should ignore the Messages tab",
+			body:       callbackEvent(`{"type":"app_home_opened","user":"U123","channel":"D123","tab":"messages","event_ts":"1700000000.000100"}`),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "Should reject request with invalid signature",
+			body:          callbackEvent(`{"type":"channel_archive","channel":"C123456789","user":"U123"}`),
+			signingSecret: "wrong-secret",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:       "Should return bad request for invalid body",
+			body:       "not-json",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m, handler, ctrl := test.GetHandlerTest(t)
+			defer ctrl.Finish()
+
+			if tt.buildMocks != nil {
+				tt.buildMocks(ctx, m)
+			}
+
+			signingSecret := tt.signingSecret
+			if signingSecret == "" {
+				signingSecret = "test-signing-secret"
+			}
+
+			req := test.CreateEventRequest(t, tt.body, signingSecret)
+			recorder := httptest.NewRecorder()
+
+			handler.HandleEvent(recorder, req)
+
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	slackcmd "github.com/diegoclair/slack-rotation-bot/internal/domain/slack"
+	"github.com/slack-go/slack"
+)
+
+// publishHome refreshes the App Home tab of the user with the rotations they belong to
+func (h *SlackHandler) publishHome(teamID, slackUserID string) error {
+	rotations, err := h.rotationService.GetUserRotations(slackUserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user rotations: %w", err)
+	}
+
+	slackClient, err := h.slackClients.ForTeam(teamID)
+	if err != nil {
+		return fmt.Errorf("failed to get Slack client: %w", err)
+	}
+
+	if _, err := slackClient.PublishView(slackUserID, slackcmd.HomeView(rotations), ""); err != nil {
+		return fmt.Errorf("failed to publish home view: %w", err)
+	}
+
+	return nil
+}
+
+// handleHomeAway marks the user away today from the App Home. Unlike the reminder button,
+// the current turn is not handed over because there is no reminder to update.
+func (h *SlackHandler) handleHomeAway(action *slack.BlockAction, callback *slack.InteractionCallback) error {
+	rotationID, err := strconv.ParseInt(action.Value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid rotation id %q: %w", action.Value, err)
+	}
+
+	scheduler, err := h.rotationService.GetSchedulerConfig(rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to get scheduler config: %w", err)
+	}
+
+	loc := time.UTC
+	if scheduler != nil {
+		loc = scheduler.GetLocation()
+	}
+	today := h.clock.Now().In(loc).Format(domain.DateFormat)
+
+	if _, err := h.rotationService.SetUserAway(rotationID, callback.User.ID, today, ""); err != nil {
+		return fmt.Errorf("failed to set user away: %w", err)
+	}
+
+	return h.publishHome(callback.Team.ID, callback.User.ID)
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	slackcmd "github.com/diegoclair/slack-rotation-bot/internal/domain/slack"
+	"github.com/slack-go/slack"
+)
+
+// HandleInteraction receives the clicks on the reminder and App Home buttons. The reminder is
+// updated in place, so the channel sees who actually took the turn.
+func (h *SlackHandler) HandleInteraction(w http.ResponseWriter, r *http.Request) {
+	// Verify request from Slack
+	if !h.verifyRequest(w, r) {
+		return
+	}
+
+	// Parse interaction, Slack sends it as JSON in the payload form field
+	var callback slack.InteractionCallback
+	if err := json.Unmarshal([]byte(r.FormValue("payload")), &callback); err != nil {
+		log.Printf("ERROR parsing interaction: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	response := h.runInteraction(r.Context(), &callback)
+	if response == nil {
+		// Slack only needs to know the interaction was received
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("ERROR encoding json response: %v", err)
+	}
+}
+
+// runInteraction handles a click on the reminder or App Home buttons or a modal submission, whichever
+// transport delivered it. Only modal submissions can have a response, the errors to show
+// in the modal. Other errors are only logged because Slack has nothing to show for them.
+func (h *SlackHandler) runInteraction(ctx context.Context, callback *slack.InteractionCallback) *slack.ViewSubmissionResponse {
+	switch callback.Type {
+	case slack.InteractionTypeBlockActions:
+		if len(callback.ActionCallback.BlockActions) == 0 {
+			return nil
+		}
+
+		action := callback.ActionCallback.BlockActions[0]
+		log.Printf("Received action: %s from user: %s in channel: %s", action.ActionID, callback.User.ID, callback.Channel.ID)
+
+		var err error
+		if action.ActionID == slackcmd.ActionHomeAway {
+			err = h.handleHomeAway(action, callback)
+		} else {
+			err = h.handleReminderAction(ctx, action, callback)
+		}
+		if err != nil {
+			log.Printf("ERROR handling action %s: %v", action.ActionID, err)
+		}
+	case slack.InteractionTypeViewSubmission:
+		if callback.View.CallbackID == slackcmd.ConfigModalCallbackID {
+			return h.handleConfigSubmission(callback)
+		}
+	}
+
+	return nil
+}
+
+// handleConfigSubmission applies the configuration editor. Invalid values are shown next
+// to their field and the modal stays open, a nil response closes it.
+func (h *SlackHandler) handleConfigSubmission(callback *slack.InteractionCallback) *slack.ViewSubmissionResponse {
+	log.Printf("Received config submission from user: %s", callback.User.ID)
+
+	rotationID, settings, err := slackcmd.ParseConfigModal(callback.View)
+	if err != nil {
+		log.Printf("ERROR parsing config modal: %v", err)
+		return slack.NewErrorsViewSubmissionResponse(map[string]string{
+			domain.ConfigFieldTime: "Could not read the configuration, please try again",
+		})
+	}
+
+	err = h.rotationService.UpdateSchedulerSettings(rotationID, settings)
+
+	var fieldErrors domain.FieldErrors
+	if errors.As(err, &fieldErrors) {
+		return slack.NewErrorsViewSubmissionResponse(fieldErrors)
+	}
+
+	if err != nil {
+		log.Printf("ERROR updating configuration: %v", err)
+		return slack.NewErrorsViewSubmissionResponse(map[string]string{
+			domain.ConfigFieldTime: "Could not save the configuration, please try again",
+		})
+	}
+
+	return nil
+}
+
+func (h *SlackHandler) handleReminderAction(ctx context.Context, action *slack.BlockAction, callback *slack.InteractionCallback) error {
+	rotationID, err := strconv.ParseInt(action.Value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid rotation id %q: %w", action.Value, err)
+	}
+
+	rotation, err := h.rotationService.GetRotationByID(rotationID)
+	if err != nil {
+		return err
+	}
+
+	scheduler, err := h.rotationService.GetSchedulerConfig(rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to get scheduler config: %w", err)
+	}
+
+	userID := callback.User.ID
+	withActions := true
+	var note string
+	var assignees []*entity.User
+
+	switch action.ActionID {
+	case slackcmd.ActionAcknowledge:
+		note = fmt.Sprintf("✅ Acknowledged by <@%s>", userID)
+		withActions = false
+	case slackcmd.ActionSkip:
+		assignees, err = h.advanceTurn(ctx, rotationID, userID)
+		if err != nil {
+			return err
+		}
+		note = fmt.Sprintf("⏭️ Skipped by <@%s>", userID)
+	case slackcmd.ActionAway:
+		loc := time.UTC
+		if scheduler != nil {
+			loc = scheduler.GetLocation()
+		}
+		today := h.clock.Now().In(loc).Format(domain.DateFormat)
+
+		if _, err := h.rotationService.SetUserAway(rotationID, userID, today, ""); err != nil {
+			return fmt.Errorf("failed to set user away: %w", err)
+		}
+		note = fmt.Sprintf("🏖️ <@%s> is away today", userID)
+
+		// Hand the turn over when the presenter is the one away
+		presenter, err := h.rotationService.GetCurrentPresenter(rotationID)
+		if err != nil {
+			return fmt.Errorf("failed to get current presenter: %w", err)
+		}
+		if presenter != nil && presenter.SlackUserID == userID {
+			assignees, err = h.advanceTurn(ctx, rotationID, userID)
+		} else {
+			assignees, err = h.withBackups(rotationID, presenter, scheduler)
+		}
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown action")
+	}
+
+	if assignees == nil {
+		presenter, err := h.rotationService.GetCurrentPresenter(rotationID)
+		if err != nil {
+			return fmt.Errorf("failed to get current presenter: %w", err)
+		}
+
+		assignees, err = h.withBackups(rotationID, presenter, scheduler)
+		if err != nil {
+			return err
+		}
+	}
+
+	role := domain.DefaultRole
+	if scheduler != nil && scheduler.Role != "" {
+		role = scheduler.Role
+	}
+
+	text := slackcmd.ReminderText(rotation.ReminderTitle(), role, formatAssignees(assignees, scheduler))
+
+	slackClient, err := h.slackClients.ForTeam(callback.Team.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get Slack client: %w", err)
+	}
+
+	_, _, _, err = slackClient.UpdateMessage(
+		callback.Channel.ID,
+		callback.Message.Timestamp,
+		slack.MsgOptionText(text, false),
+		slack.MsgOptionBlocks(slackcmd.ReminderBlocks(text, note, rotationID, withActions)...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update reminder: %w", err)
+	}
+
+	return nil
+}
+
+// advanceTurn gives the turn to the next members in line, like /rotation next
+func (h *SlackHandler) advanceTurn(ctx context.Context, rotationID int64, triggeredBy string) ([]*entity.User, error) {
+	nextUsers, err := h.rotationService.GetNextAssignees(rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next presenter: %w", err)
+	}
+
+	if err := h.rotationService.RecordPresentation(ctx, rotationID, nextUsers, domain.HistoryKindSkip, triggeredBy); err != nil {
+		return nil, fmt.Errorf("failed to record new presenter: %w", err)
+	}
+
+	return nextUsers, nil
+}
+
+// withBackups returns the current presenter followed by the backups of the turn
+func (h *SlackHandler) withBackups(rotationID int64, presenter *entity.User, scheduler *entity.Scheduler) ([]*entity.User, error) {
+	if presenter == nil {
+		return nil, fmt.Errorf("rotation has no current presenter")
+	}
+
+	assignees := []*entity.User{presenter}
+	if scheduler != nil && scheduler.GetAssignees() > 1 {
+		backups, err := h.rotationService.GetCurrentBackups(rotationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get backups: %w", err)
+		}
+		assignees = append(assignees, backups...)
+	}
+
+	return assignees, nil
+}
@@ -0,0 +1,277 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	slackcmd "github.com/diegoclair/slack-rotation-bot/internal/domain/slack"
+	"github.com/diegoclair/slack-rotation-bot/internal/handlers/test"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// interactionPayload builds the block_actions payload Slack sends when a reminder button is clicked
+func interactionPayload(t *testing.T, actionID, value string) string {
+	t.Helper()
+
+	payload := map[string]any{
+		"type":    "block_actions",
+		"user":    map[string]any{"id": "U111"},
+		"channel": map[string]any{"id": "C123456789"},
+		"message": map[string]any{"ts": "1700000000.000100"},
+		"actions": []map[string]any{
+			{"action_id": actionID, "block_id": "rotation_actions", "value": value, "type": "button"},
+		},
+	}
+
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func TestSlackHandler_HandleInteraction(t *testing.T) {
+	rotation := &entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}
+	scheduler := &entity.Scheduler{RotationID: 1, Role: "Presenter", Assignees: 1}
+	alice := &entity.User{ID: 1, SlackUserID: "U111"}
+	bob := &entity.User{ID: 2, SlackUserID: "U222"}
+	now := time.Date(2026, 10, 19, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		payload       func(t *testing.T) string
+		signingSecret string
+		buildMocks    func(ctx context.Context, m test.ServiceMocks)
+		wantStatus    int
+	}{
+		{
+			name:    "Should acknowledge the turn and remove the buttons",
+			payload: func(t *testing.T) string { return interactionPayload(t, slackcmd.ActionAcknowledge, "1") },
+			buildMocks: func(ctx context.Context, m test.ServiceMocks) {
+				gomock.InOrder(
+					m.RotationServiceMock.EXPECT().GetRotationByID(int64(1)).Return(rotation, nil).Times(1),
+					m.RotationServiceMock.EXPECT().GetSchedulerConfig(int64(1)).Return(scheduler, nil).Times(1),
+					m.RotationServiceMock.EXPECT().GetCurrentPresenter(int64(1)).Return(alice, nil).Times(1),
+					m.SlackClientMock.EXPECT().
+						UpdateMessage("C123456789", "1700000000.000100", gomock.Any(), gomock.Any()).
+						Return("C123456789", "1700000000.000100", "", nil).Times(1),
+				)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:    "Should skip to the next presenter",
+			payload: func(t *testing.T) string { return interactionPayload(t, slackcmd.ActionSkip, "1") },
+			buildMocks: func(ctx context.Context, m test.ServiceMocks) {
+				gomock.InOrder(
+					m.RotationServiceMock.EXPECT().GetRotationByID(int64(1)).Return(rotation, nil).Times(1),
+					m.RotationServiceMock.EXPECT().GetSchedulerConfig(int64(1)).Return(scheduler, nil).Times(1),
+					m.RotationServiceMock.EXPECT().GetNextAssignees(int64(1)).Return([]*entity.User{bob}, nil).Times(1),
+					m.RotationServiceMock.EXPECT().
+						RecordPresentation(gomock.Any(), int64(1), []*entity.User{bob}, domain.HistoryKindSkip, "U111").
+						Return(nil).Times(1),
+					m.SlackClientMock.EXPECT().
+						UpdateMessage("C123456789", "1700000000.000100", gomock.Any(), gomock.Any()).
+						Return("C123456789", "1700000000.000100", "", nil).Times(1),
+				)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:    "Should mark the presenter away and hand the turn over",
+			payload: func(t *testing.T) string { return interactionPayload(t, slackcmd.ActionAway, "1") },
+			buildMocks: func(ctx context.Context, m test.ServiceMocks) {
+				gomock.InOrder(
+					m.RotationServiceMock.EXPECT().GetRotationByID(int64(1)).Return(rotation, nil).Times(1),
+					m.RotationServiceMock.EXPECT().GetSchedulerConfig(int64(1)).Return(scheduler, nil).Times(1),
+					m.ClockMock.EXPECT().Now().Return(now).Times(1),
+					m.RotationServiceMock.EXPECT().
+						SetUserAway(int64(1), "U111", "2026-10-19", "").
+						Return(&entity.Availability{}, nil).Times(1),
+					m.RotationServiceMock.EXPECT().GetCurrentPresenter(int64(1)).Return(alice, nil).Times(1),
+					m.RotationServiceMock.EXPECT().GetNextAssignees(int64(1)).Return([]*entity.User{bob}, nil).Times(1),
+					m.RotationServiceMock.EXPECT().
+						RecordPresentation(gomock.Any(), int64(1), []*entity.User{bob}, domain.HistoryKindSkip, "U111").
+						Return(nil).Times(1),
+					m.SlackClientMock.EXPECT().
+						UpdateMessage("C123456789", "1700000000.000100", gomock.Any(), gomock.Any()).
+						Return("C123456789", "1700000000.000100", "", nil).Times(1),
+				)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:    "Should keep the turn when a member that is not presenting is away",
+			payload: func(t *testing.T) string { return interactionPayload(t, slackcmd.ActionAway, "1") },
+			buildMocks: func(ctx context.Context, m test.ServiceMocks) {
+				gomock.InOrder(
+					m.RotationServiceMock.EXPECT().GetRotationByID(int64(1)).Return(rotation, nil).Times(1),
+					m.RotationServiceMock.EXPECT().GetSchedulerConfig(int64(1)).Return(scheduler, nil).Times(1),
+					m.ClockMock.EXPECT().Now().Return(now).Times(1),
+					m.RotationServiceMock.EXPECT().
+						SetUserAway(int64(1), "U111", "2026-10-19", "").
+						Return(&entity.Availability{}, nil).Times(1),
+					m.RotationServiceMock.EXPECT().GetCurrentPresenter(int64(1)).Return(bob, nil).Times(1),
+					m.SlackClientMock.EXPECT().
+						UpdateMessage("C123456789", "1700000000.000100", gomock.Any(), gomock.Any()).
+						Return("C123456789", "1700000000.000100", "", nil).Times(1),
+				)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:    "Should mark the user away from the App Home and refresh it",
+			payload: func(t *testing.T) string { return interactionPayload(t, slackcmd.ActionHomeAway, "1") },
+			buildMocks: func(ctx context.Context, m test.ServiceMocks) {
+				gomock.InOrder(
+					m.RotationServiceMock.EXPECT().GetSchedulerConfig(int64(1)).Return(scheduler, nil).Times(1),
+					m.ClockMock.EXPECT().Now().Return(now).Times(1),
+					m.RotationServiceMock.EXPECT().
+						SetUserAway(int64(1), "U111", "2026-10-19", "").
+						Return(&entity.Availability{}, nil).Times(1),
+					m.RotationServiceMock.EXPECT().
+						GetUserRotations("U111").
+						Return([]*entity.UserRotation{}, nil).Times(1),
+					m.SlackClientMock.EXPECT().
+						PublishView("U111", gomock.Any(), "").
+						Return(&slack.ViewResponse{}, nil).Times(1),
+				)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:    "Should ignore unknown actions",
+			payload: func(t *testing.T) string { return interactionPayload(t, "unknown", "1") },
+			buildMocks: func(ctx context.Context, m test.ServiceMocks) {
+				m.RotationServiceMock.EXPECT().GetRotationByID(int64(1)).Return(rotation, nil).Times(1)
+				m.RotationServiceMock.EXPECT().GetSchedulerConfig(int64(1)).Return(scheduler, nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "Should reject request with invalid signature",
+			payload:       func(t *testing.T) string { return interactionPayload(t, slackcmd.ActionSkip, "1") },
+			signingSecret: "wrong-secret",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:       "Should return bad request for invalid payload",
+			payload:    func(t *testing.T) string { return "not-json" },
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m, handler, ctrl := test.GetHandlerTest(t)
+			defer ctrl.Finish()
+
+			if tt.buildMocks != nil {
+				tt.buildMocks(ctx, m)
+			}
+
+			signingSecret := tt.signingSecret
+			if signingSecret == "" {
+				signingSecret = "test-signing-secret"
+			}
+
+			req := test.CreateInteractionRequest(t, tt.payload(t), signingSecret)
+			recorder := httptest.NewRecorder()
+
+			handler.HandleInteraction(recorder, req)
+
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+		})
+	}
+}
+
+// configSubmissionPayload builds the view_submission payload of the configuration editor
+func configSubmissionPayload(t *testing.T, selectedTime string, days []string, role string, enabled bool) string {
+	t.Helper()
+
+	dayOptions := []map[string]any{}
+	for _, day := range days {
+		dayOptions = append(dayOptions, map[string]any{"value": day})
+	}
+
+	enabledOptions := []map[string]any{}
+	if enabled {
+		enabledOptions = append(enabledOptions, map[string]any{"value": "enabled"})
+	}
+
+	payload := map[string]any{
+		"type": "view_submission",
+		"user": map[string]any{"id": "U111"},
+		"view": map[string]any{
+			"callback_id":      slackcmd.ConfigModalCallbackID,
+			"private_metadata": "1",
+			"state": map[string]any{
+				"values": map[string]any{
+					domain.ConfigFieldTime:    map[string]any{"value": map[string]any{"type": "timepicker", "selected_time": selectedTime}},
+					domain.ConfigFieldDays:    map[string]any{"value": map[string]any{"type": "checkboxes", "selected_options": dayOptions}},
+					domain.ConfigFieldRole:    map[string]any{"value": map[string]any{"type": "plain_text_input", "value": role}},
+					domain.ConfigFieldEnabled: map[string]any{"value": map[string]any{"type": "checkboxes", "selected_options": enabledOptions}},
+				},
+			},
+		},
+	}
+
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func TestSlackHandler_HandleInteraction_ConfigSubmission(t *testing.T) {
+	t.Run("Should apply settings and close the modal", func(t *testing.T) {
+		m, handler, ctrl := test.GetHandlerTest(t)
+		defer ctrl.Finish()
+
+		m.RotationServiceMock.EXPECT().
+			UpdateSchedulerSettings(int64(1), entity.SchedulerSettings{
+				NotificationTime: "10:30",
+				ActiveDays:       []string{"1", "3"},
+				Role:             "presenter",
+				IsEnabled:        false,
+			}).
+			Return(nil).Times(1)
+
+		req := test.CreateInteractionRequest(t, configSubmissionPayload(t, "10:30", []string{"1", "3"}, "presenter", false), "test-signing-secret")
+		recorder := httptest.NewRecorder()
+
+		handler.HandleInteraction(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Empty(t, recorder.Body.String())
+	})
+
+	t.Run("Should show field errors in the modal", func(t *testing.T) {
+		m, handler, ctrl := test.GetHandlerTest(t)
+		defer ctrl.Finish()
+
+		m.RotationServiceMock.EXPECT().
+			UpdateSchedulerSettings(int64(1), gomock.Any()).
+			Return(domain.FieldErrors{domain.ConfigFieldDays: "select at least one day"}).Times(1)
+
+		req := test.CreateInteractionRequest(t, configSubmissionPayload(t, "10:30", nil, "presenter", true), "test-signing-secret")
+		recorder := httptest.NewRecorder()
+
+		handler.HandleInteraction(recorder, req)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response slack.ViewSubmissionResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, slack.RAErrors, response.ResponseAction)
+		assert.Equal(t, map[string]string{domain.ConfigFieldDays: "select at least one day"}, response.Errors)
+	})
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	"github.com/slack-go/slack"
+)
+
+const (
+	oauthAuthorizeURL = "https://slack.com/oauth/v2/authorize"
+	oauthStateCookie  = "slack_oauth_state"
+	oauthStateMaxAge  = 10 * time.Minute
+)
+
+// botScopes are the bot token scopes requested when the app is installed
+var botScopes = []string{"chat:write", "commands", "channels:read", "users:read", "im:write", "channels:write.topic", "usergroups:write"}
+
+// OAuthConfig holds the app credentials used to install the app in other workspaces
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the public URL of /slack/oauth/callback, it must be listed in the app redirect URLs
+	RedirectURL string
+	// APIURL is where oauth.v2.access is called, slack.APIURL when empty
+	APIURL string
+}
+
+// SetOAuth enables the installation of the app through /slack/install
+func (h *SlackHandler) SetOAuth(config OAuthConfig) {
+	h.oauth = config
+}
+
+// HandleInstall sends the user to Slack to install the app in their workspace
+func (h *SlackHandler) HandleInstall(w http.ResponseWriter, r *http.Request) {
+	if h.oauth.ClientID == "" {
+		http.Error(w, "Installation is not enabled", http.StatusNotFound)
+		return
+	}
+
+	state, err := newOAuthState()
+	if err != nil {
+		log.Printf("ERROR creating OAuth state: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// The state comes back in the callback, proving the installation started here
+	http.SetCookie(w, &http.Cookie{
+		Name:     oauthStateCookie,
+		Value:    state,
+		Path:     "/slack/oauth",
+		MaxAge:   int(oauthStateMaxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil || strings.HasPrefix(h.oauth.RedirectURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	query := url.Values{
+		"client_id":    {h.oauth.ClientID},
+		"scope":        {strings.Join(botScopes, ",")},
+		"redirect_uri": {h.oauth.RedirectURL},
+		"state":        {state},
+	}
+	http.Redirect(w, r, oauthAuthorizeURL+"?"+query.Encode(), http.StatusFound)
+}
+
+// HandleOAuthCallback finishes the installation, exchanging the code Slack sent for the bot token
+// of the workspace
+func (h *SlackHandler) HandleOAuthCallback(w http.ResponseWriter, r *http.Request) {
+	if h.oauth.ClientID == "" {
+		http.Error(w, "Installation is not enabled", http.StatusNotFound)
+		return
+	}
+
+	query := r.URL.Query()
+
+	// The user canceled the installation or Slack refused it
+	if errCode := query.Get("error"); errCode != "" {
+		log.Printf("Installation canceled: %s", errCode)
+		http.Error(w, "Installation canceled: "+errCode, http.StatusBadRequest)
+		return
+	}
+
+	cookie, err := r.Cookie(oauthStateCookie)
+	if err != nil || query.Get("state") == "" ||
+		subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(query.Get("state"))) != 1 {
+		http.Error(w, "Invalid installation state, please start again from /slack/install", http.StatusBadRequest)
+		return
+	}
+
+	// The state is only used once
+	http.SetCookie(w, &http.Cookie{Name: oauthStateCookie, Path: "/slack/oauth", MaxAge: -1})
+
+	code := query.Get("code")
+	if code == "" {
+		http.Error(w, "Missing authorization code", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.exchangeOAuthCode(r, code)
+	if err != nil {
+		log.Printf("ERROR exchanging OAuth code: %v", err)
+		http.Error(w, "Could not complete the installation, please try again", http.StatusBadGateway)
+		return
+	}
+
+	installation := &entity.Installation{
+		SlackTeamID:   resp.Team.ID,
+		SlackTeamName: resp.Team.Name,
+		BotUserID:     resp.BotUserID,
+		BotToken:      resp.AccessToken,
+		Scope:         resp.Scope,
+		InstalledBy:   resp.AuthedUser.ID,
+	}
+	if err := h.rotationService.SaveInstallation(installation); err != nil {
+		log.Printf("ERROR saving installation of team %s: %v", resp.Team.ID, err)
+		http.Error(w, "Could not complete the installation, please try again", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintf(w, "The app was installed in %s. Use /rotation in any channel to get started.", resp.Team.Name)
+}
+
+// exchangeOAuthCode calls oauth.v2.access with the code of the callback
+func (h *SlackHandler) exchangeOAuthCode(r *http.Request, code string) (*slack.OAuthV2Response, error) {
+	apiURL := h.oauth.APIURL
+	if apiURL == "" {
+		apiURL = slack.APIURL
+	}
+
+	form := url.Values{
+		"client_id":     {h.oauth.ClientID},
+		"client_secret": {h.oauth.ClientSecret},
+		"code":          {code},
+		"redirect_uri":  {h.oauth.RedirectURL},
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodPost, apiURL+"oauth.v2.access", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call oauth.v2.access: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oauth.v2.access returned status %d", httpResp.StatusCode)
+	}
+
+	var resp slack.OAuthV2Response
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to decode oauth.v2.access response: %w", err)
+	}
+
+	if err := resp.Err(); err != nil {
+		return nil, fmt.Errorf("oauth.v2.access failed: %w", err)
+	}
+
+	if resp.Team.ID == "" || resp.AccessToken == "" {
+		return nil, fmt.Errorf("oauth.v2.access returned no team or bot token")
+	}
+
+	return &resp, nil
+}
+
+// newOAuthState returns a random value tying the callback to the installation started by the user
+func newOAuthState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
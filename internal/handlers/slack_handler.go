@@ -4,11 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
 
 	"github.com/diegoclair/slack-rotation-bot/internal/domain"
 	"github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
@@ -17,27 +23,75 @@ import (
 	"github.com/slack-go/slack"
 )
 
+const (
+	defaultHistoryLimit  = 10
+	maxHistoryLimit      = 50
+	defaultScheduleLimit = 5
+	maxScheduleLimit     = 30
+)
+
 type SlackHandler struct {
-	slackClient     contract.SlackClient
+	slackClients    contract.SlackClientFactory
 	rotationService contract.RotationService
+	clock           contract.Clock
 	signingSecret   string
+	oauth           OAuthConfig
+
+	// commands queues the slow commands for the workers started by Start, nil until then
+	commands       chan commandJob
+	commandWorkers int
+	workers        sync.WaitGroup
+	// ctx is canceled on Stop so the workers return, the commands being run are not bound to it
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
-func New(slackClient contract.SlackClient, rotationService contract.RotationService, signingSecret string) *SlackHandler {
+// New returns the handler of the Slack requests. slackClients resolves the client of the
+// workspace each request comes from and clock tells the day the buttons act on.
+func New(slackClients contract.SlackClientFactory, rotationService contract.RotationService, clock contract.Clock, signingSecret string) *SlackHandler {
 	return &SlackHandler{
-		slackClient:     slackClient,
+		slackClients:    slackClients,
 		rotationService: rotationService,
+		clock:           clock,
 		signingSecret:   signingSecret,
+		commandWorkers:  defaultCommandWorkers,
 	}
 }
 
 func (h *SlackHandler) HandleSlashCommand(w http.ResponseWriter, r *http.Request) {
 	// Verify request from Slack
+	if !h.verifyRequest(w, r) {
+		return
+	}
+
+	// Parse command
+	s, err := slack.SlashCommandParse(r)
+	if err != nil {
+		log.Printf("ERROR parsing command: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("Received command: %s %s from user: %s in channel: %s", s.Command, s.Text, s.UserID, s.ChannelID)
+	log.Printf("DEBUG Slash Command data - TeamID: %s, ResponseURL: %s, TriggerID: %s", s.TeamID, s.ResponseURL, s.TriggerID)
+
+	response := h.runCommand(r.Context(), &s)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		log.Printf("ERROR encoding json response: %v", err)
+	}
+}
+
+// verifyRequest checks the Slack signature of the request, writing the error status when
+// it is not valid. The body is kept so the request can still be parsed afterwards.
+func (h *SlackHandler) verifyRequest(w http.ResponseWriter, r *http.Request) bool {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		log.Printf("ERROR reading body: %v", err)
 		w.WriteHeader(http.StatusBadRequest)
-		return
+		return false
 	}
 	r.Body = io.NopCloser(bytes.NewBuffer(body))
 
@@ -46,47 +100,37 @@ func (h *SlackHandler) HandleSlashCommand(w http.ResponseWriter, r *http.Request
 	if err != nil {
 		log.Printf("ERROR creating verifier: %v", err)
 		w.WriteHeader(http.StatusUnauthorized)
-		return
+		return false
 	}
 
 	if _, err := verifier.Write(body); err != nil {
 		log.Printf("ERROR writing to verifier: %v", err)
 		w.WriteHeader(http.StatusInternalServerError)
-		return
+		return false
 	}
 
 	if err := verifier.Ensure(); err != nil {
 		log.Printf("ERROR verifying signature: %v", err)
 		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-
-	// Parse command
-	s, err := slack.SlashCommandParse(r)
-	if err != nil {
-		log.Printf("ERROR parsing command: %v", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+		return false
 	}
 
-	log.Printf("Received command: %s %s from user: %s in channel: %s", s.Command, s.Text, s.UserID, s.ChannelID)
-	log.Printf("DEBUG Slash Command data - TeamID: %s, ResponseURL: %s, TriggerID: %s", s.TeamID, s.ResponseURL, s.TriggerID)
+	return true
+}
 
-	// Parse our command
-	cmd, err := slackcmd.ParseCommand(s.Text)
+// runCommand parses and handles a slash command, whichever transport delivered it
+func (h *SlackHandler) runCommand(ctx context.Context, slashCmd *slack.SlashCommand) *slack.Msg {
+	cmd, err := slackcmd.ParseCommand(slashCmd.Text)
 	if err != nil {
-		h.respondWithError(w, err.Error())
-		return
+		return h.createErrorResponse(err.Error())
 	}
 
-	// Handle command
-	response := h.handleCommand(r.Context(), cmd, &s)
-
-	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(response)
-	if err != nil {
-		log.Printf("ERROR encoding json response: %v", err)
+	// Slack only waits 3 seconds for the response, the slow commands answer later
+	if h.commands != nil && slashCmd.ResponseURL != "" && runsInBackground(cmd) {
+		return h.enqueueCommand(cmd, slashCmd)
 	}
+
+	return h.handleCommand(ctx, cmd, slashCmd)
 }
 
 func (h *SlackHandler) handleCommand(ctx context.Context, cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
@@ -96,17 +140,43 @@ func (h *SlackHandler) handleCommand(ctx context.Context, cmd *slackcmd.Command,
 	case slackcmd.CmdRemove:
 		return h.handleRemoveUser(ctx, cmd, slashCmd)
 	case slackcmd.CmdList:
-		return h.handleListUsers(ctx, slashCmd)
+		return h.handleListUsers(ctx, cmd, slashCmd)
+	case slackcmd.CmdMove:
+		return h.handleMove(ctx, cmd, slashCmd)
+	case slackcmd.CmdOrder:
+		return h.handleOrder(ctx, cmd, slashCmd)
+	case slackcmd.CmdShuffle:
+		return h.handleShuffle(ctx, cmd, slashCmd)
 	case slackcmd.CmdConfig:
 		return h.handleConfig(ctx, cmd, slashCmd)
 	case slackcmd.CmdNext:
-		return h.handleNext(ctx, slashCmd)
+		return h.handleNext(ctx, cmd, slashCmd)
+	case slackcmd.CmdSwap:
+		return h.handleSwap(ctx, cmd, slashCmd)
 	case slackcmd.CmdPause:
-		return h.handlePause(slashCmd)
+		return h.handlePause(cmd, slashCmd)
 	case slackcmd.CmdResume:
-		return h.handleResume(slashCmd)
+		return h.handleResume(cmd, slashCmd)
 	case slackcmd.CmdStatus:
-		return h.handleStatus(slashCmd)
+		return h.handleStatus(cmd, slashCmd)
+	case slackcmd.CmdHolidays:
+		return h.handleHolidays(ctx, cmd, slashCmd)
+	case slackcmd.CmdAway:
+		return h.handleAway(cmd, slashCmd)
+	case slackcmd.CmdHistory:
+		return h.handleHistory(cmd, slashCmd)
+	case slackcmd.CmdCreate:
+		return h.handleCreateRotation(cmd, slashCmd)
+	case slackcmd.CmdDelete:
+		return h.handleDeleteRotation(cmd, slashCmd)
+	case slackcmd.CmdRotations:
+		return h.handleListRotations(slashCmd)
+	case slackcmd.CmdMe:
+		return h.handleMe(slashCmd)
+	case slackcmd.CmdSchedule:
+		return h.handleSchedule(cmd, slashCmd)
+	case slackcmd.CmdNotify:
+		return h.handleNotify(cmd, slashCmd)
 	case slackcmd.CmdHelp:
 		return h.handleHelp()
 	default:
@@ -114,42 +184,41 @@ func (h *SlackHandler) handleCommand(ctx context.Context, cmd *slackcmd.Command,
 	}
 }
 
-func (h *SlackHandler) handleAddUser(_ context.Context, cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+func (h *SlackHandler) handleAddUser(ctx context.Context, cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
 	if len(cmd.Args) == 0 {
 		return h.createErrorResponse("Please mention at least one user: `/rotation add @user1 @user2`")
 	}
 
-	// Get channel with feedback
-	channel, feedback, err := h.setupChannelWithFeedback(slashCmd)
-	if err != nil {
-		log.Printf("ERROR setting up channel: %v", err)
-		return h.createErrorResponse("Error checking channel")
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
 	}
 
 	var addedUsers []string
 	var failedUsers []string
 
 	// Process each user mention
-	for _, userMention := range cmd.Args {
-		log.Printf("DEBUG: Raw user mention: %s", userMention)
+	for i, userMention := range cmd.Args {
+		// The command ran out of time, the remaining users are reported as not added
+		if ctx.Err() != nil {
+			for _, skipped := range cmd.Args[i:] {
+				failedUsers = append(failedUsers, skipped)
+			}
+			break
+		}
 
-		// Extract user ID from mention <@U12345> or <@U12345|username>
-		userID := strings.TrimSpace(userMention)
-		userID = strings.TrimPrefix(userID, "<@")
-		userID = strings.TrimSuffix(userID, ">")
+		log.Printf("DEBUG: Raw user mention: %s", userMention)
 
-		// Handle format <@U12345|username> - take only the ID part
-		if idx := strings.Index(userID, "|"); idx != -1 {
-			userID = userID[:idx]
-		}
+		userID := extractUserID(userMention)
 
 		log.Printf("DEBUG: Extracted user ID: %s", userID)
 
 		// Add user
-		if err := h.rotationService.AddUser(channel.ID, userID); err != nil {
+		if err := h.rotationService.AddUser(ctx, rotation.ID, userID); err != nil {
 			log.Printf("ERROR adding user %s: %v", userID, err)
 			// For failures, try to get the user's display name
-			displayName := h.getUserDisplayName(userID, userMention)
+			displayName := h.getUserDisplayName(ctx, slashCmd.TeamID, userID, userMention)
 			failedUsers = append(failedUsers, displayName)
 			continue
 		}
@@ -195,55 +264,43 @@ func (h *SlackHandler) handleAddUser(_ context.Context, cmd *slackcmd.Command, s
 	}
 }
 
-func (h *SlackHandler) handleRemoveUser(_ context.Context, cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+func (h *SlackHandler) handleRemoveUser(ctx context.Context, cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
 	if len(cmd.Args) == 0 {
 		return h.createErrorResponse("Please mention at least one user: `/rotation remove @user1 @user2`")
 	}
 
-	// Get channel with feedback
-	channel, feedback, err := h.setupChannelWithFeedback(slashCmd)
-	if err != nil {
-		return h.createErrorResponse("Error checking channel")
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
 	}
 
 	var removedUsers []string
 	var failedUsers []string
 
 	// Process each user mention
-	for _, userMention := range cmd.Args {
-		// Extract user ID from mention <@U12345> or <@U12345|username>
-		userID := strings.TrimSpace(userMention)
-		userID = strings.TrimPrefix(userID, "<@")
-		userID = strings.TrimSuffix(userID, ">")
-
-		// Handle format <@U12345|username> - take only the ID part
-		if idx := strings.Index(userID, "|"); idx != -1 {
-			userID = userID[:idx]
+	for i, userMention := range cmd.Args {
+		// The command ran out of time, the remaining users are reported as not removed
+		if ctx.Err() != nil {
+			for _, skipped := range cmd.Args[i:] {
+				failedUsers = append(failedUsers, skipped)
+			}
+			break
 		}
 
+		userID := extractUserID(userMention)
+
 		// Remove user
-		if err := h.rotationService.RemoveUser(channel.ID, userID); err != nil {
+		if err := h.rotationService.RemoveUser(rotation.ID, userID); err != nil {
 			log.Printf("ERROR removing user %s: %v", userID, err)
 			// For failures, try to get the user's display name
-			displayName := h.getUserDisplayName(userID, userMention)
+			displayName := h.getUserDisplayName(ctx, slashCmd.TeamID, userID, userMention)
 			failedUsers = append(failedUsers, displayName)
 			continue
 		}
 
 		// Get user info for display name (no @ mention for removes)
-		userInfo, err := h.slackClient.GetUserInfo(userID)
-		userName := userID // fallback
-		if err == nil {
-			userName = userInfo.Profile.RealName
-			if userName == "" {
-				userName = userInfo.Profile.DisplayName
-			}
-			if userName == "" {
-				userName = userInfo.Name
-			}
-		}
-
-		removedUsers = append(removedUsers, userName)
+		removedUsers = append(removedUsers, h.getUserDisplayName(ctx, slashCmd.TeamID, userID, userID))
 	}
 
 	// Build response message
@@ -283,15 +340,15 @@ func (h *SlackHandler) handleRemoveUser(_ context.Context, cmd *slackcmd.Command
 	}
 }
 
-func (h *SlackHandler) handleListUsers(_ context.Context, slashCmd *slack.SlashCommand) *slack.Msg {
-	// Get channel with feedback
-	channel, feedback, err := h.setupChannelWithFeedback(slashCmd)
-	if err != nil {
-		return h.createErrorResponse("Error checking channel")
+func (h *SlackHandler) handleListUsers(_ context.Context, cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
 	}
 
 	// Get users
-	users, err := h.rotationService.ListUsers(channel.ID)
+	users, err := h.rotationService.ListUsers(rotation.ID)
 	if err != nil {
 		return h.createErrorResponse("Error listing users")
 	}
@@ -304,24 +361,56 @@ func (h *SlackHandler) handleListUsers(_ context.Context, slashCmd *slack.SlashC
 	}
 
 	// Get current presenter to highlight them
-	currentPresenter, _ := h.rotationService.GetCurrentPresenter(channel.ID)
+	currentPresenter, _ := h.rotationService.GetCurrentPresenter(rotation.ID)
 
 	// Get scheduler to know the role name
-	scheduler, _ := h.rotationService.GetSchedulerConfig(channel.ID)
+	scheduler, _ := h.rotationService.GetSchedulerConfig(rotation.ID)
 	role := domain.DefaultRole
 	if scheduler != nil && scheduler.Role != "" {
 		role = scheduler.Role
 	}
 
+	// Get backups to highlight them when the turn is shared
+	backupIDs := make(map[int64]bool)
+	if scheduler != nil && scheduler.GetAssignees() > 1 {
+		backups, _ := h.rotationService.GetCurrentBackups(rotation.ID)
+		for _, backup := range backups {
+			backupIDs[backup.ID] = true
+		}
+	}
+
+	// Get away periods to show who is out of office, keeping the earliest one per member
+	absences, _ := h.rotationService.ListAbsences(rotation.ChannelID)
+	awayPeriods := make(map[string]*entity.Availability)
+	for _, absence := range absences {
+		if _, ok := awayPeriods[absence.SlackUserID]; !ok {
+			awayPeriods[absence.SlackUserID] = absence
+		}
+	}
+
+	loc := time.UTC
+	if scheduler != nil {
+		loc = scheduler.GetLocation()
+	}
+	today := time.Now().In(loc)
+
 	var userList strings.Builder
-	userList.WriteString(feedback + "*Members in rotation:*\n")
+	userList.WriteString(feedback + fmt.Sprintf("*Members in %s:*\n", formatRotationName(rotation)))
 	for i, user := range users {
 		if currentPresenter != nil && user.ID == currentPresenter.ID {
 			// Highlight current presenter with arrow and role
-			userList.WriteString(fmt.Sprintf("👉 %d. %s *(%s today)*\n", i+1, user.GetDisplayName(), role))
+			userList.WriteString(fmt.Sprintf("👉 %d. %s *(%s today)*", i+1, user.GetDisplayName(), role))
+		} else if backupIDs[user.ID] {
+			// Highlight backups with their role
+			userList.WriteString(fmt.Sprintf("🤝 %d. %s _(%s today)_", i+1, user.GetDisplayName(), backupRoleOf(scheduler)))
 		} else {
-			userList.WriteString(fmt.Sprintf("%d. %s\n", i+1, user.GetDisplayName()))
+			userList.WriteString(fmt.Sprintf("%d. %s", i+1, user.GetDisplayName()))
+		}
+
+		if period, ok := awayPeriods[user.SlackUserID]; ok {
+			userList.WriteString(" " + formatAway(period, today))
 		}
+		userList.WriteString("\n")
 	}
 
 	return &slack.Msg{
@@ -330,25 +419,187 @@ func (h *SlackHandler) handleListUsers(_ context.Context, slashCmd *slack.SlashC
 	}
 }
 
-func (h *SlackHandler) handleNext(ctx context.Context, slashCmd *slack.SlashCommand) *slack.Msg {
-	// Get channel with feedback
-	channel, feedback, err := h.setupChannelWithFeedback(slashCmd)
+func (h *SlackHandler) handleMove(ctx context.Context, cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	usage := "Use: `/rotation move @user 3` to move a member to position 3"
+
+	if len(cmd.Args) != 2 || !strings.HasPrefix(cmd.Args[0], "<@") {
+		return h.createErrorResponse(usage)
+	}
+
+	position, err := strconv.Atoi(cmd.Args[1])
 	if err != nil {
-		return h.createErrorResponse("Error checking channel")
+		return h.createErrorResponse(usage)
+	}
+
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	users, err := h.rotationService.MoveUser(ctx, rotation.ID, extractUserID(cmd.Args[0]), position)
+	if err != nil {
+		return h.createErrorResponse(fmt.Sprintf("Error moving user: %v", err))
+	}
+
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeInChannel,
+		Text:         feedback + formatRotationOrder("↕️ *Rotation order updated:*", users),
+	}
+}
+
+func (h *SlackHandler) handleOrder(ctx context.Context, cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	if len(cmd.Args) == 0 {
+		return h.createErrorResponse("Please mention the members in the new order: `/rotation order @user1 @user2 @user3`")
+	}
+
+	var userIDs []string
+	for _, userMention := range cmd.Args {
+		userIDs = append(userIDs, extractUserID(userMention))
+	}
+
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	users, err := h.rotationService.SetOrder(ctx, rotation.ID, userIDs)
+	if err != nil {
+		return h.createErrorResponse(fmt.Sprintf("Error setting rotation order: %v", err))
+	}
+
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeInChannel,
+		Text:         feedback + formatRotationOrder("↕️ *Rotation order updated:*", users),
+	}
+}
+
+func (h *SlackHandler) handleShuffle(ctx context.Context, cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	users, err := h.rotationService.ShuffleUsers(ctx, rotation.ID)
+	if err != nil {
+		return h.createErrorResponse(fmt.Sprintf("Error shuffling rotation: %v", err))
+	}
+
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeInChannel,
+		Text:         feedback + formatRotationOrder("🔀 *Rotation shuffled, new order:*", users),
+	}
+}
+
+func (h *SlackHandler) handleNext(ctx context.Context, cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
 	}
 
-	// Get next presenter
-	nextUser, err := h.rotationService.GetNextPresenter(channel.ID)
+	// A mentioned member takes the turn directly instead of the next in line
+	if len(cmd.Args) > 0 {
+		return h.handleOverride(ctx, rotation, feedback, extractUserID(cmd.Args[0]), slashCmd)
+	}
+
+	// Get next presenter and backups
+	nextUsers, err := h.rotationService.GetNextAssignees(rotation.ID)
 	if err != nil {
 		return h.createErrorResponse(fmt.Sprintf("Error determining next presenter: %v", err))
 	}
 
 	// Record new presenter
-	if err := h.rotationService.RecordPresentation(ctx, channel.ID, nextUser.ID); err != nil {
+	if err := h.rotationService.RecordPresentation(ctx, rotation.ID, nextUsers, domain.HistoryKindSkip, slashCmd.UserID); err != nil {
+		return h.createErrorResponse("Error recording new presenter")
+	}
+
+	// The scheduler names the backup role, only needed when the turn is shared
+	var scheduler *entity.Scheduler
+	if len(nextUsers) > 1 {
+		scheduler, _ = h.rotationService.GetSchedulerConfig(rotation.ID)
+	}
+
+	responseText := feedback + fmt.Sprintf("⏭️ Skipping to next presenter: %s", formatAssignees(nextUsers, scheduler))
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeInChannel,
+		Text:         responseText,
+	}
+}
+
+func (h *SlackHandler) handleOverride(ctx context.Context, rotation *entity.Rotation, feedback, userID string, slashCmd *slack.SlashCommand) *slack.Msg {
+	users, err := h.rotationService.ListUsers(rotation.ID)
+	if err != nil {
+		return h.createErrorResponse("Error listing users")
+	}
+
+	selectedIndex := -1
+	for i, user := range users {
+		if user.SlackUserID == userID {
+			selectedIndex = i
+			break
+		}
+	}
+
+	if selectedIndex == -1 {
+		return h.createErrorResponse(fmt.Sprintf("<@%s> is not in the rotation. Use `/rotation add @user` first.", userID))
+	}
+
+	// The selected member presents, backed up by the members that follow in the rotation order
+	assignees := 1
+	scheduler, _ := h.rotationService.GetSchedulerConfig(rotation.ID)
+	if scheduler != nil {
+		assignees = min(scheduler.GetAssignees(), len(users))
+	}
+
+	selected := make([]*entity.User, assignees)
+	for i := range selected {
+		selected[i] = users[(selectedIndex+i)%len(users)]
+	}
+
+	// Record new presenter
+	if err := h.rotationService.RecordPresentation(ctx, rotation.ID, selected, domain.HistoryKindOverride, slashCmd.UserID); err != nil {
 		return h.createErrorResponse("Error recording new presenter")
 	}
 
-	responseText := feedback + fmt.Sprintf("⏭️ Skipping to next presenter: <@%s>", nextUser.SlackUserID)
+	responseText := feedback + fmt.Sprintf("👉 %s now has the current turn.", formatAssignees(selected, scheduler))
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeInChannel,
+		Text:         responseText,
+	}
+}
+
+func (h *SlackHandler) handleSwap(ctx context.Context, cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	usage := "Use: `/rotation swap @user1 @user2` or `/rotation swap @user1 @user2 YYYY-MM-DD`"
+
+	if len(cmd.Args) < 2 || len(cmd.Args) > 3 || !strings.HasPrefix(cmd.Args[0], "<@") || !strings.HasPrefix(cmd.Args[1], "<@") {
+		return h.createErrorResponse(usage)
+	}
+
+	var date string
+	if len(cmd.Args) == 3 {
+		date = cmd.Args[2]
+	}
+
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	userA, userB := extractUserID(cmd.Args[0]), extractUserID(cmd.Args[1])
+	swap, err := h.rotationService.SwapUsers(ctx, rotation.ID, userA, userB, date, slashCmd.UserID)
+	if err != nil {
+		return h.createErrorResponse(fmt.Sprintf("Error swapping turns: %v", err))
+	}
+
+	responseText := feedback + fmt.Sprintf("🔁 <@%s> and <@%s> swapped places in the rotation.", userA, userB)
+	if swap.IsPending() {
+		responseText = feedback + fmt.Sprintf("🔁 <@%s> and <@%s> will swap turns on %s.", userA, userB, formatDateRange(swap.SwapDate, swap.SwapDate))
+	}
+
 	return &slack.Msg{
 		ResponseType: slack.ResponseTypeInChannel,
 		Text:         responseText,
@@ -357,24 +608,24 @@ func (h *SlackHandler) handleNext(ctx context.Context, slashCmd *slack.SlashComm
 
 func (h *SlackHandler) handleConfig(_ context.Context, cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
 	if len(cmd.Args) == 0 {
-		return h.createErrorResponse("Use: `/rotation config time HH:MM` or `/rotation config days 1,2,4,5`")
+		return h.openConfigModal(cmd, slashCmd)
 	}
 
 	if cmd.Args[0] == "show" {
-		// Get channel with feedback
-		channel, feedback, err := h.setupChannelWithFeedback(slashCmd)
-		if err != nil {
-			return h.createErrorResponse("Error checking channel")
+		// Get rotation with feedback
+		rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+		if errResponse != nil {
+			return errResponse
 		}
 
 		// Get current configuration
-		config, err := h.rotationService.GetChannelConfig(channel.ID)
+		config, err := h.rotationService.GetChannelConfig(rotation.ChannelID)
 		if err != nil {
 			return h.createErrorResponse(fmt.Sprintf("Error getting configuration: %v", err))
 		}
 
 		// Get scheduler configuration
-		scheduler, err := h.rotationService.GetSchedulerConfig(channel.ID)
+		scheduler, err := h.rotationService.GetSchedulerConfig(rotation.ID)
 		if err != nil {
 			return h.createErrorResponse(fmt.Sprintf("Error getting scheduler configuration: %v", err))
 		}
@@ -383,11 +634,33 @@ func (h *SlackHandler) handleConfig(_ context.Context, cmd *slackcmd.Command, sl
 		notificationTime := "09:00"
 		activeDays := domain.DefaultActiveDays
 		isEnabled := true
+		timezone := formatTimezone(nil)
+		strategy := domain.DefaultStrategy
+		assignees := "1"
+		dmReminders := domain.DMRemindersOff
+		topicSync := "off"
+		userGroup := "none"
 
 		if scheduler != nil {
 			notificationTime = scheduler.NotificationTime
 			activeDays = scheduler.ActiveDays
 			isEnabled = scheduler.IsEnabled
+			timezone = formatTimezone(scheduler)
+			if scheduler.Strategy != "" {
+				strategy = scheduler.Strategy
+			}
+			if scheduler.GetAssignees() > 1 {
+				assignees = fmt.Sprintf("%d (presenter + %d %s)", scheduler.GetAssignees(), scheduler.GetAssignees()-1, backupRoleOf(scheduler))
+			}
+			if scheduler.DMReminders != "" {
+				dmReminders = scheduler.DMReminders
+			}
+			if scheduler.TopicSync {
+				topicSync = "on"
+			}
+			if scheduler.UserGroupID != "" {
+				userGroup = fmt.Sprintf("<!subteam^%s>", scheduler.UserGroupID)
+			}
 		}
 
 		// Convert active days from ISO numbers to names for display
@@ -398,14 +671,27 @@ func (h *SlackHandler) handleConfig(_ context.Context, cmd *slackcmd.Command, sl
 			}
 		}
 
-		configText := feedback + fmt.Sprintf("📋 *Current Configuration for #%s*\n\n"+
+		configText := feedback + fmt.Sprintf("📋 *Current Configuration for #%s%s*\n\n"+
 			"⏰ *Notification Time:* %s\n"+
+			"🌍 *Timezone:* %s\n"+
 			"📅 *Active Days:* %s\n"+
+			"🧭 *Strategy:* %s\n"+
+			"🤝 *Assignees per turn:* %s\n"+
+			"✉️ *DM Reminders:* %s\n"+
+			"📌 *Channel Topic:* %s\n"+
+			"👥 *User Group:* %s\n"+
 			"🔔 *Channel Status:* %s\n"+
 			"📅 *Scheduler Status:* %s",
 			config.SlackChannelName,
+			formatRotationSuffix(rotation),
 			notificationTime,
+			timezone,
 			strings.Join(activeDaysNames, ", "),
+			strategy,
+			assignees,
+			dmReminders,
+			topicSync,
+			userGroup,
 			func() string {
 				if config.IsActive {
 					return "Active"
@@ -433,13 +719,13 @@ func (h *SlackHandler) handleConfig(_ context.Context, cmd *slackcmd.Command, sl
 	configType := cmd.Args[0]
 	configValue := strings.Join(cmd.Args[1:], " ")
 
-	// Get channel with feedback
-	channel, feedback, err := h.setupChannelWithFeedback(slashCmd)
-	if err != nil {
-		return h.createErrorResponse("Error checking channel")
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
 	}
 
-	if err := h.rotationService.UpdateChannelConfig(channel.ID, configType, configValue); err != nil {
+	if err := h.rotationService.UpdateChannelConfig(rotation.ID, configType, configValue); err != nil {
 		return h.createErrorResponse(fmt.Sprintf("Error updating configuration: %v", err))
 	}
 
@@ -450,26 +736,71 @@ func (h *SlackHandler) handleConfig(_ context.Context, cmd *slackcmd.Command, sl
 	}
 }
 
-func (h *SlackHandler) handlePause(slashCmd *slack.SlashCommand) *slack.Msg {
-	// Get channel with feedback
-	channel, feedback, err := h.setupChannelWithFeedback(slashCmd)
+// openConfigModal opens the configuration editor for the user that ran `/rotation config`
+func (h *SlackHandler) openConfigModal(cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	usage := "Use: `/rotation config time HH:MM` or `/rotation config days 1,2,4,5`"
+	if slashCmd.TriggerID == "" {
+		return h.createErrorResponse(usage)
+	}
+
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	scheduler, err := h.rotationService.GetSchedulerConfig(rotation.ID)
 	if err != nil {
-		return h.createErrorResponse("Error checking channel")
+		return h.createErrorResponse(fmt.Sprintf("Error getting scheduler configuration: %v", err))
+	}
+
+	// Same values the scheduler config is created with
+	if scheduler == nil {
+		scheduler = &entity.Scheduler{
+			NotificationTime: "09:00",
+			ActiveDays:       domain.DefaultActiveDays,
+			IsEnabled:        true,
+			Role:             domain.DefaultRole,
+		}
+	}
+
+	slackClient, err := h.slackClients.ForTeam(slashCmd.TeamID)
+	if err != nil {
+		log.Printf("ERROR getting Slack client of team %s: %v", slashCmd.TeamID, err)
+		return h.createErrorResponse("Could not open the configuration editor. " + usage)
+	}
+
+	if _, err := slackClient.OpenView(slashCmd.TriggerID, slackcmd.ConfigModal(rotation, scheduler)); err != nil {
+		log.Printf("ERROR opening config modal: %v", err)
+		return h.createErrorResponse("Could not open the configuration editor. " + usage)
+	}
+
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Text:         feedback + "⚙️ Configuration editor opened, changes are applied when you click *Save*.",
+	}
+}
+
+func (h *SlackHandler) handlePause(cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
 	}
 
 	// Check if scheduler exists
-	scheduler, err := h.rotationService.GetSchedulerConfig(channel.ID)
+	scheduler, err := h.rotationService.GetSchedulerConfig(rotation.ID)
 	if err != nil {
 		return h.createErrorResponse(fmt.Sprintf("Error getting scheduler configuration: %v", err))
 	}
 
 	if scheduler == nil {
 		// Create default scheduler config if it doesn't exist
-		err = h.rotationService.UpdateChannelConfig(channel.ID, "time", "09:00")
+		err = h.rotationService.UpdateChannelConfig(rotation.ID, "time", "09:00")
 		if err != nil {
 			return h.createErrorResponse(fmt.Sprintf("Error creating scheduler configuration: %v", err))
 		}
-		scheduler, _ = h.rotationService.GetSchedulerConfig(channel.ID)
+		scheduler, _ = h.rotationService.GetSchedulerConfig(rotation.ID)
 	}
 
 	// Check if already paused
@@ -481,7 +812,7 @@ func (h *SlackHandler) handlePause(slashCmd *slack.SlashCommand) *slack.Msg {
 	}
 
 	// Pause scheduler
-	err = h.rotationService.PauseScheduler(channel.ID)
+	err = h.rotationService.PauseScheduler(rotation.ID)
 	if err != nil {
 		return h.createErrorResponse(fmt.Sprintf("Error pausing scheduler: %v", err))
 	}
@@ -492,26 +823,26 @@ func (h *SlackHandler) handlePause(slashCmd *slack.SlashCommand) *slack.Msg {
 	}
 }
 
-func (h *SlackHandler) handleResume(slashCmd *slack.SlashCommand) *slack.Msg {
-	// Get channel with feedback
-	channel, feedback, err := h.setupChannelWithFeedback(slashCmd)
-	if err != nil {
-		return h.createErrorResponse("Error checking channel")
+func (h *SlackHandler) handleResume(cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
 	}
 
 	// Check if scheduler exists
-	scheduler, err := h.rotationService.GetSchedulerConfig(channel.ID)
+	scheduler, err := h.rotationService.GetSchedulerConfig(rotation.ID)
 	if err != nil {
 		return h.createErrorResponse(fmt.Sprintf("Error getting scheduler configuration: %v", err))
 	}
 
 	if scheduler == nil {
 		// Create default scheduler config if it doesn't exist
-		err = h.rotationService.UpdateChannelConfig(channel.ID, "time", "09:00")
+		err = h.rotationService.UpdateChannelConfig(rotation.ID, "time", "09:00")
 		if err != nil {
 			return h.createErrorResponse(fmt.Sprintf("Error creating scheduler configuration: %v", err))
 		}
-		scheduler, _ = h.rotationService.GetSchedulerConfig(channel.ID)
+		scheduler, _ = h.rotationService.GetSchedulerConfig(rotation.ID)
 	}
 
 	// Check if already enabled
@@ -523,7 +854,7 @@ func (h *SlackHandler) handleResume(slashCmd *slack.SlashCommand) *slack.Msg {
 	}
 
 	// Resume scheduler
-	err = h.rotationService.ResumeScheduler(channel.ID)
+	err = h.rotationService.ResumeScheduler(rotation.ID)
 	if err != nil {
 		return h.createErrorResponse(fmt.Sprintf("Error resuming scheduler: %v", err))
 	}
@@ -534,45 +865,54 @@ func (h *SlackHandler) handleResume(slashCmd *slack.SlashCommand) *slack.Msg {
 	}
 }
 
-func (h *SlackHandler) handleStatus(slashCmd *slack.SlashCommand) *slack.Msg {
-	// Get channel with feedback
-	channel, feedback, err := h.setupChannelWithFeedback(slashCmd)
-	if err != nil {
-		return h.createErrorResponse("Error checking channel")
+func (h *SlackHandler) handleStatus(cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
 	}
 
 	// Get channel configuration
-	config, err := h.rotationService.GetChannelConfig(channel.ID)
+	config, err := h.rotationService.GetChannelConfig(rotation.ChannelID)
 	if err != nil {
 		return h.createErrorResponse(fmt.Sprintf("Error getting configuration: %v", err))
 	}
 
 	// Get scheduler configuration
-	scheduler, err := h.rotationService.GetSchedulerConfig(channel.ID)
+	scheduler, err := h.rotationService.GetSchedulerConfig(rotation.ID)
 	if err != nil {
 		return h.createErrorResponse(fmt.Sprintf("Error getting scheduler configuration: %v", err))
 	}
 
 	// Get current presenter
-	currentPresenter, err := h.rotationService.GetCurrentPresenter(channel.ID)
+	currentPresenter, err := h.rotationService.GetCurrentPresenter(rotation.ID)
 	if err != nil && err.Error() != "user not found" {
 		return h.createErrorResponse(fmt.Sprintf("Error getting current presenter: %v", err))
 	}
 
-	// Get next presenter
-	nextPresenter, err := h.rotationService.GetNextPresenter(channel.ID)
-	if err != nil && err.Error() != "no active users in rotation" {
-		return h.createErrorResponse(fmt.Sprintf("Error getting next presenter: %v", err))
+	// Get backups of the current presenter
+	var currentBackups []*entity.User
+	if scheduler != nil && scheduler.GetAssignees() > 1 {
+		currentBackups, err = h.rotationService.GetCurrentBackups(rotation.ID)
+		if err != nil {
+			return h.createErrorResponse(fmt.Sprintf("Error getting current backups: %v", err))
+		}
+	}
+
+	// Get next presenter and backups
+	nextAssignees, nextErr := h.rotationService.GetNextAssignees(rotation.ID)
+	if nextErr != nil && nextErr.Error() != "no active users in rotation" && !errors.Is(nextErr, domain.ErrEveryoneAway) {
+		return h.createErrorResponse(fmt.Sprintf("Error getting next presenter: %v", nextErr))
 	}
 
 	// Get total users
-	users, err := h.rotationService.ListUsers(channel.ID)
+	users, err := h.rotationService.ListUsers(rotation.ID)
 	if err != nil {
 		return h.createErrorResponse(fmt.Sprintf("Error getting users: %v", err))
 	}
 
 	// Build status message
-	statusText := feedback + fmt.Sprintf("📊 *Rotation Status for #%s*\n\n", config.SlackChannelName)
+	statusText := feedback + fmt.Sprintf("📊 *Rotation Status for #%s%s*\n\n", config.SlackChannelName, formatRotationSuffix(rotation))
 
 	// Channel status
 	statusText += fmt.Sprintf("🔔 *Channel Status:* %s\n", func() string {
@@ -592,7 +932,7 @@ func (h *SlackHandler) handleStatus(slashCmd *slack.SlashCommand) *slack.Msg {
 		}())
 
 		// Notification time
-		statusText += fmt.Sprintf("⏰ *Notification Time:* %s UTC\n", scheduler.NotificationTime)
+		statusText += fmt.Sprintf("⏰ *Notification Time:* %s %s\n", scheduler.NotificationTime, formatTimezone(scheduler))
 
 		// Active days
 		var activeDaysNames []string
@@ -605,6 +945,11 @@ func (h *SlackHandler) handleStatus(slashCmd *slack.SlashCommand) *slack.Msg {
 
 		// Role
 		statusText += fmt.Sprintf("🎭 *Role:* %s\n", scheduler.Role)
+
+		// Backups
+		if scheduler.GetAssignees() > 1 {
+			statusText += fmt.Sprintf("🤝 *Backups per turn:* %d (%s)\n", scheduler.GetAssignees()-1, backupRoleOf(scheduler))
+		}
 	} else {
 		statusText += "📅 *Scheduler:* Not configured\n"
 	}
@@ -621,13 +966,15 @@ func (h *SlackHandler) handleStatus(slashCmd *slack.SlashCommand) *slack.Msg {
 	}
 
 	if currentPresenter != nil {
-		statusText += fmt.Sprintf("🎯 *Current %s:* <@%s>\n", role, currentPresenter.SlackUserID)
+		statusText += fmt.Sprintf("🎯 *Current %s:* %s\n", role, formatAssignees(append([]*entity.User{currentPresenter}, currentBackups...), scheduler))
 	} else {
 		statusText += fmt.Sprintf("🎯 *Current %s:* None\n", role)
 	}
 
-	if nextPresenter != nil {
-		statusText += fmt.Sprintf("⏭️ *Next %s:* <@%s>\n", role, nextPresenter.SlackUserID)
+	if len(nextAssignees) > 0 {
+		statusText += fmt.Sprintf("⏭️ *Next %s:* %s\n", role, formatAssignees(nextAssignees, scheduler))
+	} else if errors.Is(nextErr, domain.ErrEveryoneAway) {
+		statusText += fmt.Sprintf("⏭️ *Next %s:* None, everyone is away today\n", role)
 	} else {
 		statusText += fmt.Sprintf("⏭️ *Next %s:* None\n", role)
 	}
@@ -641,51 +988,627 @@ func (h *SlackHandler) handleStatus(slashCmd *slack.SlashCommand) *slack.Msg {
 	}
 }
 
-func (h *SlackHandler) handleHelp() *slack.Msg {
-	return &slack.Msg{
-		ResponseType: slack.ResponseTypeEphemeral,
-		Text:         slackcmd.GetHelpText(),
-	}
-}
+func (h *SlackHandler) handleHolidays(ctx context.Context, cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	usage := "Use: `/rotation holidays add 2026-12-24..2026-12-26 [description]`, `/rotation holidays list`, `/rotation holidays remove 2026-12-24..2026-12-26` or `/rotation holidays import <ics file contents>`"
 
-func (h *SlackHandler) createErrorResponse(message string) *slack.Msg {
-	return &slack.Msg{
-		ResponseType: slack.ResponseTypeEphemeral,
-		Text:         fmt.Sprintf("❌ %s", message),
+	subcommand := "list"
+	if len(cmd.Args) > 0 {
+		subcommand = cmd.Args[0]
 	}
-}
 
-// setupChannelWithFeedback handles channel setup and provides feedback if auto-configured
-func (h *SlackHandler) setupChannelWithFeedback(slashCmd *slack.SlashCommand) (*entity.Channel, string, error) {
-	channel, wasCreated, err := h.rotationService.SetupChannel(slashCmd.ChannelID, slashCmd.ChannelName, slashCmd.TeamID)
+	// Get channel with feedback
+	channel, feedback, err := h.setupChannelWithFeedback(slashCmd)
 	if err != nil {
-		return nil, "", err
+		return h.createErrorResponse("Error checking channel")
 	}
 
-	var feedback string
-	if wasCreated {
-		feedback = "✅ *Channel configured automatically with default settings:*\n" +
-			"⏰ Time: 09:00 | 📅 Days: Mon, Tue, Wed, Thu, Fri\n" +
-			"Use `/rotation config show` to view or `/rotation config` to customize.\n\n"
-	}
+	switch subcommand {
+	case "list", "ls":
+		holidays, err := h.rotationService.ListHolidays(channel.ID)
+		if err != nil {
+			return h.createErrorResponse(fmt.Sprintf("Error listing holidays: %v", err))
+		}
 
-	return channel, feedback, nil
-}
+		if len(holidays) == 0 {
+			return &slack.Msg{
+				ResponseType: slack.ResponseTypeEphemeral,
+				Text:         feedback + "No upcoming holidays. Use `/rotation holidays add YYYY-MM-DD` to skip a date.",
+			}
+		}
 
-func (h *SlackHandler) respondWithError(w http.ResponseWriter, message string) {
-	response := h.createErrorResponse(message)
-	w.Header().Set("Content-Type", "application/json")
-	err := json.NewEncoder(w).Encode(response)
-	if err != nil {
-		log.Printf("ERROR encoding json response: %v", err)
-	}
+		var holidayList strings.Builder
+		holidayList.WriteString(feedback + "🏖️ *Upcoming holidays (no notifications):*\n")
+		for _, holiday := range holidays {
+			holidayList.WriteString(fmt.Sprintf("• %s\n", formatHoliday(holiday)))
+		}
+
+		return &slack.Msg{
+			ResponseType: slack.ResponseTypeEphemeral,
+			Text:         holidayList.String(),
+		}
+
+	case "add":
+		if len(cmd.Args) < 2 {
+			return h.createErrorResponse(usage)
+		}
+
+		holiday, err := h.rotationService.AddHoliday(channel.ID, cmd.Args[1], strings.Join(cmd.Args[2:], " "))
+		if err != nil {
+			return h.createErrorResponse(fmt.Sprintf("Error adding holiday: %v", err))
+		}
+
+		return &slack.Msg{
+			ResponseType: slack.ResponseTypeEphemeral,
+			Text:         feedback + fmt.Sprintf("✅ Holiday added: %s", formatHoliday(holiday)),
+		}
+
+	case "remove", "rm":
+		if len(cmd.Args) < 2 {
+			return h.createErrorResponse(usage)
+		}
+
+		if err := h.rotationService.RemoveHoliday(channel.ID, cmd.Args[1]); err != nil {
+			return h.createErrorResponse(fmt.Sprintf("Error removing holiday: %v", err))
+		}
+
+		return &slack.Msg{
+			ResponseType: slack.ResponseTypeEphemeral,
+			Text:         feedback + fmt.Sprintf("✅ Holiday removed: %s", cmd.Args[1]),
+		}
+
+	case "import":
+		// Use the raw text so the line breaks of the .ics body are preserved
+		words := 2 // holidays import
+		if cmd.Rotation != "" {
+			words++
+		}
+		icsBody := strings.TrimSpace(textAfterWords(cmd.Raw, words))
+		if icsBody == "" {
+			return h.createErrorResponse(usage)
+		}
+
+		imported, err := h.rotationService.ImportHolidays(ctx, channel.ID, icsBody)
+		if err != nil {
+			return h.createErrorResponse(fmt.Sprintf("Error importing holidays: %v", err))
+		}
+
+		return &slack.Msg{
+			ResponseType: slack.ResponseTypeEphemeral,
+			Text:         feedback + fmt.Sprintf("✅ %d holidays imported. Use `/rotation holidays list` to review them.", imported),
+		}
+
+	default:
+		return h.createErrorResponse(usage)
+	}
+}
+
+func (h *SlackHandler) handleAway(cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	usage := "Use: `/rotation away @user 2026-10-20..2026-10-24 [reason]` or `/rotation away 2026-10-20 [reason]` to mark yourself"
+
+	if len(cmd.Args) == 0 {
+		return h.createErrorResponse(usage)
+	}
+
+	// The member is optional, without a mention the caller is marked as away
+	userID := slashCmd.UserID
+	args := cmd.Args
+	if strings.HasPrefix(args[0], "<@") {
+		userID = extractUserID(args[0])
+		args = args[1:]
+	}
+
+	if len(args) == 0 {
+		return h.createErrorResponse(usage)
+	}
+
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	availability, err := h.rotationService.SetUserAway(rotation.ID, userID, args[0], strings.Join(args[1:], " "))
+	if err != nil {
+		return h.createErrorResponse(fmt.Sprintf("Error marking user as away: %v", err))
+	}
+
+	responseText := feedback + fmt.Sprintf("🏖️ <@%s> is away %s", userID, formatDateRange(availability.StartDate, availability.EndDate))
+	if availability.Reason != "" {
+		responseText += fmt.Sprintf(" (%s)", availability.Reason)
+	}
+	responseText += ". They will be skipped and keep their place in the rotation."
+
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeInChannel,
+		Text:         responseText,
+	}
+}
+
+func (h *SlackHandler) handleHistory(cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	limit := defaultHistoryLimit
+	if len(cmd.Args) > 0 {
+		n, err := strconv.Atoi(cmd.Args[0])
+		if err != nil || n < 1 || n > maxHistoryLimit {
+			return h.createErrorResponse(fmt.Sprintf("Use: `/rotation history [n]` where n is between 1 and %d", maxHistoryLimit))
+		}
+		limit = n
+	}
+
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	entries, err := h.rotationService.GetHistory(rotation.ID, limit)
+	if err != nil {
+		return h.createErrorResponse(fmt.Sprintf("Error getting history: %v", err))
+	}
+
+	if len(entries) == 0 {
+		return &slack.Msg{
+			ResponseType: slack.ResponseTypeEphemeral,
+			Text:         feedback + "No turns recorded yet.",
+		}
+	}
+
+	// Show dates in the channel timezone
+	scheduler, _ := h.rotationService.GetSchedulerConfig(rotation.ID)
+	loc := time.UTC
+	if scheduler != nil {
+		loc = scheduler.GetLocation()
+	}
+
+	var historyList strings.Builder
+	historyList.WriteString(feedback + fmt.Sprintf("📜 *Last %d turns (%s):*\n", len(entries), formatTimezone(scheduler)))
+	for _, entry := range entries {
+		historyList.WriteString(fmt.Sprintf("• %s — %s\n", entry.PresentedAt.In(loc).Format("Mon, Jan 2 2006 15:04"), formatHistoryEntry(entry)))
+	}
+
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Text:         historyList.String(),
+	}
+}
+
+// handleSchedule previews the next turns of the rotation, in the channel timezone
+func (h *SlackHandler) handleSchedule(cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	limit := defaultScheduleLimit
+	if len(cmd.Args) > 0 {
+		n, err := strconv.Atoi(cmd.Args[0])
+		if err != nil || n < 1 || n > maxScheduleLimit {
+			return h.createErrorResponse(fmt.Sprintf("Use: `/rotation schedule [n]` where n is between 1 and %d", maxScheduleLimit))
+		}
+		limit = n
+	}
+
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	scheduler, err := h.rotationService.GetSchedulerConfig(rotation.ID)
+	if err != nil {
+		return h.createErrorResponse(fmt.Sprintf("Error getting scheduler configuration: %v", err))
+	}
+
+	if scheduler != nil && !scheduler.IsEnabled {
+		return &slack.Msg{
+			ResponseType: slack.ResponseTypeEphemeral,
+			Text:         feedback + fmt.Sprintf("⏸️ The %s is paused, no turns are scheduled. Use `%s resume` to restart it.", formatRotationName(rotation), rotation.CommandPrefix()),
+		}
+	}
+
+	turns, err := h.rotationService.GetSchedule(rotation.ID, limit)
+	if err != nil {
+		return h.createErrorResponse(fmt.Sprintf("Error getting schedule: %v", err))
+	}
+
+	if len(turns) == 0 {
+		return &slack.Msg{
+			ResponseType: slack.ResponseTypeEphemeral,
+			Text:         feedback + "No upcoming turns. Check the active days with `/rotation config show`.",
+		}
+	}
+
+	loc := time.UTC
+	if scheduler != nil {
+		loc = scheduler.GetLocation()
+	}
+
+	var scheduleList strings.Builder
+	scheduleList.WriteString(feedback + fmt.Sprintf("🗓️ *Next %d turns (%s):*\n", len(turns), formatTimezone(scheduler)))
+	for _, turn := range turns {
+		assignees := "nobody, everyone is away"
+		if turn.Presenter != nil {
+			assignees = formatAssignees(append([]*entity.User{turn.Presenter}, turn.Backups...), scheduler)
+		}
+		scheduleList.WriteString(fmt.Sprintf("• %s — %s\n", turn.Date.In(loc).Format("Mon, Jan 2 15:04"), assignees))
+	}
+	scheduleList.WriteString("\n_Based on the rotation strategy, holidays, out-of-office periods and planned swaps. Manual skips can still change it._")
+
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Text:         scheduleList.String(),
+	}
+}
+
+func (h *SlackHandler) handleCreateRotation(cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	if len(cmd.Args) != 1 {
+		return h.createErrorResponse("Use: `/rotation create NAME`. Example: `/rotation create reviewers`")
+	}
+
+	// Get channel with feedback
+	channel, feedback, err := h.setupChannelWithFeedback(slashCmd)
+	if err != nil {
+		return h.createErrorResponse("Error checking channel")
+	}
+
+	rotation, err := h.rotationService.CreateRotation(channel.ID, cmd.Args[0])
+	if err != nil {
+		return h.createErrorResponse(fmt.Sprintf("Error creating rotation: %v", err))
+	}
+
+	responseText := feedback + fmt.Sprintf("🆕 Rotation *%s* created. Add members with `%s add @user` and customize it with `%s config`.",
+		rotation.Name, rotation.CommandPrefix(), rotation.CommandPrefix())
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeInChannel,
+		Text:         responseText,
+	}
+}
+
+func (h *SlackHandler) handleDeleteRotation(cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	if len(cmd.Args) != 1 {
+		return h.createErrorResponse("Use: `/rotation delete NAME`. Example: `/rotation delete reviewers`")
+	}
+
+	// Get channel with feedback
+	channel, feedback, err := h.setupChannelWithFeedback(slashCmd)
+	if err != nil {
+		return h.createErrorResponse("Error checking channel")
+	}
+
+	if err := h.rotationService.DeleteRotation(channel.ID, cmd.Args[0]); err != nil {
+		return h.createErrorResponse(fmt.Sprintf("Error deleting rotation: %v", err))
+	}
+
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeInChannel,
+		Text:         feedback + fmt.Sprintf("🗑️ Rotation *%s* deleted.", strings.ToLower(cmd.Args[0])),
+	}
+}
+
+func (h *SlackHandler) handleListRotations(slashCmd *slack.SlashCommand) *slack.Msg {
+	// Get channel with feedback
+	channel, feedback, err := h.setupChannelWithFeedback(slashCmd)
+	if err != nil {
+		return h.createErrorResponse("Error checking channel")
+	}
+
+	rotations, err := h.rotationService.ListRotations(channel.ID)
+	if err != nil {
+		return h.createErrorResponse(fmt.Sprintf("Error listing rotations: %v", err))
+	}
+
+	var rotationList strings.Builder
+	rotationList.WriteString(feedback + "🔄 *Rotations in this channel:*\n")
+	for _, rotation := range rotations {
+		rotationList.WriteString(fmt.Sprintf("• *%s*", rotation.Name))
+		if rotation.IsPrimary {
+			rotationList.WriteString(" _(primary)_")
+		}
+
+		users, _ := h.rotationService.ListUsers(rotation.ID)
+		if len(users) == 1 {
+			rotationList.WriteString(" — 1 member")
+		} else {
+			rotationList.WriteString(fmt.Sprintf(" — %d members", len(users)))
+		}
+
+		if scheduler, _ := h.rotationService.GetSchedulerConfig(rotation.ID); scheduler != nil {
+			rotationList.WriteString(fmt.Sprintf(", %s at %s", scheduler.Role, scheduler.NotificationTime))
+			if !scheduler.IsEnabled {
+				rotationList.WriteString(" ⏸️")
+			}
+		}
+		rotationList.WriteString("\n")
+	}
+	rotationList.WriteString("\nCommands without a rotation name use the primary rotation, e.g. `/rotation add @user`.")
+
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Text:         rotationList.String(),
+	}
+}
+
+// handleMe lists the next turn of the caller in each rotation they belong to, in every channel,
+// soonest first. It does not set the channel up, so it also works in a direct message.
+func (h *SlackHandler) handleMe(slashCmd *slack.SlashCommand) *slack.Msg {
+	rotations, err := h.rotationService.GetUserRotations(slashCmd.UserID)
+	if err != nil {
+		return h.createErrorResponse(fmt.Sprintf("Error getting your rotations: %v", err))
+	}
+
+	if len(rotations) == 0 {
+		return &slack.Msg{
+			ResponseType: slack.ResponseTypeEphemeral,
+			Text:         "You are not in any rotation yet.",
+		}
+	}
+
+	// Rotations without an upcoming turn go last
+	sort.SliceStable(rotations, func(i, j int) bool {
+		a, b := rotations[i].NextTurn, rotations[j].NextTurn
+		return !a.IsZero() && (b.IsZero() || a.Before(b))
+	})
+
+	var upcoming, unscheduled []string
+	for _, rotation := range rotations {
+		label := formatRotationLabel(rotation)
+		if rotation.OnDuty != nil && rotation.OnDuty.ID == rotation.Member.ID {
+			label += " (on duty now)"
+		}
+
+		switch {
+		case !rotation.NextTurn.IsZero():
+			upcoming = append(upcoming, fmt.Sprintf("%s on %s", label, rotation.NextTurn.Format(domain.TurnDateFormat)))
+		case !rotation.Scheduler.IsEnabled:
+			unscheduled = append(unscheduled, label+" ⏸️ paused")
+		default:
+			unscheduled = append(unscheduled, label)
+		}
+	}
+
+	responseText := "📅 You have no upcoming turns."
+	if len(upcoming) > 0 {
+		responseText = "📅 *Your next turns:* " + strings.Join(upcoming, ", ")
+	}
+	if len(unscheduled) > 0 {
+		responseText += "\n_No turn in sight: " + strings.Join(unscheduled, ", ") + "_"
+	}
+
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Text:         responseText,
+	}
+}
+
+// formatRotationLabel names the channel of the rotation, followed by the rotation name when
+// it is not the primary one, e.g. "<#C123> (reviewers)"
+func formatRotationLabel(rotation *entity.UserRotation) string {
+	label := fmt.Sprintf("<#%s>", rotation.Channel.SlackChannelID)
+	if !rotation.Rotation.IsPrimary {
+		label += fmt.Sprintf(" (%s)", rotation.Rotation.Name)
+	}
+	return label
+}
+
+// handleNotify sets how the member running the command is reminded of their turns
+func (h *SlackHandler) handleNotify(cmd *slackcmd.Command, slashCmd *slack.SlashCommand) *slack.Msg {
+	if len(cmd.Args) != 2 || cmd.Args[0] != "dm" {
+		return h.createErrorResponse("Use: `/rotation notify dm on`, `/rotation notify dm off` or `/rotation notify dm day-before`")
+	}
+
+	// Get rotation with feedback
+	rotation, feedback, errResponse := h.setupRotationWithFeedback(cmd, slashCmd)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	if err := h.rotationService.SetDMReminders(rotation.ID, slashCmd.UserID, cmd.Args[1]); err != nil {
+		return h.createErrorResponse(fmt.Sprintf("Error updating your reminders: %v", err))
+	}
+
+	var responseText string
+	switch strings.ToLower(cmd.Args[1]) {
+	case domain.DMRemindersOn:
+		responseText = "🔔 You will get a direct message when it is your turn in " + formatRotationName(rotation) + "."
+	case domain.DMRemindersDayBefore:
+		responseText = "🔔 You will get a direct message the day before and on the day of your turn in " + formatRotationName(rotation) + "."
+	default:
+		responseText = "🔕 You will only be reminded in the channel for " + formatRotationName(rotation) + "."
+	}
+
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Text:         feedback + responseText,
+	}
+}
+
+func (h *SlackHandler) handleHelp() *slack.Msg {
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Text:         slackcmd.GetHelpText(),
+	}
+}
+
+func (h *SlackHandler) createErrorResponse(message string) *slack.Msg {
+	return &slack.Msg{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Text:         fmt.Sprintf("❌ %s", message),
+	}
+}
+
+// setupRotationWithFeedback sets the channel up like setupChannelWithFeedback and returns the
+// rotation named in the command, or the primary one. On failure it returns the error response.
+func (h *SlackHandler) setupRotationWithFeedback(cmd *slackcmd.Command, slashCmd *slack.SlashCommand) (*entity.Rotation, string, *slack.Msg) {
+	channel, feedback, err := h.setupChannelWithFeedback(slashCmd)
+	if err != nil {
+		log.Printf("ERROR setting up channel: %v", err)
+		return nil, "", h.createErrorResponse("Error checking channel")
+	}
+
+	rotation, err := h.rotationService.GetRotation(channel.ID, cmd.Rotation)
+	if err != nil {
+		return nil, "", h.createErrorResponse(fmt.Sprintf("Error finding rotation: %v", err))
+	}
+
+	return rotation, feedback, nil
+}
+
+// setupChannelWithFeedback handles channel setup and provides feedback if auto-configured
+func (h *SlackHandler) setupChannelWithFeedback(slashCmd *slack.SlashCommand) (*entity.Channel, string, error) {
+	channel, wasCreated, err := h.rotationService.SetupChannel(slashCmd.ChannelID, slashCmd.ChannelName, slashCmd.TeamID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var feedback string
+	if wasCreated {
+		feedback = "✅ *Channel configured automatically with default settings:*\n" +
+			"⏰ Time: 09:00 UTC | 📅 Days: Mon, Tue, Wed, Thu, Fri\n" +
+			"Use `/rotation config show` to view or `/rotation config` to customize.\n\n"
+	}
+
+	return channel, feedback, nil
+}
+
+// formatRotationName returns how a rotation is called in messages, e.g. "the reviewers rotation"
+func formatRotationName(rotation *entity.Rotation) string {
+	if rotation.IsPrimary {
+		return "rotation"
+	}
+	return fmt.Sprintf("the %s rotation", rotation.Name)
+}
+
+// formatRotationSuffix names a non-primary rotation after the channel name, e.g. " (reviewers)"
+func formatRotationSuffix(rotation *entity.Rotation) string {
+	if rotation.IsPrimary {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", rotation.Name)
+}
+
+// formatHoliday returns a readable holiday range, e.g. "Thu, Dec 24 2026 → Sat, Dec 26 2026 (Christmas)"
+func formatHoliday(holiday *entity.Holiday) string {
+	text := formatDateRange(holiday.StartDate, holiday.EndDate)
+	if holiday.Description != "" {
+		text += fmt.Sprintf(" (%s)", holiday.Description)
+	}
+
+	return text
+}
+
+// formatDateRange returns a readable inclusive date range, e.g. "Thu, Dec 24 2026 → Sat, Dec 26 2026"
+func formatDateRange(start, end time.Time) string {
+	const layout = "Mon, Jan 2 2006"
+
+	text := start.Format(layout)
+	if !end.Equal(start) {
+		text += " → " + end.Format(layout)
+	}
+
+	return text
+}
+
+// formatAway returns the away status shown next to a member, e.g. "🏖️ _away until Sat, Oct 24 2026_"
+func formatAway(period *entity.Availability, today time.Time) string {
+	if period.Covers(today) {
+		return fmt.Sprintf("🏖️ _away until %s_", period.EndDate.Format("Mon, Jan 2 2006"))
+	}
+	return fmt.Sprintf("🗓️ _away %s_", formatDateRange(period.StartDate, period.EndDate))
+}
+
+// formatRotationOrder lists members by their place in the rotation under a title
+func formatRotationOrder(title string, users []*entity.User) string {
+	var text strings.Builder
+	text.WriteString(title + "\n")
+	for i, user := range users {
+		text.WriteString(fmt.Sprintf("%d. %s\n", i+1, user.GetDisplayName()))
+	}
+	return text.String()
 }
 
-// getUserDisplayName attempts to get the best display name for a user
+// formatAssignees mentions the presenter followed by the backups, e.g. "<@U1> (backup: <@U2>)"
+func formatAssignees(users []*entity.User, scheduler *entity.Scheduler) string {
+	return slackcmd.FormatAssignees(users, backupRoleOf(scheduler))
+}
+
+// backupRoleOf returns the role name of the backups, "backup" when the rotation has none
+func backupRoleOf(scheduler *entity.Scheduler) string {
+	if scheduler == nil || scheduler.BackupRole == "" {
+		return domain.DefaultBackupRole
+	}
+	return scheduler.BackupRole
+}
+
+// formatHistoryEntry describes who took a turn and how, e.g. "Jane Doe (skip by <@U123>)"
+func formatHistoryEntry(entry *entity.RotationHistory) string {
+	text := entry.DisplayName
+	if entry.UserID != 0 {
+		// Mention only current members, removed ones keep the stored name
+		text = fmt.Sprintf("<@%s>", entry.SlackUserID)
+	}
+
+	// The presenter entry of the same turn already tells how it was assigned
+	if entry.IsBackup {
+		return text + " _(backup)_"
+	}
+
+	switch entry.Kind {
+	case domain.HistoryKindSkip:
+		text += " _(manual skip"
+	case domain.HistoryKindOverride:
+		text += " _(override"
+	default:
+		return text
+	}
+
+	if entry.TriggeredBy != "" {
+		text += fmt.Sprintf(" by <@%s>", entry.TriggeredBy)
+	}
+
+	return text + ")_"
+}
+
+// formatTimezone returns the scheduler timezone with its current UTC offset, e.g. "America/Sao_Paulo (UTC-03:00)"
+func formatTimezone(scheduler *entity.Scheduler) string {
+	if scheduler == nil {
+		return domain.DefaultTimezone
+	}
+
+	loc := scheduler.GetLocation()
+	if loc == time.UTC {
+		return domain.DefaultTimezone
+	}
+
+	return fmt.Sprintf("%s (UTC%s)", loc.String(), time.Now().In(loc).Format("-07:00"))
+}
+
+// textAfterWords returns the text following the first count words of raw, keeping its line breaks
+func textAfterWords(raw string, count int) string {
+	rest := raw
+	for i := 0; i < count; i++ {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end == -1 {
+			return ""
+		}
+		rest = rest[end:]
+	}
+	return rest
+}
+
+// extractUserID returns the user ID from a mention <@U12345> or <@U12345|username>
+func extractUserID(userMention string) string {
+	userID := strings.TrimSpace(userMention)
+	userID = strings.TrimPrefix(userID, "<@")
+	userID = strings.TrimSuffix(userID, ">")
+
+	// Handle format <@U12345|username> - take only the ID part
+	if idx := strings.Index(userID, "|"); idx != -1 {
+		userID = userID[:idx]
+	}
+
+	return userID
+}
+
+// getUserDisplayName attempts to get the best display name for a user of the team
 // Falls back through: API real name → API display name → API username → mention username → user ID
-func (h *SlackHandler) getUserDisplayName(userID, userMention string) string {
+func (h *SlackHandler) getUserDisplayName(ctx context.Context, teamID, userID, userMention string) string {
 	// Try to get user info from Slack API
-	if userInfo, err := h.slackClient.GetUserInfo(userID); err == nil {
+	if userInfo, err := h.getUserInfo(ctx, teamID, userID); err == nil {
 		if userInfo.Profile.RealName != "" {
 			return userInfo.Profile.RealName
 		}
@@ -709,3 +1632,13 @@ func (h *SlackHandler) getUserDisplayName(userID, userMention string) string {
 	// Last resort: return the user ID
 	return userID
 }
+
+// getUserInfo reads the user from the Slack workspace of the team
+func (h *SlackHandler) getUserInfo(ctx context.Context, teamID, userID string) (*slack.User, error) {
+	slackClient, err := h.slackClients.ForTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return slackClient.GetUserInfoContext(ctx, userID)
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/diegoclair/slack-rotation-bot/internal/domain"
 	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
@@ -57,9 +58,14 @@ func TestSlackHandler_HandleSlashCommand_AddUser(t *testing.T) {
 					SetupChannel(args.channelID, args.channelName, args.teamID).
 					Return(channel, false, nil).Times(1)
 
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
 				// Mock AddUser call
 				m.RotationServiceMock.EXPECT().
-					AddUser(int64(1), "U123456789").
+					AddUser(gomock.Any(), int64(1), "U123456789").
 					Return(nil).Times(1)
 			},
 			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
@@ -97,12 +103,17 @@ func TestSlackHandler_HandleSlashCommand_AddUser(t *testing.T) {
 					SetupChannel(args.channelID, args.channelName, args.teamID).
 					Return(channel, false, nil).Times(1)
 
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
 				// Mock AddUser calls for both users
 				m.RotationServiceMock.EXPECT().
-					AddUser(int64(1), "U123456789").
+					AddUser(gomock.Any(), int64(1), "U123456789").
 					Return(nil).Times(1)
 				m.RotationServiceMock.EXPECT().
-					AddUser(int64(1), "U987654321").
+					AddUser(gomock.Any(), int64(1), "U987654321").
 					Return(nil).Times(1)
 			},
 			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
@@ -161,9 +172,14 @@ func TestSlackHandler_HandleSlashCommand_AddUser(t *testing.T) {
 					SetupChannel(args.channelID, args.channelName, args.teamID).
 					Return(channel, false, nil).Times(1)
 
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
 				// Mock AddUser call to fail
 				m.RotationServiceMock.EXPECT().
-					AddUser(int64(1), "U123456789").
+					AddUser(gomock.Any(), int64(1), "U123456789").
 					Return(errors.New("user already exists")).Times(1)
 
 				// Mock GetUserInfo call for error case
@@ -176,7 +192,7 @@ func TestSlackHandler_HandleSlashCommand_AddUser(t *testing.T) {
 					},
 				}
 				m.SlackClientMock.EXPECT().
-					GetUserInfo("U123456789").
+					GetUserInfoContext(gomock.Any(), "U123456789").
 					Return(userInfo, nil).Times(1)
 			},
 			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
@@ -270,6 +286,11 @@ func TestSlackHandler_HandleSlashCommand_ListUsers(t *testing.T) {
 					SetupChannel(args.channelID, args.channelName, args.teamID).
 					Return(channel, false, nil).Times(1)
 
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
 				// Mock ListUsers call
 				m.RotationServiceMock.EXPECT().
 					ListUsers(int64(1)).
@@ -286,6 +307,19 @@ func TestSlackHandler_HandleSlashCommand_ListUsers(t *testing.T) {
 					Return(&entity.Scheduler{
 						Role: "presenter",
 					}, nil).Times(1)
+
+				// Mock ListAbsences call - second user is away until the end of the year
+				now := time.Now().UTC()
+				m.RotationServiceMock.EXPECT().
+					ListAbsences(int64(1)).
+					Return([]*entity.Availability{
+						{
+							UserID:      2,
+							SlackUserID: "U987654321",
+							StartDate:   time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+							EndDate:     time.Date(now.Year(), 12, 31, 0, 0, 0, 0, time.UTC),
+						},
+					}, nil).Times(1)
 			},
 			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, resp.Code)
@@ -294,10 +328,12 @@ func TestSlackHandler_HandleSlashCommand_ListUsers(t *testing.T) {
 				err := json.Unmarshal(resp.Body.Bytes(), &response)
 				require.NoError(t, err)
 
+				endOfYear := time.Date(time.Now().UTC().Year(), 12, 31, 0, 0, 0, 0, time.UTC)
+
 				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
 				assert.Contains(t, response.Text, "*Members in rotation:*")
-				assert.Contains(t, response.Text, "👉 1. Test User 1 *(presenter today)*")
-				assert.Contains(t, response.Text, "2. Test User 2")
+				assert.Contains(t, response.Text, "👉 1. Test User 1 *(presenter today)*\n")
+				assert.Contains(t, response.Text, "2. Test User 2 🏖️ _away until "+endOfYear.Format("Mon, Jan 2 2006")+"_")
 			},
 		},
 	}
@@ -430,14 +466,19 @@ func TestSlackHandler_HandleSlashCommand_Next(t *testing.T) {
 					SetupChannel(args.channelID, args.channelName, args.teamID).
 					Return(channel, false, nil).Times(1)
 
-				// Mock GetNextPresenter call
+				// Mock GetRotation call
 				m.RotationServiceMock.EXPECT().
-					GetNextPresenter(int64(1)).
-					Return(nextUser, nil).Times(1)
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
+				// Mock GetNextAssignees call
+				m.RotationServiceMock.EXPECT().
+					GetNextAssignees(int64(1)).
+					Return([]*entity.User{nextUser}, nil).Times(1)
 
 				// Mock RecordPresentation call
 				m.RotationServiceMock.EXPECT().
-					RecordPresentation(gomock.Any(), int64(1), int64(2)).
+					RecordPresentation(gomock.Any(), int64(1), []*entity.User{nextUser}, domain.HistoryKindSkip, args.userID).
 					Return(nil).Times(1)
 			},
 			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
@@ -451,6 +492,229 @@ func TestSlackHandler_HandleSlashCommand_Next(t *testing.T) {
 				assert.Contains(t, response.Text, "⏭️ Skipping to next presenter: <@U234567890>")
 			},
 		},
+		{
+			name: "Should hand the turn to the mentioned member",
+			args: args{
+				command:     "/rotation",
+				text:        "next <@U345678901|jane>",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{
+					ID:               1,
+					SlackChannelID:   args.channelID,
+					SlackChannelName: args.channelName,
+					SlackTeamID:      args.teamID,
+					IsActive:         true,
+				}
+
+				users := []*entity.User{
+					{ID: 2, ChannelID: 1, SlackUserID: "U234567890", IsActive: true},
+					{ID: 3, ChannelID: 1, SlackUserID: "U345678901", IsActive: true},
+				}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
+				// Mock ListUsers call
+				m.RotationServiceMock.EXPECT().
+					ListUsers(int64(1)).
+					Return(users, nil).Times(1)
+
+				// Mock GetSchedulerConfig call
+				m.RotationServiceMock.EXPECT().
+					GetSchedulerConfig(int64(1)).
+					Return(&entity.Scheduler{ID: 1, RotationID: 1, Assignees: 1}, nil).Times(1)
+
+				// Mock RecordPresentation call
+				m.RotationServiceMock.EXPECT().
+					RecordPresentation(gomock.Any(), int64(1), []*entity.User{users[1]}, domain.HistoryKindOverride, args.userID).
+					Return(nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeInChannel, response.ResponseType)
+				assert.Contains(t, response.Text, "👉 <@U345678901> now has the current turn.")
+			},
+		},
+		{
+			name: "Should name the backup when the turn is shared",
+			args: args{
+				command:     "/rotation",
+				text:        "next",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{
+					ID:               1,
+					SlackChannelID:   args.channelID,
+					SlackChannelName: args.channelName,
+					SlackTeamID:      args.teamID,
+					IsActive:         true,
+				}
+
+				nextUsers := []*entity.User{
+					{ID: 2, ChannelID: 1, SlackUserID: "U234567890", IsActive: true},
+					{ID: 3, ChannelID: 1, SlackUserID: "U345678901", IsActive: true},
+				}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
+				// Mock GetNextAssignees call
+				m.RotationServiceMock.EXPECT().
+					GetNextAssignees(int64(1)).
+					Return(nextUsers, nil).Times(1)
+
+				// Mock RecordPresentation call
+				m.RotationServiceMock.EXPECT().
+					RecordPresentation(gomock.Any(), int64(1), nextUsers, domain.HistoryKindSkip, args.userID).
+					Return(nil).Times(1)
+
+				// Mock GetSchedulerConfig call
+				m.RotationServiceMock.EXPECT().
+					GetSchedulerConfig(int64(1)).
+					Return(&entity.Scheduler{ID: 1, RotationID: 1, Assignees: 2, BackupRole: "pair"}, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Contains(t, response.Text, "⏭️ Skipping to next presenter: <@U234567890> (pair: <@U345678901>)")
+			},
+		},
+		{
+			name: "Should back up the mentioned member with the next in order",
+			args: args{
+				command:     "/rotation",
+				text:        "next <@U345678901|jane>",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{
+					ID:               1,
+					SlackChannelID:   args.channelID,
+					SlackChannelName: args.channelName,
+					SlackTeamID:      args.teamID,
+					IsActive:         true,
+				}
+
+				users := []*entity.User{
+					{ID: 2, ChannelID: 1, SlackUserID: "U234567890", IsActive: true},
+					{ID: 3, ChannelID: 1, SlackUserID: "U345678901", IsActive: true},
+				}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
+				// Mock ListUsers call
+				m.RotationServiceMock.EXPECT().
+					ListUsers(int64(1)).
+					Return(users, nil).Times(1)
+
+				// Mock GetSchedulerConfig call
+				m.RotationServiceMock.EXPECT().
+					GetSchedulerConfig(int64(1)).
+					Return(&entity.Scheduler{ID: 1, RotationID: 1, Assignees: 2}, nil).Times(1)
+
+				// Mock RecordPresentation call, the backup wraps around to the start of the order
+				m.RotationServiceMock.EXPECT().
+					RecordPresentation(gomock.Any(), int64(1), []*entity.User{users[1], users[0]}, domain.HistoryKindOverride, args.userID).
+					Return(nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Contains(t, response.Text, "👉 <@U345678901> (backup: <@U234567890>) now has the current turn.")
+			},
+		},
+		{
+			name: "Should return error when mentioned member is not in rotation",
+			args: args{
+				command:     "/rotation",
+				text:        "next <@U999999999>",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{
+					ID:               1,
+					SlackChannelID:   args.channelID,
+					SlackChannelName: args.channelName,
+					SlackTeamID:      args.teamID,
+					IsActive:         true,
+				}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
+				// Mock ListUsers call
+				m.RotationServiceMock.EXPECT().
+					ListUsers(int64(1)).
+					Return([]*entity.User{{ID: 2, SlackUserID: "U234567890"}}, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Contains(t, response.Text, "<@U999999999> is not in the rotation")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -514,6 +778,11 @@ func TestSlackHandler_HandleSlashCommand_RemoveUser(t *testing.T) {
 					SetupChannel(args.channelID, args.channelName, args.teamID).
 					Return(channel, false, nil).Times(1)
 
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
 				// Mock RemoveUser calls for both users
 				m.RotationServiceMock.EXPECT().
 					RemoveUser(int64(1), "U123456789").
@@ -534,10 +803,10 @@ func TestSlackHandler_HandleSlashCommand_RemoveUser(t *testing.T) {
 					Profile: slack.UserProfile{RealName: "Test User 2"},
 				}
 				m.SlackClientMock.EXPECT().
-					GetUserInfo("U123456789").
+					GetUserInfoContext(gomock.Any(), "U123456789").
 					Return(userInfo1, nil).Times(1)
 				m.SlackClientMock.EXPECT().
-					GetUserInfo("U987654321").
+					GetUserInfoContext(gomock.Any(), "U987654321").
 					Return(userInfo2, nil).Times(1)
 			},
 			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
@@ -575,6 +844,11 @@ func TestSlackHandler_HandleSlashCommand_RemoveUser(t *testing.T) {
 					SetupChannel(args.channelID, args.channelName, args.teamID).
 					Return(channel, false, nil).Times(1)
 
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
 				// Mock RemoveUser call
 				m.RotationServiceMock.EXPECT().
 					RemoveUser(int64(1), "U123456789").
@@ -590,7 +864,7 @@ func TestSlackHandler_HandleSlashCommand_RemoveUser(t *testing.T) {
 					},
 				}
 				m.SlackClientMock.EXPECT().
-					GetUserInfo("U123456789").
+					GetUserInfoContext(gomock.Any(), "U123456789").
 					Return(userInfo, nil).Times(1)
 			},
 			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
@@ -669,6 +943,8 @@ func TestSlackHandler_HandleSlashCommand_Config(t *testing.T) {
 					ActiveDays:       domain.DefaultActiveDays,
 					IsEnabled:        true,
 					Role:             "presenter",
+					Timezone:         "America/Sao_Paulo",
+					Strategy:         domain.StrategyShuffled,
 				}
 
 				// Mock SetupChannel call
@@ -676,6 +952,11 @@ func TestSlackHandler_HandleSlashCommand_Config(t *testing.T) {
 					SetupChannel(args.channelID, args.channelName, args.teamID).
 					Return(channel, false, nil).Times(1)
 
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
 				// Mock GetChannelConfig call
 				m.RotationServiceMock.EXPECT().
 					GetChannelConfig(int64(1)).
@@ -696,6 +977,8 @@ func TestSlackHandler_HandleSlashCommand_Config(t *testing.T) {
 				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
 				assert.Contains(t, response.Text, "📋 *Current Configuration for #test-channel*")
 				assert.Contains(t, response.Text, "⏰ *Notification Time:* 09:30")
+				assert.Contains(t, response.Text, "🌍 *Timezone:* America/Sao_Paulo (UTC-03:00)")
+				assert.Contains(t, response.Text, "🧭 *Strategy:* shuffled")
 				assert.Contains(t, response.Text, "🔔 *Channel Status:* Active")
 				assert.Contains(t, response.Text, "📅 *Scheduler Status:* Enabled")
 			},
@@ -724,6 +1007,11 @@ func TestSlackHandler_HandleSlashCommand_Config(t *testing.T) {
 					SetupChannel(args.channelID, args.channelName, args.teamID).
 					Return(channel, false, nil).Times(1)
 
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
 				// Mock UpdateChannelConfig call
 				m.RotationServiceMock.EXPECT().
 					UpdateChannelConfig(int64(1), "time", "10:00").
@@ -809,6 +1097,11 @@ func TestSlackHandler_HandleSlashCommand_Pause(t *testing.T) {
 					SetupChannel(args.channelID, args.channelName, args.teamID).
 					Return(channel, false, nil).Times(1)
 
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
 				// Mock GetSchedulerConfig call
 				m.RotationServiceMock.EXPECT().
 					GetSchedulerConfig(int64(1)).
@@ -899,6 +1192,11 @@ func TestSlackHandler_HandleSlashCommand_Resume(t *testing.T) {
 					SetupChannel(args.channelID, args.channelName, args.teamID).
 					Return(channel, false, nil).Times(1)
 
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
 				// Mock GetSchedulerConfig call
 				m.RotationServiceMock.EXPECT().
 					GetSchedulerConfig(int64(1)).
@@ -1004,6 +1302,11 @@ func TestSlackHandler_HandleSlashCommand_Status(t *testing.T) {
 					SetupChannel(args.channelID, args.channelName, args.teamID).
 					Return(channel, false, nil).Times(1)
 
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
 				// Mock GetChannelConfig call
 				m.RotationServiceMock.EXPECT().
 					GetChannelConfig(int64(1)).
@@ -1019,10 +1322,10 @@ func TestSlackHandler_HandleSlashCommand_Status(t *testing.T) {
 					GetCurrentPresenter(int64(1)).
 					Return(currentUser, nil).Times(1)
 
-				// Mock GetNextPresenter call
+				// Mock GetNextAssignees call
 				m.RotationServiceMock.EXPECT().
-					GetNextPresenter(int64(1)).
-					Return(nextUser, nil).Times(1)
+					GetNextAssignees(int64(1)).
+					Return([]*entity.User{nextUser}, nil).Times(1)
 
 				// Mock ListUsers call
 				m.RotationServiceMock.EXPECT().
@@ -1045,25 +1348,1662 @@ func TestSlackHandler_HandleSlashCommand_Status(t *testing.T) {
 				assert.Contains(t, response.Text, "⏭️ *Next On duty:* <@U987654321>")
 			},
 		},
-	}
+		{
+			name: "Should show backups when the turn is shared",
+			args: args{
+				command:     "/rotation",
+				text:        "status",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{
+					ID:               1,
+					SlackChannelID:   args.channelID,
+					SlackChannelName: args.channelName,
+					SlackTeamID:      args.teamID,
+					IsActive:         true,
+				}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			m, handler, ctrl := test.GetHandlerTest(t)
-			defer ctrl.Finish()
+				scheduler := &entity.Scheduler{
+					ID:               1,
+					ChannelID:        1,
+					NotificationTime: "09:00",
+					ActiveDays:       domain.DefaultActiveDays,
+					IsEnabled:        true,
+					Role:             "On duty",
+					Assignees:        2,
+					BackupRole:       "backup",
+				}
 
-			if tt.buildMocks != nil {
-				tt.buildMocks(context.Background(), m, tt.args)
-			}
+				users := []*entity.User{
+					{ID: 1, SlackUserID: "U123456789"},
+					{ID: 2, SlackUserID: "U987654321"},
+					{ID: 3, SlackUserID: "U555555555"},
+				}
 
-			recorder := test.CreateTestRecorder()
-			req := test.CreateSlackRequest(t, tt.args.command, tt.args.text, tt.args.channelID, tt.args.channelName, tt.args.userID, tt.args.teamID, "test-signing-secret")
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
 
-			handler.HandleSlashCommand(recorder, req)
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
 
-			if tt.checkResponse != nil {
-				tt.checkResponse(t, recorder)
-			}
+				// Mock GetChannelConfig call
+				m.RotationServiceMock.EXPECT().
+					GetChannelConfig(int64(1)).
+					Return(channel, nil).Times(1)
+
+				// Mock GetSchedulerConfig call
+				m.RotationServiceMock.EXPECT().
+					GetSchedulerConfig(int64(1)).
+					Return(scheduler, nil).Times(1)
+
+				// Mock GetCurrentPresenter call
+				m.RotationServiceMock.EXPECT().
+					GetCurrentPresenter(int64(1)).
+					Return(users[0], nil).Times(1)
+
+				// Mock GetCurrentBackups call
+				m.RotationServiceMock.EXPECT().
+					GetCurrentBackups(int64(1)).
+					Return([]*entity.User{users[1]}, nil).Times(1)
+
+				// Mock GetNextAssignees call
+				m.RotationServiceMock.EXPECT().
+					GetNextAssignees(int64(1)).
+					Return([]*entity.User{users[1], users[2]}, nil).Times(1)
+
+				// Mock ListUsers call
+				m.RotationServiceMock.EXPECT().
+					ListUsers(int64(1)).
+					Return(users, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Contains(t, response.Text, "🤝 *Backups per turn:* 1 (backup)")
+				assert.Contains(t, response.Text, "🎯 *Current On duty:* <@U123456789> (backup: <@U987654321>)")
+				assert.Contains(t, response.Text, "⏭️ *Next On duty:* <@U987654321> (backup: <@U555555555>)")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, handler, ctrl := test.GetHandlerTest(t)
+			defer ctrl.Finish()
+
+			if tt.buildMocks != nil {
+				tt.buildMocks(context.Background(), m, tt.args)
+			}
+
+			recorder := test.CreateTestRecorder()
+			req := test.CreateSlackRequest(t, tt.args.command, tt.args.text, tt.args.channelID, tt.args.channelName, tt.args.userID, tt.args.teamID, "test-signing-secret")
+
+			handler.HandleSlashCommand(recorder, req)
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, recorder)
+			}
+		})
+	}
+}
+
+func TestSlackHandler_HandleSlashCommand_Holidays(t *testing.T) {
+	type args struct {
+		command     string
+		text        string
+		channelID   string
+		channelName string
+		userID      string
+		teamID      string
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		buildMocks    func(ctx context.Context, m test.ServiceMocks, args args)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Should add holiday range",
+			args: args{
+				command:     "/rotation",
+				text:        "holidays add 2026-12-24..2026-12-26 Christmas break",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{
+					ID:               1,
+					SlackChannelID:   args.channelID,
+					SlackChannelName: args.channelName,
+					SlackTeamID:      args.teamID,
+					IsActive:         true,
+				}
+
+				holiday := &entity.Holiday{
+					ID:          1,
+					ChannelID:   1,
+					StartDate:   time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC),
+					EndDate:     time.Date(2026, 12, 26, 0, 0, 0, 0, time.UTC),
+					Description: "Christmas break",
+				}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock AddHoliday call
+				m.RotationServiceMock.EXPECT().
+					AddHoliday(int64(1), "2026-12-24..2026-12-26", "Christmas break").
+					Return(holiday, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Contains(t, response.Text, "✅ Holiday added: Thu, Dec 24 2026 → Sat, Dec 26 2026 (Christmas break)")
+			},
+		},
+		{
+			name: "Should list upcoming holidays",
+			args: args{
+				command:     "/rotation",
+				text:        "holidays list",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{
+					ID:               1,
+					SlackChannelID:   args.channelID,
+					SlackChannelName: args.channelName,
+					SlackTeamID:      args.teamID,
+					IsActive:         true,
+				}
+
+				holidays := []*entity.Holiday{
+					{
+						ID:        1,
+						ChannelID: 1,
+						StartDate: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+						EndDate:   time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+				}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock ListHolidays call
+				m.RotationServiceMock.EXPECT().
+					ListHolidays(int64(1)).
+					Return(holidays, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Contains(t, response.Text, "🏖️ *Upcoming holidays (no notifications):*")
+				assert.Contains(t, response.Text, "• Fri, Jan 1 2027")
+			},
+		},
+		{
+			name: "Should import holidays from ics body",
+			args: args{
+				command:     "/rotation",
+				text:        "holidays import BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20261225\nEND:VEVENT\nEND:VCALENDAR",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{
+					ID:               1,
+					SlackChannelID:   args.channelID,
+					SlackChannelName: args.channelName,
+					SlackTeamID:      args.teamID,
+					IsActive:         true,
+				}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock ImportHolidays call keeping line breaks
+				m.RotationServiceMock.EXPECT().
+					ImportHolidays(gomock.Any(), int64(1), "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20261225\nEND:VEVENT\nEND:VCALENDAR").
+					Return(1, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Contains(t, response.Text, "✅ 1 holidays imported.")
+			},
+		},
+		{
+			name: "Should import holidays from ics body when the rotation name contains import",
+			args: args{
+				command:     "/rotation",
+				text:        "imports holidays import BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20261225\nEND:VEVENT\nEND:VCALENDAR",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{
+					ID:               1,
+					SlackChannelID:   args.channelID,
+					SlackChannelName: args.channelName,
+					SlackTeamID:      args.teamID,
+					IsActive:         true,
+				}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock ImportHolidays call keeping line breaks
+				m.RotationServiceMock.EXPECT().
+					ImportHolidays(gomock.Any(), int64(1), "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20261225\nEND:VEVENT\nEND:VCALENDAR").
+					Return(1, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Contains(t, response.Text, "✅ 1 holidays imported.")
+			},
+		},
+		{
+			name: "Should return error when removing unknown holiday",
+			args: args{
+				command:     "/rotation",
+				text:        "holidays remove 2026-12-24",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{
+					ID:               1,
+					SlackChannelID:   args.channelID,
+					SlackChannelName: args.channelName,
+					SlackTeamID:      args.teamID,
+					IsActive:         true,
+				}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock RemoveHoliday call
+				m.RotationServiceMock.EXPECT().
+					RemoveHoliday(int64(1), "2026-12-24").
+					Return(errors.New("no holiday found for 2026-12-24")).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Contains(t, response.Text, "❌ Error removing holiday: no holiday found for 2026-12-24")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, handler, ctrl := test.GetHandlerTest(t)
+			defer ctrl.Finish()
+
+			if tt.buildMocks != nil {
+				tt.buildMocks(context.Background(), m, tt.args)
+			}
+
+			recorder := test.CreateTestRecorder()
+			req := test.CreateSlackRequest(t, tt.args.command, tt.args.text, tt.args.channelID, tt.args.channelName, tt.args.userID, tt.args.teamID, "test-signing-secret")
+
+			handler.HandleSlashCommand(recorder, req)
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, recorder)
+			}
+		})
+	}
+}
+
+func TestSlackHandler_HandleSlashCommand_Away(t *testing.T) {
+	type args struct {
+		command     string
+		text        string
+		channelID   string
+		channelName string
+		userID      string
+		teamID      string
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		buildMocks    func(ctx context.Context, m test.ServiceMocks, args args)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Should mark mentioned user as away",
+			args: args{
+				command:     "/rotation",
+				text:        "away <@U123456789|john> 2026-10-20..2026-10-24 family trip",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{
+					ID:               1,
+					SlackChannelID:   args.channelID,
+					SlackChannelName: args.channelName,
+					SlackTeamID:      args.teamID,
+					IsActive:         true,
+				}
+
+				availability := &entity.Availability{
+					ID:        1,
+					UserID:    3,
+					StartDate: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+					EndDate:   time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC),
+					Reason:    "family trip",
+				}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
+				// Mock SetUserAway call
+				m.RotationServiceMock.EXPECT().
+					SetUserAway(int64(1), "U123456789", "2026-10-20..2026-10-24", "family trip").
+					Return(availability, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeInChannel, response.ResponseType)
+				assert.Contains(t, response.Text, "🏖️ <@U123456789> is away Tue, Oct 20 2026 → Sat, Oct 24 2026 (family trip)")
+			},
+		},
+		{
+			name: "Should mark caller as away when no user is mentioned",
+			args: args{
+				command:     "/rotation",
+				text:        "away 2026-10-20",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{
+					ID:               1,
+					SlackChannelID:   args.channelID,
+					SlackChannelName: args.channelName,
+					SlackTeamID:      args.teamID,
+					IsActive:         true,
+				}
+
+				availability := &entity.Availability{
+					ID:        1,
+					UserID:    2,
+					StartDate: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+					EndDate:   time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+				}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
+				// Mock SetUserAway call
+				m.RotationServiceMock.EXPECT().
+					SetUserAway(int64(1), args.userID, "2026-10-20", "").
+					Return(availability, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeInChannel, response.ResponseType)
+				assert.Contains(t, response.Text, "🏖️ <@U987654321> is away Tue, Oct 20 2026. They will be skipped")
+			},
+		},
+		{
+			name: "Should show usage when date range is missing",
+			args: args{
+				command:     "/rotation",
+				text:        "away <@U123456789>",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Contains(t, response.Text, "❌ Use: `/rotation away @user")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, handler, ctrl := test.GetHandlerTest(t)
+			defer ctrl.Finish()
+
+			if tt.buildMocks != nil {
+				tt.buildMocks(context.Background(), m, tt.args)
+			}
+
+			recorder := test.CreateTestRecorder()
+			req := test.CreateSlackRequest(t, tt.args.command, tt.args.text, tt.args.channelID, tt.args.channelName, tt.args.userID, tt.args.teamID, "test-signing-secret")
+
+			handler.HandleSlashCommand(recorder, req)
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, recorder)
+			}
+		})
+	}
+}
+
+func TestSlackHandler_HandleSlashCommand_History(t *testing.T) {
+	type args struct {
+		command     string
+		text        string
+		channelID   string
+		channelName string
+		userID      string
+		teamID      string
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		buildMocks    func(ctx context.Context, m test.ServiceMocks, args args)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Should show last turns in channel timezone",
+			args: args{
+				command:     "/rotation",
+				text:        "history 3",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{
+					ID:               1,
+					SlackChannelID:   args.channelID,
+					SlackChannelName: args.channelName,
+					SlackTeamID:      args.teamID,
+					IsActive:         true,
+				}
+
+				entries := []*entity.RotationHistory{
+					{
+						ID:          3,
+						UserID:      3,
+						SlackUserID: "U345678901",
+						DisplayName: "Jane",
+						PresentedAt: time.Date(2026, 10, 21, 15, 30, 0, 0, time.UTC),
+						Kind:        domain.HistoryKindOverride,
+						TriggeredBy: "U987654321",
+					},
+					{
+						ID:          2,
+						UserID:      2,
+						SlackUserID: "U234567890",
+						DisplayName: "John",
+						PresentedAt: time.Date(2026, 10, 20, 12, 5, 0, 0, time.UTC),
+						Kind:        domain.HistoryKindSkip,
+						TriggeredBy: "U987654321",
+					},
+					{
+						ID:          1,
+						SlackUserID: "U111111111",
+						DisplayName: "Removed Member",
+						PresentedAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+						Kind:        domain.HistoryKindAutomatic,
+					},
+				}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
+				// Mock GetHistory call
+				m.RotationServiceMock.EXPECT().
+					GetHistory(int64(1), 3).
+					Return(entries, nil).Times(1)
+
+				// Mock GetSchedulerConfig call
+				m.RotationServiceMock.EXPECT().
+					GetSchedulerConfig(int64(1)).
+					Return(&entity.Scheduler{Timezone: "America/Sao_Paulo"}, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Contains(t, response.Text, "📜 *Last 3 turns (America/Sao_Paulo")
+				assert.Contains(t, response.Text, "• Wed, Oct 21 2026 12:30 — <@U345678901> _(override by <@U987654321>)_")
+				assert.Contains(t, response.Text, "• Tue, Oct 20 2026 09:05 — <@U234567890> _(manual skip by <@U987654321>)_")
+				assert.Contains(t, response.Text, "• Mon, Oct 19 2026 09:00 — Removed Member\n")
+			},
+		},
+		{
+			name: "Should reject invalid limit",
+			args: args{
+				command:     "/rotation",
+				text:        "history 500",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Contains(t, response.Text, "❌ Use: `/rotation history [n]` where n is between 1 and 50")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, handler, ctrl := test.GetHandlerTest(t)
+			defer ctrl.Finish()
+
+			if tt.buildMocks != nil {
+				tt.buildMocks(context.Background(), m, tt.args)
+			}
+
+			recorder := test.CreateTestRecorder()
+			req := test.CreateSlackRequest(t, tt.args.command, tt.args.text, tt.args.channelID, tt.args.channelName, tt.args.userID, tt.args.teamID, "test-signing-secret")
+
+			handler.HandleSlashCommand(recorder, req)
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, recorder)
+			}
+		})
+	}
+}
+
+func TestSlackHandler_HandleSlashCommand_Order(t *testing.T) {
+	type args struct {
+		command     string
+		text        string
+		channelID   string
+		channelName string
+		userID      string
+		teamID      string
+	}
+
+	reordered := []*entity.User{
+		{ID: 2, ChannelID: 1, SlackUserID: "U234567890", DisplayName: "Jane", Position: 1},
+		{ID: 1, ChannelID: 1, SlackUserID: "U123456789", DisplayName: "John", Position: 2},
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		buildMocks    func(ctx context.Context, m test.ServiceMocks, args args)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Should move member to position",
+			args: args{
+				command:     "/rotation",
+				text:        "move <@U234567890|jane> 1",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{ID: 1, SlackChannelID: args.channelID, SlackChannelName: args.channelName, SlackTeamID: args.teamID, IsActive: true}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
+				// Mock MoveUser call
+				m.RotationServiceMock.EXPECT().
+					MoveUser(gomock.Any(), int64(1), "U234567890", 1).
+					Return(reordered, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeInChannel, response.ResponseType)
+				assert.Contains(t, response.Text, "↕️ *Rotation order updated:*\n1. Jane\n2. John\n")
+			},
+		},
+		{
+			name: "Should show usage when position is not a number",
+			args: args{
+				command:     "/rotation",
+				text:        "move <@U234567890> first",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Contains(t, response.Text, "❌ Use: `/rotation move @user 3`")
+			},
+		},
+		{
+			name: "Should set rotation order",
+			args: args{
+				command:     "/rotation",
+				text:        "order <@U234567890> <@U123456789>",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{ID: 1, SlackChannelID: args.channelID, SlackChannelName: args.channelName, SlackTeamID: args.teamID, IsActive: true}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
+				// Mock SetOrder call
+				m.RotationServiceMock.EXPECT().
+					SetOrder(gomock.Any(), int64(1), []string{"U234567890", "U123456789"}).
+					Return(reordered, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeInChannel, response.ResponseType)
+				assert.Contains(t, response.Text, "1. Jane\n2. John\n")
+			},
+		},
+		{
+			name: "Should shuffle rotation",
+			args: args{
+				command:     "/rotation",
+				text:        "shuffle",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{ID: 1, SlackChannelID: args.channelID, SlackChannelName: args.channelName, SlackTeamID: args.teamID, IsActive: true}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
+				// Mock ShuffleUsers call
+				m.RotationServiceMock.EXPECT().
+					ShuffleUsers(gomock.Any(), int64(1)).
+					Return(reordered, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeInChannel, response.ResponseType)
+				assert.Contains(t, response.Text, "🔀 *Rotation shuffled, new order:*\n1. Jane\n2. John\n")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, handler, ctrl := test.GetHandlerTest(t)
+			defer ctrl.Finish()
+
+			if tt.buildMocks != nil {
+				tt.buildMocks(context.Background(), m, tt.args)
+			}
+
+			recorder := test.CreateTestRecorder()
+			req := test.CreateSlackRequest(t, tt.args.command, tt.args.text, tt.args.channelID, tt.args.channelName, tt.args.userID, tt.args.teamID, "test-signing-secret")
+
+			handler.HandleSlashCommand(recorder, req)
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, recorder)
+			}
+		})
+	}
+}
+
+func TestSlackHandler_HandleSlashCommand_Swap(t *testing.T) {
+	type args struct {
+		command     string
+		text        string
+		channelID   string
+		channelName string
+		userID      string
+		teamID      string
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		buildMocks    func(ctx context.Context, m test.ServiceMocks, args args)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Should swap members right away",
+			args: args{
+				command:     "/rotation",
+				text:        "swap <@U123456789|john> <@U234567890|jane>",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{ID: 1, SlackChannelID: args.channelID, SlackChannelName: args.channelName, SlackTeamID: args.teamID, IsActive: true}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
+				// Mock SwapUsers call
+				m.RotationServiceMock.EXPECT().
+					SwapUsers(gomock.Any(), int64(1), "U123456789", "U234567890", "", args.userID).
+					Return(&entity.Swap{ID: 1, ChannelID: 1, UserAID: 1, UserBID: 2, AppliedAt: time.Now()}, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeInChannel, response.ResponseType)
+				assert.Contains(t, response.Text, "🔁 <@U123456789> and <@U234567890> swapped places in the rotation.")
+			},
+		},
+		{
+			name: "Should schedule dated swap",
+			args: args{
+				command:     "/rotation",
+				text:        "swap <@U123456789> <@U234567890> 2026-10-22",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{ID: 1, SlackChannelID: args.channelID, SlackChannelName: args.channelName, SlackTeamID: args.teamID, IsActive: true}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
+				// Mock SwapUsers call
+				m.RotationServiceMock.EXPECT().
+					SwapUsers(gomock.Any(), int64(1), "U123456789", "U234567890", "2026-10-22", args.userID).
+					Return(&entity.Swap{ID: 1, ChannelID: 1, UserAID: 1, UserBID: 2, SwapDate: time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC)}, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeInChannel, response.ResponseType)
+				assert.Contains(t, response.Text, "🔁 <@U123456789> and <@U234567890> will swap turns on Thu, Oct 22 2026.")
+			},
+		},
+		{
+			name: "Should show usage when only one member is mentioned",
+			args: args{
+				command:     "/rotation",
+				text:        "swap <@U123456789>",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Contains(t, response.Text, "❌ Use: `/rotation swap @user1 @user2`")
+			},
+		},
+		{
+			name: "Should return error when swap fails",
+			args: args{
+				command:     "/rotation",
+				text:        "swap <@U123456789> <@U999999999>",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{ID: 1, SlackChannelID: args.channelID, SlackChannelName: args.channelName, SlackTeamID: args.teamID, IsActive: true}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "").
+					Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1)
+
+				// Mock SwapUsers call
+				m.RotationServiceMock.EXPECT().
+					SwapUsers(gomock.Any(), int64(1), "U123456789", "U999999999", "", args.userID).
+					Return(nil, errors.New("<@U999999999> is not in the rotation")).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Contains(t, response.Text, "❌ Error swapping turns: <@U999999999> is not in the rotation")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, handler, ctrl := test.GetHandlerTest(t)
+			defer ctrl.Finish()
+
+			if tt.buildMocks != nil {
+				tt.buildMocks(context.Background(), m, tt.args)
+			}
+
+			recorder := test.CreateTestRecorder()
+			req := test.CreateSlackRequest(t, tt.args.command, tt.args.text, tt.args.channelID, tt.args.channelName, tt.args.userID, tt.args.teamID, "test-signing-secret")
+
+			handler.HandleSlashCommand(recorder, req)
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, recorder)
+			}
+		})
+	}
+}
+
+func TestSlackHandler_HandleSlashCommand_Rotations(t *testing.T) {
+	type args struct {
+		command     string
+		text        string
+		channelID   string
+		channelName string
+		userID      string
+		teamID      string
+	}
+
+	reviewers := &entity.Rotation{ID: 2, ChannelID: 1, Name: "reviewers"}
+
+	tests := []struct {
+		name          string
+		args          args
+		buildMocks    func(ctx context.Context, m test.ServiceMocks, args args)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Should create rotation",
+			args: args{
+				command:     "/rotation",
+				text:        "create reviewers",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{ID: 1, SlackChannelID: args.channelID, SlackChannelName: args.channelName, SlackTeamID: args.teamID, IsActive: true}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock CreateRotation call
+				m.RotationServiceMock.EXPECT().
+					CreateRotation(int64(1), "reviewers").
+					Return(reviewers, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeInChannel, response.ResponseType)
+				assert.Contains(t, response.Text, "🆕 Rotation *reviewers* created")
+				assert.Contains(t, response.Text, "`/rotation reviewers add @user`")
+			},
+		},
+		{
+			name: "Should return usage when create has no name",
+			args: args{
+				command:     "/rotation",
+				text:        "create",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Contains(t, response.Text, "Use: `/rotation create NAME`")
+			},
+		},
+		{
+			name: "Should delete rotation",
+			args: args{
+				command:     "/rotation",
+				text:        "delete reviewers",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{ID: 1, SlackChannelID: args.channelID, SlackChannelName: args.channelName, SlackTeamID: args.teamID, IsActive: true}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock DeleteRotation call
+				m.RotationServiceMock.EXPECT().
+					DeleteRotation(int64(1), "reviewers").
+					Return(nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeInChannel, response.ResponseType)
+				assert.Contains(t, response.Text, "🗑️ Rotation *reviewers* deleted.")
+			},
+		},
+		{
+			name: "Should list rotations of the channel",
+			args: args{
+				command:     "/rotation",
+				text:        "rotations",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{ID: 1, SlackChannelID: args.channelID, SlackChannelName: args.channelName, SlackTeamID: args.teamID, IsActive: true}
+				primary := &entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock ListRotations call
+				m.RotationServiceMock.EXPECT().
+					ListRotations(int64(1)).
+					Return([]*entity.Rotation{primary, reviewers}, nil).Times(1)
+
+				// Mock members and schedule of each rotation
+				m.RotationServiceMock.EXPECT().
+					ListUsers(int64(1)).
+					Return([]*entity.User{{ID: 1}, {ID: 2}}, nil).Times(1)
+				m.RotationServiceMock.EXPECT().
+					GetSchedulerConfig(int64(1)).
+					Return(&entity.Scheduler{Role: "On duty", NotificationTime: "09:00", IsEnabled: true}, nil).Times(1)
+				m.RotationServiceMock.EXPECT().
+					ListUsers(int64(2)).
+					Return([]*entity.User{{ID: 3}}, nil).Times(1)
+				m.RotationServiceMock.EXPECT().
+					GetSchedulerConfig(int64(2)).
+					Return(&entity.Scheduler{Role: "Reviewer", NotificationTime: "14:00", IsEnabled: false}, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Contains(t, response.Text, "• *default* _(primary)_ — 2 members, On duty at 09:00\n")
+				assert.Contains(t, response.Text, "• *reviewers* — 1 member, Reviewer at 14:00 ⏸️\n")
+			},
+		},
+		{
+			name: "Should run command on the named rotation",
+			args: args{
+				command:     "/rotation",
+				text:        "reviewers add <@U123456789|testuser>",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{ID: 1, SlackChannelID: args.channelID, SlackChannelName: args.channelName, SlackTeamID: args.teamID, IsActive: true}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "reviewers").
+					Return(reviewers, nil).Times(1)
+
+				// Mock AddUser call
+				m.RotationServiceMock.EXPECT().
+					AddUser(gomock.Any(), reviewers.ID, "U123456789").
+					Return(nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeInChannel, response.ResponseType)
+			},
+		},
+		{
+			name: "Should return error when named rotation does not exist",
+			args: args{
+				command:     "/rotation",
+				text:        "oncall next",
+				channelID:   "C123456789",
+				channelName: "test-channel",
+				userID:      "U987654321",
+				teamID:      "T123456789",
+			},
+			buildMocks: func(ctx context.Context, m test.ServiceMocks, args args) {
+				channel := &entity.Channel{ID: 1, SlackChannelID: args.channelID, SlackChannelName: args.channelName, SlackTeamID: args.teamID, IsActive: true}
+
+				// Mock SetupChannel call
+				m.RotationServiceMock.EXPECT().
+					SetupChannel(args.channelID, args.channelName, args.teamID).
+					Return(channel, false, nil).Times(1)
+
+				// Mock GetRotation call
+				m.RotationServiceMock.EXPECT().
+					GetRotation(int64(1), "oncall").
+					Return(nil, errors.New("rotation oncall not found")).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Contains(t, response.Text, "❌ Error finding rotation: rotation oncall not found")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, handler, ctrl := test.GetHandlerTest(t)
+			defer ctrl.Finish()
+
+			if tt.buildMocks != nil {
+				tt.buildMocks(context.Background(), m, tt.args)
+			}
+
+			recorder := test.CreateTestRecorder()
+			req := test.CreateSlackRequest(t, tt.args.command, tt.args.text, tt.args.channelID, tt.args.channelName, tt.args.userID, tt.args.teamID, "test-signing-secret")
+
+			handler.HandleSlashCommand(recorder, req)
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, recorder)
+			}
+		})
+	}
+}
+
+func TestSlackHandler_HandleSlashCommand_ConfigModal(t *testing.T) {
+	channel := &entity.Channel{ID: 1, SlackChannelID: "C123456789", SlackChannelName: "test-channel", SlackTeamID: "T123456789", IsActive: true}
+	rotation := &entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}
+
+	tests := []struct {
+		name          string
+		buildMocks    func(m test.ServiceMocks)
+		checkResponse func(t *testing.T, resp *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Should open config modal pre-filled with the scheduler config",
+			buildMocks: func(m test.ServiceMocks) {
+				gomock.InOrder(
+					m.RotationServiceMock.EXPECT().
+						SetupChannel("C123456789", "test-channel", "T123456789").
+						Return(channel, false, nil).Times(1),
+					m.RotationServiceMock.EXPECT().
+						GetRotation(int64(1), "").
+						Return(rotation, nil).Times(1),
+					m.RotationServiceMock.EXPECT().
+						GetSchedulerConfig(int64(1)).
+						Return(&entity.Scheduler{RotationID: 1, NotificationTime: "10:30", ActiveDays: []int{1, 3}, IsEnabled: true, Role: "presenter", Timezone: "America/Sao_Paulo"}, nil).Times(1),
+					m.SlackClientMock.EXPECT().
+						OpenView("test-trigger-id", gomock.Any()).
+						DoAndReturn(func(triggerID string, view slack.ModalViewRequest) (*slack.ViewResponse, error) {
+							assert.Equal(t, "rotation_config", view.CallbackID)
+							assert.Equal(t, "1", view.PrivateMetadata)
+
+							data, err := json.Marshal(view)
+							require.NoError(t, err)
+							assert.Contains(t, string(data), `"initial_time":"10:30"`)
+							assert.Contains(t, string(data), `"timezone":"America/Sao_Paulo"`)
+							assert.Contains(t, string(data), `"initial_value":"presenter"`)
+							return &slack.ViewResponse{}, nil
+						}).Times(1),
+				)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Contains(t, response.Text, "Configuration editor opened")
+			},
+		},
+		{
+			name: "Should fall back to usage when modal cannot be opened",
+			buildMocks: func(m test.ServiceMocks) {
+				gomock.InOrder(
+					m.RotationServiceMock.EXPECT().
+						SetupChannel("C123456789", "test-channel", "T123456789").
+						Return(channel, false, nil).Times(1),
+					m.RotationServiceMock.EXPECT().
+						GetRotation(int64(1), "").
+						Return(rotation, nil).Times(1),
+					m.RotationServiceMock.EXPECT().
+						GetSchedulerConfig(int64(1)).
+						Return(nil, nil).Times(1),
+					m.SlackClientMock.EXPECT().
+						OpenView("test-trigger-id", gomock.Any()).
+						Return(nil, errors.New("expired_trigger_id")).Times(1),
+				)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				var response slack.Msg
+				require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+
+				assert.Contains(t, response.Text, "❌ Could not open the configuration editor")
+				assert.Contains(t, response.Text, "/rotation config time HH:MM")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, handler, ctrl := test.GetHandlerTest(t)
+			defer ctrl.Finish()
+
+			tt.buildMocks(m)
+
+			recorder := test.CreateTestRecorder()
+			req := test.CreateSlackRequest(t, "/rotation", "config", "C123456789", "test-channel", "U987654321", "T123456789", "test-signing-secret")
+
+			handler.HandleSlashCommand(recorder, req)
+
+			tt.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestSlackHandler_HandleSlashCommand_Me(t *testing.T) {
+	standup := &entity.Channel{ID: 1, SlackChannelID: "C111111111", IsActive: true}
+	review := &entity.Channel{ID: 2, SlackChannelID: "C222222222", IsActive: true}
+	member := &entity.User{ID: 1, SlackUserID: "U987654321"}
+
+	tests := []struct {
+		name          string
+		buildMocks    func(m test.ServiceMocks)
+		checkResponse func(t *testing.T, resp *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Should list next turns across channels soonest first",
+			buildMocks: func(m test.ServiceMocks) {
+				m.RotationServiceMock.EXPECT().
+					GetUserRotations("U987654321").
+					Return([]*entity.UserRotation{
+						{
+							Channel:   review,
+							Rotation:  &entity.Rotation{ID: 3, ChannelID: 2, Name: "reviewers"},
+							Scheduler: &entity.Scheduler{IsEnabled: true},
+							Member:    member,
+							NextTurn:  time.Date(2026, 10, 26, 9, 0, 0, 0, time.UTC),
+						},
+						{
+							Channel:   standup,
+							Rotation:  &entity.Rotation{ID: 1, ChannelID: 1, IsPrimary: true},
+							Scheduler: &entity.Scheduler{IsEnabled: true},
+							Member:    member,
+							NextTurn:  time.Date(2026, 10, 22, 9, 0, 0, 0, time.UTC),
+						},
+						{
+							Channel:   review,
+							Rotation:  &entity.Rotation{ID: 2, ChannelID: 2, IsPrimary: true},
+							Scheduler: &entity.Scheduler{},
+							Member:    member,
+						},
+					}, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Equal(t, "📅 *Your next turns:* <#C111111111> on Thu Oct 22, <#C222222222> (reviewers) on Mon Oct 26\n"+
+					"_No turn in sight: <#C222222222> ⏸️ paused_", response.Text)
+			},
+		},
+		{
+			name: "Should tell when the user is in no rotation",
+			buildMocks: func(m test.ServiceMocks) {
+				m.RotationServiceMock.EXPECT().
+					GetUserRotations("U987654321").
+					Return(nil, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				var response slack.Msg
+				require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+
+				assert.Equal(t, "You are not in any rotation yet.", response.Text)
+			},
+		},
+		{
+			name: "Should return error when rotations cannot be loaded",
+			buildMocks: func(m test.ServiceMocks) {
+				m.RotationServiceMock.EXPECT().
+					GetUserRotations("U987654321").
+					Return(nil, errors.New("database error")).Times(1)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				var response slack.Msg
+				require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+
+				assert.Contains(t, response.Text, "❌ Error getting your rotations")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, handler, ctrl := test.GetHandlerTest(t)
+			defer ctrl.Finish()
+
+			tt.buildMocks(m)
+
+			recorder := test.CreateTestRecorder()
+			req := test.CreateSlackRequest(t, "/rotation", "me", "C123456789", "test-channel", "U987654321", "T123456789", "test-signing-secret")
+
+			handler.HandleSlashCommand(recorder, req)
+
+			tt.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestSlackHandler_HandleSlashCommand_Schedule(t *testing.T) {
+	channel := &entity.Channel{ID: 1, SlackChannelID: "C123456789", SlackChannelName: "test-channel", SlackTeamID: "T123456789", IsActive: true}
+	rotation := &entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}
+	alice := &entity.User{ID: 1, SlackUserID: "U111"}
+	bob := &entity.User{ID: 2, SlackUserID: "U222"}
+
+	tests := []struct {
+		name          string
+		text          string
+		buildMocks    func(m test.ServiceMocks)
+		checkResponse func(t *testing.T, resp *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Should list the next turns in the channel timezone",
+			text: "schedule 3",
+			buildMocks: func(m test.ServiceMocks) {
+				scheduler := &entity.Scheduler{RotationID: 1, IsEnabled: true, Timezone: "America/Sao_Paulo", Assignees: 2}
+				gomock.InOrder(
+					m.RotationServiceMock.EXPECT().
+						SetupChannel("C123456789", "test-channel", "T123456789").
+						Return(channel, false, nil).Times(1),
+					m.RotationServiceMock.EXPECT().
+						GetRotation(int64(1), "").
+						Return(rotation, nil).Times(1),
+					m.RotationServiceMock.EXPECT().
+						GetSchedulerConfig(int64(1)).
+						Return(scheduler, nil).Times(1),
+					m.RotationServiceMock.EXPECT().
+						GetSchedule(int64(1), 3).
+						Return([]entity.ScheduledTurn{
+							{Date: time.Date(2026, 10, 22, 12, 0, 0, 0, time.UTC), Presenter: alice, Backups: []*entity.User{bob}},
+							{Date: time.Date(2026, 10, 23, 12, 0, 0, 0, time.UTC)},
+							{Date: time.Date(2026, 10, 26, 12, 0, 0, 0, time.UTC), Presenter: bob, Backups: []*entity.User{alice}},
+						}, nil).Times(1),
+				)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, resp.Code)
+
+				var response slack.Msg
+				require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Contains(t, response.Text, "🗓️ *Next 3 turns (America/Sao_Paulo")
+				assert.Contains(t, response.Text, "• Thu, Oct 22 09:00 — <@U111> (backup: <@U222>)\n")
+				assert.Contains(t, response.Text, "• Fri, Oct 23 09:00 — nobody, everyone is away\n")
+				assert.Contains(t, response.Text, "• Mon, Oct 26 09:00 — <@U222> (backup: <@U111>)\n")
+				assert.Contains(t, response.Text, "_Based on the rotation strategy, holidays, out-of-office periods and planned swaps. Manual skips can still change it._")
+			},
+		},
+		{
+			name: "Should tell when the rotation is paused",
+			text: "schedule",
+			buildMocks: func(m test.ServiceMocks) {
+				gomock.InOrder(
+					m.RotationServiceMock.EXPECT().
+						SetupChannel("C123456789", "test-channel", "T123456789").
+						Return(channel, false, nil).Times(1),
+					m.RotationServiceMock.EXPECT().
+						GetRotation(int64(1), "").
+						Return(rotation, nil).Times(1),
+					m.RotationServiceMock.EXPECT().
+						GetSchedulerConfig(int64(1)).
+						Return(&entity.Scheduler{RotationID: 1}, nil).Times(1),
+				)
+			},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				var response slack.Msg
+				require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+
+				assert.Contains(t, response.Text, "⏸️ The rotation is paused")
+			},
+		},
+		{
+			name:       "Should reject an invalid number of turns",
+			text:       "schedule 31",
+			buildMocks: func(m test.ServiceMocks) {},
+			checkResponse: func(t *testing.T, resp *httptest.ResponseRecorder) {
+				var response slack.Msg
+				require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+
+				assert.Contains(t, response.Text, "❌ Use: `/rotation schedule [n]` where n is between 1 and 30")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, handler, ctrl := test.GetHandlerTest(t)
+			defer ctrl.Finish()
+
+			tt.buildMocks(m)
+
+			recorder := test.CreateTestRecorder()
+			req := test.CreateSlackRequest(t, "/rotation", tt.text, "C123456789", "test-channel", "U987654321", "T123456789", "test-signing-secret")
+
+			handler.HandleSlashCommand(recorder, req)
+
+			tt.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestSlackHandler_HandleSlashCommand_Notify(t *testing.T) {
+	channel := &entity.Channel{ID: 1, SlackChannelID: "C123456789", SlackChannelName: "test-channel", SlackTeamID: "T123456789", IsActive: true}
+	rotation := &entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}
+
+	tests := []struct {
+		name          string
+		text          string
+		buildMocks    func(m test.ServiceMocks)
+		checkResponse func(t *testing.T, response slack.Msg)
+	}{
+		{
+			name: "Should turn on the heads-up of the member running the command",
+			text: "notify dm day-before",
+			buildMocks: func(m test.ServiceMocks) {
+				gomock.InOrder(
+					m.RotationServiceMock.EXPECT().
+						SetupChannel("C123456789", "test-channel", "T123456789").
+						Return(channel, false, nil).Times(1),
+					m.RotationServiceMock.EXPECT().
+						GetRotation(int64(1), "").
+						Return(rotation, nil).Times(1),
+					m.RotationServiceMock.EXPECT().
+						SetDMReminders(int64(1), "U987654321", "day-before").
+						Return(nil).Times(1),
+				)
+			},
+			checkResponse: func(t *testing.T, response slack.Msg) {
+				assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+				assert.Contains(t, response.Text, "the day before and on the day of your turn")
+			},
+		},
+		{
+			name: "Should turn off the DMs of the member",
+			text: "notify dm off",
+			buildMocks: func(m test.ServiceMocks) {
+				m.RotationServiceMock.EXPECT().SetupChannel("C123456789", "test-channel", "T123456789").Return(channel, false, nil).Times(1)
+				m.RotationServiceMock.EXPECT().GetRotation(int64(1), "").Return(rotation, nil).Times(1)
+				m.RotationServiceMock.EXPECT().SetDMReminders(int64(1), "U987654321", "off").Return(nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, response slack.Msg) {
+				assert.Contains(t, response.Text, "only be reminded in the channel")
+			},
+		},
+		{
+			name: "Should report an error of the service",
+			text: "notify dm sometimes",
+			buildMocks: func(m test.ServiceMocks) {
+				m.RotationServiceMock.EXPECT().SetupChannel("C123456789", "test-channel", "T123456789").Return(channel, false, nil).Times(1)
+				m.RotationServiceMock.EXPECT().GetRotation(int64(1), "").Return(rotation, nil).Times(1)
+				m.RotationServiceMock.EXPECT().SetDMReminders(int64(1), "U987654321", "sometimes").Return(assert.AnError).Times(1)
+			},
+			checkResponse: func(t *testing.T, response slack.Msg) {
+				assert.Contains(t, response.Text, "❌ Error updating your reminders")
+			},
+		},
+		{
+			name:       "Should show the usage without a setting",
+			text:       "notify dm",
+			buildMocks: func(m test.ServiceMocks) {},
+			checkResponse: func(t *testing.T, response slack.Msg) {
+				assert.Contains(t, response.Text, "❌ Use: `/rotation notify dm on`")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, handler, ctrl := test.GetHandlerTest(t)
+			defer ctrl.Finish()
+
+			tt.buildMocks(m)
+
+			recorder := test.CreateTestRecorder()
+			req := test.CreateSlackRequest(t, "/rotation", tt.text, "C123456789", "test-channel", "U987654321", "T123456789", "test-signing-secret")
+
+			handler.HandleSlashCommand(recorder, req)
+
+			require.Equal(t, http.StatusOK, recorder.Code)
+			var response slack.Msg
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			tt.checkResponse(t, response)
 		})
 	}
 }
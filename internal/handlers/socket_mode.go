@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
+)
+
+// maxSocketEvents is how many Socket Mode events are handled at the same time, so a slow one
+// does not hold back the others while a burst cannot start goroutines without end
+const maxSocketEvents = 16
+
+// RunSocketMode receives slash commands, interactions and events over the Socket Mode WebSocket, so
+// the bot works without a public URL. Requests are handled by the same logic as the HTTP
+// endpoints. It blocks until ctx is cancelled or the connection cannot be recovered, then
+// waits for the events being handled.
+func (h *SlackHandler) RunSocketMode(ctx context.Context, client *socketmode.Client) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Deferred after cancel so it runs before it
+	var handling sync.WaitGroup
+	defer handling.Wait()
+	slots := make(chan struct{}, maxSocketEvents)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- client.RunContext(ctx)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errCh:
+			return err
+		case evt := <-client.Events:
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return nil
+			}
+
+			handling.Add(1)
+			go func() {
+				defer func() {
+					<-slots
+					handling.Done()
+				}()
+				h.handleSocketEvent(ctx, client, evt)
+			}()
+		}
+	}
+}
+
+func (h *SlackHandler) handleSocketEvent(ctx context.Context, client *socketmode.Client, evt socketmode.Event) {
+	switch evt.Type {
+	case socketmode.EventTypeConnecting:
+		log.Println("Connecting to Slack with Socket Mode...")
+	case socketmode.EventTypeConnected:
+		log.Println("Connected to Slack with Socket Mode")
+	case socketmode.EventTypeConnectionError:
+		log.Printf("ERROR Socket Mode connection failed: %v", evt.Data)
+	case socketmode.EventTypeErrorBadMessage:
+		log.Printf("ERROR Socket Mode message could not be parsed: %v", evt.Data)
+	case socketmode.EventTypeSlashCommand:
+		slashCmd, ok := evt.Data.(slack.SlashCommand)
+		if !ok || evt.Request == nil {
+			log.Printf("ERROR unexpected slash command event: %+v", evt)
+			return
+		}
+
+		log.Printf("Received command: %s %s from user: %s in channel: %s", slashCmd.Command, slashCmd.Text, slashCmd.UserID, slashCmd.ChannelID)
+
+		// The acknowledgement carries the response, like the body of the HTTP endpoint
+		client.Ack(*evt.Request, h.runCommand(ctx, &slashCmd))
+	case socketmode.EventTypeInteractive:
+		callback, ok := evt.Data.(slack.InteractionCallback)
+		if !ok || evt.Request == nil {
+			log.Printf("ERROR unexpected interaction event: %+v", evt)
+			return
+		}
+
+		// Modal submissions are answered in the acknowledgement, the errors to show in the modal
+		if callback.Type == slack.InteractionTypeViewSubmission {
+			if response := h.runInteraction(ctx, &callback); response != nil {
+				client.Ack(*evt.Request, response)
+			} else {
+				client.Ack(*evt.Request)
+			}
+			return
+		}
+
+		// Acknowledge first, Slack only waits 3 seconds and the reminder is updated separately
+		client.Ack(*evt.Request)
+		h.runInteraction(ctx, &callback)
+	case socketmode.EventTypeEventsAPI:
+		event, ok := evt.Data.(slackevents.EventsAPIEvent)
+		if !ok || evt.Request == nil {
+			log.Printf("ERROR unexpected events API event: %+v", evt)
+			return
+		}
+
+		// Acknowledge first so Slack does not retry the event
+		client.Ack(*evt.Request)
+		h.runEvent(event)
+	}
+}
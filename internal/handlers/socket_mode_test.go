@@ -0,0 +1,159 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/diegoclair/slack-rotation-bot/internal/domain"
+	"github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
+	slackcmd "github.com/diegoclair/slack-rotation-bot/internal/domain/slack"
+	"github.com/diegoclair/slack-rotation-bot/internal/handlers/test"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSlackHandler_RunSocketMode(t *testing.T) {
+	t.Run("Should respond to slash command in the acknowledgement", func(t *testing.T) {
+		_, handler, ctrl := test.GetHandlerTest(t)
+		defer ctrl.Finish()
+
+		fake := test.NewFakeSocketMode(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go handler.RunSocketMode(ctx, fake.Client())
+
+		fake.Send("env-1", socketmode.RequestTypeSlashCommands, map[string]any{
+			"command":               "/rotation",
+			"text":                  "help",
+			"channel_id":            "C123456789",
+			"channel_name":          "test-channel",
+			"user_id":               "U987654321",
+			"team_id":               "T123456789",
+			"is_enterprise_install": "false",
+		})
+
+		ack := fake.WaitAck(t)
+		require.Equal(t, "env-1", ack.EnvelopeID)
+
+		var response slack.Msg
+		require.NoError(t, json.Unmarshal(ack.Payload, &response))
+		assert.Equal(t, slack.ResponseTypeEphemeral, response.ResponseType)
+		assert.Contains(t, response.Text, "*🔄 People Rotation Bot - Commands*")
+	})
+
+	t.Run("Should acknowledge interaction and update the reminder", func(t *testing.T) {
+		m, handler, ctrl := test.GetHandlerTest(t)
+		defer ctrl.Finish()
+
+		updated := make(chan struct{})
+		gomock.InOrder(
+			m.RotationServiceMock.EXPECT().
+				GetRotationByID(int64(1)).
+				Return(&entity.Rotation{ID: 1, ChannelID: 1, Name: domain.DefaultRotationName, IsPrimary: true}, nil).Times(1),
+			m.RotationServiceMock.EXPECT().
+				GetSchedulerConfig(int64(1)).
+				Return(&entity.Scheduler{RotationID: 1, Assignees: 1}, nil).Times(1),
+			m.RotationServiceMock.EXPECT().
+				GetCurrentPresenter(int64(1)).
+				Return(&entity.User{ID: 1, SlackUserID: "U111"}, nil).Times(1),
+			m.SlackClientMock.EXPECT().
+				UpdateMessage("C123456789", "1700000000.000100", gomock.Any(), gomock.Any()).
+				DoAndReturn(func(channelID, timestamp string, options ...slack.MsgOption) (string, string, string, error) {
+					close(updated)
+					return channelID, timestamp, "", nil
+				}).Times(1),
+		)
+
+		fake := test.NewFakeSocketMode(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go handler.RunSocketMode(ctx, fake.Client())
+
+		fake.Send("env-2", socketmode.RequestTypeInteractive, json.RawMessage(interactionPayload(t, slackcmd.ActionAcknowledge, "1")))
+
+		ack := fake.WaitAck(t)
+		assert.Equal(t, "env-2", ack.EnvelopeID)
+		assert.Empty(t, ack.Payload)
+
+		<-updated
+	})
+	t.Run("Should acknowledge event and handle it", func(t *testing.T) {
+		m, handler, ctrl := test.GetHandlerTest(t)
+		defer ctrl.Finish()
+
+		handled := make(chan struct{})
+		m.RotationServiceMock.EXPECT().
+			SetChannelActive("C123456789", false).
+			DoAndReturn(func(slackChannelID string, active bool) error {
+				close(handled)
+				return nil
+			}).Times(1)
+
+		fake := test.NewFakeSocketMode(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go handler.RunSocketMode(ctx, fake.Client())
+
+		fake.Send("env-3", socketmode.RequestTypeEventsAPI, json.RawMessage(callbackEvent(`{"type":"channel_archive","channel":"C123456789","user":"U123"}`)))
+
+		ack := fake.WaitAck(t)
+		assert.Equal(t, "env-3", ack.EnvelopeID)
+
+		<-handled
+	})
+
+	t.Run("Should handle an event while another one is still being handled", func(t *testing.T) {
+		m, handler, ctrl := test.GetHandlerTest(t)
+		defer ctrl.Finish()
+
+		release := make(chan struct{})
+		m.RotationServiceMock.EXPECT().
+			GetRotationByID(int64(1)).
+			DoAndReturn(func(rotationID int64) (*entity.Rotation, error) {
+				<-release
+				return nil, assert.AnError
+			}).Times(1)
+
+		fake := test.NewFakeSocketMode(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		done := make(chan error, 1)
+		go func() {
+			done <- handler.RunSocketMode(ctx, fake.Client())
+		}()
+
+		fake.Send("env-4", socketmode.RequestTypeInteractive, json.RawMessage(interactionPayload(t, slackcmd.ActionAcknowledge, "1")))
+		assert.Equal(t, "env-4", fake.WaitAck(t).EnvelopeID)
+
+		fake.Send("env-5", socketmode.RequestTypeSlashCommands, map[string]any{
+			"command":               "/rotation",
+			"text":                  "help",
+			"channel_id":            "C123456789",
+			"channel_name":          "test-channel",
+			"user_id":               "U987654321",
+			"team_id":               "T123456789",
+			"is_enterprise_install": "false",
+		})
+		assert.Equal(t, "env-5", fake.WaitAck(t).EnvelopeID)
+
+		// Stopping waits for the interaction still being handled
+		cancel()
+		select {
+		case <-done:
+			require.FailNow(t, "returned before the interaction was handled")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(release)
+		require.NoError(t, <-done)
+	})
+}
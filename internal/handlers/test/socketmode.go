@@ -0,0 +1,118 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+	"github.com/stretchr/testify/require"
+)
+
+// SocketModeAck is an acknowledgement sent by the bot over the Socket Mode connection
+type SocketModeAck struct {
+	EnvelopeID string          `json:"envelope_id"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+}
+
+// FakeSocketMode is a local Socket Mode server. It hands out its own WebSocket URL on
+// apps.connections.open, sends the queued envelopes and collects the acknowledgements.
+type FakeSocketMode struct {
+	server    *httptest.Server
+	envelopes chan any
+	acks      chan SocketModeAck
+	done      chan struct{}
+}
+
+func NewFakeSocketMode(t *testing.T) *FakeSocketMode {
+	t.Helper()
+
+	f := &FakeSocketMode{
+		envelopes: make(chan any, 10),
+		acks:      make(chan SocketModeAck, 10),
+		done:      make(chan struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/apps.connections.open", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		wsURL := "ws" + strings.TrimPrefix(f.server.URL, "http") + "/link"
+		fmt.Fprintf(w, `{"ok":true,"url":%q}`, wsURL)
+	})
+	mux.HandleFunc("/link", f.serveLink)
+
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	t.Cleanup(func() { close(f.done) })
+
+	return f
+}
+
+// Client returns a Socket Mode client connected to the fake server
+func (f *FakeSocketMode) Client() *socketmode.Client {
+	api := slack.New("xoxb-test", slack.OptionAppLevelToken("xapp-test"), slack.OptionAPIURL(f.server.URL+"/"))
+	return socketmode.New(api)
+}
+
+// Send queues an envelope of the given type, like slash_commands or interactive
+func (f *FakeSocketMode) Send(envelopeID, requestType string, payload any) {
+	f.envelopes <- map[string]any{
+		"envelope_id":              envelopeID,
+		"type":                     requestType,
+		"payload":                  payload,
+		"accepts_response_payload": requestType == socketmode.RequestTypeSlashCommands,
+	}
+}
+
+// WaitAck returns the next acknowledgement sent by the bot
+func (f *FakeSocketMode) WaitAck(t *testing.T) SocketModeAck {
+	t.Helper()
+
+	select {
+	case ack := <-f.acks:
+		return ack
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for Socket Mode acknowledgement")
+		return SocketModeAck{}
+	}
+}
+
+func (f *FakeSocketMode) serveLink(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	go func() {
+		for {
+			var ack SocketModeAck
+			if err := conn.ReadJSON(&ack); err != nil {
+				return
+			}
+			f.acks <- ack
+		}
+	}()
+
+	if err := conn.WriteJSON(map[string]any{"type": socketmode.RequestTypeHello}); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case envelope := <-f.envelopes:
+			if err := conn.WriteJSON(envelope); err != nil {
+				return
+			}
+		case <-f.done:
+			return
+		}
+	}
+}
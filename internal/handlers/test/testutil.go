@@ -22,6 +22,8 @@ import (
 type ServiceMocks struct {
 	RotationServiceMock *mocks.MockRotationService
 	SlackClientMock     *mocks.MockSlackClient
+	SlackClientsMock    *mocks.MockSlackClientFactory
+	ClockMock           *mocks.MockClock
 }
 
 func GetHandlerTest(t *testing.T) (m ServiceMocks, handler *handlers.SlackHandler, ctrl *gomock.Controller) {
@@ -31,10 +33,13 @@ func GetHandlerTest(t *testing.T) (m ServiceMocks, handler *handlers.SlackHandle
 	m = ServiceMocks{
 		RotationServiceMock: mocks.NewMockRotationService(ctrl),
 		SlackClientMock:     mocks.NewMockSlackClient(ctrl),
+		SlackClientsMock:    mocks.NewMockSlackClientFactory(ctrl),
+		ClockMock:           mocks.NewMockClock(ctrl),
 	}
+	m.SlackClientsMock.EXPECT().ForTeam(gomock.Any()).Return(m.SlackClientMock, nil).AnyTimes()
 
 	signingSecret := "test-signing-secret"
-	handler = handlers.New(m.SlackClientMock, m.RotationServiceMock, signingSecret)
+	handler = handlers.New(m.SlackClientsMock, m.RotationServiceMock, m.ClockMock, signingSecret)
 
 	return
 }
@@ -43,6 +48,14 @@ func GetHandlerTest(t *testing.T) (m ServiceMocks, handler *handlers.SlackHandle
 func CreateSlackRequest(t *testing.T, command, text, channelID, channelName, userID, teamID, signingSecret string) *http.Request {
 	t.Helper()
 
+	return CreateSlackRequestWithResponseURL(t, command, text, channelID, channelName, userID, teamID, "https://hooks.slack.com/commands/test", signingSecret)
+}
+
+// CreateSlackRequestWithResponseURL creates a properly signed Slack slash command request whose
+// delayed responses are posted to responseURL
+func CreateSlackRequestWithResponseURL(t *testing.T, command, text, channelID, channelName, userID, teamID, responseURL, signingSecret string) *http.Request {
+	t.Helper()
+
 	// Create form data matching Slack's slash command format
 	form := url.Values{
 		"token":        {"test-token"},
@@ -54,7 +67,7 @@ func CreateSlackRequest(t *testing.T, command, text, channelID, channelName, use
 		"user_name":    {"test-user"},
 		"command":      {command},
 		"text":         {text},
-		"response_url": {"https://hooks.slack.com/commands/test"},
+		"response_url": {responseURL},
 		"trigger_id":   {"test-trigger-id"},
 	}
 
@@ -76,6 +89,40 @@ func CreateSlackRequest(t *testing.T, command, text, channelID, channelName, use
 	return req
 }
 
+// CreateInteractionRequest creates a properly signed Slack interaction request with the given JSON payload
+func CreateInteractionRequest(t *testing.T, payload, signingSecret string) *http.Request {
+	t.Helper()
+
+	body := url.Values{"payload": {payload}}.Encode()
+
+	req, err := http.NewRequest(http.MethodPost, "/slack/interactions", strings.NewReader(body))
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", generateSlackSignature(signingSecret, timestamp, body))
+
+	return req
+}
+
+// CreateEventRequest creates a properly signed Slack Events API request with the given JSON body
+func CreateEventRequest(t *testing.T, body, signingSecret string) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/slack/events", strings.NewReader(body))
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/json")
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", generateSlackSignature(signingSecret, timestamp, body))
+
+	return req
+}
+
 func generateSlackSignature(signingSecret, timestamp, body string) string {
 	baseString := fmt.Sprintf("v0:%s:%s", timestamp, body)
 	h := hmac.New(sha256.New, []byte(signingSecret))
@@ -0,0 +1,2 @@
+-- Add timezone field to scheduler_configs table (IANA name, e.g. America/Sao_Paulo)
+ALTER TABLE scheduler_configs ADD COLUMN timezone TEXT DEFAULT 'UTC';
\ No newline at end of file
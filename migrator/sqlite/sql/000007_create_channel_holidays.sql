@@ -0,0 +1,14 @@
+-- Create channel_holidays table for dates without notifications
+-- Dates are stored as YYYY-MM-DD text, end_date is inclusive
+CREATE TABLE IF NOT EXISTS channel_holidays (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    channel_id INTEGER NOT NULL,
+    start_date TEXT NOT NULL,
+    end_date TEXT NOT NULL,
+    description TEXT DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE
+);
+
+-- Create index for efficient lookup of a channel's holidays by date
+CREATE INDEX IF NOT EXISTS idx_channel_holidays_channel_dates ON channel_holidays(channel_id, start_date, end_date);
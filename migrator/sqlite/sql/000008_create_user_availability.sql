@@ -0,0 +1,14 @@
+-- Create user_availability table for out-of-office periods of rotation members
+-- Dates are stored as YYYY-MM-DD text, end_date is inclusive
+CREATE TABLE IF NOT EXISTS user_availability (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    start_date TEXT NOT NULL,
+    end_date TEXT NOT NULL,
+    reason TEXT DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create index for efficient lookup of current and upcoming absences
+CREATE INDEX IF NOT EXISTS idx_user_availability_user_dates ON user_availability(user_id, end_date);
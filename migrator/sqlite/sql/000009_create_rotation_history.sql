@@ -0,0 +1,18 @@
+-- Create rotation_history table to keep every turn of the rotation
+-- slack_user_id and display_name are copied so the history survives member removal
+CREATE TABLE IF NOT EXISTS rotation_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    channel_id INTEGER NOT NULL,
+    user_id INTEGER,
+    slack_user_id TEXT NOT NULL,
+    display_name TEXT NOT NULL,
+    presented_at DATETIME NOT NULL,
+    kind TEXT NOT NULL DEFAULT 'automatic',
+    triggered_by TEXT DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Create index for efficient lookup of the latest turns
+CREATE INDEX IF NOT EXISTS idx_rotation_history_channel_date ON rotation_history(channel_id, presented_at);
@@ -0,0 +1,12 @@
+-- Add position column to users table to persist the rotation order
+ALTER TABLE users ADD COLUMN position INTEGER DEFAULT 0;
+
+-- Backfill positions from the previous implicit order (joined_at, then id)
+UPDATE users SET position = (
+    SELECT COUNT(*) FROM users AS other
+    WHERE other.channel_id = users.channel_id
+    AND (other.joined_at < users.joined_at OR (other.joined_at = users.joined_at AND other.id <= users.id))
+);
+
+-- Create index for ordered lookups
+CREATE INDEX IF NOT EXISTS idx_users_channel_position ON users(channel_id, position);
@@ -0,0 +1,18 @@
+-- Create rotation_swaps table to record turn swaps between two members
+-- swap_date is NULL for immediate swaps, applied_at is NULL while a dated swap is pending
+CREATE TABLE IF NOT EXISTS rotation_swaps (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    channel_id INTEGER NOT NULL,
+    user_a_id INTEGER NOT NULL,
+    user_b_id INTEGER NOT NULL,
+    swap_date TEXT,
+    applied_at DATETIME,
+    created_by TEXT DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_a_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_b_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create index for efficient lookup of pending swaps
+CREATE INDEX IF NOT EXISTS idx_rotation_swaps_channel_pending ON rotation_swaps(channel_id, applied_at, swap_date);
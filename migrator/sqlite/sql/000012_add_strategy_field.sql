@@ -0,0 +1,2 @@
+-- Add strategy field to scheduler_configs table (round-robin, shuffled or fair)
+ALTER TABLE scheduler_configs ADD COLUMN strategy TEXT DEFAULT 'round-robin';
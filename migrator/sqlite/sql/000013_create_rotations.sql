@@ -0,0 +1,151 @@
+-- Create rotations table so a channel can run several independent rotations
+-- The primary rotation receives the commands that do not name a rotation
+CREATE TABLE IF NOT EXISTS rotations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    channel_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    is_primary BOOLEAN DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+    UNIQUE(channel_id, name)
+);
+
+-- Every existing channel gets a primary rotation with the same id as the channel
+-- so the backfills below can copy channel_id into rotation_id
+INSERT INTO rotations (id, channel_id, name, is_primary)
+SELECT id, id, 'default', 1 FROM channels;
+
+-- users, user_availability, rotation_history and rotation_swaps are rebuilt to scope
+-- members by rotation. Tables referencing users are copied aside first so dropping
+-- users never cascades into them.
+CREATE TABLE user_availability_backup AS SELECT * FROM user_availability;
+
+CREATE TABLE rotation_history_backup AS SELECT * FROM rotation_history;
+
+CREATE TABLE rotation_swaps_backup AS SELECT * FROM rotation_swaps;
+
+DROP TABLE user_availability;
+
+DROP TABLE rotation_history;
+
+DROP TABLE rotation_swaps;
+
+CREATE TABLE users_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    channel_id INTEGER NOT NULL,
+    rotation_id INTEGER NOT NULL,
+    slack_user_id TEXT NOT NULL,
+    slack_user_name TEXT NOT NULL,
+    display_name TEXT NOT NULL,
+    is_active BOOLEAN DEFAULT 1,
+    joined_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    last_presenter BOOLEAN DEFAULT 0,
+    position INTEGER DEFAULT 0,
+    FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+    FOREIGN KEY (rotation_id) REFERENCES rotations(id) ON DELETE CASCADE,
+    UNIQUE(rotation_id, slack_user_id)
+);
+
+INSERT INTO users_new (id, channel_id, rotation_id, slack_user_id, slack_user_name, display_name, is_active, joined_at, last_presenter, position)
+SELECT id, channel_id, channel_id, slack_user_id, slack_user_name, display_name, is_active, joined_at, last_presenter, position FROM users;
+
+DROP TABLE users;
+
+ALTER TABLE users_new RENAME TO users;
+
+CREATE INDEX IF NOT EXISTS idx_users_rotation ON users(rotation_id, is_active);
+
+CREATE INDEX IF NOT EXISTS idx_users_rotation_position ON users(rotation_id, position);
+
+CREATE INDEX IF NOT EXISTS idx_users_last_presenter ON users(rotation_id, last_presenter);
+
+CREATE INDEX IF NOT EXISTS idx_users_channel_slack_user ON users(channel_id, slack_user_id);
+
+CREATE TABLE IF NOT EXISTS user_availability (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    start_date TEXT NOT NULL,
+    end_date TEXT NOT NULL,
+    reason TEXT DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+INSERT INTO user_availability (id, user_id, start_date, end_date, reason, created_at)
+SELECT id, user_id, start_date, end_date, reason, created_at FROM user_availability_backup;
+
+CREATE INDEX IF NOT EXISTS idx_user_availability_user_dates ON user_availability(user_id, end_date);
+
+CREATE TABLE IF NOT EXISTS rotation_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    channel_id INTEGER NOT NULL,
+    rotation_id INTEGER NOT NULL,
+    user_id INTEGER,
+    slack_user_id TEXT NOT NULL,
+    display_name TEXT NOT NULL,
+    presented_at DATETIME NOT NULL,
+    kind TEXT NOT NULL DEFAULT 'automatic',
+    triggered_by TEXT DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+    FOREIGN KEY (rotation_id) REFERENCES rotations(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+INSERT INTO rotation_history (id, channel_id, rotation_id, user_id, slack_user_id, display_name, presented_at, kind, triggered_by, created_at)
+SELECT id, channel_id, channel_id, user_id, slack_user_id, display_name, presented_at, kind, triggered_by, created_at FROM rotation_history_backup;
+
+CREATE INDEX IF NOT EXISTS idx_rotation_history_rotation_date ON rotation_history(rotation_id, presented_at);
+
+CREATE TABLE IF NOT EXISTS rotation_swaps (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    channel_id INTEGER NOT NULL,
+    rotation_id INTEGER NOT NULL,
+    user_a_id INTEGER NOT NULL,
+    user_b_id INTEGER NOT NULL,
+    swap_date TEXT,
+    applied_at DATETIME,
+    created_by TEXT DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+    FOREIGN KEY (rotation_id) REFERENCES rotations(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_a_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_b_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+INSERT INTO rotation_swaps (id, channel_id, rotation_id, user_a_id, user_b_id, swap_date, applied_at, created_by, created_at)
+SELECT id, channel_id, channel_id, user_a_id, user_b_id, swap_date, applied_at, created_by, created_at FROM rotation_swaps_backup;
+
+CREATE INDEX IF NOT EXISTS idx_rotation_swaps_pending ON rotation_swaps(rotation_id, applied_at, swap_date);
+
+DROP TABLE user_availability_backup;
+
+DROP TABLE rotation_history_backup;
+
+DROP TABLE rotation_swaps_backup;
+
+-- scheduler_configs is rebuilt so each rotation has its own schedule
+CREATE TABLE scheduler_configs_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    channel_id INTEGER NOT NULL,
+    rotation_id INTEGER NOT NULL UNIQUE,
+    notification_time TEXT DEFAULT '09:00',
+    active_days TEXT DEFAULT '[1,2,3,4,5]',
+    is_enabled BOOLEAN DEFAULT 1,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    role TEXT DEFAULT 'On duty',
+    timezone TEXT DEFAULT 'UTC',
+    strategy TEXT DEFAULT 'round-robin',
+    FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+    FOREIGN KEY (rotation_id) REFERENCES rotations(id) ON DELETE CASCADE
+);
+
+INSERT INTO scheduler_configs_new (id, channel_id, rotation_id, notification_time, active_days, is_enabled, created_at, updated_at, role, timezone, strategy)
+SELECT id, channel_id, channel_id, notification_time, active_days, is_enabled, created_at, updated_at, role, timezone, strategy FROM scheduler_configs;
+
+DROP TABLE scheduler_configs;
+
+ALTER TABLE scheduler_configs_new RENAME TO scheduler_configs;
+
+CREATE INDEX IF NOT EXISTS idx_scheduler_configs_enabled ON scheduler_configs(is_enabled);
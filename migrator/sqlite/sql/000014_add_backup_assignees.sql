@@ -0,0 +1,10 @@
+-- Add assignees and backup_role to scheduler_configs so a turn can have backups
+ALTER TABLE scheduler_configs ADD COLUMN assignees INTEGER DEFAULT 1;
+
+ALTER TABLE scheduler_configs ADD COLUMN backup_role TEXT DEFAULT 'backup';
+
+-- Mark the members that back up the current presenter
+ALTER TABLE users ADD COLUMN last_backup BOOLEAN DEFAULT 0;
+
+-- Backup turns are kept in the history but do not count as turns taken
+ALTER TABLE rotation_history ADD COLUMN is_backup BOOLEAN DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Remember the occurrence of the last reminder sent by each scheduler, NULL until the first one.
+-- Used to catch up on reminders missed while the bot was down and to never send one twice
+ALTER TABLE scheduler_configs ADD COLUMN last_notified_at DATETIME;
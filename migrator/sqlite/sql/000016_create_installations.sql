@@ -0,0 +1,13 @@
+-- Create installations table, one row per workspace that installed the app through OAuth.
+-- Holds the bot token used to talk to that workspace.
+CREATE TABLE IF NOT EXISTS installations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    slack_team_id TEXT UNIQUE NOT NULL,
+    slack_team_name TEXT NOT NULL DEFAULT '',
+    bot_user_id TEXT NOT NULL DEFAULT '',
+    bot_token TEXT NOT NULL,
+    scope TEXT NOT NULL DEFAULT '',
+    installed_by TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
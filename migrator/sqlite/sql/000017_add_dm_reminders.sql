@@ -0,0 +1,7 @@
+-- Direct message reminders to the assignees: off, on (at the notification time) or day-before
+-- (also a heads-up the day before). Members follow the rotation setting while theirs is empty
+ALTER TABLE scheduler_configs ADD COLUMN dm_reminders TEXT NOT NULL DEFAULT 'off';
+ALTER TABLE users ADD COLUMN dm_reminders TEXT NOT NULL DEFAULT '';
+
+-- Occurrence whose heads-up was last sent, so each one goes out once like the reminders
+ALTER TABLE scheduler_configs ADD COLUMN last_heads_up_at DATETIME;
@@ -0,0 +1,2 @@
+-- Keep the channel topic saying who is on duty in the rotation
+ALTER TABLE scheduler_configs ADD COLUMN topic_sync BOOLEAN NOT NULL DEFAULT 0;
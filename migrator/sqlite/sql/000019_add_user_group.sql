@@ -0,0 +1,3 @@
+-- Slack user group (e.g. @team-oncall) whose members are kept as the assignees of the rotation,
+-- empty when the rotation is not linked to one
+ALTER TABLE scheduler_configs ADD COLUMN user_group_id TEXT NOT NULL DEFAULT '';
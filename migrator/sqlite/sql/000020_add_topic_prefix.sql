@@ -0,0 +1,3 @@
+-- Start of the part of the channel topic the bot last wrote for the rotation, e.g. "On duty: ",
+-- empty until it wrote one
+ALTER TABLE scheduler_configs ADD COLUMN topic_prefix TEXT NOT NULL DEFAULT '';
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	contract "github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
 	entity "github.com/diegoclair/slack-rotation-bot/internal/domain/entity"
@@ -42,6 +43,20 @@ func (m *MockDataManager) EXPECT() *MockDataManagerMockRecorder {
 	return m.recorder
 }
 
+// Availability mocks base method.
+func (m *MockDataManager) Availability() contract.AvailabilityRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Availability")
+	ret0, _ := ret[0].(contract.AvailabilityRepo)
+	return ret0
+}
+
+// Availability indicates an expected call of Availability.
+func (mr *MockDataManagerMockRecorder) Availability() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Availability", reflect.TypeOf((*MockDataManager)(nil).Availability))
+}
+
 // Channel mocks base method.
 func (m *MockDataManager) Channel() contract.ChannelRepo {
 	m.ctrl.T.Helper()
@@ -56,6 +71,62 @@ func (mr *MockDataManagerMockRecorder) Channel() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Channel", reflect.TypeOf((*MockDataManager)(nil).Channel))
 }
 
+// History mocks base method.
+func (m *MockDataManager) History() contract.HistoryRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History")
+	ret0, _ := ret[0].(contract.HistoryRepo)
+	return ret0
+}
+
+// History indicates an expected call of History.
+func (mr *MockDataManagerMockRecorder) History() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockDataManager)(nil).History))
+}
+
+// Holiday mocks base method.
+func (m *MockDataManager) Holiday() contract.HolidayRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Holiday")
+	ret0, _ := ret[0].(contract.HolidayRepo)
+	return ret0
+}
+
+// Holiday indicates an expected call of Holiday.
+func (mr *MockDataManagerMockRecorder) Holiday() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Holiday", reflect.TypeOf((*MockDataManager)(nil).Holiday))
+}
+
+// Installation mocks base method.
+func (m *MockDataManager) Installation() contract.InstallationRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Installation")
+	ret0, _ := ret[0].(contract.InstallationRepo)
+	return ret0
+}
+
+// Installation indicates an expected call of Installation.
+func (mr *MockDataManagerMockRecorder) Installation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Installation", reflect.TypeOf((*MockDataManager)(nil).Installation))
+}
+
+// Rotation mocks base method.
+func (m *MockDataManager) Rotation() contract.RotationRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotation")
+	ret0, _ := ret[0].(contract.RotationRepo)
+	return ret0
+}
+
+// Rotation indicates an expected call of Rotation.
+func (mr *MockDataManagerMockRecorder) Rotation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotation", reflect.TypeOf((*MockDataManager)(nil).Rotation))
+}
+
 // Scheduler mocks base method.
 func (m *MockDataManager) Scheduler() contract.SchedulerRepo {
 	m.ctrl.T.Helper()
@@ -70,6 +141,20 @@ func (mr *MockDataManagerMockRecorder) Scheduler() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scheduler", reflect.TypeOf((*MockDataManager)(nil).Scheduler))
 }
 
+// Swap mocks base method.
+func (m *MockDataManager) Swap() contract.SwapRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Swap")
+	ret0, _ := ret[0].(contract.SwapRepo)
+	return ret0
+}
+
+// Swap indicates an expected call of Swap.
+func (mr *MockDataManagerMockRecorder) Swap() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Swap", reflect.TypeOf((*MockDataManager)(nil).Swap))
+}
+
 // User mocks base method.
 func (m *MockDataManager) User() contract.UserRepo {
 	m.ctrl.T.Helper()
@@ -195,6 +280,118 @@ func (mr *MockChannelRepoMockRecorder) Update(channel any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChannelRepo)(nil).Update), channel)
 }
 
+// MockRotationRepo is a mock of RotationRepo interface.
+type MockRotationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRotationRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockRotationRepoMockRecorder is the mock recorder for MockRotationRepo.
+type MockRotationRepoMockRecorder struct {
+	mock *MockRotationRepo
+}
+
+// NewMockRotationRepo creates a new mock instance.
+func NewMockRotationRepo(ctrl *gomock.Controller) *MockRotationRepo {
+	mock := &MockRotationRepo{ctrl: ctrl}
+	mock.recorder = &MockRotationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRotationRepo) EXPECT() *MockRotationRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRotationRepo) Create(rotation *entity.Rotation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", rotation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRotationRepoMockRecorder) Create(rotation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRotationRepo)(nil).Create), rotation)
+}
+
+// Delete mocks base method.
+func (m *MockRotationRepo) Delete(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRotationRepoMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRotationRepo)(nil).Delete), id)
+}
+
+// GetByChannelAndName mocks base method.
+func (m *MockRotationRepo) GetByChannelAndName(channelID int64, name string) (*entity.Rotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByChannelAndName", channelID, name)
+	ret0, _ := ret[0].(*entity.Rotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByChannelAndName indicates an expected call of GetByChannelAndName.
+func (mr *MockRotationRepoMockRecorder) GetByChannelAndName(channelID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChannelAndName", reflect.TypeOf((*MockRotationRepo)(nil).GetByChannelAndName), channelID, name)
+}
+
+// GetByChannelID mocks base method.
+func (m *MockRotationRepo) GetByChannelID(channelID int64) ([]*entity.Rotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByChannelID", channelID)
+	ret0, _ := ret[0].([]*entity.Rotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByChannelID indicates an expected call of GetByChannelID.
+func (mr *MockRotationRepoMockRecorder) GetByChannelID(channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChannelID", reflect.TypeOf((*MockRotationRepo)(nil).GetByChannelID), channelID)
+}
+
+// GetByID mocks base method.
+func (m *MockRotationRepo) GetByID(id int64) (*entity.Rotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*entity.Rotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRotationRepoMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRotationRepo)(nil).GetByID), id)
+}
+
+// GetPrimary mocks base method.
+func (m *MockRotationRepo) GetPrimary(channelID int64) (*entity.Rotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrimary", channelID)
+	ret0, _ := ret[0].(*entity.Rotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrimary indicates an expected call of GetPrimary.
+func (mr *MockRotationRepoMockRecorder) GetPrimary(channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrimary", reflect.TypeOf((*MockRotationRepo)(nil).GetPrimary), channelID)
+}
+
 // MockUserRepo is a mock of UserRepo interface.
 type MockUserRepo struct {
 	ctrl     *gomock.Controller
@@ -219,18 +416,32 @@ func (m *MockUserRepo) EXPECT() *MockUserRepoMockRecorder {
 	return m.recorder
 }
 
+// ClearLastBackups mocks base method.
+func (m *MockUserRepo) ClearLastBackups(rotationID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearLastBackups", rotationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearLastBackups indicates an expected call of ClearLastBackups.
+func (mr *MockUserRepoMockRecorder) ClearLastBackups(rotationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearLastBackups", reflect.TypeOf((*MockUserRepo)(nil).ClearLastBackups), rotationID)
+}
+
 // ClearLastPresenter mocks base method.
-func (m *MockUserRepo) ClearLastPresenter(channelID int64) error {
+func (m *MockUserRepo) ClearLastPresenter(rotationID int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ClearLastPresenter", channelID)
+	ret := m.ctrl.Call(m, "ClearLastPresenter", rotationID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ClearLastPresenter indicates an expected call of ClearLastPresenter.
-func (mr *MockUserRepoMockRecorder) ClearLastPresenter(channelID any) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) ClearLastPresenter(rotationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearLastPresenter", reflect.TypeOf((*MockUserRepo)(nil).ClearLastPresenter), channelID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearLastPresenter", reflect.TypeOf((*MockUserRepo)(nil).ClearLastPresenter), rotationID)
 }
 
 // Create mocks base method.
@@ -261,49 +472,107 @@ func (mr *MockUserRepoMockRecorder) Delete(userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepo)(nil).Delete), userID)
 }
 
-// GetActiveUsersByChannel mocks base method.
-func (m *MockUserRepo) GetActiveUsersByChannel(channelID int64) ([]*entity.User, error) {
+// GetActiveUsersByRotation mocks base method.
+func (m *MockUserRepo) GetActiveUsersByRotation(rotationID int64) ([]*entity.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActiveUsersByChannel", channelID)
+	ret := m.ctrl.Call(m, "GetActiveUsersByRotation", rotationID)
 	ret0, _ := ret[0].([]*entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetActiveUsersByChannel indicates an expected call of GetActiveUsersByChannel.
-func (mr *MockUserRepoMockRecorder) GetActiveUsersByChannel(channelID any) *gomock.Call {
+// GetActiveUsersByRotation indicates an expected call of GetActiveUsersByRotation.
+func (mr *MockUserRepoMockRecorder) GetActiveUsersByRotation(rotationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUsersByChannel", reflect.TypeOf((*MockUserRepo)(nil).GetActiveUsersByChannel), channelID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUsersByRotation", reflect.TypeOf((*MockUserRepo)(nil).GetActiveUsersByRotation), rotationID)
 }
 
-// GetByChannelAndSlackID mocks base method.
-func (m *MockUserRepo) GetByChannelAndSlackID(channelID int64, slackUserID string) (*entity.User, error) {
+// GetByRotationAndSlackID mocks base method.
+func (m *MockUserRepo) GetByRotationAndSlackID(rotationID int64, slackUserID string) (*entity.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByChannelAndSlackID", channelID, slackUserID)
+	ret := m.ctrl.Call(m, "GetByRotationAndSlackID", rotationID, slackUserID)
 	ret0, _ := ret[0].(*entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByChannelAndSlackID indicates an expected call of GetByChannelAndSlackID.
-func (mr *MockUserRepoMockRecorder) GetByChannelAndSlackID(channelID, slackUserID any) *gomock.Call {
+// GetByRotationAndSlackID indicates an expected call of GetByRotationAndSlackID.
+func (mr *MockUserRepoMockRecorder) GetByRotationAndSlackID(rotationID, slackUserID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChannelAndSlackID", reflect.TypeOf((*MockUserRepo)(nil).GetByChannelAndSlackID), channelID, slackUserID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRotationAndSlackID", reflect.TypeOf((*MockUserRepo)(nil).GetByRotationAndSlackID), rotationID, slackUserID)
+}
+
+// GetBySlackUserID mocks base method.
+func (m *MockUserRepo) GetBySlackUserID(slackUserID string) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySlackUserID", slackUserID)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySlackUserID indicates an expected call of GetBySlackUserID.
+func (mr *MockUserRepoMockRecorder) GetBySlackUserID(slackUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlackUserID", reflect.TypeOf((*MockUserRepo)(nil).GetBySlackUserID), slackUserID)
+}
+
+// GetLastBackups mocks base method.
+func (m *MockUserRepo) GetLastBackups(rotationID int64) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastBackups", rotationID)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastBackups indicates an expected call of GetLastBackups.
+func (mr *MockUserRepoMockRecorder) GetLastBackups(rotationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastBackups", reflect.TypeOf((*MockUserRepo)(nil).GetLastBackups), rotationID)
 }
 
 // GetLastPresenter mocks base method.
-func (m *MockUserRepo) GetLastPresenter(channelID int64) (*entity.User, error) {
+func (m *MockUserRepo) GetLastPresenter(rotationID int64) (*entity.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLastPresenter", channelID)
+	ret := m.ctrl.Call(m, "GetLastPresenter", rotationID)
 	ret0, _ := ret[0].(*entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLastPresenter indicates an expected call of GetLastPresenter.
-func (mr *MockUserRepoMockRecorder) GetLastPresenter(channelID any) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) GetLastPresenter(rotationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastPresenter", reflect.TypeOf((*MockUserRepo)(nil).GetLastPresenter), rotationID)
+}
+
+// SetDMReminders mocks base method.
+func (m *MockUserRepo) SetDMReminders(userID int64, dmReminders string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDMReminders", userID, dmReminders)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDMReminders indicates an expected call of SetDMReminders.
+func (mr *MockUserRepoMockRecorder) SetDMReminders(userID, dmReminders any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDMReminders", reflect.TypeOf((*MockUserRepo)(nil).SetDMReminders), userID, dmReminders)
+}
+
+// SetLastBackup mocks base method.
+func (m *MockUserRepo) SetLastBackup(userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLastBackup", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLastBackup indicates an expected call of SetLastBackup.
+func (mr *MockUserRepoMockRecorder) SetLastBackup(userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastPresenter", reflect.TypeOf((*MockUserRepo)(nil).GetLastPresenter), channelID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastBackup", reflect.TypeOf((*MockUserRepo)(nil).SetLastBackup), userID)
 }
 
 // SetLastPresenter mocks base method.
@@ -320,6 +589,20 @@ func (mr *MockUserRepoMockRecorder) SetLastPresenter(userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastPresenter", reflect.TypeOf((*MockUserRepo)(nil).SetLastPresenter), userID)
 }
 
+// UpdatePositions mocks base method.
+func (m *MockUserRepo) UpdatePositions(userIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePositions", userIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePositions indicates an expected call of UpdatePositions.
+func (mr *MockUserRepoMockRecorder) UpdatePositions(userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePositions", reflect.TypeOf((*MockUserRepo)(nil).UpdatePositions), userIDs)
+}
+
 // MockSchedulerRepo is a mock of SchedulerRepo interface.
 type MockSchedulerRepo struct {
 	ctrl     *gomock.Controller
@@ -359,17 +642,17 @@ func (mr *MockSchedulerRepoMockRecorder) Create(scheduler any) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockSchedulerRepo) Delete(channelID int64) error {
+func (m *MockSchedulerRepo) Delete(rotationID int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", channelID)
+	ret := m.ctrl.Call(m, "Delete", rotationID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockSchedulerRepoMockRecorder) Delete(channelID any) *gomock.Call {
+func (mr *MockSchedulerRepoMockRecorder) Delete(rotationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSchedulerRepo)(nil).Delete), channelID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSchedulerRepo)(nil).Delete), rotationID)
 }
 
 // GetByChannelID mocks base method.
@@ -387,6 +670,21 @@ func (mr *MockSchedulerRepoMockRecorder) GetByChannelID(channelID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChannelID", reflect.TypeOf((*MockSchedulerRepo)(nil).GetByChannelID), channelID)
 }
 
+// GetByRotationID mocks base method.
+func (m *MockSchedulerRepo) GetByRotationID(rotationID int64) (*entity.Scheduler, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRotationID", rotationID)
+	ret0, _ := ret[0].(*entity.Scheduler)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByRotationID indicates an expected call of GetByRotationID.
+func (mr *MockSchedulerRepoMockRecorder) GetByRotationID(rotationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRotationID", reflect.TypeOf((*MockSchedulerRepo)(nil).GetByRotationID), rotationID)
+}
+
 // GetEnabled mocks base method.
 func (m *MockSchedulerRepo) GetEnabled() ([]*entity.Scheduler, error) {
 	m.ctrl.T.Helper()
@@ -402,18 +700,77 @@ func (mr *MockSchedulerRepoMockRecorder) GetEnabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabled", reflect.TypeOf((*MockSchedulerRepo)(nil).GetEnabled))
 }
 
+// GetEnabledByChannelID mocks base method.
+func (m *MockSchedulerRepo) GetEnabledByChannelID(channelID int64) ([]*entity.Scheduler, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabledByChannelID", channelID)
+	ret0, _ := ret[0].([]*entity.Scheduler)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabledByChannelID indicates an expected call of GetEnabledByChannelID.
+func (mr *MockSchedulerRepoMockRecorder) GetEnabledByChannelID(channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledByChannelID", reflect.TypeOf((*MockSchedulerRepo)(nil).GetEnabledByChannelID), channelID)
+}
+
+// MarkHeadsUp mocks base method.
+func (m *MockSchedulerRepo) MarkHeadsUp(rotationID int64, occurrence time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkHeadsUp", rotationID, occurrence)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkHeadsUp indicates an expected call of MarkHeadsUp.
+func (mr *MockSchedulerRepoMockRecorder) MarkHeadsUp(rotationID, occurrence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkHeadsUp", reflect.TypeOf((*MockSchedulerRepo)(nil).MarkHeadsUp), rotationID, occurrence)
+}
+
+// MarkNotified mocks base method.
+func (m *MockSchedulerRepo) MarkNotified(rotationID int64, occurrence time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotified", rotationID, occurrence)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNotified indicates an expected call of MarkNotified.
+func (mr *MockSchedulerRepoMockRecorder) MarkNotified(rotationID, occurrence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotified", reflect.TypeOf((*MockSchedulerRepo)(nil).MarkNotified), rotationID, occurrence)
+}
+
 // SetEnabled mocks base method.
-func (m *MockSchedulerRepo) SetEnabled(channelID int64, enabled bool) error {
+func (m *MockSchedulerRepo) SetEnabled(rotationID int64, enabled bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetEnabled", channelID, enabled)
+	ret := m.ctrl.Call(m, "SetEnabled", rotationID, enabled)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SetEnabled indicates an expected call of SetEnabled.
-func (mr *MockSchedulerRepoMockRecorder) SetEnabled(channelID, enabled any) *gomock.Call {
+func (mr *MockSchedulerRepoMockRecorder) SetEnabled(rotationID, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnabled", reflect.TypeOf((*MockSchedulerRepo)(nil).SetEnabled), rotationID, enabled)
+}
+
+// SetTopicPrefix mocks base method.
+func (m *MockSchedulerRepo) SetTopicPrefix(rotationID int64, prefix string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTopicPrefix", rotationID, prefix)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTopicPrefix indicates an expected call of SetTopicPrefix.
+func (mr *MockSchedulerRepoMockRecorder) SetTopicPrefix(rotationID, prefix any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnabled", reflect.TypeOf((*MockSchedulerRepo)(nil).SetEnabled), channelID, enabled)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTopicPrefix", reflect.TypeOf((*MockSchedulerRepo)(nil).SetTopicPrefix), rotationID, prefix)
 }
 
 // Update mocks base method.
@@ -429,3 +786,312 @@ func (mr *MockSchedulerRepoMockRecorder) Update(scheduler any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSchedulerRepo)(nil).Update), scheduler)
 }
+
+// MockHolidayRepo is a mock of HolidayRepo interface.
+type MockHolidayRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockHolidayRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockHolidayRepoMockRecorder is the mock recorder for MockHolidayRepo.
+type MockHolidayRepoMockRecorder struct {
+	mock *MockHolidayRepo
+}
+
+// NewMockHolidayRepo creates a new mock instance.
+func NewMockHolidayRepo(ctrl *gomock.Controller) *MockHolidayRepo {
+	mock := &MockHolidayRepo{ctrl: ctrl}
+	mock.recorder = &MockHolidayRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHolidayRepo) EXPECT() *MockHolidayRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockHolidayRepo) Create(holiday *entity.Holiday) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", holiday)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHolidayRepoMockRecorder) Create(holiday any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHolidayRepo)(nil).Create), holiday)
+}
+
+// DeleteByRange mocks base method.
+func (m *MockHolidayRepo) DeleteByRange(channelID int64, startDate, endDate time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByRange", channelID, startDate, endDate)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByRange indicates an expected call of DeleteByRange.
+func (mr *MockHolidayRepoMockRecorder) DeleteByRange(channelID, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByRange", reflect.TypeOf((*MockHolidayRepo)(nil).DeleteByRange), channelID, startDate, endDate)
+}
+
+// GetByChannelID mocks base method.
+func (m *MockHolidayRepo) GetByChannelID(channelID int64) ([]*entity.Holiday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByChannelID", channelID)
+	ret0, _ := ret[0].([]*entity.Holiday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByChannelID indicates an expected call of GetByChannelID.
+func (mr *MockHolidayRepoMockRecorder) GetByChannelID(channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChannelID", reflect.TypeOf((*MockHolidayRepo)(nil).GetByChannelID), channelID)
+}
+
+// MockAvailabilityRepo is a mock of AvailabilityRepo interface.
+type MockAvailabilityRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAvailabilityRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockAvailabilityRepoMockRecorder is the mock recorder for MockAvailabilityRepo.
+type MockAvailabilityRepoMockRecorder struct {
+	mock *MockAvailabilityRepo
+}
+
+// NewMockAvailabilityRepo creates a new mock instance.
+func NewMockAvailabilityRepo(ctrl *gomock.Controller) *MockAvailabilityRepo {
+	mock := &MockAvailabilityRepo{ctrl: ctrl}
+	mock.recorder = &MockAvailabilityRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAvailabilityRepo) EXPECT() *MockAvailabilityRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAvailabilityRepo) Create(availability *entity.Availability) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", availability)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAvailabilityRepoMockRecorder) Create(availability any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAvailabilityRepo)(nil).Create), availability)
+}
+
+// GetUpcomingByChannel mocks base method.
+func (m *MockAvailabilityRepo) GetUpcomingByChannel(channelID int64, from time.Time) ([]*entity.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpcomingByChannel", channelID, from)
+	ret0, _ := ret[0].([]*entity.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpcomingByChannel indicates an expected call of GetUpcomingByChannel.
+func (mr *MockAvailabilityRepoMockRecorder) GetUpcomingByChannel(channelID, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpcomingByChannel", reflect.TypeOf((*MockAvailabilityRepo)(nil).GetUpcomingByChannel), channelID, from)
+}
+
+// MockHistoryRepo is a mock of HistoryRepo interface.
+type MockHistoryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockHistoryRepoMockRecorder is the mock recorder for MockHistoryRepo.
+type MockHistoryRepoMockRecorder struct {
+	mock *MockHistoryRepo
+}
+
+// NewMockHistoryRepo creates a new mock instance.
+func NewMockHistoryRepo(ctrl *gomock.Controller) *MockHistoryRepo {
+	mock := &MockHistoryRepo{ctrl: ctrl}
+	mock.recorder = &MockHistoryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryRepo) EXPECT() *MockHistoryRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockHistoryRepo) Create(entry *entity.RotationHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHistoryRepoMockRecorder) Create(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHistoryRepo)(nil).Create), entry)
+}
+
+// GetByRotationID mocks base method.
+func (m *MockHistoryRepo) GetByRotationID(rotationID int64, limit int) ([]*entity.RotationHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRotationID", rotationID, limit)
+	ret0, _ := ret[0].([]*entity.RotationHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByRotationID indicates an expected call of GetByRotationID.
+func (mr *MockHistoryRepoMockRecorder) GetByRotationID(rotationID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRotationID", reflect.TypeOf((*MockHistoryRepo)(nil).GetByRotationID), rotationID, limit)
+}
+
+// GetTurnStats mocks base method.
+func (m *MockHistoryRepo) GetTurnStats(rotationID int64) ([]*entity.TurnStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTurnStats", rotationID)
+	ret0, _ := ret[0].([]*entity.TurnStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTurnStats indicates an expected call of GetTurnStats.
+func (mr *MockHistoryRepoMockRecorder) GetTurnStats(rotationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTurnStats", reflect.TypeOf((*MockHistoryRepo)(nil).GetTurnStats), rotationID)
+}
+
+// MockSwapRepo is a mock of SwapRepo interface.
+type MockSwapRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSwapRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockSwapRepoMockRecorder is the mock recorder for MockSwapRepo.
+type MockSwapRepoMockRecorder struct {
+	mock *MockSwapRepo
+}
+
+// NewMockSwapRepo creates a new mock instance.
+func NewMockSwapRepo(ctrl *gomock.Controller) *MockSwapRepo {
+	mock := &MockSwapRepo{ctrl: ctrl}
+	mock.recorder = &MockSwapRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSwapRepo) EXPECT() *MockSwapRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSwapRepo) Create(swap *entity.Swap) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", swap)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSwapRepoMockRecorder) Create(swap any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSwapRepo)(nil).Create), swap)
+}
+
+// GetPendingByRotation mocks base method.
+func (m *MockSwapRepo) GetPendingByRotation(rotationID int64, until time.Time) ([]*entity.Swap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingByRotation", rotationID, until)
+	ret0, _ := ret[0].([]*entity.Swap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingByRotation indicates an expected call of GetPendingByRotation.
+func (mr *MockSwapRepoMockRecorder) GetPendingByRotation(rotationID, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingByRotation", reflect.TypeOf((*MockSwapRepo)(nil).GetPendingByRotation), rotationID, until)
+}
+
+// MarkApplied mocks base method.
+func (m *MockSwapRepo) MarkApplied(swapID int64, appliedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkApplied", swapID, appliedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkApplied indicates an expected call of MarkApplied.
+func (mr *MockSwapRepoMockRecorder) MarkApplied(swapID, appliedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkApplied", reflect.TypeOf((*MockSwapRepo)(nil).MarkApplied), swapID, appliedAt)
+}
+
+// MockInstallationRepo is a mock of InstallationRepo interface.
+type MockInstallationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockInstallationRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockInstallationRepoMockRecorder is the mock recorder for MockInstallationRepo.
+type MockInstallationRepoMockRecorder struct {
+	mock *MockInstallationRepo
+}
+
+// NewMockInstallationRepo creates a new mock instance.
+func NewMockInstallationRepo(ctrl *gomock.Controller) *MockInstallationRepo {
+	mock := &MockInstallationRepo{ctrl: ctrl}
+	mock.recorder = &MockInstallationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInstallationRepo) EXPECT() *MockInstallationRepoMockRecorder {
+	return m.recorder
+}
+
+// GetByTeamID mocks base method.
+func (m *MockInstallationRepo) GetByTeamID(slackTeamID string) (*entity.Installation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTeamID", slackTeamID)
+	ret0, _ := ret[0].(*entity.Installation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTeamID indicates an expected call of GetByTeamID.
+func (mr *MockInstallationRepoMockRecorder) GetByTeamID(slackTeamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTeamID", reflect.TypeOf((*MockInstallationRepo)(nil).GetByTeamID), slackTeamID)
+}
+
+// Save mocks base method.
+func (m *MockInstallationRepo) Save(installation *entity.Installation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", installation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockInstallationRepoMockRecorder) Save(installation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockInstallationRepo)(nil).Save), installation)
+}
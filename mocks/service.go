@@ -41,18 +41,62 @@ func (m *MockRotationService) EXPECT() *MockRotationServiceMockRecorder {
 	return m.recorder
 }
 
+// AddHoliday mocks base method.
+func (m *MockRotationService) AddHoliday(channelID int64, dateRange, description string) (*entity.Holiday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddHoliday", channelID, dateRange, description)
+	ret0, _ := ret[0].(*entity.Holiday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddHoliday indicates an expected call of AddHoliday.
+func (mr *MockRotationServiceMockRecorder) AddHoliday(channelID, dateRange, description any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHoliday", reflect.TypeOf((*MockRotationService)(nil).AddHoliday), channelID, dateRange, description)
+}
+
 // AddUser mocks base method.
-func (m *MockRotationService) AddUser(channelID int64, slackUserID string) error {
+func (m *MockRotationService) AddUser(ctx context.Context, rotationID int64, slackUserID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddUser", channelID, slackUserID)
+	ret := m.ctrl.Call(m, "AddUser", ctx, rotationID, slackUserID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddUser indicates an expected call of AddUser.
-func (mr *MockRotationServiceMockRecorder) AddUser(channelID, slackUserID any) *gomock.Call {
+func (mr *MockRotationServiceMockRecorder) AddUser(ctx, rotationID, slackUserID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockRotationService)(nil).AddUser), channelID, slackUserID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockRotationService)(nil).AddUser), ctx, rotationID, slackUserID)
+}
+
+// CreateRotation mocks base method.
+func (m *MockRotationService) CreateRotation(channelID int64, name string) (*entity.Rotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRotation", channelID, name)
+	ret0, _ := ret[0].(*entity.Rotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRotation indicates an expected call of CreateRotation.
+func (mr *MockRotationServiceMockRecorder) CreateRotation(channelID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRotation", reflect.TypeOf((*MockRotationService)(nil).CreateRotation), channelID, name)
+}
+
+// DeleteRotation mocks base method.
+func (m *MockRotationService) DeleteRotation(channelID int64, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRotation", channelID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRotation indicates an expected call of DeleteRotation.
+func (mr *MockRotationServiceMockRecorder) DeleteRotation(channelID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRotation", reflect.TypeOf((*MockRotationService)(nil).DeleteRotation), channelID, name)
 }
 
 // GetChannelConfig mocks base method.
@@ -85,120 +129,413 @@ func (mr *MockRotationServiceMockRecorder) GetChannelStatus(channelID any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelStatus", reflect.TypeOf((*MockRotationService)(nil).GetChannelStatus), channelID)
 }
 
+// GetCurrentBackups mocks base method.
+func (m *MockRotationService) GetCurrentBackups(rotationID int64) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentBackups", rotationID)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentBackups indicates an expected call of GetCurrentBackups.
+func (mr *MockRotationServiceMockRecorder) GetCurrentBackups(rotationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentBackups", reflect.TypeOf((*MockRotationService)(nil).GetCurrentBackups), rotationID)
+}
+
 // GetCurrentPresenter mocks base method.
-func (m *MockRotationService) GetCurrentPresenter(channelID int64) (*entity.User, error) {
+func (m *MockRotationService) GetCurrentPresenter(rotationID int64) (*entity.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCurrentPresenter", channelID)
+	ret := m.ctrl.Call(m, "GetCurrentPresenter", rotationID)
 	ret0, _ := ret[0].(*entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCurrentPresenter indicates an expected call of GetCurrentPresenter.
-func (mr *MockRotationServiceMockRecorder) GetCurrentPresenter(channelID any) *gomock.Call {
+func (mr *MockRotationServiceMockRecorder) GetCurrentPresenter(rotationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentPresenter", reflect.TypeOf((*MockRotationService)(nil).GetCurrentPresenter), channelID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentPresenter", reflect.TypeOf((*MockRotationService)(nil).GetCurrentPresenter), rotationID)
 }
 
-// GetNextPresenter mocks base method.
-func (m *MockRotationService) GetNextPresenter(channelID int64) (*entity.User, error) {
+// GetHistory mocks base method.
+func (m *MockRotationService) GetHistory(rotationID int64, limit int) ([]*entity.RotationHistory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNextPresenter", channelID)
-	ret0, _ := ret[0].(*entity.User)
+	ret := m.ctrl.Call(m, "GetHistory", rotationID, limit)
+	ret0, _ := ret[0].([]*entity.RotationHistory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetNextPresenter indicates an expected call of GetNextPresenter.
-func (mr *MockRotationServiceMockRecorder) GetNextPresenter(channelID any) *gomock.Call {
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockRotationServiceMockRecorder) GetHistory(rotationID, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextPresenter", reflect.TypeOf((*MockRotationService)(nil).GetNextPresenter), channelID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockRotationService)(nil).GetHistory), rotationID, limit)
+}
+
+// GetNextAssignees mocks base method.
+func (m *MockRotationService) GetNextAssignees(rotationID int64) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextAssignees", rotationID)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextAssignees indicates an expected call of GetNextAssignees.
+func (mr *MockRotationServiceMockRecorder) GetNextAssignees(rotationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextAssignees", reflect.TypeOf((*MockRotationService)(nil).GetNextAssignees), rotationID)
+}
+
+// GetRotation mocks base method.
+func (m *MockRotationService) GetRotation(channelID int64, name string) (*entity.Rotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRotation", channelID, name)
+	ret0, _ := ret[0].(*entity.Rotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRotation indicates an expected call of GetRotation.
+func (mr *MockRotationServiceMockRecorder) GetRotation(channelID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRotation", reflect.TypeOf((*MockRotationService)(nil).GetRotation), channelID, name)
+}
+
+// GetRotationByID mocks base method.
+func (m *MockRotationService) GetRotationByID(rotationID int64) (*entity.Rotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRotationByID", rotationID)
+	ret0, _ := ret[0].(*entity.Rotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRotationByID indicates an expected call of GetRotationByID.
+func (mr *MockRotationServiceMockRecorder) GetRotationByID(rotationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRotationByID", reflect.TypeOf((*MockRotationService)(nil).GetRotationByID), rotationID)
+}
+
+// GetSchedule mocks base method.
+func (m *MockRotationService) GetSchedule(rotationID int64, count int) ([]entity.ScheduledTurn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", rotationID, count)
+	ret0, _ := ret[0].([]entity.ScheduledTurn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockRotationServiceMockRecorder) GetSchedule(rotationID, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockRotationService)(nil).GetSchedule), rotationID, count)
 }
 
 // GetSchedulerConfig mocks base method.
-func (m *MockRotationService) GetSchedulerConfig(channelID int64) (*entity.Scheduler, error) {
+func (m *MockRotationService) GetSchedulerConfig(rotationID int64) (*entity.Scheduler, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSchedulerConfig", channelID)
+	ret := m.ctrl.Call(m, "GetSchedulerConfig", rotationID)
 	ret0, _ := ret[0].(*entity.Scheduler)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSchedulerConfig indicates an expected call of GetSchedulerConfig.
-func (mr *MockRotationServiceMockRecorder) GetSchedulerConfig(channelID any) *gomock.Call {
+func (mr *MockRotationServiceMockRecorder) GetSchedulerConfig(rotationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedulerConfig", reflect.TypeOf((*MockRotationService)(nil).GetSchedulerConfig), rotationID)
+}
+
+// GetUserRotations mocks base method.
+func (m *MockRotationService) GetUserRotations(slackUserID string) ([]*entity.UserRotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRotations", slackUserID)
+	ret0, _ := ret[0].([]*entity.UserRotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRotations indicates an expected call of GetUserRotations.
+func (mr *MockRotationServiceMockRecorder) GetUserRotations(slackUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRotations", reflect.TypeOf((*MockRotationService)(nil).GetUserRotations), slackUserID)
+}
+
+// ImportHolidays mocks base method.
+func (m *MockRotationService) ImportHolidays(ctx context.Context, channelID int64, icsBody string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportHolidays", ctx, channelID, icsBody)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportHolidays indicates an expected call of ImportHolidays.
+func (mr *MockRotationServiceMockRecorder) ImportHolidays(ctx, channelID, icsBody any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportHolidays", reflect.TypeOf((*MockRotationService)(nil).ImportHolidays), ctx, channelID, icsBody)
+}
+
+// ListAbsences mocks base method.
+func (m *MockRotationService) ListAbsences(channelID int64) ([]*entity.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAbsences", channelID)
+	ret0, _ := ret[0].([]*entity.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAbsences indicates an expected call of ListAbsences.
+func (mr *MockRotationServiceMockRecorder) ListAbsences(channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAbsences", reflect.TypeOf((*MockRotationService)(nil).ListAbsences), channelID)
+}
+
+// ListHolidays mocks base method.
+func (m *MockRotationService) ListHolidays(channelID int64) ([]*entity.Holiday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHolidays", channelID)
+	ret0, _ := ret[0].([]*entity.Holiday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHolidays indicates an expected call of ListHolidays.
+func (mr *MockRotationServiceMockRecorder) ListHolidays(channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHolidays", reflect.TypeOf((*MockRotationService)(nil).ListHolidays), channelID)
+}
+
+// ListRotations mocks base method.
+func (m *MockRotationService) ListRotations(channelID int64) ([]*entity.Rotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRotations", channelID)
+	ret0, _ := ret[0].([]*entity.Rotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRotations indicates an expected call of ListRotations.
+func (mr *MockRotationServiceMockRecorder) ListRotations(channelID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedulerConfig", reflect.TypeOf((*MockRotationService)(nil).GetSchedulerConfig), channelID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRotations", reflect.TypeOf((*MockRotationService)(nil).ListRotations), channelID)
 }
 
 // ListUsers mocks base method.
-func (m *MockRotationService) ListUsers(channelID int64) ([]*entity.User, error) {
+func (m *MockRotationService) ListUsers(rotationID int64) ([]*entity.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", channelID)
+	ret := m.ctrl.Call(m, "ListUsers", rotationID)
 	ret0, _ := ret[0].([]*entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListUsers indicates an expected call of ListUsers.
-func (mr *MockRotationServiceMockRecorder) ListUsers(channelID any) *gomock.Call {
+func (mr *MockRotationServiceMockRecorder) ListUsers(rotationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockRotationService)(nil).ListUsers), channelID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockRotationService)(nil).ListUsers), rotationID)
+}
+
+// MoveUser mocks base method.
+func (m *MockRotationService) MoveUser(ctx context.Context, rotationID int64, slackUserID string, position int) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveUser", ctx, rotationID, slackUserID, position)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveUser indicates an expected call of MoveUser.
+func (mr *MockRotationServiceMockRecorder) MoveUser(ctx, rotationID, slackUserID, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveUser", reflect.TypeOf((*MockRotationService)(nil).MoveUser), ctx, rotationID, slackUserID, position)
 }
 
 // PauseScheduler mocks base method.
-func (m *MockRotationService) PauseScheduler(channelID int64) error {
+func (m *MockRotationService) PauseScheduler(rotationID int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PauseScheduler", channelID)
+	ret := m.ctrl.Call(m, "PauseScheduler", rotationID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // PauseScheduler indicates an expected call of PauseScheduler.
-func (mr *MockRotationServiceMockRecorder) PauseScheduler(channelID any) *gomock.Call {
+func (mr *MockRotationServiceMockRecorder) PauseScheduler(rotationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseScheduler", reflect.TypeOf((*MockRotationService)(nil).PauseScheduler), channelID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseScheduler", reflect.TypeOf((*MockRotationService)(nil).PauseScheduler), rotationID)
 }
 
 // RecordPresentation mocks base method.
-func (m *MockRotationService) RecordPresentation(ctx context.Context, channelID, userID int64) error {
+func (m *MockRotationService) RecordPresentation(ctx context.Context, rotationID int64, users []*entity.User, kind, triggeredBy string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RecordPresentation", ctx, channelID, userID)
+	ret := m.ctrl.Call(m, "RecordPresentation", ctx, rotationID, users, kind, triggeredBy)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RecordPresentation indicates an expected call of RecordPresentation.
-func (mr *MockRotationServiceMockRecorder) RecordPresentation(ctx, channelID, userID any) *gomock.Call {
+func (mr *MockRotationServiceMockRecorder) RecordPresentation(ctx, rotationID, users, kind, triggeredBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPresentation", reflect.TypeOf((*MockRotationService)(nil).RecordPresentation), ctx, rotationID, users, kind, triggeredBy)
+}
+
+// RemoveDeactivatedUser mocks base method.
+func (m *MockRotationService) RemoveDeactivatedUser(slackUserID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDeactivatedUser", slackUserID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDeactivatedUser indicates an expected call of RemoveDeactivatedUser.
+func (mr *MockRotationServiceMockRecorder) RemoveDeactivatedUser(slackUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDeactivatedUser", reflect.TypeOf((*MockRotationService)(nil).RemoveDeactivatedUser), slackUserID)
+}
+
+// RemoveHoliday mocks base method.
+func (m *MockRotationService) RemoveHoliday(channelID int64, dateRange string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveHoliday", channelID, dateRange)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveHoliday indicates an expected call of RemoveHoliday.
+func (mr *MockRotationServiceMockRecorder) RemoveHoliday(channelID, dateRange any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveHoliday", reflect.TypeOf((*MockRotationService)(nil).RemoveHoliday), channelID, dateRange)
+}
+
+// RemoveLeftMember mocks base method.
+func (m *MockRotationService) RemoveLeftMember(slackChannelID, slackUserID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveLeftMember", slackChannelID, slackUserID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveLeftMember indicates an expected call of RemoveLeftMember.
+func (mr *MockRotationServiceMockRecorder) RemoveLeftMember(slackChannelID, slackUserID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPresentation", reflect.TypeOf((*MockRotationService)(nil).RecordPresentation), ctx, channelID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLeftMember", reflect.TypeOf((*MockRotationService)(nil).RemoveLeftMember), slackChannelID, slackUserID)
 }
 
 // RemoveUser mocks base method.
-func (m *MockRotationService) RemoveUser(channelID int64, slackUserID string) error {
+func (m *MockRotationService) RemoveUser(rotationID int64, slackUserID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveUser", channelID, slackUserID)
+	ret := m.ctrl.Call(m, "RemoveUser", rotationID, slackUserID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveUser indicates an expected call of RemoveUser.
-func (mr *MockRotationServiceMockRecorder) RemoveUser(channelID, slackUserID any) *gomock.Call {
+func (mr *MockRotationServiceMockRecorder) RemoveUser(rotationID, slackUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUser", reflect.TypeOf((*MockRotationService)(nil).RemoveUser), rotationID, slackUserID)
+}
+
+// RenameChannel mocks base method.
+func (m *MockRotationService) RenameChannel(slackChannelID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameChannel", slackChannelID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameChannel indicates an expected call of RenameChannel.
+func (mr *MockRotationServiceMockRecorder) RenameChannel(slackChannelID, name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUser", reflect.TypeOf((*MockRotationService)(nil).RemoveUser), channelID, slackUserID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameChannel", reflect.TypeOf((*MockRotationService)(nil).RenameChannel), slackChannelID, name)
 }
 
 // ResumeScheduler mocks base method.
-func (m *MockRotationService) ResumeScheduler(channelID int64) error {
+func (m *MockRotationService) ResumeScheduler(rotationID int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResumeScheduler", channelID)
+	ret := m.ctrl.Call(m, "ResumeScheduler", rotationID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ResumeScheduler indicates an expected call of ResumeScheduler.
-func (mr *MockRotationServiceMockRecorder) ResumeScheduler(channelID any) *gomock.Call {
+func (mr *MockRotationServiceMockRecorder) ResumeScheduler(rotationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeScheduler", reflect.TypeOf((*MockRotationService)(nil).ResumeScheduler), channelID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeScheduler", reflect.TypeOf((*MockRotationService)(nil).ResumeScheduler), rotationID)
+}
+
+// SaveInstallation mocks base method.
+func (m *MockRotationService) SaveInstallation(installation *entity.Installation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveInstallation", installation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveInstallation indicates an expected call of SaveInstallation.
+func (mr *MockRotationServiceMockRecorder) SaveInstallation(installation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInstallation", reflect.TypeOf((*MockRotationService)(nil).SaveInstallation), installation)
+}
+
+// SetChannelActive mocks base method.
+func (m *MockRotationService) SetChannelActive(slackChannelID string, active bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChannelActive", slackChannelID, active)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChannelActive indicates an expected call of SetChannelActive.
+func (mr *MockRotationServiceMockRecorder) SetChannelActive(slackChannelID, active any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChannelActive", reflect.TypeOf((*MockRotationService)(nil).SetChannelActive), slackChannelID, active)
+}
+
+// SetDMReminders mocks base method.
+func (m *MockRotationService) SetDMReminders(rotationID int64, slackUserID, value string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDMReminders", rotationID, slackUserID, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDMReminders indicates an expected call of SetDMReminders.
+func (mr *MockRotationServiceMockRecorder) SetDMReminders(rotationID, slackUserID, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDMReminders", reflect.TypeOf((*MockRotationService)(nil).SetDMReminders), rotationID, slackUserID, value)
+}
+
+// SetOrder mocks base method.
+func (m *MockRotationService) SetOrder(ctx context.Context, rotationID int64, slackUserIDs []string) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOrder", ctx, rotationID, slackUserIDs)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOrder indicates an expected call of SetOrder.
+func (mr *MockRotationServiceMockRecorder) SetOrder(ctx, rotationID, slackUserIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrder", reflect.TypeOf((*MockRotationService)(nil).SetOrder), ctx, rotationID, slackUserIDs)
+}
+
+// SetUserAway mocks base method.
+func (m *MockRotationService) SetUserAway(rotationID int64, slackUserID, dateRange, reason string) (*entity.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserAway", rotationID, slackUserID, dateRange, reason)
+	ret0, _ := ret[0].(*entity.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserAway indicates an expected call of SetUserAway.
+func (mr *MockRotationServiceMockRecorder) SetUserAway(rotationID, slackUserID, dateRange, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserAway", reflect.TypeOf((*MockRotationService)(nil).SetUserAway), rotationID, slackUserID, dateRange, reason)
 }
 
 // SetupChannel mocks base method.
@@ -217,16 +554,60 @@ func (mr *MockRotationServiceMockRecorder) SetupChannel(slackChannelID, channelN
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetupChannel", reflect.TypeOf((*MockRotationService)(nil).SetupChannel), slackChannelID, channelName, teamID)
 }
 
+// ShuffleUsers mocks base method.
+func (m *MockRotationService) ShuffleUsers(ctx context.Context, rotationID int64) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShuffleUsers", ctx, rotationID)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShuffleUsers indicates an expected call of ShuffleUsers.
+func (mr *MockRotationServiceMockRecorder) ShuffleUsers(ctx, rotationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShuffleUsers", reflect.TypeOf((*MockRotationService)(nil).ShuffleUsers), ctx, rotationID)
+}
+
+// SwapUsers mocks base method.
+func (m *MockRotationService) SwapUsers(ctx context.Context, rotationID int64, slackUserIDA, slackUserIDB, date, requestedBy string) (*entity.Swap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwapUsers", ctx, rotationID, slackUserIDA, slackUserIDB, date, requestedBy)
+	ret0, _ := ret[0].(*entity.Swap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SwapUsers indicates an expected call of SwapUsers.
+func (mr *MockRotationServiceMockRecorder) SwapUsers(ctx, rotationID, slackUserIDA, slackUserIDB, date, requestedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwapUsers", reflect.TypeOf((*MockRotationService)(nil).SwapUsers), ctx, rotationID, slackUserIDA, slackUserIDB, date, requestedBy)
+}
+
 // UpdateChannelConfig mocks base method.
-func (m *MockRotationService) UpdateChannelConfig(channelID int64, configType, configValue string) error {
+func (m *MockRotationService) UpdateChannelConfig(rotationID int64, configType, configValue string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateChannelConfig", channelID, configType, configValue)
+	ret := m.ctrl.Call(m, "UpdateChannelConfig", rotationID, configType, configValue)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateChannelConfig indicates an expected call of UpdateChannelConfig.
-func (mr *MockRotationServiceMockRecorder) UpdateChannelConfig(channelID, configType, configValue any) *gomock.Call {
+func (mr *MockRotationServiceMockRecorder) UpdateChannelConfig(rotationID, configType, configValue any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannelConfig", reflect.TypeOf((*MockRotationService)(nil).UpdateChannelConfig), rotationID, configType, configValue)
+}
+
+// UpdateSchedulerSettings mocks base method.
+func (m *MockRotationService) UpdateSchedulerSettings(rotationID int64, settings entity.SchedulerSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSchedulerSettings", rotationID, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSchedulerSettings indicates an expected call of UpdateSchedulerSettings.
+func (mr *MockRotationServiceMockRecorder) UpdateSchedulerSettings(rotationID, settings any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannelConfig", reflect.TypeOf((*MockRotationService)(nil).UpdateChannelConfig), channelID, configType, configValue)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchedulerSettings", reflect.TypeOf((*MockRotationService)(nil).UpdateSchedulerSettings), rotationID, settings)
 }
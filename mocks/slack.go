@@ -10,8 +10,10 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
+	contract "github.com/diegoclair/slack-rotation-bot/internal/domain/contract"
 	slack "github.com/slack-go/slack"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,19 +42,66 @@ func (m *MockSlackClient) EXPECT() *MockSlackClientMockRecorder {
 	return m.recorder
 }
 
-// GetUserInfo mocks base method.
-func (m *MockSlackClient) GetUserInfo(userID string) (*slack.User, error) {
+// GetConversationInfoContext mocks base method.
+func (m *MockSlackClient) GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserInfo", userID)
+	ret := m.ctrl.Call(m, "GetConversationInfoContext", ctx, input)
+	ret0, _ := ret[0].(*slack.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversationInfoContext indicates an expected call of GetConversationInfoContext.
+func (mr *MockSlackClientMockRecorder) GetConversationInfoContext(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversationInfoContext", reflect.TypeOf((*MockSlackClient)(nil).GetConversationInfoContext), ctx, input)
+}
+
+// GetUserInfoContext mocks base method.
+func (m *MockSlackClient) GetUserInfoContext(ctx context.Context, userID string) (*slack.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserInfoContext", ctx, userID)
 	ret0, _ := ret[0].(*slack.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserInfo indicates an expected call of GetUserInfo.
-func (mr *MockSlackClientMockRecorder) GetUserInfo(userID any) *gomock.Call {
+// GetUserInfoContext indicates an expected call of GetUserInfoContext.
+func (mr *MockSlackClientMockRecorder) GetUserInfoContext(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInfo", reflect.TypeOf((*MockSlackClient)(nil).GetUserInfo), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInfoContext", reflect.TypeOf((*MockSlackClient)(nil).GetUserInfoContext), ctx, userID)
+}
+
+// OpenConversationContext mocks base method.
+func (m *MockSlackClient) OpenConversationContext(ctx context.Context, params *slack.OpenConversationParameters) (*slack.Channel, bool, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenConversationContext", ctx, params)
+	ret0, _ := ret[0].(*slack.Channel)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// OpenConversationContext indicates an expected call of OpenConversationContext.
+func (mr *MockSlackClientMockRecorder) OpenConversationContext(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenConversationContext", reflect.TypeOf((*MockSlackClient)(nil).OpenConversationContext), ctx, params)
+}
+
+// OpenView mocks base method.
+func (m *MockSlackClient) OpenView(triggerID string, view slack.ModalViewRequest) (*slack.ViewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenView", triggerID, view)
+	ret0, _ := ret[0].(*slack.ViewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenView indicates an expected call of OpenView.
+func (mr *MockSlackClientMockRecorder) OpenView(triggerID, view any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenView", reflect.TypeOf((*MockSlackClient)(nil).OpenView), triggerID, view)
 }
 
 // PostMessage mocks base method.
@@ -75,3 +124,147 @@ func (mr *MockSlackClientMockRecorder) PostMessage(channelID any, options ...any
 	varargs := append([]any{channelID}, options...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostMessage", reflect.TypeOf((*MockSlackClient)(nil).PostMessage), varargs...)
 }
+
+// PostMessageContext mocks base method.
+func (m *MockSlackClient) PostMessageContext(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, channelID}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PostMessageContext", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PostMessageContext indicates an expected call of PostMessageContext.
+func (mr *MockSlackClientMockRecorder) PostMessageContext(ctx, channelID any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, channelID}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostMessageContext", reflect.TypeOf((*MockSlackClient)(nil).PostMessageContext), varargs...)
+}
+
+// PublishView mocks base method.
+func (m *MockSlackClient) PublishView(userID string, view slack.HomeTabViewRequest, hash string) (*slack.ViewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishView", userID, view, hash)
+	ret0, _ := ret[0].(*slack.ViewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishView indicates an expected call of PublishView.
+func (mr *MockSlackClientMockRecorder) PublishView(userID, view, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishView", reflect.TypeOf((*MockSlackClient)(nil).PublishView), userID, view, hash)
+}
+
+// SetTopicOfConversationContext mocks base method.
+func (m *MockSlackClient) SetTopicOfConversationContext(ctx context.Context, channelID, topic string) (*slack.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTopicOfConversationContext", ctx, channelID, topic)
+	ret0, _ := ret[0].(*slack.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTopicOfConversationContext indicates an expected call of SetTopicOfConversationContext.
+func (mr *MockSlackClientMockRecorder) SetTopicOfConversationContext(ctx, channelID, topic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTopicOfConversationContext", reflect.TypeOf((*MockSlackClient)(nil).SetTopicOfConversationContext), ctx, channelID, topic)
+}
+
+// UpdateMessage mocks base method.
+func (m *MockSlackClient) UpdateMessage(channelID, timestamp string, options ...slack.MsgOption) (string, string, string, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{channelID, timestamp}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateMessage", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// UpdateMessage indicates an expected call of UpdateMessage.
+func (mr *MockSlackClientMockRecorder) UpdateMessage(channelID, timestamp any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{channelID, timestamp}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessage", reflect.TypeOf((*MockSlackClient)(nil).UpdateMessage), varargs...)
+}
+
+// UpdateUserGroupMembersContext mocks base method.
+func (m *MockSlackClient) UpdateUserGroupMembersContext(ctx context.Context, userGroup, members string, options ...slack.UpdateUserGroupMembersOption) (slack.UserGroup, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, userGroup, members}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateUserGroupMembersContext", varargs...)
+	ret0, _ := ret[0].(slack.UserGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserGroupMembersContext indicates an expected call of UpdateUserGroupMembersContext.
+func (mr *MockSlackClientMockRecorder) UpdateUserGroupMembersContext(ctx, userGroup, members any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, userGroup, members}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserGroupMembersContext", reflect.TypeOf((*MockSlackClient)(nil).UpdateUserGroupMembersContext), varargs...)
+}
+
+// MockSlackClientFactory is a mock of SlackClientFactory interface.
+type MockSlackClientFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockSlackClientFactoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSlackClientFactoryMockRecorder is the mock recorder for MockSlackClientFactory.
+type MockSlackClientFactoryMockRecorder struct {
+	mock *MockSlackClientFactory
+}
+
+// NewMockSlackClientFactory creates a new mock instance.
+func NewMockSlackClientFactory(ctrl *gomock.Controller) *MockSlackClientFactory {
+	mock := &MockSlackClientFactory{ctrl: ctrl}
+	mock.recorder = &MockSlackClientFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSlackClientFactory) EXPECT() *MockSlackClientFactoryMockRecorder {
+	return m.recorder
+}
+
+// ForTeam mocks base method.
+func (m *MockSlackClientFactory) ForTeam(teamID string) (contract.SlackClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForTeam", teamID)
+	ret0, _ := ret[0].(contract.SlackClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForTeam indicates an expected call of ForTeam.
+func (mr *MockSlackClientFactoryMockRecorder) ForTeam(teamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForTeam", reflect.TypeOf((*MockSlackClientFactory)(nil).ForTeam), teamID)
+}
+
+// Forget mocks base method.
+func (m *MockSlackClientFactory) Forget(teamID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Forget", teamID)
+}
+
+// Forget indicates an expected call of Forget.
+func (mr *MockSlackClientFactoryMockRecorder) Forget(teamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forget", reflect.TypeOf((*MockSlackClientFactory)(nil).Forget), teamID)
+}